@@ -9,7 +9,12 @@ import (
 	"github.com/sleuth-io/skills/internal/buildinfo"
 	"github.com/sleuth-io/skills/internal/clients"
 	"github.com/sleuth-io/skills/internal/clients/claude_code"
+	"github.com/sleuth-io/skills/internal/clients/cline"
+	"github.com/sleuth-io/skills/internal/clients/codex"
 	"github.com/sleuth-io/skills/internal/clients/cursor"
+	"github.com/sleuth-io/skills/internal/clients/gemini"
+	"github.com/sleuth-io/skills/internal/clients/profile"
+	"github.com/sleuth-io/skills/internal/clients/windsurf"
 	"github.com/sleuth-io/skills/internal/commands"
 	"github.com/sleuth-io/skills/internal/git"
 	"github.com/sleuth-io/skills/internal/logger"
@@ -20,6 +25,13 @@ func init() {
 	// Register all clients
 	clients.Register(claude_code.NewClient())
 	clients.Register(cursor.NewClient()) // TODO: Uncomment after thorough testing
+	clients.Register(windsurf.NewClient())
+	clients.Register(cline.NewClient())
+	clients.Register(gemini.NewClient())
+	clients.Register(codex.NewClient())
+
+	// Clients described by built-in and custom profiles
+	profile.Register(clients.Global(), profile.Load())
 }
 
 func main() {
@@ -80,13 +92,25 @@ from remote Sleuth servers or Git repositories.`,
 	rootCmd.AddCommand(commands.NewInitCommand())
 	rootCmd.AddCommand(commands.NewInstallCommand())
 	rootCmd.AddCommand(commands.NewUninstallCommand())
+	rootCmd.AddCommand(commands.NewRollbackCommand())
 	rootCmd.AddCommand(commands.NewLockCommand())
+	rootCmd.AddCommand(commands.NewOutdatedCommand())
+	rootCmd.AddCommand(commands.NewUpgradeCommand())
 	rootCmd.AddCommand(commands.NewAddCommand())
 	rootCmd.AddCommand(commands.NewUpdateTemplatesCommand())
 	rootCmd.AddCommand(commands.NewUpdateCommand())
 	rootCmd.AddCommand(commands.NewReportUsageCommand())
+	rootCmd.AddCommand(commands.NewMCPExecCommand())
 	rootCmd.AddCommand(commands.NewServeCommand())
 	rootCmd.AddCommand(commands.NewConfigCommand())
+	rootCmd.AddCommand(commands.NewPolicyCommand())
+	rootCmd.AddCommand(commands.NewAuditCommand())
+	rootCmd.AddCommand(commands.NewDoctorCommand())
+	rootCmd.AddCommand(commands.NewStatsCommand())
+	rootCmd.AddCommand(commands.NewNewCommand())
+	rootCmd.AddCommand(commands.NewValidateCommand())
+	rootCmd.AddCommand(commands.NewPackCommand())
+	rootCmd.AddCommand(commands.NewDevCommand())
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -11,12 +11,23 @@ import (
 	"github.com/sleuth-io/skills/internal/lockfile"
 	"github.com/sleuth-io/skills/internal/metadata"
 	"github.com/sleuth-io/skills/internal/repository"
+	"github.com/sleuth-io/skills/internal/signing"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
 // ArtifactFetcher handles fetching artifacts from a repository
 type ArtifactFetcher struct {
 	repo repository.Repository
+
+	// policy decides which signatures are accepted, loaded from the config directory on first use
+	policy     *signing.TrustPolicy
+	policyErr  error
+	policyOnce sync.Once
+}
+
+// namedRepository is implemented by repositories that combine several named repositories
+type namedRepository interface {
+	RepositoryName(artifactName string) string
 }
 
 // NewArtifactFetcher creates a new artifact fetcher
@@ -26,6 +37,30 @@ func NewArtifactFetcher(repo repository.Repository) *ArtifactFetcher {
 	}
 }
 
+// SetTrustPolicy overrides the trust policy loaded from the config directory
+func (f *ArtifactFetcher) SetTrustPolicy(policy *signing.TrustPolicy) {
+	f.policyOnce.Do(func() {})
+	f.policy = policy
+	f.policyErr = nil
+}
+
+// checkTrust verifies the artifact's signature against the trust policy for its repository
+func (f *ArtifactFetcher) checkTrust(artifact *lockfile.Artifact, zipData []byte) error {
+	f.policyOnce.Do(func() {
+		f.policy, f.policyErr = signing.LoadTrustPolicy()
+	})
+	if f.policyErr != nil {
+		return fmt.Errorf("failed to load trust policy: %w", f.policyErr)
+	}
+
+	repoName := config.DefaultRepositoryName
+	if named, ok := f.repo.(namedRepository); ok {
+		repoName = named.RepositoryName(artifact.Name)
+	}
+
+	return f.policy.Check(repoName, artifact, zipData)
+}
+
 // FetchArtifact downloads a single artifact
 func (f *ArtifactFetcher) FetchArtifact(ctx context.Context, artifact *lockfile.Artifact) (zipData []byte, meta *metadata.Metadata, err error) {
 	// Try disk cache first
@@ -35,7 +70,7 @@ func (f *ArtifactFetcher) FetchArtifact(ctx context.Context, artifact *lockfile.
 		metadataBytes, err := utils.ReadZipFile(zipData, "metadata.toml")
 		if err == nil {
 			meta, err = metadata.Parse(metadataBytes)
-			if err == nil && meta.Validate() == nil {
+			if err == nil && meta.Validate() == nil && f.checkTrust(artifact, zipData) == nil {
 				// Valid cached artifact
 				return zipData, meta, nil
 			}
@@ -70,6 +105,11 @@ func (f *ArtifactFetcher) FetchArtifact(ctx context.Context, artifact *lockfile.
 		return nil, nil, fmt.Errorf("metadata validation failed: %w", err)
 	}
 
+	// Refuse unsigned or untrusted artifacts when the trust policy demands it
+	if err := f.checkTrust(artifact, zipData); err != nil {
+		return nil, nil, err
+	}
+
 	// Cache to disk for future use
 	_ = cache.SaveArtifactToDisk(artifact.Name, artifact.Version, zipData)
 	// Ignore cache save errors - not critical
@@ -86,7 +126,7 @@ func (f *ArtifactFetcher) FetchArtifactWithProgress(ctx context.Context, artifac
 		metadataBytes, err := utils.ReadZipFile(zipData, "metadata.toml")
 		if err == nil {
 			meta, err = metadata.Parse(metadataBytes)
-			if err == nil && meta.Validate() == nil {
+			if err == nil && meta.Validate() == nil && f.checkTrust(artifact, zipData) == nil {
 				// Valid cached artifact - complete progress bar immediately
 				if bar != nil {
 					bar.ChangeMax64(int64(len(zipData)))
@@ -131,6 +171,11 @@ func (f *ArtifactFetcher) FetchArtifactWithProgress(ctx context.Context, artifac
 		return nil, nil, fmt.Errorf("metadata validation failed: %w", err)
 	}
 
+	// Refuse unsigned or untrusted artifacts when the trust policy demands it
+	if err := f.checkTrust(artifact, zipData); err != nil {
+		return nil, nil, err
+	}
+
 	// Cache to disk for future use
 	_ = cache.SaveArtifactToDisk(artifact.Name, artifact.Version, zipData)
 	// Ignore cache save errors - not critical
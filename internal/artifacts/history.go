@@ -0,0 +1,208 @@
+package artifacts
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+)
+
+// MaxGenerationsPerScope is how many installed generations the tracker keeps for each scope
+const MaxGenerationsPerScope = 10
+
+// Generation is a snapshot of the artifacts installed in one scope after an install
+// The artifact zips stay in the artifact cache, so a generation can be reinstalled offline
+type Generation struct {
+	Number     int                 `json:"number"`
+	Repository string              `json:"repository,omitempty"` // Empty for global scope
+	Path       string              `json:"path,omitempty"`       // Path within repo (if path-scoped)
+	CreatedAt  time.Time           `json:"created_at"`
+	Pinned     bool                `json:"pinned,omitempty"` // Set by rollback, holds the scope until install --unpin
+	Artifacts  []InstalledArtifact `json:"artifacts"`
+}
+
+// ScopeDescription returns a human-readable scope description
+func (g *Generation) ScopeDescription() string {
+	scope := InstalledArtifact{Repository: g.Repository, Path: g.Path}
+	return scope.ScopeDescription()
+}
+
+// Generations returns the recorded generations for a scope, oldest first
+func (t *Tracker) Generations(repository, path string) []Generation {
+	var result []Generation
+	for _, g := range t.History {
+		if g.Repository == repository && g.Path == path {
+			result = append(result, g)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Number < result[j].Number
+	})
+	return result
+}
+
+// FindGeneration finds a generation by number within a scope
+func (t *Tracker) FindGeneration(repository, path string, number int) *Generation {
+	for i := range t.History {
+		g := &t.History[i]
+		if g.Repository == repository && g.Path == path && g.Number == number {
+			return g
+		}
+	}
+	return nil
+}
+
+// PinnedGeneration returns the generation a scope is held at, or nil if it follows the lock file
+func (t *Tracker) PinnedGeneration(repository, path string) *Generation {
+	for i := range t.History {
+		g := &t.History[i]
+		if g.Repository == repository && g.Path == path && g.Pinned {
+			return g
+		}
+	}
+	return nil
+}
+
+// ActiveGeneration returns the generation currently installed in a scope:
+// the pinned one if the scope was rolled back, otherwise the latest
+func (t *Tracker) ActiveGeneration(repository, path string) *Generation {
+	if pinned := t.PinnedGeneration(repository, path); pinned != nil {
+		return pinned
+	}
+	generations := t.Generations(repository, path)
+	if len(generations) == 0 {
+		return nil
+	}
+	return t.FindGeneration(repository, path, generations[len(generations)-1].Number)
+}
+
+// PreviousGeneration returns the generation recorded before the active one
+func (t *Tracker) PreviousGeneration(repository, path string) *Generation {
+	active := t.ActiveGeneration(repository, path)
+	if active == nil {
+		return nil
+	}
+	generations := t.Generations(repository, path)
+	for i := len(generations) - 1; i >= 0; i-- {
+		if generations[i].Number < active.Number {
+			return t.FindGeneration(repository, path, generations[i].Number)
+		}
+	}
+	return nil
+}
+
+// PinGeneration holds a scope at the given generation
+func (t *Tracker) PinGeneration(repository, path string, number int) error {
+	target := t.FindGeneration(repository, path, number)
+	if target == nil {
+		return fmt.Errorf("generation %d not found", number)
+	}
+	t.Unpin(repository, path)
+	target.Pinned = true
+	return nil
+}
+
+// Unpin releases a scope held by rollback, returning true if it was pinned
+func (t *Tracker) Unpin(repository, path string) bool {
+	unpinned := false
+	for i := range t.History {
+		g := &t.History[i]
+		if g.Repository == repository && g.Path == path && g.Pinned {
+			g.Pinned = false
+			unpinned = true
+		}
+	}
+	return unpinned
+}
+
+// RecordGeneration snapshots the artifacts installed in a scope as a new generation
+// Nothing is recorded if the installed set is unchanged since the latest generation
+// Only the newest MaxGenerationsPerScope generations are kept, plus any pinned one
+func (t *Tracker) RecordGeneration(repository, path string, now time.Time) *Generation {
+	installed := t.FindByScope(repository, path)
+	generations := t.Generations(repository, path)
+
+	next := 1
+	if len(generations) > 0 {
+		latest := generations[len(generations)-1]
+		if sameInstalledSet(latest.Artifacts, installed) {
+			return nil
+		}
+		next = latest.Number + 1
+	} else if len(installed) == 0 {
+		return nil
+	}
+
+	snapshot := make([]InstalledArtifact, len(installed))
+	for i, a := range installed {
+		a.Clients = slices.Clone(a.Clients)
+		snapshot[i] = a
+	}
+	t.History = append(t.History, Generation{
+		Number:     next,
+		Repository: repository,
+		Path:       path,
+		CreatedAt:  now.UTC(),
+		Artifacts:  snapshot,
+	})
+
+	t.pruneHistory(repository, path)
+	return t.FindGeneration(repository, path, next)
+}
+
+// pruneHistory drops the oldest unpinned generations of a scope beyond MaxGenerationsPerScope
+func (t *Tracker) pruneHistory(repository, path string) {
+	generations := t.Generations(repository, path)
+	excess := len(generations) - MaxGenerationsPerScope
+	if excess <= 0 {
+		return
+	}
+
+	drop := make(map[int]bool)
+	for _, g := range generations {
+		if len(drop) == excess {
+			break
+		}
+		if !g.Pinned {
+			drop[g.Number] = true
+		}
+	}
+
+	var kept []Generation
+	for _, g := range t.History {
+		if g.Repository == repository && g.Path == path && drop[g.Number] {
+			continue
+		}
+		kept = append(kept, g)
+	}
+	t.History = kept
+}
+
+// sameInstalledSet reports whether two snapshots hold the same names, versions and clients
+func sameInstalledSet(a, b []InstalledArtifact) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	byName := make(map[string]InstalledArtifact, len(a))
+	for _, art := range a {
+		byName[art.Name] = art
+	}
+	for _, art := range b {
+		other, ok := byName[art.Name]
+		if !ok || other.Version != art.Version {
+			return false
+		}
+		if !sameClients(other.Clients, art.Clients) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameClients compares client lists ignoring order
+func sameClients(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
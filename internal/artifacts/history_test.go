@@ -0,0 +1,132 @@
+package artifacts
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRecordGeneration(t *testing.T) {
+	tracker := &Tracker{Version: TrackerFormatVersion}
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if g := tracker.RecordGeneration("", "", now); g != nil {
+		t.Errorf("Expected no generation for an empty scope, got %d", g.Number)
+	}
+
+	tracker.UpsertArtifact(InstalledArtifact{Name: "a", Version: "1.0.0", Clients: []string{"claude-code", "cursor"}})
+	tracker.UpsertArtifact(InstalledArtifact{Name: "b", Version: "1.0.0", Repository: "github.com/org/repo", Clients: []string{"cursor"}})
+
+	first := tracker.RecordGeneration("", "", now)
+	if first == nil || first.Number != 1 {
+		t.Fatalf("Expected generation 1, got %+v", first)
+	}
+	if len(first.Artifacts) != 1 || first.Artifacts[0].Name != "a" {
+		t.Errorf("Expected only the global artifact in the global generation, got %+v", first.Artifacts)
+	}
+
+	// Client order doesn't count as a change
+	tracker.UpsertArtifact(InstalledArtifact{Name: "a", Version: "1.0.0", Clients: []string{"cursor", "claude-code"}})
+	if g := tracker.RecordGeneration("", "", now); g != nil {
+		t.Errorf("Expected no generation for an unchanged scope, got %d", g.Number)
+	}
+
+	tracker.UpsertArtifact(InstalledArtifact{Name: "a", Version: "2.0.0", Clients: []string{"claude-code"}})
+	second := tracker.RecordGeneration("", "", now)
+	if second == nil || second.Number != 2 {
+		t.Fatalf("Expected generation 2, got %+v", second)
+	}
+
+	// Scopes are numbered independently
+	if g := tracker.RecordGeneration("github.com/org/repo", "", now); g == nil || g.Number != 1 {
+		t.Errorf("Expected repository generation 1, got %+v", g)
+	}
+
+	// Snapshots don't share client slices with the live tracker
+	tracker.FindArtifact(ArtifactKey{Name: "a"}).Clients[0] = "changed"
+	if got := tracker.FindGeneration("", "", 2).Artifacts[0].Clients[0]; got != "claude-code" {
+		t.Errorf("Generation snapshot changed with the tracker, got client %q", got)
+	}
+}
+
+func TestGenerationPinning(t *testing.T) {
+	tracker := &Tracker{Version: TrackerFormatVersion}
+	now := time.Now()
+	for _, version := range []string{"1.0.0", "2.0.0", "3.0.0"} {
+		tracker.UpsertArtifact(InstalledArtifact{Name: "a", Version: version, Clients: []string{"claude-code"}})
+		tracker.RecordGeneration("", "", now)
+	}
+
+	if g := tracker.PreviousGeneration("", ""); g == nil || g.Number != 2 {
+		t.Fatalf("Expected previous generation 2, got %+v", g)
+	}
+
+	if err := tracker.PinGeneration("", "", 2); err != nil {
+		t.Fatalf("PinGeneration failed: %v", err)
+	}
+	if g := tracker.ActiveGeneration("", ""); g == nil || g.Number != 2 {
+		t.Errorf("Expected pinned generation 2 to be active, got %+v", g)
+	}
+
+	// Rolling back again steps back from the pinned generation, not the latest
+	if g := tracker.PreviousGeneration("", ""); g == nil || g.Number != 1 {
+		t.Fatalf("Expected previous generation 1, got %+v", g)
+	}
+	if err := tracker.PinGeneration("", "", 1); err != nil {
+		t.Fatalf("PinGeneration failed: %v", err)
+	}
+	if tracker.FindGeneration("", "", 2).Pinned {
+		t.Error("Expected pinning generation 1 to release generation 2")
+	}
+	if g := tracker.PreviousGeneration("", ""); g != nil {
+		t.Errorf("Expected no generation before 1, got %d", g.Number)
+	}
+
+	if err := tracker.PinGeneration("", "", 9); err == nil {
+		t.Error("Expected error pinning a missing generation")
+	}
+
+	if !tracker.Unpin("", "") {
+		t.Error("Expected Unpin to report a pinned scope")
+	}
+	if tracker.Unpin("", "") {
+		t.Error("Expected Unpin of an unpinned scope to report false")
+	}
+	if g := tracker.ActiveGeneration("", ""); g == nil || g.Number != 3 {
+		t.Errorf("Expected latest generation 3 to be active after unpin, got %+v", g)
+	}
+}
+
+func TestGenerationHistoryIsBounded(t *testing.T) {
+	tracker := &Tracker{Version: TrackerFormatVersion}
+	now := time.Now()
+
+	record := func(n int) {
+		tracker.UpsertArtifact(InstalledArtifact{Name: "a", Version: fmt.Sprintf("%d.0.0", n), Clients: []string{"claude-code"}})
+		tracker.RecordGeneration("", "", now)
+	}
+
+	record(1)
+	if err := tracker.PinGeneration("", "", 1); err != nil {
+		t.Fatalf("PinGeneration failed: %v", err)
+	}
+	for n := 2; n <= MaxGenerationsPerScope+5; n++ {
+		record(n)
+	}
+
+	generations := tracker.Generations("", "")
+	if len(generations) != MaxGenerationsPerScope {
+		t.Fatalf("Expected %d generations, got %d", MaxGenerationsPerScope, len(generations))
+	}
+
+	// The pinned generation survives pruning; the oldest unpinned ones go
+	if generations[0].Number != 1 || !generations[0].Pinned {
+		t.Errorf("Expected pinned generation 1 to be kept, got %+v", generations[0])
+	}
+	if want := 7; generations[1].Number != want {
+		t.Errorf("Expected oldest unpinned generation to be %d, got %d", want, generations[1].Number)
+	}
+	if last := generations[len(generations)-1]; last.Number != MaxGenerationsPerScope+5 {
+		t.Errorf("Expected newest generation %d, got %d", MaxGenerationsPerScope+5, last.Number)
+	}
+}
@@ -1,6 +1,7 @@
 package artifacts
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,16 +10,18 @@ import (
 
 	"github.com/sleuth-io/skills/internal/cache"
 	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
 // TrackerFormatVersion is the version of the tracker file format
-const TrackerFormatVersion = "3"
+const TrackerFormatVersion = "4"
 
 // Tracker tracks all installed artifacts across all scopes
 type Tracker struct {
 	Version   string              `json:"version"`
 	Artifacts []InstalledArtifact `json:"artifacts"`
+	History   []Generation        `json:"history,omitempty"` // Previously installed sets per scope - added in v4
 }
 
 // InstalledArtifact represents a single installed artifact with its scope
@@ -112,17 +115,13 @@ func LoadTracker() (*Tracker, error) {
 }
 
 // SaveTracker saves the tracker file
-func SaveTracker(tracker *Tracker) error {
+// The file is replaced atomically, and journaled if ctx carries an install transaction
+func SaveTracker(ctx context.Context, tracker *Tracker) error {
 	trackerPath, err := GetTrackerPath()
 	if err != nil {
 		return err
 	}
 
-	// Ensure directory exists
-	if err := utils.EnsureDir(filepath.Dir(trackerPath)); err != nil {
-		return fmt.Errorf("failed to create tracker directory: %w", err)
-	}
-
 	tracker.Version = TrackerFormatVersion
 
 	data, err := json.MarshalIndent(tracker, "", "  ")
@@ -130,7 +129,7 @@ func SaveTracker(tracker *Tracker) error {
 		return fmt.Errorf("failed to marshal tracker: %w", err)
 	}
 
-	if err := os.WriteFile(trackerPath, data, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, trackerPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write tracker: %w", err)
 	}
 
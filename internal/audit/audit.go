@@ -0,0 +1,220 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// Severity ranks how dangerous a finding is
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+)
+
+var severityNames = []string{"info", "low", "medium", "high"}
+
+// String returns the severity's name
+func (s Severity) String() string {
+	if s < 0 || int(s) >= len(severityNames) {
+		return "unknown"
+	}
+	return severityNames[s]
+}
+
+// ParseSeverity parses a severity name
+func ParseSeverity(name string) (Severity, error) {
+	for i, n := range severityNames {
+		if strings.EqualFold(name, n) {
+			return Severity(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown severity %q (expected one of: %s)", name, strings.Join(severityNames, ", "))
+}
+
+// MarshalJSON encodes the severity as its name
+func (s Severity) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// Finding is one issue found in an artifact
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// String formats the finding for display
+func (f Finding) String() string {
+	location := f.File
+	if location != "" && f.Line > 0 {
+		location = fmt.Sprintf("%s:%d", location, f.Line)
+	}
+	if location == "" {
+		return fmt.Sprintf("[%s] %s (%s)", f.Severity, f.Message, f.Rule)
+	}
+	return fmt.Sprintf("[%s] %s: %s (%s)", f.Severity, location, f.Message, f.Rule)
+}
+
+// Report is the result of scanning an artifact
+type Report struct {
+	Name     string    `json:"name,omitempty"`
+	Version  string    `json:"version,omitempty"`
+	Type     string    `json:"type,omitempty"`
+	Score    int       `json:"score"` // 100 for no findings, down to 0
+	Findings []Finding `json:"findings"`
+}
+
+// Highest returns the highest severity found, and false if there are no findings
+func (r *Report) Highest() (Severity, bool) {
+	if len(r.Findings) == 0 {
+		return 0, false
+	}
+	highest := SeverityInfo
+	for _, f := range r.Findings {
+		if f.Severity > highest {
+			highest = f.Severity
+		}
+	}
+	return highest, true
+}
+
+// Count returns the number of findings with the given severity
+func (r *Report) Count(severity Severity) int {
+	count := 0
+	for _, f := range r.Findings {
+		if f.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// severityPenalty is how many points each finding takes off the score
+var severityPenalty = map[Severity]int{
+	SeverityInfo:   0,
+	SeverityLow:    3,
+	SeverityMedium: 10,
+	SeverityHigh:   30,
+}
+
+// Options configures a scan
+type Options struct {
+	// MaxFileSize is the largest uncompressed file allowed (0 for the default)
+	MaxFileSize uint64
+
+	// MaxTotalSize is the largest uncompressed artifact allowed (0 for the default)
+	MaxTotalSize uint64
+}
+
+const (
+	// DefaultMaxFileSize is the default largest uncompressed file
+	DefaultMaxFileSize = 5 << 20
+
+	// DefaultMaxTotalSize is the default largest uncompressed artifact
+	DefaultMaxTotalSize = 50 << 20
+)
+
+// Scan checks an artifact zip for content that could harm the developer who installs it
+func Scan(zipData []byte, opts Options) (*Report, error) {
+	if opts.MaxFileSize == 0 {
+		opts.MaxFileSize = DefaultMaxFileSize
+	}
+	if opts.MaxTotalSize == 0 {
+		opts.MaxTotalSize = DefaultMaxTotalSize
+	}
+
+	entries, err := utils.ListZipEntries(zipData)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &scanner{zipData: zipData, report: &Report{}}
+
+	if data, err := utils.ReadZipFile(zipData, "metadata.toml"); err == nil {
+		if meta, err := metadata.Parse(data); err == nil {
+			s.meta = meta
+			s.report.Name = meta.Artifact.Name
+			s.report.Version = meta.Artifact.Version
+			s.report.Type = meta.Artifact.Type.Key
+		}
+	}
+
+	var total uint64
+	for _, entry := range entries {
+		total += entry.Size
+		s.checkEntry(entry, opts)
+	}
+	if total > opts.MaxTotalSize {
+		s.add(Finding{Rule: "oversized", Severity: SeverityMedium, Message: fmt.Sprintf("artifact unpacks to %s (limit %s)", formatSize(total), formatSize(opts.MaxTotalSize))})
+	}
+
+	if s.meta != nil {
+		s.checkHook()
+		s.checkMCP()
+		s.checkPromptFiles()
+	}
+
+	sort.SliceStable(s.report.Findings, func(i, j int) bool {
+		return s.report.Findings[i].Severity > s.report.Findings[j].Severity
+	})
+
+	s.report.Score = 100
+	for _, f := range s.report.Findings {
+		s.report.Score -= severityPenalty[f.Severity]
+	}
+	if s.report.Score < 0 {
+		s.report.Score = 0
+	}
+
+	return s.report, nil
+}
+
+// scanner accumulates the findings of one scan
+type scanner struct {
+	zipData []byte
+	meta    *metadata.Metadata
+	report  *Report
+}
+
+// add records a finding
+func (s *scanner) add(f Finding) {
+	s.report.Findings = append(s.report.Findings, f)
+}
+
+// readFile reads a file from the artifact, returning nil if it can't be read
+func (s *scanner) readFile(name string) []byte {
+	data, err := utils.ReadZipFile(s.zipData, name)
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// formatSize formats a byte count for display
+func formatSize(size uint64) string {
+	switch {
+	case size >= 1<<20:
+		return fmt.Sprintf("%.1f MiB", float64(size)/(1<<20))
+	case size >= 1<<10:
+		return fmt.Sprintf("%.1f KiB", float64(size)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", size)
+	}
+}
+
+// cleanName returns a zip entry's path relative to the artifact root
+func cleanName(name string) string {
+	return strings.TrimPrefix(path.Clean("/"+name), "/")
+}
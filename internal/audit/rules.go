@@ -0,0 +1,248 @@
+package audit
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// scriptExtensions are files a shell or OS will run directly
+var scriptExtensions = map[string]bool{
+	".sh": true, ".bash": true, ".zsh": true, ".ps1": true, ".bat": true, ".cmd": true, ".exe": true,
+}
+
+// binaryMagic are the leading bytes of native executables
+var binaryMagic = [][]byte{
+	{0x7f, 'E', 'L', 'F'},    // ELF
+	{0xcf, 0xfa, 0xed, 0xfe}, // Mach-O 64-bit
+	{0xce, 0xfa, 0xed, 0xfe}, // Mach-O 32-bit
+	{0xca, 0xfe, 0xba, 0xbe}, // Mach-O universal
+	{'M', 'Z'},               // PE
+}
+
+var (
+	// pipeToShell matches downloads piped straight into an interpreter
+	pipeToShell = regexp.MustCompile(`(?i)\b(curl|wget|iwr|invoke-webrequest)\b[^\n|]*\|\s*(sudo\s+)?(sh|bash|zsh|python3?|node|iex|invoke-expression)\b`)
+
+	// networkCommand matches tools that talk to the network
+	networkCommand = regexp.MustCompile(`(?i)\b(curl|wget|nc|ncat|netcat|telnet|invoke-webrequest|invoke-restmethod|iwr)\b`)
+
+	// shellMetachars matches argument content a shell would interpret
+	shellMetachars = regexp.MustCompile("[|;`]|&&|\\$\\(|>\\s*/")
+
+	// envReference matches ${NAME} references in MCP env values
+	envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+	// injectionMarkers match text that tries to override the agent's instructions
+	injectionMarkers = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)\b(ignore|disregard|forget)\s+(all\s+|any\s+)?(the\s+)?(previous|prior|above|earlier)\s+(instructions|rules|prompts?)`),
+		regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(a|an|in|the)\b`),
+		regexp.MustCompile(`(?i)\b(reveal|print|show|output)\s+(your\s+|the\s+)?system\s+prompt`),
+		regexp.MustCompile(`(?i)\b(do\s+not|don't|never)\s+(tell|inform|mention\s+to)\s+the\s+user`),
+		regexp.MustCompile(`(?i)\bwithout\s+(telling|informing|asking)\s+the\s+user`),
+	}
+
+	// hiddenCharacters are zero-width and bidirectional control characters that hide text from reviewers
+	hiddenCharacters = "\u200b\u200c\u200d\u2060\ufeff\u202a\u202b\u202c\u202d\u202e\u2066\u2067\u2068\u2069"
+)
+
+// shells are interpreters that run an arbitrary command string
+var shells = map[string]bool{
+	"sh": true, "bash": true, "zsh": true, "cmd": true, "cmd.exe": true, "powershell": true, "pwsh": true,
+}
+
+// checkEntry applies the per-file rules to a zip entry
+func (s *scanner) checkEntry(entry utils.ZipEntry, opts Options) {
+	name := entry.Name
+	if err := utils.CheckZipEntryName(name); err != nil {
+		s.add(Finding{Rule: "path-escape", Severity: SeverityHigh, File: name, Message: "path escapes the artifact directory"})
+	}
+
+	if entry.Mode&os.ModeSymlink != 0 {
+		s.add(Finding{Rule: "symlink", Severity: SeverityHigh, File: name, Message: "symbolic link"})
+		return
+	}
+	if entry.Mode.IsDir() || strings.HasSuffix(name, "/") {
+		return
+	}
+
+	for _, part := range strings.Split(cleanName(name), "/") {
+		if strings.HasPrefix(part, ".") {
+			s.add(Finding{Rule: "hidden-file", Severity: SeverityLow, File: name, Message: "hidden file"})
+			break
+		}
+	}
+
+	if entry.Size > opts.MaxFileSize {
+		s.add(Finding{Rule: "oversized", Severity: SeverityMedium, File: name, Message: fmt.Sprintf("file is %s (limit %s)", formatSize(entry.Size), formatSize(opts.MaxFileSize))})
+		return
+	}
+
+	s.checkExecutable(entry)
+}
+
+// checkExecutable flags native binaries and scripts, and scans scripts for network commands
+func (s *scanner) checkExecutable(entry utils.ZipEntry) {
+	data := s.readFile(entry.Name)
+	name := cleanName(entry.Name)
+
+	for _, magic := range binaryMagic {
+		if bytes.HasPrefix(data, magic) {
+			s.add(Finding{Rule: "executable", Severity: SeverityHigh, File: entry.Name, Message: "native executable"})
+			return
+		}
+	}
+
+	isScript := entry.Mode&0111 != 0 ||
+		scriptExtensions[strings.ToLower(path.Ext(name))] ||
+		bytes.HasPrefix(data, []byte("#!"))
+	if !isScript {
+		return
+	}
+
+	// A hook's own script is expected; anything else that runs deserves a look
+	if s.meta != nil && s.meta.Hook != nil && cleanName(s.meta.Hook.ScriptFile) == name {
+		s.add(Finding{Rule: "executable", Severity: SeverityInfo, File: entry.Name, Message: "hook script"})
+	} else {
+		s.add(Finding{Rule: "executable", Severity: SeverityMedium, File: entry.Name, Message: "executable script"})
+	}
+
+	s.checkNetworkCommands(entry.Name, string(data))
+}
+
+// checkNetworkCommands flags lines of a script or command that reach the network
+func (s *scanner) checkNetworkCommands(file, content string) {
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if pipeToShell.MatchString(line) {
+			s.add(Finding{Rule: "network-command", Severity: SeverityHigh, File: file, Line: i + 1, Message: "downloads and runs code: " + truncate(trimmed)})
+		} else if networkCommand.MatchString(line) {
+			s.add(Finding{Rule: "network-command", Severity: SeverityMedium, File: file, Line: i + 1, Message: "network access: " + truncate(trimmed)})
+		}
+	}
+}
+
+// checkHook checks a hook's inline command
+func (s *scanner) checkHook() {
+	if s.meta.Hook == nil || s.meta.Hook.Command == "" {
+		return
+	}
+	s.checkNetworkCommands("metadata.toml", s.meta.Hook.Command)
+}
+
+// checkMCP checks an MCP server's command, args and env
+func (s *scanner) checkMCP() {
+	mcp := s.meta.MCP
+	if mcp == nil || mcp.Command == "" {
+		return
+	}
+
+	command := strings.ToLower(path.Base(strings.ReplaceAll(mcp.Command, "\\", "/")))
+	switch {
+	case shells[command] && containsAny(mcp.Args, "-c", "/c", "-command", "-encodedcommand"):
+		s.add(Finding{Rule: "mcp-command", Severity: SeverityHigh, File: "metadata.toml", Message: fmt.Sprintf("MCP server runs a shell command via %s", mcp.Command)})
+	case networkCommand.MatchString(command):
+		s.add(Finding{Rule: "mcp-command", Severity: SeverityHigh, File: "metadata.toml", Message: fmt.Sprintf("MCP command %q is a network tool", mcp.Command)})
+	}
+
+	for _, arg := range mcp.Args {
+		if pipeToShell.MatchString(arg) {
+			s.add(Finding{Rule: "mcp-args", Severity: SeverityHigh, File: "metadata.toml", Message: "MCP argument downloads and runs code: " + truncate(arg)})
+		} else if shellMetachars.MatchString(arg) {
+			s.add(Finding{Rule: "mcp-args", Severity: SeverityMedium, File: "metadata.toml", Message: "MCP argument contains shell syntax: " + truncate(arg)})
+		}
+	}
+
+	declared := make(map[string]bool)
+	for _, input := range mcp.Inputs {
+		declared[input.Name] = true
+	}
+	keys := make([]string, 0, len(mcp.Env))
+	for key := range mcp.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := mcp.Env[key]
+		for _, match := range envReference.FindAllStringSubmatch(value, -1) {
+			if !declared[match[1]] {
+				s.add(Finding{Rule: "mcp-env", Severity: SeverityMedium, File: "metadata.toml", Message: fmt.Sprintf("MCP env %s reads ${%s} from the developer's environment without declaring it as an input", key, match[1])})
+			}
+		}
+		if shellMetachars.MatchString(value) {
+			s.add(Finding{Rule: "mcp-env", Severity: SeverityMedium, File: "metadata.toml", Message: fmt.Sprintf("MCP env %s contains shell syntax", key)})
+		}
+	}
+}
+
+// checkPromptFiles scans prompt files for prompt-injection markers
+func (s *scanner) checkPromptFiles() {
+	var files []string
+	for _, promptFile := range []string{s.promptFile(), "SKILL.md"} {
+		if promptFile != "" && !slices.Contains(files, cleanName(promptFile)) {
+			files = append(files, cleanName(promptFile))
+		}
+	}
+
+	for _, file := range files {
+		data := s.readFile(file)
+		if data == nil {
+			continue
+		}
+		for i, line := range strings.Split(string(data), "\n") {
+			for _, marker := range injectionMarkers {
+				if match := marker.FindString(line); match != "" {
+					s.add(Finding{Rule: "prompt-injection", Severity: SeverityHigh, File: file, Line: i + 1, Message: fmt.Sprintf("instruction override %q", match)})
+					break
+				}
+			}
+			if strings.ContainsAny(line, hiddenCharacters) {
+				s.add(Finding{Rule: "prompt-injection", Severity: SeverityMedium, File: file, Line: i + 1, Message: "invisible or bidirectional control characters"})
+			}
+		}
+	}
+}
+
+// promptFile returns the prompt file declared in the metadata, if any
+func (s *scanner) promptFile() string {
+	switch {
+	case s.meta.Skill != nil:
+		return s.meta.Skill.PromptFile
+	case s.meta.Command != nil:
+		return s.meta.Command.PromptFile
+	case s.meta.Agent != nil:
+		return s.meta.Agent.PromptFile
+	}
+	return ""
+}
+
+// containsAny reports whether any of values is in list, ignoring case
+func containsAny(list []string, values ...string) bool {
+	for _, item := range list {
+		for _, v := range values {
+			if strings.EqualFold(item, v) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// truncate shortens text for display
+func truncate(text string) string {
+	const max = 80
+	if len(text) <= max {
+		return text
+	}
+	return text[:max] + "..."
+}
@@ -85,6 +85,15 @@ func GetLockFileCacheDir() (string, error) {
 	return filepath.Join(cacheDir, "lockfiles"), nil
 }
 
+// GetTransactionsDir returns the directory holding install journals and backups
+func GetTransactionsDir() (string, error) {
+	cacheDir, err := GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "transactions"), nil
+}
+
 // EnsureCacheDirs creates all necessary cache directories
 func EnsureCacheDirs() error {
 	dirs := []func() (string, error){
@@ -144,6 +153,15 @@ type ETagCache struct {
 	Date time.Time `json:"date"`
 }
 
+// RepositoryCacheKey returns the key used for a named repository's lock file and ETag cache
+// The default repository keeps using its URL so caches from single-repository configs stay valid
+func RepositoryCacheKey(name, repoURL string) string {
+	if name == "" || name == "default" {
+		return repoURL
+	}
+	return name + "|" + repoURL
+}
+
 // GetLockFileETagPath returns the path for storing lock file ETag
 func GetLockFileETagPath(repoURL string) (string, error) {
 	lockFileCacheDir, err := GetLockFileCacheDir()
@@ -245,6 +263,41 @@ func LoadLockFile(repoURL string) ([]byte, error) {
 	return os.ReadFile(path)
 }
 
+// GetCachedPolicyPath returns path for a repository's cached policy
+func GetCachedPolicyPath(repoURL string) (string, error) {
+	lockFileCacheDir, err := GetLockFileCacheDir()
+	if err != nil {
+		return "", err
+	}
+	urlHash := utils.URLHash(repoURL)
+	return filepath.Join(lockFileCacheDir, urlHash+".policy.toml"), nil
+}
+
+// SavePolicy caches a repository's policy to disk
+// An empty policy records that the repository has none
+func SavePolicy(repoURL string, data []byte) error {
+	path, err := GetCachedPolicyPath(repoURL)
+	if err != nil {
+		return err
+	}
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadPolicy loads a repository's cached policy
+func LoadPolicy(repoURL string) ([]byte, error) {
+	path, err := GetCachedPolicyPath(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	if !utils.FileExists(path) {
+		return nil, os.ErrNotExist
+	}
+	return os.ReadFile(path)
+}
+
 // GetTrackerCacheDir returns the directory for tracking installed artifacts state
 func GetTrackerCacheDir() (string, error) {
 	cacheDir, err := GetCacheDir()
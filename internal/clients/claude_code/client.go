@@ -207,6 +207,8 @@ func (c *Client) ListSkills(ctx context.Context, scope *clients.InstallScope) ([
 			Name:        info.Name,
 			Description: info.Description,
 			Version:     info.Version,
+			Keywords:    info.Keywords,
+			Triggers:    info.Triggers,
 		})
 	}
 
@@ -240,12 +242,12 @@ func (c *Client) EnsureSkillsSupport(ctx context.Context, scope *clients.Install
 
 // InstallHooks installs Claude Code-specific hooks (auto-update and usage tracking)
 func (c *Client) InstallHooks(ctx context.Context) error {
-	return installHooks()
+	return installHooks(ctx)
 }
 
 // UninstallHooks removes Claude Code-specific hooks (SessionStart and PostToolUse)
 func (c *Client) UninstallHooks(ctx context.Context) error {
-	return uninstallHooks()
+	return uninstallHooks(ctx)
 }
 
 // ShouldInstall always returns true for Claude Code.
@@ -255,6 +257,15 @@ func (c *Client) ShouldInstall(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
+// ValidateArtifact checks an artifact's zip with the handler for its type
+func (c *Client) ValidateArtifact(meta *metadata.Metadata, zipData []byte) error {
+	handler, err := handlers.NewHandler(meta.Artifact.Type, meta)
+	if err != nil {
+		return err
+	}
+	return handler.Validate(zipData)
+}
+
 // VerifyArtifacts checks if artifacts are actually installed on the filesystem
 func (c *Client) VerifyArtifacts(ctx context.Context, artifacts []*lockfile.Artifact, scope *clients.InstallScope) []clients.VerifyResult {
 	targetBase := c.determineTargetBase(scope)
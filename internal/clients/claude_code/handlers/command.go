@@ -3,12 +3,12 @@ package handlers
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
@@ -111,18 +111,13 @@ func (h *CommandHandler) Install(ctx context.Context, zipData []byte, targetBase
 	// Determine installation path (commands/{name}.md)
 	installPath := filepath.Join(targetBase, h.GetInstallPath())
 
-	// Ensure parent directory exists
-	if err := utils.EnsureDir(filepath.Dir(installPath)); err != nil {
-		return fmt.Errorf("failed to create commands directory: %w", err)
-	}
-
 	// Write the command file
-	if err := os.WriteFile(installPath, promptData, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, installPath, promptData, 0644); err != nil {
 		return fmt.Errorf("failed to write command file: %w", err)
 	}
 
 	// Write metadata file for version tracking
-	if err := h.writeMetadataFile(zipData, installPath); err != nil {
+	if err := h.writeMetadataFile(ctx, zipData, installPath); err != nil {
 		return err
 	}
 
@@ -138,12 +133,12 @@ func (h *CommandHandler) Remove(ctx context.Context, targetBase string) error {
 		return nil
 	}
 
-	if err := os.Remove(installPath); err != nil {
+	if err := transaction.RemoveAll(ctx, installPath); err != nil {
 		return fmt.Errorf("failed to remove command: %w", err)
 	}
 
 	// Remove metadata file if it exists
-	h.removeMetadataFile(installPath)
+	h.removeMetadataFile(ctx, installPath)
 
 	return nil
 }
@@ -200,7 +195,7 @@ func (h *CommandHandler) Validate(zipData []byte) error {
 }
 
 // writeMetadataFile writes the metadata file alongside the command for version tracking
-func (h *CommandHandler) writeMetadataFile(zipData []byte, installPath string) error {
+func (h *CommandHandler) writeMetadataFile(ctx context.Context, zipData []byte, installPath string) error {
 	metadataPath := strings.TrimSuffix(installPath, ".md") + "-metadata.toml"
 	metadataBytes, err := utils.ReadZipFile(zipData, "metadata.toml")
 	if err != nil {
@@ -209,7 +204,7 @@ func (h *CommandHandler) writeMetadataFile(zipData []byte, installPath string) e
 	}
 
 	// Write metadata file alongside the command
-	if err := os.WriteFile(metadataPath, metadataBytes, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, metadataPath, metadataBytes, 0644); err != nil {
 		return fmt.Errorf("failed to write metadata file: %w", err)
 	}
 
@@ -217,10 +212,10 @@ func (h *CommandHandler) writeMetadataFile(zipData []byte, installPath string) e
 }
 
 // removeMetadataFile removes the metadata file if it exists
-func (h *CommandHandler) removeMetadataFile(installPath string) {
+func (h *CommandHandler) removeMetadataFile(ctx context.Context, installPath string) {
 	metadataPath := strings.TrimSuffix(installPath, ".md") + "-metadata.toml"
 	if utils.FileExists(metadataPath) {
-		os.Remove(metadataPath) // Ignore errors, metadata is optional
+		_ = transaction.RemoveAll(ctx, metadataPath) // Ignore errors, metadata is optional
 	}
 }
 
@@ -25,6 +25,9 @@ type Handler interface {
 	// VerifyInstalled checks if the artifact is properly installed
 	// Returns (installed bool, message string)
 	VerifyInstalled(targetBase string) (bool, string)
+
+	// Validate checks that the zip has everything needed to install the artifact
+	Validate(zipData []byte) error
 }
 
 // NewHandler creates a handler for the given artifact type and metadata
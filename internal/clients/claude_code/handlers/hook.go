@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/handlers/dirartifact"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
@@ -79,8 +82,8 @@ func (h *HookHandler) ValidateMetadata(meta *metadata.Metadata) error {
 	if meta.Hook.Event == "" {
 		return fmt.Errorf("hook event is required")
 	}
-	if meta.Hook.ScriptFile == "" {
-		return fmt.Errorf("hook script-file is required")
+	if meta.Hook.ScriptFile == "" && meta.Hook.Command == "" {
+		return fmt.Errorf("hook script-file or command is required")
 	}
 	return nil
 }
@@ -104,7 +107,7 @@ func (h *HookHandler) Install(ctx context.Context, zipData []byte, targetBase st
 	}
 
 	// Update settings.json to register the hook
-	if err := h.updateSettings(targetBase); err != nil {
+	if err := h.updateSettings(ctx, targetBase); err != nil {
 		return fmt.Errorf("failed to update settings: %w", err)
 	}
 
@@ -114,7 +117,7 @@ func (h *HookHandler) Install(ctx context.Context, zipData []byte, targetBase st
 // Remove uninstalls the hook artifact
 func (h *HookHandler) Remove(ctx context.Context, targetBase string) error {
 	// Remove from settings.json first
-	if err := h.removeFromSettings(targetBase); err != nil {
+	if err := h.removeFromSettings(ctx, targetBase); err != nil {
 		return fmt.Errorf("failed to remove from settings: %w", err)
 	}
 
@@ -166,7 +169,7 @@ func (h *HookHandler) Validate(zipData []byte) error {
 		return fmt.Errorf("[hook] section missing in metadata")
 	}
 
-	if !containsFile(files, meta.Hook.ScriptFile) {
+	if meta.Hook.ScriptFile != "" && !containsFile(files, meta.Hook.ScriptFile) {
 		return fmt.Errorf("script file not found in zip: %s", meta.Hook.ScriptFile)
 	}
 
@@ -174,154 +177,158 @@ func (h *HookHandler) Validate(zipData []byte) error {
 }
 
 // updateSettings updates settings.json to register the hook
-func (h *HookHandler) updateSettings(targetBase string) error {
+// Claude Code expects each event to hold matcher groups: [{matcher, hooks: [{type, command, timeout}]}]
+// The group carries an _artifact marker so it can be replaced or removed without touching other hooks
+func (h *HookHandler) updateSettings(ctx context.Context, targetBase string) error {
 	settingsPath := filepath.Join(targetBase, "settings.json")
 
-	// Read existing settings or create new
-	var settings map[string]interface{}
-	if utils.FileExists(settingsPath) {
-		data, err := os.ReadFile(settingsPath)
-		if err != nil {
-			return fmt.Errorf("failed to read settings.json: %w", err)
-		}
-		if err := json.Unmarshal(data, &settings); err != nil {
-			return fmt.Errorf("failed to parse settings.json: %w", err)
-		}
-	} else {
-		settings = make(map[string]interface{})
+	settings, err := readSettings(settingsPath)
+	if err != nil {
+		return err
 	}
 
 	// Ensure hooks section exists
-	if settings["hooks"] == nil {
-		settings["hooks"] = make(map[string]interface{})
+	hooks, ok := settings["hooks"].(map[string]interface{})
+	if !ok {
+		hooks = make(map[string]interface{})
+		settings["hooks"] = hooks
 	}
-	hooks := settings["hooks"].(map[string]interface{})
 
-	// Build hook configuration
-	hookConfig := h.buildHookConfig()
+	// Drop any previous registration first, since a new version may use a different event
+	removeArtifactHooks(hooks, h.metadata.Artifact.Name)
 
-	// Add/update hook entry
-	hookEvent := h.metadata.Hook.Event
-	if hooks[hookEvent] == nil {
-		hooks[hookEvent] = []interface{}{}
-	}
+	event, _ := claudeCodeEvent(h.metadata.Hook)
+	eventHooks, _ := hooks[event].([]interface{})
+	hooks[event] = append(eventHooks, h.buildHookConfig(targetBase))
 
-	// Get existing hooks for this event
-	eventHooks := hooks[hookEvent].([]interface{})
-
-	// Remove any existing entry for this artifact (by checking _artifact field)
-	var filtered []interface{}
-	for _, hook := range eventHooks {
-		hookMap, ok := hook.(map[string]interface{})
-		if !ok {
-			continue
-		}
-		artifactID, ok := hookMap["_artifact"].(string)
-		if !ok || artifactID != h.metadata.Artifact.Name {
-			filtered = append(filtered, hook)
-		}
-	}
-
-	// Add new hook entry
-	filtered = append(filtered, hookConfig)
-	hooks[hookEvent] = filtered
-
-	// Write updated settings
-	data, err := json.MarshalIndent(settings, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal settings: %w", err)
-	}
-
-	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write settings.json: %w", err)
-	}
-
-	return nil
+	return writeSettings(ctx, settingsPath, settings)
 }
 
 // removeFromSettings removes the hook from settings.json
-func (h *HookHandler) removeFromSettings(targetBase string) error {
+func (h *HookHandler) removeFromSettings(ctx context.Context, targetBase string) error {
 	settingsPath := filepath.Join(targetBase, "settings.json")
 
 	if !utils.FileExists(settingsPath) {
 		return nil // Nothing to remove
 	}
 
-	// Read settings
-	data, err := os.ReadFile(settingsPath)
+	settings, err := readSettings(settingsPath)
 	if err != nil {
-		return fmt.Errorf("failed to read settings.json: %w", err)
+		return err
 	}
 
-	var settings map[string]interface{}
-	if err := json.Unmarshal(data, &settings); err != nil {
-		return fmt.Errorf("failed to parse settings.json: %w", err)
+	hooks, ok := settings["hooks"].(map[string]interface{})
+	if !ok {
+		return nil
 	}
 
-	// Check if hooks section exists
-	if settings["hooks"] == nil {
+	if !removeArtifactHooks(hooks, h.metadata.Artifact.Name) {
 		return nil
 	}
-	hooks := settings["hooks"].(map[string]interface{})
 
-	// Remove from the specific event
-	hookEvent := h.metadata.Hook.Event
-	if hooks[hookEvent] == nil {
-		return nil
+	return writeSettings(ctx, settingsPath, settings)
+}
+
+// buildHookConfig builds the matcher group registered in settings.json
+func (h *HookHandler) buildHookConfig(targetBase string) map[string]interface{} {
+	scriptDir := filepath.Join(targetBase, h.GetInstallPath())
+
+	command := map[string]interface{}{
+		"type":    "command",
+		"command": h.metadata.Hook.CommandLine(scriptDir, runtime.GOOS),
+	}
+	if h.metadata.Hook.Timeout > 0 {
+		command["timeout"] = h.metadata.Hook.Timeout
+	}
+
+	group := map[string]interface{}{
+		"hooks":     []interface{}{command},
+		"_artifact": h.metadata.Artifact.Name,
+	}
+	if _, matcher := claudeCodeEvent(h.metadata.Hook); matcher != "" {
+		group["matcher"] = matcher
+	}
+
+	return group
+}
+
+// claudeCodeEvent returns the Claude Code event and tool matcher a hook registers under
+// Generic git events run around the Bash tool, which is where Claude Code runs git
+func claudeCodeEvent(hook *metadata.HookConfig) (event, matcher string) {
+	if metadata.IsClaudeCodeHookEvent(hook.Event) {
+		return hook.Event, hook.Matcher
 	}
 
-	eventHooks := hooks[hookEvent].([]interface{})
+	matcher = hook.Matcher
+	if matcher == "" {
+		matcher = "Bash"
+	}
+	if strings.HasPrefix(hook.Event, "pre-") {
+		return "PreToolUse", matcher
+	}
+	return "PostToolUse", matcher
+}
 
-	// Filter out this artifact's hook
-	var filtered []interface{}
-	for _, hook := range eventHooks {
-		hookMap, ok := hook.(map[string]interface{})
+// removeArtifactHooks removes every entry marked with the artifact from all events,
+// including flat entries written by older versions, and returns true if any were removed
+func removeArtifactHooks(hooks map[string]interface{}, artifactName string) bool {
+	removed := false
+	for event, value := range hooks {
+		eventHooks, ok := value.([]interface{})
 		if !ok {
 			continue
 		}
-		artifactID, ok := hookMap["_artifact"].(string)
-		if !ok || artifactID != h.metadata.Artifact.Name {
+
+		var filtered []interface{}
+		for _, hook := range eventHooks {
+			if hookMap, ok := hook.(map[string]interface{}); ok && hookMap["_artifact"] == artifactName {
+				removed = true
+				continue
+			}
 			filtered = append(filtered, hook)
 		}
+
+		if len(filtered) == 0 {
+			delete(hooks, event)
+		} else {
+			hooks[event] = filtered
+		}
 	}
+	return removed
+}
 
-	hooks[hookEvent] = filtered
+// readSettings reads settings.json, returning empty settings if it doesn't exist
+func readSettings(settingsPath string) (map[string]interface{}, error) {
+	settings := make(map[string]interface{})
+	if !utils.FileExists(settingsPath) {
+		return settings, nil
+	}
 
-	// Write updated settings
-	data, err = json.MarshalIndent(settings, "", "  ")
+	data, err := os.ReadFile(settingsPath)
 	if err != nil {
-		return fmt.Errorf("failed to marshal settings: %w", err)
+		return nil, fmt.Errorf("failed to read settings.json: %w", err)
 	}
-
-	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write settings.json: %w", err)
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings.json: %w", err)
 	}
-
-	return nil
+	if settings == nil {
+		settings = make(map[string]interface{})
+	}
+	return settings, nil
 }
 
-// buildHookConfig builds the hook configuration for settings.json
-func (h *HookHandler) buildHookConfig() map[string]interface{} {
-	// Get absolute path to script file
-	scriptPath := filepath.Join(h.GetInstallPath(), h.metadata.Hook.ScriptFile)
-
-	config := map[string]interface{}{
-		"script":    scriptPath,
-		"_artifact": h.metadata.Artifact.Name,
+// writeSettings writes settings.json as part of the install transaction in ctx
+func writeSettings(ctx context.Context, settingsPath string, settings map[string]interface{}) error {
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal settings: %w", err)
 	}
 
-	// Add optional fields
-	if h.metadata.Hook.Async {
-		config["async"] = true
-	}
-	if !h.metadata.Hook.FailOnError {
-		config["failOnError"] = false
-	}
-	if h.metadata.Hook.Timeout > 0 {
-		config["timeout"] = h.metadata.Hook.Timeout
+	if err := transaction.WriteFile(ctx, settingsPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write settings.json: %w", err)
 	}
 
-	return config
+	return nil
 }
 
 // CanDetectInstalledState returns true since hooks preserve metadata.toml
@@ -330,6 +337,38 @@ func (h *HookHandler) CanDetectInstalledState() bool {
 }
 
 // VerifyInstalled checks if the hook is properly installed
+// Besides the installed files, settings.json must register it in Claude Code's matcher group schema
 func (h *HookHandler) VerifyInstalled(targetBase string) (bool, string) {
-	return hookOps.VerifyInstalled(targetBase, h.metadata.Artifact.Name, h.metadata.Artifact.Version)
+	if ok, msg := hookOps.VerifyInstalled(targetBase, h.metadata.Artifact.Name, h.metadata.Artifact.Version); !ok {
+		return false, msg
+	}
+
+	settings, err := readSettings(filepath.Join(targetBase, "settings.json"))
+	if err != nil {
+		return false, err.Error()
+	}
+	hooks, _ := settings["hooks"].(map[string]interface{})
+
+	event, matcher := claudeCodeEvent(h.metadata.Hook)
+	eventHooks, _ := hooks[event].([]interface{})
+	for _, entry := range eventHooks {
+		group, ok := entry.(map[string]interface{})
+		if !ok || group["_artifact"] != h.metadata.Artifact.Name {
+			continue
+		}
+
+		if got, _ := group["matcher"].(string); got != matcher {
+			return false, fmt.Sprintf("hook registered with matcher %q, expected %q", got, matcher)
+		}
+		commands, _ := group["hooks"].([]interface{})
+		for _, c := range commands {
+			command, _ := c.(map[string]interface{})
+			if line, _ := command["command"].(string); command["type"] == "command" && line != "" {
+				return true, "installed"
+			}
+		}
+		return false, "hook registration in settings.json has no command"
+	}
+
+	return false, fmt.Sprintf("hook not registered for %s in settings.json", event)
 }
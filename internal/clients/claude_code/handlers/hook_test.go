@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+const guardHookMetadata = `[artifact]
+name = "bash-guard"
+version = "1.0.0"
+type = "hook"
+
+[hook]
+event = "PreToolUse"
+matcher = "Bash"
+script-file = "guard.sh"
+timeout = 30
+`
+
+func buildHookZip(t *testing.T, metadataTOML string) ([]byte, *metadata.Metadata) {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "metadata.toml"), []byte(metadataTOML), 0644); err != nil {
+		t.Fatalf("Failed to write metadata.toml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "guard.sh"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatalf("Failed to write guard.sh: %v", err)
+	}
+	zipData, err := utils.CreateZip(dir)
+	if err != nil {
+		t.Fatalf("Failed to create zip: %v", err)
+	}
+	meta, err := metadata.Parse([]byte(metadataTOML))
+	if err != nil {
+		t.Fatalf("Failed to parse metadata: %v", err)
+	}
+	return zipData, meta
+}
+
+func readTestSettings(t *testing.T, path string) map[string]interface{} {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read settings.json: %v", err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("Failed to parse settings.json: %v", err)
+	}
+	return settings
+}
+
+func TestHookHandlerWritesMatcherGroups(t *testing.T) {
+	targetBase := t.TempDir()
+	settingsPath := filepath.Join(targetBase, "settings.json")
+
+	// A user's own hook, plus a flat entry left by an older version of this artifact
+	existing := `{
+  "model": "opus",
+  "hooks": {
+    "PreToolUse": [{"matcher": "Read", "hooks": [{"type": "command", "command": "audit-reads"}]}],
+    "pre-commit": [{"script": "hooks/bash-guard/guard.sh", "_artifact": "bash-guard"}]
+  }
+}`
+	if err := os.WriteFile(settingsPath, []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write settings.json: %v", err)
+	}
+
+	zipData, meta := buildHookZip(t, guardHookMetadata)
+	handler := NewHookHandler(meta)
+	ctx := context.Background()
+
+	if err := handler.Install(ctx, zipData, targetBase); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	settings := readTestSettings(t, settingsPath)
+	hooks := settings["hooks"].(map[string]interface{})
+	if _, ok := hooks["pre-commit"]; ok {
+		t.Error("Expected the legacy flat entry to be removed")
+	}
+
+	preToolUse := hooks["PreToolUse"].([]interface{})
+	if len(preToolUse) != 2 {
+		t.Fatalf("Expected the user's hook and ours under PreToolUse, got %v", preToolUse)
+	}
+	group := preToolUse[1].(map[string]interface{})
+	if group["matcher"] != "Bash" || group["_artifact"] != "bash-guard" {
+		t.Errorf("Unexpected matcher group: %v", group)
+	}
+	command := group["hooks"].([]interface{})[0].(map[string]interface{})
+	wantCommand := filepath.Join(targetBase, "hooks", "bash-guard", "guard.sh")
+	if command["type"] != "command" || command["command"] != wantCommand || command["timeout"] != float64(30) {
+		t.Errorf("Unexpected hook command: %v", command)
+	}
+
+	if ok, msg := handler.VerifyInstalled(targetBase); !ok {
+		t.Errorf("VerifyInstalled() = false: %s", msg)
+	}
+
+	// Reinstalling replaces our group rather than adding another
+	if err := handler.Install(ctx, zipData, targetBase); err != nil {
+		t.Fatalf("Reinstall failed: %v", err)
+	}
+	hooks = readTestSettings(t, settingsPath)["hooks"].(map[string]interface{})
+	if got := len(hooks["PreToolUse"].([]interface{})); got != 2 {
+		t.Errorf("Expected 2 PreToolUse groups after reinstall, got %d", got)
+	}
+
+	if err := handler.Remove(ctx, targetBase); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	settings = readTestSettings(t, settingsPath)
+	preToolUse = settings["hooks"].(map[string]interface{})["PreToolUse"].([]interface{})
+	if len(preToolUse) != 1 || preToolUse[0].(map[string]interface{})["matcher"] != "Read" {
+		t.Errorf("Expected only the user's hook to remain, got %v", preToolUse)
+	}
+	if settings["model"] != "opus" {
+		t.Errorf("Expected unrelated settings to be kept, got %v", settings)
+	}
+}
+
+func TestHookHandlerVerifyInstalledChecksSchema(t *testing.T) {
+	targetBase := t.TempDir()
+	settingsPath := filepath.Join(targetBase, "settings.json")
+
+	zipData, meta := buildHookZip(t, guardHookMetadata)
+	handler := NewHookHandler(meta)
+	if err := handler.Install(context.Background(), zipData, targetBase); err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		settings string
+	}{
+		{
+			name:     "flat entry",
+			settings: `{"hooks": {"PreToolUse": [{"script": "guard.sh", "_artifact": "bash-guard"}]}}`,
+		},
+		{
+			name:     "wrong matcher",
+			settings: `{"hooks": {"PreToolUse": [{"matcher": "Edit", "hooks": [{"type": "command", "command": "guard.sh"}], "_artifact": "bash-guard"}]}}`,
+		},
+		{
+			name:     "missing registration",
+			settings: `{"hooks": {}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(settingsPath, []byte(tt.settings), 0644); err != nil {
+				t.Fatalf("Failed to write settings.json: %v", err)
+			}
+			if ok, _ := handler.VerifyInstalled(targetBase); ok {
+				t.Error("VerifyInstalled() = true, want false")
+			}
+		})
+	}
+}
+
+func TestClaudeCodeEvent(t *testing.T) {
+	tests := []struct {
+		hook        metadata.HookConfig
+		wantEvent   string
+		wantMatcher string
+	}{
+		{metadata.HookConfig{Event: "PreToolUse", Matcher: "Edit|Write"}, "PreToolUse", "Edit|Write"},
+		{metadata.HookConfig{Event: "UserPromptSubmit"}, "UserPromptSubmit", ""},
+		{metadata.HookConfig{Event: "pre-commit"}, "PreToolUse", "Bash"},
+		{metadata.HookConfig{Event: "post-push"}, "PostToolUse", "Bash"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.hook.Event, func(t *testing.T) {
+			event, matcher := claudeCodeEvent(&tt.hook)
+			if event != tt.wantEvent || matcher != tt.wantMatcher {
+				t.Errorf("claudeCodeEvent() = %s, %q; want %s, %q", event, matcher, tt.wantEvent, tt.wantMatcher)
+			}
+		})
+	}
+}
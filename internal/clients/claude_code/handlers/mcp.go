@@ -11,6 +11,8 @@ import (
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/handlers/dirartifact"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/secrets"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
@@ -103,7 +105,7 @@ func (h *MCPHandler) Install(ctx context.Context, zipData []byte, targetBase str
 
 	// Update .mcp.json to register the MCP server
 	installPath := filepath.Join(targetBase, h.GetInstallPath())
-	if err := h.updateMCPConfig(targetBase, installPath); err != nil {
+	if err := h.updateMCPConfig(ctx, targetBase, installPath); err != nil {
 		return fmt.Errorf("failed to update MCP config: %w", err)
 	}
 
@@ -113,7 +115,7 @@ func (h *MCPHandler) Install(ctx context.Context, zipData []byte, targetBase str
 // Remove uninstalls the MCP server artifact
 func (h *MCPHandler) Remove(ctx context.Context, targetBase string) error {
 	// Remove from .mcp.json first
-	if err := h.removeFromMCPConfig(targetBase); err != nil {
+	if err := h.removeFromMCPConfig(ctx, targetBase); err != nil {
 		return fmt.Errorf("failed to remove from MCP config: %w", err)
 	}
 
@@ -169,7 +171,7 @@ func (h *MCPHandler) Validate(zipData []byte) error {
 }
 
 // updateMCPConfig updates .mcp.json to register the MCP server
-func (h *MCPHandler) updateMCPConfig(targetBase, installPath string) error {
+func (h *MCPHandler) updateMCPConfig(ctx context.Context, targetBase, installPath string) error {
 	mcpConfigPath := filepath.Join(targetBase, ".mcp.json")
 
 	// Read existing config or create new
@@ -195,7 +197,10 @@ func (h *MCPHandler) updateMCPConfig(targetBase, installPath string) error {
 	mcpServers := config["mcpServers"].(map[string]interface{})
 
 	// Build MCP server configuration
-	serverConfig := h.buildMCPServerConfig(installPath)
+	serverConfig, err := h.buildMCPServerConfig(installPath)
+	if err != nil {
+		return err
+	}
 
 	// Add/update MCP server entry
 	mcpServers[h.metadata.Artifact.Name] = serverConfig
@@ -206,7 +211,7 @@ func (h *MCPHandler) updateMCPConfig(targetBase, installPath string) error {
 		return fmt.Errorf("failed to marshal MCP config: %w", err)
 	}
 
-	if err := os.WriteFile(mcpConfigPath, data, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, mcpConfigPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write .mcp.json: %w", err)
 	}
 
@@ -214,7 +219,7 @@ func (h *MCPHandler) updateMCPConfig(targetBase, installPath string) error {
 }
 
 // removeFromMCPConfig removes the MCP server from .mcp.json
-func (h *MCPHandler) removeFromMCPConfig(targetBase string) error {
+func (h *MCPHandler) removeFromMCPConfig(ctx context.Context, targetBase string) error {
 	mcpConfigPath := filepath.Join(targetBase, ".mcp.json")
 
 	if !utils.FileExists(mcpConfigPath) {
@@ -247,7 +252,7 @@ func (h *MCPHandler) removeFromMCPConfig(targetBase string) error {
 		return fmt.Errorf("failed to marshal MCP config: %w", err)
 	}
 
-	if err := os.WriteFile(mcpConfigPath, data, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, mcpConfigPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write .mcp.json: %w", err)
 	}
 
@@ -255,7 +260,7 @@ func (h *MCPHandler) removeFromMCPConfig(targetBase string) error {
 }
 
 // buildMCPServerConfig builds the MCP server configuration for .mcp.json
-func (h *MCPHandler) buildMCPServerConfig(installPath string) map[string]interface{} {
+func (h *MCPHandler) buildMCPServerConfig(installPath string) (map[string]interface{}, error) {
 	mcpConfig := h.metadata.MCP
 
 	// Convert relative command paths to absolute (relative to install path)
@@ -265,7 +270,7 @@ func (h *MCPHandler) buildMCPServerConfig(installPath string) map[string]interfa
 	}
 
 	// Convert relative args paths to absolute
-	args := make([]interface{}, len(mcpConfig.Args))
+	args := make([]string, len(mcpConfig.Args))
 	for i, arg := range mcpConfig.Args {
 		// If arg looks like a relative path (contains / or \), make it absolute
 		if !filepath.IsAbs(arg) && (filepath.Base(arg) != arg) {
@@ -275,21 +280,27 @@ func (h *MCPHandler) buildMCPServerConfig(installPath string) map[string]interfa
 		}
 	}
 
+	// Declared inputs are referenced rather than written into .mcp.json
+	launch, err := secrets.ApplyInputs(h.metadata.Artifact.Name, secrets.Launch{Command: command, Args: args, Env: mcpConfig.Env}, mcpConfig.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply MCP inputs: %w", err)
+	}
+
 	config := map[string]interface{}{
-		"command":   command,
-		"args":      args,
+		"command":   launch.Command,
+		"args":      launch.Args,
 		"_artifact": h.metadata.Artifact.Name,
 	}
 
 	// Add optional fields
-	if len(mcpConfig.Env) > 0 {
-		config["env"] = mcpConfig.Env
+	if len(launch.Env) > 0 {
+		config["env"] = launch.Env
 	}
 	if mcpConfig.Timeout > 0 {
 		config["timeout"] = mcpConfig.Timeout
 	}
 
-	return config
+	return config, nil
 }
 
 // CanDetectInstalledState returns true since MCP servers preserve metadata.toml
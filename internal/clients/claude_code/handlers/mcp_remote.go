@@ -10,6 +10,8 @@ import (
 
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/secrets"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
@@ -35,7 +37,7 @@ func (h *MCPRemoteHandler) Install(ctx context.Context, zipData []byte, targetBa
 
 	// For MCP remote, we only need to update .mcp.json
 	// No files need to be extracted
-	if err := h.updateMCPConfig(targetBase); err != nil {
+	if err := h.updateMCPConfig(ctx, targetBase); err != nil {
 		return fmt.Errorf("failed to update MCP config: %w", err)
 	}
 
@@ -45,7 +47,7 @@ func (h *MCPRemoteHandler) Install(ctx context.Context, zipData []byte, targetBa
 // Remove uninstalls the MCP remote configuration
 func (h *MCPRemoteHandler) Remove(ctx context.Context, targetBase string) error {
 	// Remove from .mcp.json
-	if err := h.removeFromMCPConfig(targetBase); err != nil {
+	if err := h.removeFromMCPConfig(ctx, targetBase); err != nil {
 		return fmt.Errorf("failed to remove from MCP config: %w", err)
 	}
 
@@ -101,7 +103,7 @@ func (h *MCPRemoteHandler) Validate(zipData []byte) error {
 }
 
 // updateMCPConfig updates .mcp.json to register the MCP remote server
-func (h *MCPRemoteHandler) updateMCPConfig(targetBase string) error {
+func (h *MCPRemoteHandler) updateMCPConfig(ctx context.Context, targetBase string) error {
 	mcpConfigPath := filepath.Join(targetBase, ".mcp.json")
 
 	// Read existing config or create new
@@ -127,7 +129,10 @@ func (h *MCPRemoteHandler) updateMCPConfig(targetBase string) error {
 	mcpServers := config["mcpServers"].(map[string]interface{})
 
 	// Build MCP server configuration
-	serverConfig := h.buildMCPServerConfig()
+	serverConfig, err := h.buildMCPServerConfig()
+	if err != nil {
+		return err
+	}
 
 	// Add/update MCP server entry
 	mcpServers[h.metadata.Artifact.Name] = serverConfig
@@ -138,7 +143,7 @@ func (h *MCPRemoteHandler) updateMCPConfig(targetBase string) error {
 		return fmt.Errorf("failed to marshal MCP config: %w", err)
 	}
 
-	if err := os.WriteFile(mcpConfigPath, data, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, mcpConfigPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write .mcp.json: %w", err)
 	}
 
@@ -146,7 +151,7 @@ func (h *MCPRemoteHandler) updateMCPConfig(targetBase string) error {
 }
 
 // removeFromMCPConfig removes the MCP remote server from .mcp.json
-func (h *MCPRemoteHandler) removeFromMCPConfig(targetBase string) error {
+func (h *MCPRemoteHandler) removeFromMCPConfig(ctx context.Context, targetBase string) error {
 	mcpConfigPath := filepath.Join(targetBase, ".mcp.json")
 
 	if !utils.FileExists(mcpConfigPath) {
@@ -179,7 +184,7 @@ func (h *MCPRemoteHandler) removeFromMCPConfig(targetBase string) error {
 		return fmt.Errorf("failed to marshal MCP config: %w", err)
 	}
 
-	if err := os.WriteFile(mcpConfigPath, data, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, mcpConfigPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write .mcp.json: %w", err)
 	}
 
@@ -187,31 +192,35 @@ func (h *MCPRemoteHandler) removeFromMCPConfig(targetBase string) error {
 }
 
 // buildMCPServerConfig builds the MCP server configuration for .mcp.json
-func (h *MCPRemoteHandler) buildMCPServerConfig() map[string]interface{} {
+func (h *MCPRemoteHandler) buildMCPServerConfig() (map[string]interface{}, error) {
 	mcpConfig := h.metadata.MCP
 
 	// For remote MCPs, commands are external (npx, docker, etc.)
 	// No path conversion needed
-	args := make([]interface{}, len(mcpConfig.Args))
-	for i, arg := range mcpConfig.Args {
-		args[i] = arg
+	launch, err := secrets.ApplyInputs(h.metadata.Artifact.Name, secrets.Launch{Command: mcpConfig.Command, Args: mcpConfig.Args, Env: mcpConfig.Env}, mcpConfig.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply MCP inputs: %w", err)
+	}
+	args := launch.Args
+	if args == nil {
+		args = []string{}
 	}
 
 	config := map[string]interface{}{
-		"command":   mcpConfig.Command,
+		"command":   launch.Command,
 		"args":      args,
 		"_artifact": h.metadata.Artifact.Name,
 	}
 
 	// Add optional fields
-	if len(mcpConfig.Env) > 0 {
-		config["env"] = mcpConfig.Env
+	if len(launch.Env) > 0 {
+		config["env"] = launch.Env
 	}
 	if mcpConfig.Timeout > 0 {
 		config["timeout"] = mcpConfig.Timeout
 	}
 
-	return config
+	return config, nil
 }
 
 // CanDetectInstalledState returns false since mcp-remote doesn't preserve metadata.toml
@@ -1,6 +1,7 @@
 package claude_code
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -8,11 +9,12 @@ import (
 	"strings"
 
 	"github.com/sleuth-io/skills/internal/logger"
+	"github.com/sleuth-io/skills/internal/transaction"
 )
 
 // installHooks installs system hooks for Claude Code (auto-update and usage tracking).
 // This is different from installing hook artifacts - these are the skills CLI's own hooks.
-func installHooks() error {
+func installHooks(ctx context.Context) error {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
@@ -22,13 +24,13 @@ func installHooks() error {
 	log := logger.Get()
 
 	// Install usage reporting hook
-	if err := installUsageReportingHook(claudeDir); err != nil {
+	if err := installUsageReportingHook(ctx, claudeDir); err != nil {
 		log.Error("failed to install usage reporting hook", "error", err)
 		return fmt.Errorf("failed to install usage reporting hook: %w", err)
 	}
 
 	// Install session start hook for auto-update
-	if err := installSessionStartHook(claudeDir); err != nil {
+	if err := installSessionStartHook(ctx, claudeDir); err != nil {
 		log.Error("failed to install session start hook", "error", err)
 		return fmt.Errorf("failed to install session start hook: %w", err)
 	}
@@ -37,7 +39,7 @@ func installHooks() error {
 }
 
 // installSessionStartHook installs the SessionStart hook for auto-updating artifacts
-func installSessionStartHook(claudeDir string) error {
+func installSessionStartHook(ctx context.Context, claudeDir string) error {
 	settingsPath := filepath.Join(claudeDir, "settings.json")
 	log := logger.Get()
 
@@ -126,7 +128,7 @@ func installSessionStartHook(claudeDir string) error {
 		return fmt.Errorf("failed to marshal settings: %w", err)
 	}
 
-	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, settingsPath, data, 0644); err != nil {
 		log.Error("failed to write settings.json for SessionStart hook", "error", err, "path", settingsPath)
 		return fmt.Errorf("failed to write settings.json: %w", err)
 	}
@@ -136,7 +138,7 @@ func installSessionStartHook(claudeDir string) error {
 
 // uninstallHooks removes system hooks for Claude Code.
 // This removes the SessionStart hook (auto-install) and PostToolUse hook (usage tracking).
-func uninstallHooks() error {
+func uninstallHooks(ctx context.Context) error {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
@@ -212,7 +214,7 @@ func uninstallHooks() error {
 		return fmt.Errorf("failed to marshal settings: %w", err)
 	}
 
-	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, settingsPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write settings.json: %w", err)
 	}
 
@@ -260,7 +262,7 @@ func removeSkillsHooks(hooks []interface{}, commandPrefix string) []interface{}
 }
 
 // installUsageReportingHook installs the PostToolUse hook for usage tracking
-func installUsageReportingHook(claudeDir string) error {
+func installUsageReportingHook(ctx context.Context, claudeDir string) error {
 	settingsPath := filepath.Join(claudeDir, "settings.json")
 	log := logger.Get()
 
@@ -347,7 +349,7 @@ func installUsageReportingHook(claudeDir string) error {
 		return fmt.Errorf("failed to marshal settings: %w", err)
 	}
 
-	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, settingsPath, data, 0644); err != nil {
 		log.Error("failed to write settings.json for PostToolUse hook", "error", err, "path", settingsPath)
 		return fmt.Errorf("failed to write settings.json: %w", err)
 	}
@@ -1,6 +1,7 @@
 package claude_code
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -64,7 +65,7 @@ func TestUninstallHooks(t *testing.T) {
 	}
 
 	// Run uninstallHooks
-	if err := uninstallHooks(); err != nil {
+	if err := uninstallHooks(context.Background()); err != nil {
 		t.Fatalf("uninstallHooks failed: %v", err)
 	}
 
@@ -154,7 +155,7 @@ func TestUninstallHooksPreservesOtherHooks(t *testing.T) {
 	}
 
 	// Run uninstallHooks
-	if err := uninstallHooks(); err != nil {
+	if err := uninstallHooks(context.Background()); err != nil {
 		t.Fatalf("uninstallHooks failed: %v", err)
 	}
 
@@ -256,7 +257,7 @@ func TestUninstallHooksNoSettingsFile(t *testing.T) {
 	// Don't create settings.json - it shouldn't exist
 
 	// Run uninstallHooks - should not error
-	if err := uninstallHooks(); err != nil {
+	if err := uninstallHooks(context.Background()); err != nil {
 		t.Fatalf("uninstallHooks should not fail when settings.json doesn't exist: %v", err)
 	}
 
@@ -287,7 +288,7 @@ func TestUninstallHooksNoHooksSection(t *testing.T) {
 	}
 
 	// Run uninstallHooks - should not error
-	if err := uninstallHooks(); err != nil {
+	if err := uninstallHooks(context.Background()); err != nil {
 		t.Fatalf("uninstallHooks should not fail when hooks section doesn't exist: %v", err)
 	}
 
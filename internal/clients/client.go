@@ -63,11 +63,20 @@ type Client interface {
 	VerifyArtifacts(ctx context.Context, artifacts []*lockfile.Artifact, scope *InstallScope) []VerifyResult
 }
 
+// ArtifactValidator is implemented by clients that can check an artifact's zip for
+// what their handlers need before installing it
+type ArtifactValidator interface {
+	// ValidateArtifact returns an error if the client couldn't install the artifact
+	ValidateArtifact(meta *metadata.Metadata, zipData []byte) error
+}
+
 // InstalledSkill represents a skill that has been installed
 type InstalledSkill struct {
-	Name        string // Skill name
-	Description string // Skill description from metadata
-	Version     string // Skill version
+	Name        string   // Skill name
+	Description string   // Skill description from metadata
+	Version     string   // Skill version
+	Keywords    []string // Keywords from metadata
+	Triggers    []string // Phrases that should bring the skill to mind
 }
 
 // SkillContent contains the full content of a skill for MCP responses
@@ -109,6 +118,22 @@ const (
 	ScopePath       ScopeType = "path"
 )
 
+// ScopeChain returns the scopes visible from scope, most specific first
+func ScopeChain(scope *InstallScope) []*InstallScope {
+	var chain []*InstallScope
+	if scope.Type == ScopePath {
+		chain = append(chain, scope)
+	}
+	if scope.Type == ScopePath || scope.Type == ScopeRepository {
+		chain = append(chain, &InstallScope{
+			Type:     ScopeRepository,
+			RepoRoot: scope.RepoRoot,
+			RepoURL:  scope.RepoURL,
+		})
+	}
+	return append(chain, &InstallScope{Type: ScopeGlobal})
+}
+
 // InstallOptions contains optional installation settings
 type InstallOptions struct {
 	Force   bool // Force reinstall even if already installed
@@ -188,3 +213,16 @@ func NewBaseClient(id, displayName string, supportedTypes []artifact.Type) BaseC
 		capabilities: capabilities,
 	}
 }
+
+// NoHooks provides the hook methods for clients that have no hooks to run installs from:
+// nothing is installed, and installs always proceed
+type NoHooks struct{}
+
+// InstallHooks is a no-op since there are no hooks to install
+func (NoHooks) InstallHooks(ctx context.Context) error { return nil }
+
+// UninstallHooks is a no-op since InstallHooks installs nothing
+func (NoHooks) UninstallHooks(ctx context.Context) error { return nil }
+
+// ShouldInstall always returns true since installs never run from hooks
+func (NoHooks) ShouldInstall(ctx context.Context) (bool, error) { return true, nil }
@@ -0,0 +1,108 @@
+// Package clienttest provides helpers for testing client implementations.
+package clienttest
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// BuildBundle zips files, which must include a metadata.toml, into an artifact bundle
+func BuildBundle(t testing.TB, files map[string]string) *clients.ArtifactBundle {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	zipData, err := utils.CreateZip(dir)
+	if err != nil {
+		t.Fatalf("Failed to create zip: %v", err)
+	}
+	meta, err := metadata.Parse([]byte(files["metadata.toml"]))
+	if err != nil {
+		t.Fatalf("Failed to parse metadata: %v", err)
+	}
+	return &clients.ArtifactBundle{
+		Artifact: &lockfile.Artifact{Name: meta.Artifact.Name, Version: meta.Artifact.Version, Type: meta.Artifact.Type},
+		Metadata: meta,
+		ZipData:  zipData,
+	}
+}
+
+// Bundles returns the artifacts InstallVerifyUninstall installs: a skill ("review"),
+// a command ("deploy") taking $ARGUMENTS and a packaged MCP server ("db")
+func Bundles(t testing.TB) []*clients.ArtifactBundle {
+	t.Helper()
+	return []*clients.ArtifactBundle{
+		BuildBundle(t, map[string]string{
+			"metadata.toml": "[artifact]\nname = \"review\"\nversion = \"1.0.0\"\ntype = \"skill\"\ndescription = \"Review code\"\n\n[skill]\nprompt-file = \"SKILL.md\"\n",
+			"SKILL.md":      "Review carefully",
+		}),
+		BuildBundle(t, map[string]string{
+			"metadata.toml": "[artifact]\nname = \"deploy\"\nversion = \"1.0.0\"\ntype = \"command\"\ndescription = \"Deploy the app\"\n\n[command]\nprompt-file = \"COMMAND.md\"\n",
+			"COMMAND.md":    "Deploy $ARGUMENTS",
+		}),
+		BuildBundle(t, map[string]string{
+			"metadata.toml": "[artifact]\nname = \"db\"\nversion = \"1.0.0\"\ntype = \"mcp\"\n\n[mcp]\ncommand = \"node\"\nargs = [\"./index.js\"]\n\n[mcp.env]\nDB_HOST = \"localhost\"\n",
+			"index.js":      "",
+		}),
+	}
+}
+
+// InstallVerifyUninstall installs Bundles in scope and checks they verify and that
+// ListSkills reports the skill, then uninstalls them and checks they no longer verify
+// installed runs the client's own assertions on where and how they were installed
+func InstallVerifyUninstall(t *testing.T, client clients.Client, scope *clients.InstallScope, installed func()) {
+	t.Helper()
+	ctx := context.Background()
+	bundles := Bundles(t)
+
+	resp, err := client.InstallArtifacts(ctx, clients.InstallRequest{Artifacts: bundles, Scope: scope})
+	if err != nil {
+		t.Fatalf("InstallArtifacts failed: %v", err)
+	}
+	for _, result := range resp.Results {
+		if result.Status != clients.StatusSuccess {
+			t.Fatalf("Install of %s: %s %v", result.ArtifactName, result.Status, result.Error)
+		}
+	}
+
+	lockArtifacts := make([]*lockfile.Artifact, len(bundles))
+	for i, bundle := range bundles {
+		lockArtifacts[i] = bundle.Artifact
+	}
+	for _, result := range client.VerifyArtifacts(ctx, lockArtifacts, scope) {
+		if !result.Installed {
+			t.Errorf("Verify %s: %s", result.Artifact.Name, result.Message)
+		}
+	}
+
+	skills, err := client.ListSkills(ctx, scope)
+	if err != nil || len(skills) != 1 || skills[0].Name != "review" {
+		t.Errorf("ListSkills() = %v, %v; want review", skills, err)
+	}
+
+	installed()
+
+	uninstall := make([]artifact.Artifact, len(bundles))
+	for i, bundle := range bundles {
+		uninstall[i] = artifact.Artifact{Name: bundle.Artifact.Name, Type: bundle.Artifact.Type}
+	}
+	if _, err := client.UninstallArtifacts(ctx, clients.UninstallRequest{Artifacts: uninstall, Scope: scope}); err != nil {
+		t.Fatalf("UninstallArtifacts failed: %v", err)
+	}
+	for _, result := range client.VerifyArtifacts(ctx, lockArtifacts, scope) {
+		if result.Installed {
+			t.Errorf("Expected %s to be uninstalled", result.Artifact.Name)
+		}
+	}
+}
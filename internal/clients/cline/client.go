@@ -0,0 +1,182 @@
+package cline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/skillsindex"
+	"github.com/sleuth-io/skills/internal/handlers/mcpconfig"
+	"github.com/sleuth-io/skills/internal/handlers/shared"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/logger"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+// extensionID is Cline's VS Code extension, whose global storage holds its settings
+const extensionID = "saoudrizwan.claude-dev"
+
+// Client implements the clients.Client interface for Cline
+type Client struct {
+	clients.BaseClient
+	clients.NoHooks // Cline has no hooks to run installs from
+}
+
+// NewClient creates a new Cline client
+func NewClient() *Client {
+	return &Client{
+		BaseClient: clients.NewBaseClient(
+			"cline",
+			"Cline",
+			[]artifact.Type{
+				artifact.TypeMCP,
+				artifact.TypeMCPRemote,
+				artifact.TypeSkill,   // Listed in .clinerules/skills.md, read via the skills MCP server
+				artifact.TypeCommand, // Installed as workflows
+			},
+		),
+	}
+}
+
+// vscodeUserDir returns VS Code's per-user data directory
+func vscodeUserDir() string {
+	home, _ := os.UserHomeDir()
+	switch runtime.GOOS {
+	case "darwin":
+		return filepath.Join(home, "Library", "Application Support", "Code", "User")
+	case "windows":
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			return filepath.Join(appData, "Code", "User")
+		}
+		return filepath.Join(home, "AppData", "Roaming", "Code", "User")
+	default:
+		return filepath.Join(home, ".config", "Code", "User")
+	}
+}
+
+// StorageDir returns Cline's global storage directory
+func StorageDir() string {
+	return filepath.Join(vscodeUserDir(), "globalStorage", extensionID)
+}
+
+// IsInstalled checks if Cline is installed by checking for its global storage in VS Code
+func (c *Client) IsInstalled() bool {
+	stat, err := os.Stat(StorageDir())
+	return err == nil && stat.IsDir()
+}
+
+// GetVersion returns the Cline version
+func (c *Client) GetVersion() string {
+	// Cline is a VS Code extension without a version command
+	return ""
+}
+
+// determinePaths returns where artifacts are installed for scope
+// MCP servers are always registered in Cline's global settings, and global workflows
+// live alongside Cline's global rules in ~/Documents/Cline
+func (c *Client) determinePaths(scope *clients.InstallScope) shared.Paths {
+	home, _ := os.UserHomeDir()
+	paths := shared.Paths{
+		Base:      filepath.Join(home, ".cline"),
+		Commands:  filepath.Join(home, "Documents", "Cline", "Workflows"),
+		MCPConfig: mcpconfig.NewJSONFile(filepath.Join(StorageDir(), "settings", "cline_mcp_settings.json"), mcpconfig.ServersKey),
+	}
+
+	switch scope.Type {
+	case clients.ScopeRepository:
+		paths.Base = filepath.Join(scope.RepoRoot, ".cline")
+		paths.Commands = filepath.Join(scope.RepoRoot, ".clinerules", "workflows")
+	case clients.ScopePath:
+		paths.Base = filepath.Join(scope.RepoRoot, scope.Path, ".cline")
+		paths.Commands = filepath.Join(scope.RepoRoot, scope.Path, ".clinerules", "workflows")
+	}
+
+	return paths
+}
+
+// newHandler creates the handler for an artifact: commands are installed as workflows
+func newHandler(meta *metadata.Metadata) (shared.Handler, error) {
+	switch meta.Artifact.Type {
+	case artifact.TypeSkill:
+		return shared.NewSkillHandler(meta), nil
+	case artifact.TypeCommand:
+		return NewWorkflowHandler(meta), nil
+	case artifact.TypeMCP:
+		return shared.NewMCPHandler(meta), nil
+	case artifact.TypeMCPRemote:
+		return shared.NewMCPRemoteHandler(meta), nil
+	default:
+		return nil, fmt.Errorf("unsupported artifact type: %s", meta.Artifact.Type.Key)
+	}
+}
+
+// InstallArtifacts installs artifacts to Cline using client-specific handlers
+func (c *Client) InstallArtifacts(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	return shared.InstallArtifacts(ctx, req, c.determinePaths(req.Scope), newHandler), nil
+}
+
+// UninstallArtifacts removes artifacts from Cline
+func (c *Client) UninstallArtifacts(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	return shared.UninstallArtifacts(ctx, req, c.determinePaths(req.Scope), newHandler), nil
+}
+
+// EnsureSkillsSupport registers the skills MCP server and writes .clinerules/skills.md
+// listing the skills from every scope visible from the current context
+func (c *Client) EnsureSkillsSupport(ctx context.Context, scope *clients.InstallScope) error {
+	log := logger.Get()
+
+	entry, err := mcpconfig.SkillsServerEntry()
+	if err != nil {
+		return fmt.Errorf("failed to register MCP server: %w", err)
+	}
+	mcpConfig := c.determinePaths(&clients.InstallScope{Type: clients.ScopeGlobal}).MCPConfig
+	if err := mcpConfig.SetIfMissing(ctx, "skills", entry); err != nil {
+		return fmt.Errorf("failed to register MCP server: %w", err)
+	}
+
+	localTarget := determineLocalTarget(scope)
+	if localTarget == "" {
+		log.Warn("no local target for rules file", "scope_type", scope.Type, "repo_root", scope.RepoRoot)
+		return nil
+	}
+
+	skills := skillsindex.Collect(ctx, c, clients.ScopeChain(scope)...)
+	log.Debug("generating rules file", "target", localTarget, "skill_count", len(skills))
+
+	return skillsindex.WriteFile(ctx, filepath.Join(localTarget, "skills.md"), skillsindex.Content(skillsindex.GeneratedNotice, skills))
+}
+
+// determineLocalTarget returns the .clinerules directory the rules file is written to
+func determineLocalTarget(scope *clients.InstallScope) string {
+	switch {
+	case scope.Type == clients.ScopePath && scope.RepoRoot != "" && scope.Path != "":
+		return filepath.Join(scope.RepoRoot, scope.Path, ".clinerules")
+	case scope.RepoRoot != "":
+		return filepath.Join(scope.RepoRoot, ".clinerules")
+	default:
+		cwd, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		return filepath.Join(cwd, ".clinerules")
+	}
+}
+
+// ListSkills returns all installed skills for a given scope
+func (c *Client) ListSkills(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	return shared.ListSkills(c.determinePaths(scope).Base)
+}
+
+// ReadSkill reads the content of a specific skill by name
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	return shared.ReadSkill(c.determinePaths(scope).Base, name)
+}
+
+// VerifyArtifacts checks if artifacts are actually installed on the filesystem
+func (c *Client) VerifyArtifacts(ctx context.Context, artifacts []*lockfile.Artifact, scope *clients.InstallScope) []clients.VerifyResult {
+	return shared.VerifyArtifacts(artifacts, c.determinePaths(scope), newHandler)
+}
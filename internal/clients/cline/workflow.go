@@ -0,0 +1,60 @@
+package cline
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/sleuth-io/skills/internal/handlers/shared"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// WorkflowHandler installs commands as Cline workflows, run with /{name}.md
+type WorkflowHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewWorkflowHandler creates a new workflow handler
+func NewWorkflowHandler(meta *metadata.Metadata) *WorkflowHandler {
+	return &WorkflowHandler{metadata: meta}
+}
+
+// Install writes the command's prompt to workflows/{name}.md
+func (h *WorkflowHandler) Install(ctx context.Context, zipData []byte, paths shared.Paths) error {
+	if h.metadata.Command == nil || h.metadata.Command.PromptFile == "" {
+		return fmt.Errorf("no prompt file specified in metadata")
+	}
+
+	promptContent, err := utils.ReadZipFile(zipData, h.metadata.Command.PromptFile)
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file: %w", err)
+	}
+
+	if err := transaction.WriteFile(ctx, h.workflowPath(paths), promptContent, 0644); err != nil {
+		return fmt.Errorf("failed to write workflow file: %w", err)
+	}
+	return nil
+}
+
+// Remove removes the workflow file
+func (h *WorkflowHandler) Remove(ctx context.Context, paths shared.Paths) error {
+	if err := transaction.RemoveAll(ctx, h.workflowPath(paths)); err != nil {
+		return fmt.Errorf("failed to remove workflow file: %w", err)
+	}
+	return nil
+}
+
+// VerifyInstalled checks if the workflow file exists
+func (h *WorkflowHandler) VerifyInstalled(paths shared.Paths) (bool, string) {
+	if !utils.FileExists(h.workflowPath(paths)) {
+		return false, "workflow file not found"
+	}
+	// Workflows don't have version tracking
+	return true, "installed"
+}
+
+func (h *WorkflowHandler) workflowPath(paths shared.Paths) string {
+	return filepath.Join(paths.Commands, h.metadata.Artifact.Name+".md")
+}
@@ -0,0 +1,171 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/skillsindex"
+	"github.com/sleuth-io/skills/internal/handlers/mcpconfig"
+	"github.com/sleuth-io/skills/internal/handlers/shared"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/logger"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+// serversKey is the config.toml table MCP servers are defined in
+const serversKey = "mcp_servers"
+
+// contextFileName is the file Codex loads instructions from, globally and in each directory
+const contextFileName = "AGENTS.md"
+
+// Client implements the clients.Client interface for Codex
+type Client struct {
+	clients.BaseClient
+	clients.NoHooks // Codex has no hooks to run installs from
+}
+
+// NewClient creates a new Codex client
+func NewClient() *Client {
+	return &Client{
+		BaseClient: clients.NewBaseClient(
+			"codex",
+			"Codex",
+			[]artifact.Type{
+				artifact.TypeMCP,
+				artifact.TypeMCPRemote,
+				artifact.TypeSkill,   // Listed in AGENTS.md, read via the skills MCP server
+				artifact.TypeCommand, // Installed as custom prompts
+			},
+		),
+	}
+}
+
+// GlobalDir returns Codex's home directory, ~/.codex unless CODEX_HOME is set
+func GlobalDir() string {
+	if dir := os.Getenv("CODEX_HOME"); dir != "" {
+		return dir
+	}
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".codex")
+}
+
+// IsInstalled checks if Codex is installed by checking for its home directory
+func (c *Client) IsInstalled() bool {
+	stat, err := os.Stat(GlobalDir())
+	return err == nil && stat.IsDir()
+}
+
+// GetVersion returns the Codex version
+func (c *Client) GetVersion() string {
+	cmd := exec.Command("codex", "--version")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return string(output)
+}
+
+// determinePaths returns where artifacts are installed for scope
+// Codex only reads custom prompts and MCP servers from its home directory
+func (c *Client) determinePaths(scope *clients.InstallScope) shared.Paths {
+	global := GlobalDir()
+	paths := shared.Paths{
+		Base:      global,
+		Commands:  filepath.Join(global, "prompts"),
+		MCPConfig: mcpconfig.NewTOMLFile(filepath.Join(global, "config.toml"), serversKey),
+	}
+
+	switch scope.Type {
+	case clients.ScopeRepository:
+		paths.Base = filepath.Join(scope.RepoRoot, ".codex")
+	case clients.ScopePath:
+		paths.Base = filepath.Join(scope.RepoRoot, scope.Path, ".codex")
+	}
+
+	return paths
+}
+
+// determineContextFile returns the AGENTS.md that lists the skills installed in scope
+func determineContextFile(scope *clients.InstallScope) string {
+	switch scope.Type {
+	case clients.ScopeRepository:
+		return filepath.Join(scope.RepoRoot, contextFileName)
+	case clients.ScopePath:
+		return filepath.Join(scope.RepoRoot, scope.Path, contextFileName)
+	default:
+		return filepath.Join(GlobalDir(), contextFileName)
+	}
+}
+
+// newHandler creates the handler for an artifact: commands are installed as custom prompts
+func newHandler(meta *metadata.Metadata) (shared.Handler, error) {
+	switch meta.Artifact.Type {
+	case artifact.TypeSkill:
+		return shared.NewSkillHandler(meta), nil
+	case artifact.TypeCommand:
+		return NewPromptHandler(meta), nil
+	case artifact.TypeMCP:
+		return shared.NewMCPHandler(meta), nil
+	case artifact.TypeMCPRemote:
+		return shared.NewMCPRemoteHandler(meta), nil
+	default:
+		return nil, fmt.Errorf("unsupported artifact type: %s", meta.Artifact.Type.Key)
+	}
+}
+
+// InstallArtifacts installs artifacts to Codex using client-specific handlers
+func (c *Client) InstallArtifacts(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	return shared.InstallArtifacts(ctx, req, c.determinePaths(req.Scope), newHandler), nil
+}
+
+// UninstallArtifacts removes artifacts from Codex
+func (c *Client) UninstallArtifacts(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	return shared.UninstallArtifacts(ctx, req, c.determinePaths(req.Scope), newHandler), nil
+}
+
+// EnsureSkillsSupport registers the skills MCP server and keeps a list of skills in
+// the AGENTS.md of every scope visible from the current context. Codex loads
+// AGENTS.md files hierarchically, so each one only lists the skills of its own scope
+func (c *Client) EnsureSkillsSupport(ctx context.Context, scope *clients.InstallScope) error {
+	log := logger.Get()
+
+	entry, err := mcpconfig.SkillsServerEntry()
+	if err != nil {
+		return fmt.Errorf("failed to register MCP server: %w", err)
+	}
+	mcpConfig := c.determinePaths(&clients.InstallScope{Type: clients.ScopeGlobal}).MCPConfig
+	if err := mcpConfig.SetIfMissing(ctx, "skills", entry); err != nil {
+		return fmt.Errorf("failed to register MCP server: %w", err)
+	}
+
+	for _, chainScope := range clients.ScopeChain(scope) {
+		skills := skillsindex.Collect(ctx, c, chainScope)
+		contextFile := determineContextFile(chainScope)
+		log.Debug("updating skills in context file", "target", contextFile, "skill_count", len(skills))
+		if err := skillsindex.WriteBlock(ctx, contextFile, skillsindex.Content("", skills)); err != nil {
+			return fmt.Errorf("failed to update %s: %w", contextFileName, err)
+		}
+	}
+
+	return nil
+}
+
+// ListSkills returns all installed skills for a given scope
+func (c *Client) ListSkills(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	return shared.ListSkills(c.determinePaths(scope).Base)
+}
+
+// ReadSkill reads the content of a specific skill by name
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	return shared.ReadSkill(c.determinePaths(scope).Base, name)
+}
+
+// VerifyArtifacts checks if artifacts are actually installed on the filesystem
+func (c *Client) VerifyArtifacts(ctx context.Context, artifacts []*lockfile.Artifact, scope *clients.InstallScope) []clients.VerifyResult {
+	return shared.VerifyArtifacts(artifacts, c.determinePaths(scope), newHandler)
+}
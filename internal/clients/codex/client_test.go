@@ -0,0 +1,111 @@
+package codex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/clienttest"
+	"github.com/sleuth-io/skills/internal/handlers/mcpconfig"
+)
+
+func TestClient_InstallVerifyUninstall(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("CODEX_HOME", "")
+
+	client := NewClient()
+	if client.IsInstalled() {
+		t.Fatal("Expected Codex not to be detected without ~/.codex")
+	}
+	codexDir := filepath.Join(homeDir, ".codex")
+	if err := os.MkdirAll(codexDir, 0755); err != nil {
+		t.Fatalf("Failed to create .codex directory: %v", err)
+	}
+	if !client.IsInstalled() {
+		t.Fatal("Expected Codex to be detected")
+	}
+
+	configPath := filepath.Join(codexDir, "config.toml")
+	if err := os.WriteFile(configPath, []byte("model = \"o3\"\n\n[mcp_servers.mine]\ncommand = \"mine\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config.toml: %v", err)
+	}
+
+	// Repository skills stay in the repository, but prompts and MCP servers can only be global
+	repoRoot := t.TempDir()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repoRoot}
+	clienttest.InstallVerifyUninstall(t, client, scope, func() {
+		if _, err := os.Stat(filepath.Join(repoRoot, ".codex", "skills", "review", "SKILL.md")); err != nil {
+			t.Errorf("Expected skill in the repository: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(codexDir, "prompts", "deploy.md")); err != nil {
+			t.Errorf("Expected custom prompt in ~/.codex/prompts: %v", err)
+		}
+
+		var config struct {
+			Model      string `toml:"model"`
+			MCPServers map[string]struct {
+				Command string            `toml:"command"`
+				Args    []string          `toml:"args"`
+				Env     map[string]string `toml:"env"`
+			} `toml:"mcp_servers"`
+		}
+		if _, err := toml.DecodeFile(configPath, &config); err != nil {
+			t.Fatalf("Failed to parse config.toml: %v", err)
+		}
+		db := config.MCPServers["db"]
+		if config.Model != "o3" || config.MCPServers["mine"].Command != "mine" {
+			t.Errorf("Expected other settings to be preserved, got %+v", config)
+		}
+		serverDir := filepath.Join(repoRoot, ".codex", "mcp-servers", "db")
+		if len(db.Args) != 1 || db.Args[0] != filepath.Join(serverDir, "index.js") || db.Env["DB_HOST"] != "localhost" {
+			t.Errorf("Unexpected MCP server entry: %+v", db)
+		}
+	})
+
+	data, _ := os.ReadFile(configPath)
+	if !strings.Contains(string(data), "mine") {
+		t.Errorf("Expected other MCP servers to be preserved, got %s", data)
+	}
+}
+
+func TestClient_EnsureSkillsSupport(t *testing.T) {
+	codexDir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CODEX_HOME", codexDir)
+
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeGlobal}
+	bundle := clienttest.BuildBundle(t, map[string]string{
+		"metadata.toml": "[artifact]\nname = \"review\"\nversion = \"1.0.0\"\ntype = \"skill\"\ndescription = \"Review code\"\n\n[skill]\nprompt-file = \"SKILL.md\"\n",
+		"SKILL.md":      "Review carefully",
+	})
+	if _, err := client.InstallArtifacts(context.Background(), clients.InstallRequest{Artifacts: []*clients.ArtifactBundle{bundle}, Scope: scope}); err != nil {
+		t.Fatalf("InstallArtifacts failed: %v", err)
+	}
+
+	if err := client.EnsureSkillsSupport(context.Background(), scope); err != nil {
+		t.Fatalf("EnsureSkillsSupport failed: %v", err)
+	}
+
+	agents, err := os.ReadFile(filepath.Join(codexDir, "AGENTS.md"))
+	if err != nil {
+		t.Fatalf("Expected AGENTS.md in CODEX_HOME: %v", err)
+	}
+	if !strings.Contains(string(agents), "<name>review</name>") {
+		t.Errorf("Unexpected AGENTS.md:\n%s", agents)
+	}
+
+	registered, err := mcpconfig.NewTOMLFile(filepath.Join(codexDir, "config.toml"), serversKey).Has("skills")
+	if err != nil {
+		t.Fatalf("Failed to read config.toml: %v", err)
+	}
+	if !registered {
+		t.Error("Expected skills MCP server to be registered")
+	}
+}
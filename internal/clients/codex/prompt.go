@@ -0,0 +1,67 @@
+package codex
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/skills/internal/handlers/shared"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// PromptHandler installs commands as Codex custom prompts, run with /prompts:{name}
+type PromptHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewPromptHandler creates a new prompt handler
+func NewPromptHandler(meta *metadata.Metadata) *PromptHandler {
+	return &PromptHandler{metadata: meta}
+}
+
+// Install writes the command's prompt to prompts/{name}.md
+func (h *PromptHandler) Install(ctx context.Context, zipData []byte, paths shared.Paths) error {
+	if h.metadata.Command == nil || h.metadata.Command.PromptFile == "" {
+		return fmt.Errorf("no prompt file specified in metadata")
+	}
+
+	promptContent, err := utils.ReadZipFile(zipData, h.metadata.Command.PromptFile)
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file: %w", err)
+	}
+
+	// Codex shows the description from frontmatter in the slash command popup
+	content := string(promptContent)
+	if description := h.metadata.Artifact.Description; description != "" && !strings.HasPrefix(content, "---") {
+		content = fmt.Sprintf("---\ndescription: %q\n---\n\n%s", description, content)
+	}
+
+	if err := transaction.WriteFile(ctx, h.promptPath(paths), []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write prompt file: %w", err)
+	}
+	return nil
+}
+
+// Remove removes the prompt file
+func (h *PromptHandler) Remove(ctx context.Context, paths shared.Paths) error {
+	if err := transaction.RemoveAll(ctx, h.promptPath(paths)); err != nil {
+		return fmt.Errorf("failed to remove prompt file: %w", err)
+	}
+	return nil
+}
+
+// VerifyInstalled checks if the prompt file exists
+func (h *PromptHandler) VerifyInstalled(paths shared.Paths) (bool, string) {
+	if !utils.FileExists(h.promptPath(paths)) {
+		return false, "prompt file not found"
+	}
+	// Custom prompts don't have version tracking
+	return true, "installed"
+}
+
+func (h *PromptHandler) promptPath(paths shared.Paths) string {
+	return filepath.Join(paths.Commands, h.metadata.Artifact.Name+".md")
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/sleuth-io/skills/internal/lockfile"
 	"github.com/sleuth-io/skills/internal/logger"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
 )
 
 var skillOps = dirartifact.NewOperations("skills", &artifact.TypeSkill)
@@ -210,7 +211,7 @@ func (c *Client) EnsureSkillsSupport(ctx context.Context, scope *clients.Install
 	log := logger.Get()
 
 	// 1. Register skills MCP server globally (idempotent)
-	if err := c.registerSkillsMCPServer(); err != nil {
+	if err := c.registerSkillsMCPServer(ctx); err != nil {
 		return fmt.Errorf("failed to register MCP server: %w", err)
 	}
 
@@ -229,7 +230,7 @@ func (c *Client) EnsureSkillsSupport(ctx context.Context, scope *clients.Install
 	log.Debug("generating rules file", "target", localTarget, "skill_count", len(allSkills))
 
 	// 4. Generate rules file with all skills
-	return c.generateSkillsRulesFileFromSkills(allSkills, localTarget)
+	return c.generateSkillsRulesFileFromSkills(ctx, allSkills, localTarget)
 }
 
 // collectAllScopeSkills gathers skills from global, repo, and path scopes
@@ -305,20 +306,13 @@ func (c *Client) determineLocalTarget(scope *clients.InstallScope) string {
 }
 
 // generateSkillsRulesFileFromSkills creates the rules file from a list of skills
-func (c *Client) generateSkillsRulesFileFromSkills(skills []clients.InstalledSkill, targetBase string) error {
+func (c *Client) generateSkillsRulesFileFromSkills(ctx context.Context, skills []clients.InstalledSkill, targetBase string) error {
 	rulesDir := filepath.Join(targetBase, "rules")
-	if err := os.MkdirAll(rulesDir, 0755); err != nil {
-		return err
-	}
-
 	rulePath := filepath.Join(rulesDir, "skills.md")
 
 	// If no skills, remove the rules file if it exists
 	if len(skills) == 0 {
-		if _, err := os.Stat(rulePath); err == nil {
-			return os.Remove(rulePath)
-		}
-		return nil
+		return transaction.RemoveAll(ctx, rulePath)
 	}
 
 	// Build skill list
@@ -352,11 +346,11 @@ Invoke %sread_skill(name: "skill-name")%s via the MCP tool when needed.
 The tool returns the skill content as markdown. Any %s@filename%s references in the content are automatically resolved to absolute paths.
 `, "`", "`", skillsList, "`", "`", "`", "`")
 
-	return os.WriteFile(rulePath, []byte(content), 0644)
+	return transaction.WriteFile(ctx, rulePath, []byte(content), 0644)
 }
 
 // registerSkillsMCPServer adds skills MCP server to ~/.cursor/mcp.json
-func (c *Client) registerSkillsMCPServer() error {
+func (c *Client) registerSkillsMCPServer(ctx context.Context) error {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return err
@@ -392,7 +386,7 @@ func (c *Client) registerSkillsMCPServer() error {
 		"args":    []string{"serve"},
 	}
 
-	return handlers.WriteMCPConfig(mcpConfigPath, config)
+	return handlers.WriteMCPConfig(ctx, mcpConfigPath, config)
 }
 
 // ListSkills returns all installed skills for a given scope
@@ -410,6 +404,8 @@ func (c *Client) ListSkills(ctx context.Context, scope *clients.InstallScope) ([
 			Name:        info.Name,
 			Description: info.Description,
 			Version:     info.Version,
+			Keywords:    info.Keywords,
+			Triggers:    info.Triggers,
 		})
 	}
 
@@ -436,12 +432,12 @@ func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.Inst
 
 // InstallHooks installs Cursor-specific hooks (auto-install on prompt submission).
 func (c *Client) InstallHooks(ctx context.Context) error {
-	return c.installBeforeSubmitPromptHook()
+	return c.installBeforeSubmitPromptHook(ctx)
 }
 
 // UninstallHooks removes Cursor-specific hooks (beforeSubmitPrompt).
 func (c *Client) UninstallHooks(ctx context.Context) error {
-	return c.uninstallBeforeSubmitPromptHook()
+	return c.uninstallBeforeSubmitPromptHook(ctx)
 }
 
 // ShouldInstall checks if installation should proceed based on conversation tracking.
@@ -542,7 +538,7 @@ func parseCursorHookInput() (*cursorHookInput, error) {
 }
 
 // uninstallBeforeSubmitPromptHook removes the beforeSubmitPrompt hook
-func (c *Client) uninstallBeforeSubmitPromptHook() error {
+func (c *Client) uninstallBeforeSubmitPromptHook(ctx context.Context) error {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
@@ -593,7 +589,7 @@ func (c *Client) uninstallBeforeSubmitPromptHook() error {
 
 	log.Info("hook removed", "hook", "beforeSubmitPrompt")
 
-	if err := handlers.WriteHooksJSON(hooksJSONPath, config); err != nil {
+	if err := handlers.WriteHooksJSON(ctx, hooksJSONPath, config); err != nil {
 		return fmt.Errorf("failed to write hooks.json: %w", err)
 	}
 
@@ -601,7 +597,7 @@ func (c *Client) uninstallBeforeSubmitPromptHook() error {
 }
 
 // installBeforeSubmitPromptHook installs the beforeSubmitPrompt hook for auto-install
-func (c *Client) installBeforeSubmitPromptHook() error {
+func (c *Client) installBeforeSubmitPromptHook(ctx context.Context) error {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return fmt.Errorf("failed to get home directory: %w", err)
@@ -657,13 +653,26 @@ func (c *Client) installBeforeSubmitPromptHook() error {
 		log.Info("hook installed", "hook", "beforeSubmitPrompt", "command", hookCommand, "cwd", cwd)
 	}
 
-	if err := handlers.WriteHooksJSON(hooksJSONPath, config); err != nil {
+	if err := handlers.WriteHooksJSON(ctx, hooksJSONPath, config); err != nil {
 		return fmt.Errorf("failed to write hooks.json: %w", err)
 	}
 
 	return nil
 }
 
+// ValidateArtifact checks an artifact's zip with the handler for its type, for the
+// handlers that check more than metadata
+func (c *Client) ValidateArtifact(meta *metadata.Metadata, zipData []byte) error {
+	handler, err := handlers.NewHandler(meta.Artifact.Type, meta)
+	if err != nil {
+		return err
+	}
+	if v, ok := handler.(interface{ Validate(zipData []byte) error }); ok {
+		return v.Validate(zipData)
+	}
+	return nil
+}
+
 // VerifyArtifacts checks if artifacts are actually installed on the filesystem
 func (c *Client) VerifyArtifacts(ctx context.Context, artifacts []*lockfile.Artifact, scope *clients.InstallScope) []clients.VerifyResult {
 	targetBase := c.determineTargetBase(scope)
@@ -3,10 +3,10 @@ package handlers
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
@@ -23,9 +23,6 @@ func NewCommandHandler(meta *metadata.Metadata) *CommandHandler {
 // Install installs a command/skill as a Cursor slash command
 func (h *CommandHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
 	commandsDir := filepath.Join(targetBase, "commands")
-	if err := os.MkdirAll(commandsDir, 0755); err != nil {
-		return fmt.Errorf("failed to create commands directory: %w", err)
-	}
 
 	// Get prompt file from metadata
 	promptFile := h.getPromptFile()
@@ -41,7 +38,7 @@ func (h *CommandHandler) Install(ctx context.Context, zipData []byte, targetBase
 
 	// Write to .cursor/commands/{name}.md
 	destPath := filepath.Join(commandsDir, h.metadata.Artifact.Name+".md")
-	if err := os.WriteFile(destPath, promptContent, 0644); err != nil {
+	if err := transaction.WriteFile(ctx, destPath, promptContent, 0644); err != nil {
 		return fmt.Errorf("failed to write command file: %w", err)
 	}
 
@@ -51,10 +48,8 @@ func (h *CommandHandler) Install(ctx context.Context, zipData []byte, targetBase
 // Remove removes a slash command from Cursor
 func (h *CommandHandler) Remove(ctx context.Context, targetBase string) error {
 	commandFile := filepath.Join(targetBase, "commands", h.metadata.Artifact.Name+".md")
-	if err := os.Remove(commandFile); err != nil {
-		if os.IsNotExist(err) {
-			return nil // Already removed
-		}
+	// Already removed is fine
+	if err := transaction.RemoveAll(ctx, commandFile); err != nil {
 		return fmt.Errorf("failed to remove command file: %w", err)
 	}
 	return nil
@@ -6,10 +6,13 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
 
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/handlers/dirartifact"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
@@ -34,18 +37,15 @@ func (h *HookHandler) Install(ctx context.Context, zipData []byte, targetBase st
 
 	// Extract to .cursor/hooks/{name}/
 	installPath := filepath.Join(targetBase, "hooks", h.metadata.Artifact.Name)
-	if err := os.RemoveAll(installPath); err != nil {
-		return fmt.Errorf("failed to remove existing hook: %w", err)
-	}
-	if err := utils.EnsureDir(installPath); err != nil {
-		return fmt.Errorf("failed to create hook directory: %w", err)
-	}
-	if err := utils.ExtractZip(zipData, installPath); err != nil {
+	err := transaction.ReplaceDir(ctx, installPath, func(dir string) error {
+		return metadata.ExtractZip(zipData, dir)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to extract hook: %w", err)
 	}
 
 	// Update hooks.json
-	if err := h.updateHooksJSON(targetBase); err != nil {
+	if err := h.updateHooksJSON(ctx, targetBase); err != nil {
 		return fmt.Errorf("failed to update hooks.json: %w", err)
 	}
 
@@ -55,13 +55,13 @@ func (h *HookHandler) Install(ctx context.Context, zipData []byte, targetBase st
 // Remove uninstalls a hook artifact from Cursor
 func (h *HookHandler) Remove(ctx context.Context, targetBase string) error {
 	// Remove from hooks.json
-	if err := h.removeFromHooksJSON(targetBase); err != nil {
+	if err := h.removeFromHooksJSON(ctx, targetBase); err != nil {
 		return fmt.Errorf("failed to remove from hooks.json: %w", err)
 	}
 
 	// Remove directory
 	installPath := filepath.Join(targetBase, "hooks", h.metadata.Artifact.Name)
-	if err := os.RemoveAll(installPath); err != nil {
+	if err := transaction.RemoveAll(ctx, installPath); err != nil {
 		return fmt.Errorf("failed to remove hook directory: %w", err)
 	}
 
@@ -83,7 +83,7 @@ func (h *HookHandler) Validate(zipData []byte) error {
 		return fmt.Errorf("[hook] section missing in metadata")
 	}
 
-	if !containsFile(files, h.metadata.Hook.ScriptFile) {
+	if h.metadata.Hook.ScriptFile != "" && !containsFile(files, h.metadata.Hook.ScriptFile) {
 		return fmt.Errorf("script file not found in zip: %s", h.metadata.Hook.ScriptFile)
 	}
 
@@ -96,7 +96,7 @@ type HooksConfig struct {
 	Hooks   map[string][]map[string]interface{} `json:"hooks"`
 }
 
-func (h *HookHandler) updateHooksJSON(targetBase string) error {
+func (h *HookHandler) updateHooksJSON(ctx context.Context, targetBase string) error {
 	hooksJSONPath := filepath.Join(targetBase, "hooks.json")
 
 	config, err := ReadHooksJSON(hooksJSONPath)
@@ -105,15 +105,18 @@ func (h *HookHandler) updateHooksJSON(targetBase string) error {
 	}
 
 	// Map event to Cursor lifecycle hook
-	cursorEvent := mapEventToCursorHook(h.metadata.Hook.Event)
+	cursorEvent := mapEventToCursorHook(h.metadata.Hook)
 	if cursorEvent == "" {
+		if h.metadata.Hook.Matcher != "" {
+			return fmt.Errorf("unsupported hook event for Cursor: %s with matcher %q", h.metadata.Hook.Event, h.metadata.Hook.Matcher)
+		}
 		return fmt.Errorf("unsupported hook event for Cursor: %s (supported: pre-commit, post-commit, pre-push, on-save, on-file-read)", h.metadata.Hook.Event)
 	}
 
-	// Build entry with absolute path to script
-	scriptPath := filepath.Join(targetBase, "hooks", h.metadata.Artifact.Name, h.metadata.Hook.ScriptFile)
+	// Build entry with the command, using the absolute path to any script
+	scriptDir := filepath.Join(targetBase, "hooks", h.metadata.Artifact.Name)
 	entry := map[string]interface{}{
-		"command":   scriptPath,
+		"command":   h.metadata.Hook.CommandLine(scriptDir, runtime.GOOS),
 		"_artifact": h.metadata.Artifact.Name,
 	}
 
@@ -134,10 +137,10 @@ func (h *HookHandler) updateHooksJSON(targetBase string) error {
 	filtered = append(filtered, entry)
 	config.Hooks[cursorEvent] = filtered
 
-	return WriteHooksJSON(hooksJSONPath, config)
+	return WriteHooksJSON(ctx, hooksJSONPath, config)
 }
 
-func (h *HookHandler) removeFromHooksJSON(targetBase string) error {
+func (h *HookHandler) removeFromHooksJSON(ctx context.Context, targetBase string) error {
 	hooksJSONPath := filepath.Join(targetBase, "hooks.json")
 
 	config, err := ReadHooksJSON(hooksJSONPath)
@@ -159,7 +162,7 @@ func (h *HookHandler) removeFromHooksJSON(targetBase string) error {
 		config.Hooks[eventName] = filtered
 	}
 
-	return WriteHooksJSON(hooksJSONPath, config)
+	return WriteHooksJSON(ctx, hooksJSONPath, config)
 }
 
 // ReadHooksJSON reads and parses the hooks.json file
@@ -189,37 +192,65 @@ func ReadHooksJSON(path string) (*HooksConfig, error) {
 }
 
 // WriteHooksJSON writes the hooks config to the hooks.json file
-func WriteHooksJSON(path string, config *HooksConfig) error {
-	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
-		return err
-	}
-
+func WriteHooksJSON(ctx context.Context, path string, config *HooksConfig) error {
 	data, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, data, 0644)
+	return transaction.WriteFile(ctx, path, data, 0644)
 }
 
 // mapEventToCursorHook maps Skills hook events to Cursor lifecycle hooks
-func mapEventToCursorHook(event string) string {
+func mapEventToCursorHook(hook *metadata.HookConfig) string {
 	mapping := map[string]string{
-		"pre-commit":   "beforeShellExecution",
-		"post-commit":  "afterShellExecution",
-		"pre-push":     "beforeShellExecution",
-		"on-save":      "afterFileEdit",
-		"on-file-read": "beforeReadFile",
-		"after-edit":   "afterFileEdit",
+		"pre-commit":       "beforeShellExecution",
+		"post-commit":      "afterShellExecution",
+		"pre-push":         "beforeShellExecution",
+		"on-save":          "afterFileEdit",
+		"on-file-read":     "beforeReadFile",
+		"after-edit":       "afterFileEdit",
+		"UserPromptSubmit": "beforeSubmitPrompt",
+		"Stop":             "stop",
 	}
 
-	if cursorEvent, ok := mapping[event]; ok {
+	if cursorEvent, ok := mapping[hook.Event]; ok {
 		return cursorEvent
 	}
 
+	// Claude Code tool events map to Cursor hooks for the matching kind of tool
+	switch hook.Event {
+	case "PreToolUse":
+		switch {
+		case hook.Matcher == "" || hook.Matcher == "Bash":
+			return "beforeShellExecution"
+		case hook.Matcher == "Read":
+			return "beforeReadFile"
+		case strings.HasPrefix(hook.Matcher, "mcp__"):
+			return "beforeMCPExecution"
+		}
+	case "PostToolUse":
+		switch {
+		case hook.Matcher == "" || hook.Matcher == "Bash":
+			return "afterShellExecution"
+		case matchesOnlyEditTools(hook.Matcher):
+			return "afterFileEdit"
+		}
+	}
+
 	return "" // Unsupported
 }
 
+// matchesOnlyEditTools returns true if a matcher names only Claude Code's file editing tools
+func matchesOnlyEditTools(matcher string) bool {
+	for _, tool := range strings.Split(matcher, "|") {
+		if tool != "Edit" && tool != "MultiEdit" && tool != "Write" {
+			return false
+		}
+	}
+	return true
+}
+
 func containsFile(files []string, name string) bool {
 	for _, f := range files {
 		if f == name {
@@ -10,7 +10,8 @@ import (
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/handlers/dirartifact"
 	"github.com/sleuth-io/skills/internal/metadata"
-	"github.com/sleuth-io/skills/internal/utils"
+	"github.com/sleuth-io/skills/internal/secrets"
+	"github.com/sleuth-io/skills/internal/transaction"
 )
 
 var mcpOps = dirartifact.NewOperations("mcp-servers", &artifact.TypeMCP)
@@ -37,12 +38,18 @@ func (h *MCPHandler) Install(ctx context.Context, zipData []byte, targetBase str
 
 	// Extract MCP server files to .cursor/mcp-servers/{name}/
 	serverDir := filepath.Join(targetBase, "mcp-servers", h.metadata.Artifact.Name)
-	if err := utils.ExtractZip(zipData, serverDir); err != nil {
+	err = transaction.ReplaceDir(ctx, serverDir, func(dir string) error {
+		return metadata.ExtractZip(zipData, dir)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to extract MCP server: %w", err)
 	}
 
 	// Generate MCP entry from metadata (with paths relative to extraction)
-	entry := h.generateMCPEntry(serverDir)
+	entry, err := h.generateMCPEntry(serverDir)
+	if err != nil {
+		return err
+	}
 
 	// Add to config
 	if config.MCPServers == nil {
@@ -51,7 +58,7 @@ func (h *MCPHandler) Install(ctx context.Context, zipData []byte, targetBase str
 	config.MCPServers[h.metadata.Artifact.Name] = entry
 
 	// Write updated mcp.json
-	if err := WriteMCPConfig(mcpConfigPath, config); err != nil {
+	if err := WriteMCPConfig(ctx, mcpConfigPath, config); err != nil {
 		return fmt.Errorf("failed to write mcp.json: %w", err)
 	}
 
@@ -72,18 +79,18 @@ func (h *MCPHandler) Remove(ctx context.Context, targetBase string) error {
 	delete(config.MCPServers, h.metadata.Artifact.Name)
 
 	// Write updated mcp.json
-	if err := WriteMCPConfig(mcpConfigPath, config); err != nil {
+	if err := WriteMCPConfig(ctx, mcpConfigPath, config); err != nil {
 		return fmt.Errorf("failed to write mcp.json: %w", err)
 	}
 
 	// Remove server directory (if exists)
 	serverDir := filepath.Join(targetBase, "mcp-servers", h.metadata.Artifact.Name)
-	os.RemoveAll(serverDir) // Ignore errors if doesn't exist
+	_ = transaction.RemoveAll(ctx, serverDir) // Ignore errors if doesn't exist
 
 	return nil
 }
 
-func (h *MCPHandler) generateMCPEntry(serverDir string) map[string]interface{} {
+func (h *MCPHandler) generateMCPEntry(serverDir string) (map[string]interface{}, error) {
 	mcpConfig := h.metadata.MCP
 
 	// Convert relative command paths to absolute (relative to server directory)
@@ -93,7 +100,7 @@ func (h *MCPHandler) generateMCPEntry(serverDir string) map[string]interface{} {
 	}
 
 	// Convert relative args paths to absolute
-	args := make([]interface{}, len(mcpConfig.Args))
+	args := make([]string, len(mcpConfig.Args))
 	for i, arg := range mcpConfig.Args {
 		// If arg looks like a relative path (contains / or \), make it absolute
 		if !filepath.IsAbs(arg) && (filepath.Base(arg) != arg) {
@@ -103,17 +110,23 @@ func (h *MCPHandler) generateMCPEntry(serverDir string) map[string]interface{} {
 		}
 	}
 
+	// Declared inputs are referenced rather than written into mcp.json
+	launch, err := secrets.ApplyInputs(h.metadata.Artifact.Name, secrets.Launch{Command: command, Args: args, Env: mcpConfig.Env}, mcpConfig.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply MCP inputs: %w", err)
+	}
+
 	entry := map[string]interface{}{
-		"command": command,
-		"args":    args,
+		"command": launch.Command,
+		"args":    launch.Args,
 	}
 
 	// Add env if present
-	if len(mcpConfig.Env) > 0 {
-		entry["env"] = mcpConfig.Env
+	if len(launch.Env) > 0 {
+		entry["env"] = launch.Env
 	}
 
-	return entry
+	return entry, nil
 }
 
 // MCPConfig represents Cursor's mcp.json structure
@@ -143,13 +156,13 @@ func ReadMCPConfig(path string) (*MCPConfig, error) {
 }
 
 // WriteMCPConfig writes Cursor's mcp.json file
-func WriteMCPConfig(path string, config *MCPConfig) error {
+func WriteMCPConfig(ctx context.Context, path string, config *MCPConfig) error {
 	data, err := json.MarshalIndent(config, "", "  ")
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, data, 0644)
+	return transaction.WriteFile(ctx, path, data, 0644)
 }
 
 // VerifyInstalled checks if the MCP server is properly installed
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/secrets"
 )
 
 // MCPRemoteHandler handles MCP remote artifact installation for Cursor
@@ -30,7 +31,10 @@ func (h *MCPRemoteHandler) Install(ctx context.Context, zipData []byte, targetBa
 	}
 
 	// Generate MCP entry from metadata (no path conversion for remote)
-	entry := h.generateMCPEntry()
+	entry, err := h.generateMCPEntry()
+	if err != nil {
+		return err
+	}
 
 	// Add to config
 	if config.MCPServers == nil {
@@ -39,7 +43,7 @@ func (h *MCPRemoteHandler) Install(ctx context.Context, zipData []byte, targetBa
 	config.MCPServers[h.metadata.Artifact.Name] = entry
 
 	// Write updated mcp.json
-	if err := WriteMCPConfig(mcpConfigPath, config); err != nil {
+	if err := WriteMCPConfig(ctx, mcpConfigPath, config); err != nil {
 		return fmt.Errorf("failed to write mcp.json: %w", err)
 	}
 
@@ -60,34 +64,38 @@ func (h *MCPRemoteHandler) Remove(ctx context.Context, targetBase string) error
 	delete(config.MCPServers, h.metadata.Artifact.Name)
 
 	// Write updated mcp.json
-	if err := WriteMCPConfig(mcpConfigPath, config); err != nil {
+	if err := WriteMCPConfig(ctx, mcpConfigPath, config); err != nil {
 		return fmt.Errorf("failed to write mcp.json: %w", err)
 	}
 
 	return nil
 }
 
-func (h *MCPRemoteHandler) generateMCPEntry() map[string]interface{} {
+func (h *MCPRemoteHandler) generateMCPEntry() (map[string]interface{}, error) {
 	mcpConfig := h.metadata.MCP
 
 	// For remote MCPs, commands are external (npx, docker, etc.)
 	// No path conversion needed
-	args := make([]interface{}, len(mcpConfig.Args))
-	for i, arg := range mcpConfig.Args {
-		args[i] = arg
+	launch, err := secrets.ApplyInputs(h.metadata.Artifact.Name, secrets.Launch{Command: mcpConfig.Command, Args: mcpConfig.Args, Env: mcpConfig.Env}, mcpConfig.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply MCP inputs: %w", err)
+	}
+	args := launch.Args
+	if args == nil {
+		args = []string{}
 	}
 
 	entry := map[string]interface{}{
-		"command": mcpConfig.Command,
+		"command": launch.Command,
 		"args":    args,
 	}
 
 	// Add env if present
-	if len(mcpConfig.Env) > 0 {
-		entry["env"] = mcpConfig.Env
+	if len(launch.Env) > 0 {
+		entry["env"] = launch.Env
 	}
 
-	return entry
+	return entry, nil
 }
 
 // VerifyInstalled checks if the MCP remote server is registered in mcp.json
@@ -3,12 +3,12 @@ package handlers
 import (
 	"context"
 	"fmt"
-	"os"
 	"path/filepath"
 
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/handlers/dirartifact"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
@@ -29,20 +29,11 @@ func NewSkillHandler(meta *metadata.Metadata) *SkillHandler {
 func (h *SkillHandler) Install(ctx context.Context, zipData []byte, targetBase string) error {
 	skillsDir := filepath.Join(targetBase, "skills", h.metadata.Artifact.Name)
 
-	// Remove existing installation if present
-	if utils.IsDirectory(skillsDir) {
-		if err := os.RemoveAll(skillsDir); err != nil {
-			return fmt.Errorf("failed to remove existing installation: %w", err)
-		}
-	}
-
-	// Create installation directory
-	if err := utils.EnsureDir(skillsDir); err != nil {
-		return fmt.Errorf("failed to create installation directory: %w", err)
-	}
-
-	// Extract entire zip to skills directory
-	if err := utils.ExtractZip(zipData, skillsDir); err != nil {
+	// Extract entire zip to a staging directory that replaces any existing installation
+	err := transaction.ReplaceDir(ctx, skillsDir, func(dir string) error {
+		return metadata.ExtractZip(zipData, dir)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to extract skill: %w", err)
 	}
 
@@ -58,7 +49,7 @@ func (h *SkillHandler) Remove(ctx context.Context, targetBase string) error {
 		return nil
 	}
 
-	if err := os.RemoveAll(skillsDir); err != nil {
+	if err := transaction.RemoveAll(ctx, skillsDir); err != nil {
 		return fmt.Errorf("failed to remove skill: %w", err)
 	}
 
@@ -1,6 +1,7 @@
 package cursor
 
 import (
+	"context"
 	"encoding/json"
 	"os"
 	"path/filepath"
@@ -34,7 +35,7 @@ func TestUninstallHooks(t *testing.T) {
 			},
 		},
 	}
-	if err := handlers.WriteHooksJSON(hooksPath, hooksConfig); err != nil {
+	if err := handlers.WriteHooksJSON(context.Background(), hooksPath, hooksConfig); err != nil {
 		t.Fatalf("Failed to write hooks.json: %v", err)
 	}
 
@@ -46,7 +47,7 @@ func TestUninstallHooks(t *testing.T) {
 
 	// Create client and run UninstallHooks
 	client := NewClient()
-	if err := client.uninstallBeforeSubmitPromptHook(); err != nil {
+	if err := client.uninstallBeforeSubmitPromptHook(context.Background()); err != nil {
 		t.Fatalf("uninstallBeforeSubmitPromptHook failed: %v", err)
 	}
 
@@ -103,13 +104,13 @@ func TestUninstallHooksPreservesOtherHooks(t *testing.T) {
 			},
 		},
 	}
-	if err := handlers.WriteHooksJSON(hooksPath, hooksConfig); err != nil {
+	if err := handlers.WriteHooksJSON(context.Background(), hooksPath, hooksConfig); err != nil {
 		t.Fatalf("Failed to write hooks.json: %v", err)
 	}
 
 	// Create client and run UninstallHooks
 	client := NewClient()
-	if err := client.uninstallBeforeSubmitPromptHook(); err != nil {
+	if err := client.uninstallBeforeSubmitPromptHook(context.Background()); err != nil {
 		t.Fatalf("uninstallBeforeSubmitPromptHook failed: %v", err)
 	}
 
@@ -179,7 +180,7 @@ func TestUninstallHooksNoHooksFile(t *testing.T) {
 
 	// Create client and run UninstallHooks - should not error
 	client := NewClient()
-	if err := client.uninstallBeforeSubmitPromptHook(); err != nil {
+	if err := client.uninstallBeforeSubmitPromptHook(context.Background()); err != nil {
 		t.Fatalf("uninstallBeforeSubmitPromptHook should not fail when hooks.json doesn't exist: %v", err)
 	}
 
@@ -211,13 +212,13 @@ func TestUninstallHooksEmptyBeforeSubmitPrompt(t *testing.T) {
 			},
 		},
 	}
-	if err := handlers.WriteHooksJSON(hooksPath, hooksConfig); err != nil {
+	if err := handlers.WriteHooksJSON(context.Background(), hooksPath, hooksConfig); err != nil {
 		t.Fatalf("Failed to write hooks.json: %v", err)
 	}
 
 	// Create client and run UninstallHooks - should not error
 	client := NewClient()
-	if err := client.uninstallBeforeSubmitPromptHook(); err != nil {
+	if err := client.uninstallBeforeSubmitPromptHook(context.Background()); err != nil {
 		t.Fatalf("uninstallBeforeSubmitPromptHook should not fail when beforeSubmitPrompt doesn't exist: %v", err)
 	}
 
@@ -256,13 +257,13 @@ func TestUninstallHooksOnlySkillsHook(t *testing.T) {
 			},
 		},
 	}
-	if err := handlers.WriteHooksJSON(hooksPath, hooksConfig); err != nil {
+	if err := handlers.WriteHooksJSON(context.Background(), hooksPath, hooksConfig); err != nil {
 		t.Fatalf("Failed to write hooks.json: %v", err)
 	}
 
 	// Create client and run UninstallHooks
 	client := NewClient()
-	if err := client.uninstallBeforeSubmitPromptHook(); err != nil {
+	if err := client.uninstallBeforeSubmitPromptHook(context.Background()); err != nil {
 		t.Fatalf("uninstallBeforeSubmitPromptHook failed: %v", err)
 	}
 
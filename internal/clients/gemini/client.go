@@ -0,0 +1,163 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/skillsindex"
+	"github.com/sleuth-io/skills/internal/handlers/mcpconfig"
+	"github.com/sleuth-io/skills/internal/handlers/shared"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/logger"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+// contextFileName is the file Gemini CLI loads instructions from, globally and in each directory
+const contextFileName = "GEMINI.md"
+
+// Client implements the clients.Client interface for Gemini CLI
+type Client struct {
+	clients.BaseClient
+	clients.NoHooks // Gemini CLI has no hooks to run installs from
+}
+
+// NewClient creates a new Gemini CLI client
+func NewClient() *Client {
+	return &Client{
+		BaseClient: clients.NewBaseClient(
+			"gemini",
+			"Gemini CLI",
+			[]artifact.Type{
+				artifact.TypeMCP,
+				artifact.TypeMCPRemote,
+				artifact.TypeSkill,   // Listed in GEMINI.md, read via the skills MCP server
+				artifact.TypeCommand, // Installed as custom commands
+			},
+		),
+	}
+}
+
+// GlobalDir returns Gemini CLI's global configuration directory
+func GlobalDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".gemini")
+}
+
+// IsInstalled checks if Gemini CLI is installed by checking for ~/.gemini
+func (c *Client) IsInstalled() bool {
+	stat, err := os.Stat(GlobalDir())
+	return err == nil && stat.IsDir()
+}
+
+// GetVersion returns the Gemini CLI version
+func (c *Client) GetVersion() string {
+	cmd := exec.Command("gemini", "--version")
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return string(output)
+}
+
+// determinePaths returns where artifacts are installed for scope
+// Gemini CLI reads settings.json and commands from both ~/.gemini and a project's .gemini
+func (c *Client) determinePaths(scope *clients.InstallScope) shared.Paths {
+	base := GlobalDir()
+	switch scope.Type {
+	case clients.ScopeRepository:
+		base = filepath.Join(scope.RepoRoot, ".gemini")
+	case clients.ScopePath:
+		base = filepath.Join(scope.RepoRoot, scope.Path, ".gemini")
+	}
+
+	return shared.Paths{
+		Base:      base,
+		Commands:  filepath.Join(base, "commands"),
+		MCPConfig: mcpconfig.NewJSONFile(filepath.Join(base, "settings.json"), mcpconfig.ServersKey),
+	}
+}
+
+// determineContextFile returns the GEMINI.md that lists the skills installed in scope
+func determineContextFile(scope *clients.InstallScope) string {
+	switch scope.Type {
+	case clients.ScopeRepository:
+		return filepath.Join(scope.RepoRoot, contextFileName)
+	case clients.ScopePath:
+		return filepath.Join(scope.RepoRoot, scope.Path, contextFileName)
+	default:
+		return filepath.Join(GlobalDir(), contextFileName)
+	}
+}
+
+// newHandler creates the handler for an artifact: commands are installed as custom commands
+func newHandler(meta *metadata.Metadata) (shared.Handler, error) {
+	switch meta.Artifact.Type {
+	case artifact.TypeSkill:
+		return shared.NewSkillHandler(meta), nil
+	case artifact.TypeCommand:
+		return NewCommandHandler(meta), nil
+	case artifact.TypeMCP:
+		return shared.NewMCPHandler(meta), nil
+	case artifact.TypeMCPRemote:
+		return shared.NewMCPRemoteHandler(meta), nil
+	default:
+		return nil, fmt.Errorf("unsupported artifact type: %s", meta.Artifact.Type.Key)
+	}
+}
+
+// InstallArtifacts installs artifacts to Gemini CLI using client-specific handlers
+func (c *Client) InstallArtifacts(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	return shared.InstallArtifacts(ctx, req, c.determinePaths(req.Scope), newHandler), nil
+}
+
+// UninstallArtifacts removes artifacts from Gemini CLI
+func (c *Client) UninstallArtifacts(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	return shared.UninstallArtifacts(ctx, req, c.determinePaths(req.Scope), newHandler), nil
+}
+
+// EnsureSkillsSupport registers the skills MCP server and keeps a list of skills in
+// the GEMINI.md of every scope visible from the current context. Gemini CLI loads
+// GEMINI.md files hierarchically, so each one only lists the skills of its own scope
+func (c *Client) EnsureSkillsSupport(ctx context.Context, scope *clients.InstallScope) error {
+	log := logger.Get()
+
+	entry, err := mcpconfig.SkillsServerEntry()
+	if err != nil {
+		return fmt.Errorf("failed to register MCP server: %w", err)
+	}
+	settings := c.determinePaths(&clients.InstallScope{Type: clients.ScopeGlobal}).MCPConfig
+	if err := settings.SetIfMissing(ctx, "skills", entry); err != nil {
+		return fmt.Errorf("failed to register MCP server: %w", err)
+	}
+
+	for _, chainScope := range clients.ScopeChain(scope) {
+		skills := skillsindex.Collect(ctx, c, chainScope)
+		contextFile := determineContextFile(chainScope)
+		log.Debug("updating skills in context file", "target", contextFile, "skill_count", len(skills))
+		if err := skillsindex.WriteBlock(ctx, contextFile, skillsindex.Content("", skills)); err != nil {
+			return fmt.Errorf("failed to update %s: %w", contextFileName, err)
+		}
+	}
+
+	return nil
+}
+
+// ListSkills returns all installed skills for a given scope
+func (c *Client) ListSkills(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	return shared.ListSkills(c.determinePaths(scope).Base)
+}
+
+// ReadSkill reads the content of a specific skill by name
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	return shared.ReadSkill(c.determinePaths(scope).Base, name)
+}
+
+// VerifyArtifacts checks if artifacts are actually installed on the filesystem
+func (c *Client) VerifyArtifacts(ctx context.Context, artifacts []*lockfile.Artifact, scope *clients.InstallScope) []clients.VerifyResult {
+	return shared.VerifyArtifacts(artifacts, c.determinePaths(scope), newHandler)
+}
@@ -0,0 +1,117 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/clienttest"
+)
+
+func TestClient_InstallVerifyUninstall(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+
+	client := NewClient()
+	if client.IsInstalled() {
+		t.Fatal("Expected Gemini CLI not to be detected without ~/.gemini")
+	}
+	geminiDir := filepath.Join(homeDir, ".gemini")
+	if err := os.MkdirAll(geminiDir, 0755); err != nil {
+		t.Fatalf("Failed to create .gemini directory: %v", err)
+	}
+	if !client.IsInstalled() {
+		t.Fatal("Expected Gemini CLI to be detected")
+	}
+
+	settingsPath := filepath.Join(geminiDir, "settings.json")
+	if err := os.WriteFile(settingsPath, []byte(`{"theme": "Dracula", "mcpServers": {"mine": {"command": "mine"}}}`), 0644); err != nil {
+		t.Fatalf("Failed to write settings.json: %v", err)
+	}
+
+	scope := &clients.InstallScope{Type: clients.ScopeGlobal}
+	clienttest.InstallVerifyUninstall(t, client, scope, func() {
+		var command struct {
+			Description string `toml:"description"`
+			Prompt      string `toml:"prompt"`
+		}
+		if _, err := toml.DecodeFile(filepath.Join(geminiDir, "commands", "deploy.toml"), &command); err != nil {
+			t.Fatalf("Failed to read custom command: %v", err)
+		}
+		if command.Description != "Deploy the app" || command.Prompt != "Deploy {{args}}" {
+			t.Errorf("Unexpected custom command: %+v", command)
+		}
+
+		var settings map[string]interface{}
+		data, _ := os.ReadFile(settingsPath)
+		if err := json.Unmarshal(data, &settings); err != nil {
+			t.Fatalf("Failed to parse settings.json: %v", err)
+		}
+		server := settings["mcpServers"].(map[string]interface{})["db"].(map[string]interface{})
+		if want := filepath.Join(geminiDir, "mcp-servers", "db", "index.js"); server["args"].([]interface{})[0] != want {
+			t.Errorf("Expected server args relative to its directory, got %v", server["args"])
+		}
+	})
+
+	data, _ := os.ReadFile(settingsPath)
+	if !strings.Contains(string(data), `"mine"`) || !strings.Contains(string(data), `"Dracula"`) {
+		t.Errorf("Expected other settings to be preserved, got %s", data)
+	}
+}
+
+func TestClient_EnsureSkillsSupport(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	repoRoot := t.TempDir()
+
+	contextPath := filepath.Join(repoRoot, "GEMINI.md")
+	if err := os.WriteFile(contextPath, []byte("# Project notes\n"), 0644); err != nil {
+		t.Fatalf("Failed to write GEMINI.md: %v", err)
+	}
+
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repoRoot}
+	bundle := clienttest.BuildBundle(t, map[string]string{
+		"metadata.toml": "[artifact]\nname = \"review\"\nversion = \"1.0.0\"\ntype = \"skill\"\ndescription = \"Review code\"\n\n[skill]\nprompt-file = \"SKILL.md\"\n",
+		"SKILL.md":      "Review carefully",
+	})
+	if _, err := client.InstallArtifacts(context.Background(), clients.InstallRequest{Artifacts: []*clients.ArtifactBundle{bundle}, Scope: scope}); err != nil {
+		t.Fatalf("InstallArtifacts failed: %v", err)
+	}
+
+	if err := client.EnsureSkillsSupport(context.Background(), scope); err != nil {
+		t.Fatalf("EnsureSkillsSupport failed: %v", err)
+	}
+
+	data, err := os.ReadFile(contextPath)
+	if err != nil {
+		t.Fatalf("Failed to read GEMINI.md: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "# Project notes\n") || !strings.Contains(string(data), "<name>review</name>") {
+		t.Errorf("Unexpected GEMINI.md:\n%s", data)
+	}
+	if _, err := os.Stat(filepath.Join(homeDir, ".gemini", "GEMINI.md")); !os.IsNotExist(err) {
+		t.Error("Expected no global GEMINI.md without global skills")
+	}
+
+	// Removing the skill removes its list but keeps the user's notes
+	if _, err := client.UninstallArtifacts(context.Background(), clients.UninstallRequest{
+		Artifacts: []artifact.Artifact{{Name: "review", Type: artifact.TypeSkill}},
+		Scope:     scope,
+	}); err != nil {
+		t.Fatalf("UninstallArtifacts failed: %v", err)
+	}
+	if err := client.EnsureSkillsSupport(context.Background(), scope); err != nil {
+		t.Fatalf("EnsureSkillsSupport failed: %v", err)
+	}
+	if data, _ := os.ReadFile(contextPath); string(data) != "# Project notes\n" {
+		t.Errorf("Expected only the user's notes to be left, got:\n%s", data)
+	}
+}
@@ -0,0 +1,81 @@
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sleuth-io/skills/internal/handlers/shared"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// commandFile is a Gemini CLI custom command
+type commandFile struct {
+	Description string `toml:"description,omitempty"`
+	Prompt      string `toml:"prompt"`
+}
+
+// CommandHandler installs commands as Gemini CLI custom commands in .gemini/commands/{name}.toml
+type CommandHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewCommandHandler creates a new command handler
+func NewCommandHandler(meta *metadata.Metadata) *CommandHandler {
+	return &CommandHandler{metadata: meta}
+}
+
+// Install converts the command's prompt to a custom command file
+func (h *CommandHandler) Install(ctx context.Context, zipData []byte, paths shared.Paths) error {
+	if h.metadata.Command == nil || h.metadata.Command.PromptFile == "" {
+		return fmt.Errorf("no prompt file specified in metadata")
+	}
+
+	promptContent, err := utils.ReadZipFile(zipData, h.metadata.Command.PromptFile)
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file: %w", err)
+	}
+
+	// Gemini CLI substitutes {{args}} where other clients use $ARGUMENTS
+	command := commandFile{
+		Description: h.metadata.Artifact.Description,
+		Prompt:      strings.ReplaceAll(string(promptContent), "$ARGUMENTS", "{{args}}"),
+	}
+
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(command); err != nil {
+		return fmt.Errorf("failed to encode command file: %w", err)
+	}
+
+	if err := transaction.WriteFile(ctx, h.commandPath(paths), buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write command file: %w", err)
+	}
+	return nil
+}
+
+// Remove removes the custom command file
+func (h *CommandHandler) Remove(ctx context.Context, paths shared.Paths) error {
+	if err := transaction.RemoveAll(ctx, h.commandPath(paths)); err != nil {
+		return fmt.Errorf("failed to remove command file: %w", err)
+	}
+	return nil
+}
+
+// VerifyInstalled checks if the custom command file exists
+func (h *CommandHandler) VerifyInstalled(paths shared.Paths) (bool, string) {
+	if !utils.FileExists(h.commandPath(paths)) {
+		return false, "command file not found"
+	}
+	// Custom commands don't have version tracking
+	return true, "installed"
+}
+
+func (h *CommandHandler) commandPath(paths shared.Paths) string {
+	return filepath.Join(paths.Commands, h.metadata.Artifact.Name+".toml")
+}
@@ -67,6 +67,13 @@ func (o *Orchestrator) InstallToClients(ctx context.Context,
 
 			resp, err := client.InstallArtifacts(ctx, req)
 			if err != nil {
+				// Client failed before reporting per-artifact results - fail them all
+				if len(resp.Results) == 0 {
+					for _, bundle := range compatibleArtifacts {
+						resp.Results = append(resp.Results, ArtifactResult{ArtifactName: bundle.Artifact.Name})
+					}
+				}
+
 				// Client returned error - ensure all results marked as failed
 				for i := range resp.Results {
 					if resp.Results[i].Status != StatusFailed {
@@ -0,0 +1,434 @@
+package profile
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/skillsindex"
+	"github.com/sleuth-io/skills/internal/handlers/dirartifact"
+	"github.com/sleuth-io/skills/internal/handlers/mcpconfig"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/logger"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// Client implements the clients.Client interface from a Profile
+type Client struct {
+	clients.BaseClient
+	clients.NoHooks // Profiles don't describe hooks
+	profile         *Profile
+}
+
+// NewClient creates a client that installs artifacts as described by p
+func NewClient(p *Profile) *Client {
+	var types []artifact.Type
+	for key := range p.Artifacts {
+		types = append(types, artifact.FromString(key))
+	}
+	return &Client{
+		BaseClient: clients.NewBaseClient(p.ID, p.Name, types),
+		profile:    p,
+	}
+}
+
+// Profile returns the profile the client was created from
+func (c *Client) Profile() *Profile {
+	return c.profile
+}
+
+// IsInstalled checks if any of the profile's detection paths exist
+func (c *Client) IsInstalled() bool {
+	for _, path := range c.profile.Detect {
+		if _, err := os.Stat(expandPath(path)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// GetVersion returns "" since profiles don't describe how to find a version
+func (c *Client) GetVersion() string {
+	return ""
+}
+
+// scopeDir returns the directory artifacts of scope are installed relative to
+// Profiles without a project directory install everything globally
+func (c *Client) scopeDir(scope *clients.InstallScope) (string, bool) {
+	if scope.Type == clients.ScopeGlobal || c.profile.Dirs.Project == "" {
+		return expandPath(c.profile.Dirs.Global), true
+	}
+	if scope.Type == clients.ScopePath {
+		return filepath.Join(scope.RepoRoot, scope.Path, c.profile.Dirs.Project), false
+	}
+	return filepath.Join(scope.RepoRoot, c.profile.Dirs.Project), false
+}
+
+// location returns where artifacts of type t are installed for scope
+func (c *Client) location(t artifact.Type, scope *clients.InstallScope) string {
+	dir, global := c.scopeDir(scope)
+	return c.profile.Artifacts[t.Key].resolve(dir, global)
+}
+
+// mcpConfig returns the MCP config file for scope
+func (c *Client) mcpConfig(scope *clients.InstallScope) *mcpconfig.File {
+	dir, global := c.scopeDir(scope)
+	key := c.profile.MCP.Key
+	if key == "" {
+		key = mcpconfig.ServersKey
+	}
+	return mcpconfig.NewJSONFile(c.profile.MCP.resolve(dir, global), key)
+}
+
+// dirOps returns directory operations for artifacts extracted into dir
+func dirOps(dir string, t *artifact.Type) (*dirartifact.Operations, string) {
+	return dirartifact.NewOperations(filepath.Base(dir), t), filepath.Dir(dir)
+}
+
+// promptPath returns the file a command or agent is written to
+func (c *Client) promptPath(t artifact.Type, name string, scope *clients.InstallScope) string {
+	ext := c.profile.Artifacts[t.Key].Extension
+	if ext == "" {
+		ext = ".md"
+	}
+	return filepath.Join(c.location(t, scope), name+ext)
+}
+
+// InstallArtifacts installs artifacts where the profile says they go
+func (c *Client) InstallArtifacts(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	resp := clients.InstallResponse{
+		Results: make([]clients.ArtifactResult, 0, len(req.Artifacts)),
+	}
+
+	for _, bundle := range req.Artifacts {
+		result := clients.ArtifactResult{
+			ArtifactName: bundle.Artifact.Name,
+		}
+
+		artifactType := bundle.Metadata.Artifact.Type
+		if !c.SupportsArtifactType(artifactType) {
+			result.Status = clients.StatusSkipped
+			result.Message = fmt.Sprintf("Unsupported artifact type: %s", artifactType.Key)
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		if err := c.install(ctx, bundle, req.Scope); err != nil {
+			result.Status = clients.StatusFailed
+			result.Error = err
+			result.Message = fmt.Sprintf("Installation failed: %v", err)
+		} else {
+			result.Status = clients.StatusSuccess
+			result.Message = fmt.Sprintf("Installed to %s", c.location(artifactType, req.Scope))
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) install(ctx context.Context, bundle *clients.ArtifactBundle, scope *clients.InstallScope) error {
+	meta := bundle.Metadata
+	name := meta.Artifact.Name
+
+	switch meta.Artifact.Type {
+	case artifact.TypeSkill:
+		ops, base := dirOps(c.location(artifact.TypeSkill, scope), &artifact.TypeSkill)
+		return ops.Install(ctx, bundle.ZipData, base, name)
+
+	case artifact.TypeCommand, artifact.TypeAgent:
+		promptFile := promptFileOf(meta)
+		if promptFile == "" {
+			return fmt.Errorf("no prompt file specified in metadata")
+		}
+		content, err := utils.ReadZipFile(bundle.ZipData, promptFile)
+		if err != nil {
+			return fmt.Errorf("failed to read prompt file: %w", err)
+		}
+		if err := transaction.WriteFile(ctx, c.promptPath(meta.Artifact.Type, name, scope), content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s file: %w", meta.Artifact.Type.Key, err)
+		}
+		return nil
+
+	case artifact.TypeMCP, artifact.TypeMCPRemote:
+		serverDir := ""
+		if meta.Artifact.Type == artifact.TypeMCP {
+			ops, base := dirOps(c.location(artifact.TypeMCP, scope), &artifact.TypeMCP)
+			if err := ops.Install(ctx, bundle.ZipData, base, name); err != nil {
+				return fmt.Errorf("failed to extract MCP server: %w", err)
+			}
+			serverDir = ops.GetArtifactDir(base, name)
+		}
+		entry, err := mcpconfig.Entry(meta, serverDir)
+		if err != nil {
+			return err
+		}
+		config := c.mcpConfig(scope)
+		if err := config.Set(ctx, name, entry); err != nil {
+			return fmt.Errorf("failed to update %s: %w", filepath.Base(config.Path), err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported artifact type: %s", meta.Artifact.Type.Key)
+}
+
+// promptFileOf returns the prompt file of a command or agent
+func promptFileOf(meta *metadata.Metadata) string {
+	if meta.Command != nil && meta.Command.PromptFile != "" {
+		return meta.Command.PromptFile
+	}
+	if meta.Agent != nil && meta.Agent.PromptFile != "" {
+		return meta.Agent.PromptFile
+	}
+	return ""
+}
+
+// UninstallArtifacts removes artifacts from where the profile says they go
+func (c *Client) UninstallArtifacts(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	resp := clients.UninstallResponse{
+		Results: make([]clients.ArtifactResult, 0, len(req.Artifacts)),
+	}
+
+	for _, art := range req.Artifacts {
+		result := clients.ArtifactResult{
+			ArtifactName: art.Name,
+		}
+
+		if !c.SupportsArtifactType(art.Type) {
+			result.Status = clients.StatusSkipped
+			result.Message = fmt.Sprintf("Unsupported artifact type: %s", art.Type.Key)
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		if err := c.remove(ctx, art, req.Scope); err != nil {
+			result.Status = clients.StatusFailed
+			result.Error = err
+		} else {
+			result.Status = clients.StatusSuccess
+			result.Message = "Uninstalled successfully"
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) remove(ctx context.Context, art artifact.Artifact, scope *clients.InstallScope) error {
+	switch art.Type {
+	case artifact.TypeSkill:
+		ops, base := dirOps(c.location(artifact.TypeSkill, scope), &artifact.TypeSkill)
+		return ops.Remove(ctx, base, art.Name)
+
+	case artifact.TypeCommand, artifact.TypeAgent:
+		if err := transaction.RemoveAll(ctx, c.promptPath(art.Type, art.Name, scope)); err != nil {
+			return fmt.Errorf("failed to remove %s file: %w", art.Type.Key, err)
+		}
+		return nil
+
+	case artifact.TypeMCP, artifact.TypeMCPRemote:
+		config := c.mcpConfig(scope)
+		if err := config.Delete(ctx, art.Name); err != nil {
+			return fmt.Errorf("failed to update %s: %w", filepath.Base(config.Path), err)
+		}
+		if c.SupportsArtifactType(artifact.TypeMCP) {
+			ops, base := dirOps(c.location(artifact.TypeMCP, scope), &artifact.TypeMCP)
+			return ops.Remove(ctx, base, art.Name) // Remote servers have no directory
+		}
+		return nil
+	}
+
+	return fmt.Errorf("unsupported artifact type: %s", art.Type.Key)
+}
+
+// EnsureSkillsSupport registers the skills MCP server and writes the profile's rules index
+func (c *Client) EnsureSkillsSupport(ctx context.Context, scope *clients.InstallScope) error {
+	log := logger.Get()
+
+	if !c.SupportsArtifactType(artifact.TypeSkill) {
+		return nil
+	}
+
+	if c.profile.MCP != nil {
+		entry, err := mcpconfig.SkillsServerEntry()
+		if err != nil {
+			return fmt.Errorf("failed to register MCP server: %w", err)
+		}
+		if err := c.mcpConfig(&clients.InstallScope{Type: clients.ScopeGlobal}).SetIfMissing(ctx, "skills", entry); err != nil {
+			return fmt.Errorf("failed to register MCP server: %w", err)
+		}
+	}
+
+	rules := c.profile.Rules
+	if rules == nil {
+		return nil
+	}
+
+	if rules.Hierarchical {
+		for _, chainScope := range clients.ScopeChain(scope) {
+			dir, global := c.scopeDir(chainScope)
+			if err := c.writeRules(ctx, rules.resolve(dir, global), skillsindex.Collect(ctx, c, chainScope)); err != nil {
+				return err
+			}
+			if global && chainScope.Type != clients.ScopeGlobal {
+				break // Without a project directory every scope shares the global rules file
+			}
+		}
+		return nil
+	}
+
+	localDir, err := c.localDir(scope)
+	if err != nil {
+		log.Warn("no local target for rules file", "client", c.ID(), "error", err)
+		return nil
+	}
+	return c.writeRules(ctx, rules.resolve(localDir, false), skillsindex.Collect(ctx, c, clients.ScopeChain(scope)...))
+}
+
+// localDir returns the project directory of the current context, for clients that
+// only read rules from the project they're working in
+func (c *Client) localDir(scope *clients.InstallScope) (string, error) {
+	if c.profile.Dirs.Project == "" {
+		dir, _ := c.scopeDir(scope)
+		return dir, nil
+	}
+	if scope.Type != clients.ScopeGlobal && scope.RepoRoot != "" {
+		dir, _ := c.scopeDir(scope)
+		return dir, nil
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cwd, c.profile.Dirs.Project), nil
+}
+
+// writeRules renders the rules template for skills and writes it to path
+func (c *Client) writeRules(ctx context.Context, path string, skills []clients.InstalledSkill) error {
+	rules := c.profile.Rules
+	tmpl := rules.Template
+	if tmpl == "" {
+		tmpl = "{{ .Index }}"
+		if rules.Mode != RulesModeBlock {
+			tmpl = skillsindex.GeneratedNotice + tmpl
+		}
+	}
+
+	content, err := skillsindex.RenderTemplate(tmpl, skills)
+	if err != nil {
+		return fmt.Errorf("client profile %s: %w", c.ID(), err)
+	}
+
+	logger.Get().Debug("generating rules file", "client", c.ID(), "target", path, "skill_count", len(skills))
+	if rules.Mode == RulesModeBlock {
+		return skillsindex.WriteBlock(ctx, path, content)
+	}
+	return skillsindex.WriteFile(ctx, path, content)
+}
+
+// ListSkills returns all installed skills for a given scope
+func (c *Client) ListSkills(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	if !c.SupportsArtifactType(artifact.TypeSkill) {
+		return nil, nil
+	}
+
+	ops, base := dirOps(c.location(artifact.TypeSkill, scope), &artifact.TypeSkill)
+	installed, err := ops.ScanInstalled(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan installed skills: %w", err)
+	}
+
+	skills := make([]clients.InstalledSkill, 0, len(installed))
+	for _, info := range installed {
+		skills = append(skills, clients.InstalledSkill{
+			Name:        info.Name,
+			Description: info.Description,
+			Version:     info.Version,
+			Keywords:    info.Keywords,
+			Triggers:    info.Triggers,
+		})
+	}
+
+	return skills, nil
+}
+
+// ReadSkill reads the content of a specific skill by name
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	if !c.SupportsArtifactType(artifact.TypeSkill) {
+		return nil, fmt.Errorf("%s does not support skills", c.DisplayName())
+	}
+
+	ops, base := dirOps(c.location(artifact.TypeSkill, scope), &artifact.TypeSkill)
+	result, err := ops.ReadPromptContent(base, name, "SKILL.md", func(m *metadata.Metadata) string { return m.Skill.PromptFile })
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients.SkillContent{
+		Name:        name,
+		Description: result.Description,
+		Version:     result.Version,
+		Content:     result.Content,
+		BaseDir:     result.BaseDir,
+	}, nil
+}
+
+// VerifyArtifacts checks if artifacts are actually installed on the filesystem
+func (c *Client) VerifyArtifacts(ctx context.Context, artifacts []*lockfile.Artifact, scope *clients.InstallScope) []clients.VerifyResult {
+	results := make([]clients.VerifyResult, 0, len(artifacts))
+
+	for _, art := range artifacts {
+		result := clients.VerifyResult{
+			Artifact: art,
+		}
+		if !c.SupportsArtifactType(art.Type) {
+			result.Message = fmt.Sprintf("unsupported artifact type: %s", art.Type.Key)
+		} else {
+			result.Installed, result.Message = c.verify(art, scope)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (c *Client) verify(art *lockfile.Artifact, scope *clients.InstallScope) (bool, string) {
+	switch art.Type {
+	case artifact.TypeSkill:
+		ops, base := dirOps(c.location(artifact.TypeSkill, scope), &artifact.TypeSkill)
+		return ops.VerifyInstalled(base, art.Name, art.Version)
+
+	case artifact.TypeCommand, artifact.TypeAgent:
+		if !utils.FileExists(c.promptPath(art.Type, art.Name, scope)) {
+			return false, art.Type.Key + " file not found"
+		}
+		return true, "installed"
+
+	case artifact.TypeMCP, artifact.TypeMCPRemote:
+		if art.Type == artifact.TypeMCP {
+			ops, base := dirOps(c.location(artifact.TypeMCP, scope), &artifact.TypeMCP)
+			if installed, message := ops.VerifyInstalled(base, art.Name, art.Version); !installed {
+				return false, message
+			}
+		}
+		config := c.mcpConfig(scope)
+		registered, err := config.Has(art.Name)
+		if err != nil {
+			return false, "failed to read " + filepath.Base(config.Path) + ": " + err.Error()
+		}
+		if !registered {
+			return false, "MCP server not registered"
+		}
+		return true, "installed"
+	}
+
+	return false, "unsupported artifact type: " + art.Type.Key
+}
@@ -0,0 +1,273 @@
+package profile
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/logger"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+//go:embed profiles/*.yaml
+var builtinProfiles embed.FS
+
+// ProfilesDirName is the directory under the config dir that custom profiles are loaded from
+const ProfilesDirName = "clients"
+
+var idRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Rules file modes
+const (
+	RulesModeFile  = "file"  // skills owns the whole rules file
+	RulesModeBlock = "block" // skills keeps a marked block in a file the user also edits
+)
+
+// Profile declaratively describes a client that only needs files in directories,
+// an MCP JSON config and a rules index
+type Profile struct {
+	ID     string   `yaml:"id"`     // Machine name used with --client
+	Name   string   `yaml:"name"`   // Human name
+	Detect []string `yaml:"detect"` // The client is installed if any of these paths exist
+
+	Dirs Dirs `yaml:"dirs"`
+
+	// Artifacts maps supported artifact types (skill, command, agent, mcp, mcp-remote)
+	// to where they are installed, relative to the scope's directory
+	Artifacts map[string]Location `yaml:"artifacts"`
+
+	MCP   *MCP   `yaml:"mcp,omitempty"`
+	Rules *Rules `yaml:"rules,omitempty"`
+
+	source string // Where the profile was loaded from, for error messages
+}
+
+// Dirs are the directories each scope installs into
+type Dirs struct {
+	Global  string `yaml:"global"`  // Directory for global installs, e.g. ~/.roo
+	Project string `yaml:"project"` // Directory for repository and path installs, relative to them, e.g. .roo
+}
+
+// Location is a path relative to the scope's directory; GlobalPath replaces it for
+// global installs. Paths may be absolute and may start with ~, {home} or {config}
+// (the platform's user config directory, e.g. ~/.config on Linux)
+type Location struct {
+	Path       string `yaml:"path,omitempty"`
+	GlobalPath string `yaml:"global_path,omitempty"`
+	Extension  string `yaml:"extension,omitempty"` // For commands and agents, defaults to .md
+}
+
+// MCP describes the JSON file MCP servers are registered in
+type MCP struct {
+	Location `yaml:",inline"`
+	Key      string `yaml:"key"` // Key of the servers object, defaults to mcpServers
+}
+
+// Rules describes the rules file that lists installed skills
+type Rules struct {
+	Location `yaml:",inline"`
+
+	// Mode is "file" (default) or "block"
+	Mode string `yaml:"mode,omitempty"`
+
+	// Hierarchical clients load the rules file of every scope, so each one only lists
+	// its own scope's skills. Otherwise a single file in the current repository
+	// (or working directory) lists the skills of every visible scope
+	Hierarchical bool `yaml:"hierarchical,omitempty"`
+
+	// Template is a text/template for the file or block, with .Skills (name, description,
+	// version) and .Index, the default listing. Defaults to the index alone
+	Template string `yaml:"template,omitempty"`
+}
+
+// Parse parses and validates a profile
+func Parse(data []byte) (*Profile, error) {
+	var p Profile
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse client profile: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks that the profile is complete and consistent
+func (p *Profile) Validate() error {
+	if !idRegex.MatchString(p.ID) {
+		return fmt.Errorf("invalid client profile id %q (must be lowercase letters, digits and dashes)", p.ID)
+	}
+	if p.Name == "" {
+		return fmt.Errorf("client profile %s: name is required", p.ID)
+	}
+	if len(p.Detect) == 0 {
+		return fmt.Errorf("client profile %s: detect needs at least one path", p.ID)
+	}
+	if p.Dirs.Global == "" {
+		return fmt.Errorf("client profile %s: dirs.global is required", p.ID)
+	}
+	if len(p.Artifacts) == 0 {
+		return fmt.Errorf("client profile %s: artifacts needs at least one artifact type", p.ID)
+	}
+
+	for key, location := range p.Artifacts {
+		switch artifact.FromString(key) {
+		case artifact.TypeSkill, artifact.TypeCommand, artifact.TypeAgent, artifact.TypeMCP:
+			if location.Path == "" {
+				return fmt.Errorf("client profile %s: artifacts.%s.path is required", p.ID, key)
+			}
+		case artifact.TypeMCPRemote:
+		default:
+			return fmt.Errorf("client profile %s: unsupported artifact type %q", p.ID, key)
+		}
+	}
+
+	if p.supports(artifact.TypeMCP) || p.supports(artifact.TypeMCPRemote) {
+		if p.MCP == nil || p.MCP.Path == "" {
+			return fmt.Errorf("client profile %s: mcp.path is required to install MCP servers", p.ID)
+		}
+	}
+
+	if p.Rules != nil {
+		if p.Rules.Path == "" {
+			return fmt.Errorf("client profile %s: rules.path is required", p.ID)
+		}
+		if p.Rules.Mode != "" && p.Rules.Mode != RulesModeFile && p.Rules.Mode != RulesModeBlock {
+			return fmt.Errorf("client profile %s: rules.mode must be %q or %q", p.ID, RulesModeFile, RulesModeBlock)
+		}
+	}
+
+	return nil
+}
+
+// supports reports whether the profile installs artifacts of type t
+func (p *Profile) supports(t artifact.Type) bool {
+	_, ok := p.Artifacts[t.Key]
+	return ok
+}
+
+// resolve returns the location's path for a scope whose directory is scopeDir
+func (l Location) resolve(scopeDir string, global bool) string {
+	path := l.Path
+	if global && l.GlobalPath != "" {
+		path = l.GlobalPath
+	}
+	path = expandPath(path)
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(scopeDir, path)
+}
+
+// expandPath expands a leading ~, {home} or {config}
+func expandPath(path string) string {
+	home, _ := os.UserHomeDir()
+	switch {
+	case path == "~" || strings.HasPrefix(path, "~/"):
+		return filepath.Join(home, strings.TrimPrefix(path, "~"))
+	case strings.HasPrefix(path, "{home}"):
+		return filepath.Join(home, strings.TrimPrefix(path, "{home}"))
+	case strings.HasPrefix(path, "{config}"):
+		configDir, err := os.UserConfigDir()
+		if err != nil {
+			configDir = filepath.Join(home, ".config")
+		}
+		return filepath.Join(configDir, strings.TrimPrefix(path, "{config}"))
+	}
+	return filepath.FromSlash(path)
+}
+
+// Load returns the built-in profiles and those in the config dir's clients directory,
+// sorted by ID. A custom profile replaces a built-in one with the same ID, and
+// invalid custom profiles are logged and skipped
+func Load() []*Profile {
+	log := logger.Get()
+	byID := make(map[string]*Profile)
+
+	entries, _ := builtinProfiles.ReadDir("profiles")
+	for _, entry := range entries {
+		data, err := builtinProfiles.ReadFile("profiles/" + entry.Name())
+		if err != nil {
+			continue
+		}
+		p, err := Parse(data)
+		if err != nil {
+			log.Error("invalid built-in client profile", "file", entry.Name(), "error", err)
+			continue
+		}
+		p.source = "built-in"
+		byID[p.ID] = p
+	}
+
+	if configDir, err := utils.GetConfigDir(); err == nil {
+		custom, err := LoadDir(filepath.Join(configDir, ProfilesDirName))
+		if err != nil {
+			log.Warn("failed to load client profiles", "error", err)
+		}
+		for _, p := range custom {
+			byID[p.ID] = p
+		}
+	}
+
+	profiles := make([]*Profile, 0, len(byID))
+	for _, p := range byID {
+		profiles = append(profiles, p)
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].ID < profiles[j].ID })
+	return profiles
+}
+
+// LoadDir loads the *.yaml and *.yml profiles in dir, skipping invalid ones with a warning
+func LoadDir(dir string) ([]*Profile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var profiles []*Profile
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			logger.Get().Warn("failed to read client profile", "path", path, "error", err)
+			continue
+		}
+		p, err := Parse(data)
+		if err != nil {
+			logger.Get().Warn("skipping invalid client profile", "path", path, "error", err)
+			continue
+		}
+		p.source = path
+		profiles = append(profiles, p)
+	}
+
+	return profiles, nil
+}
+
+// Register adds a client for each profile to registry, leaving clients that are
+// already registered (such as built-in ones) in place
+func Register(registry *clients.Registry, profiles []*Profile) {
+	for _, p := range profiles {
+		if _, err := registry.Get(p.ID); err == nil {
+			logger.Get().Warn("client profile ignored, a client with its id already exists", "id", p.ID, "source", p.source)
+			continue
+		}
+		registry.Register(NewClient(p))
+	}
+}
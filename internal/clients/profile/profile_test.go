@@ -0,0 +1,201 @@
+package profile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/clienttest"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+const testProfile = `
+id: acme
+name: Acme Assistant
+detect:
+  - ~/.acme
+dirs:
+  global: ~/.acme
+  project: .acme
+artifacts:
+  skill:
+    path: skills
+  command:
+    path: prompts
+    global_path: ~/.acme/global-prompts
+  mcp:
+    path: servers
+  mcp-remote: {}
+mcp:
+  path: ~/.acme/tools.json
+  key: tools
+rules:
+  path: ../ACME.md
+  global_path: ~/.acme/ACME.md
+  mode: block
+  hierarchical: true
+  template: |
+    Skills:{{ range .Skills }} {{ .Name }}{{ end }}
+`
+
+func TestParse(t *testing.T) {
+	if _, err := Parse([]byte(testProfile)); err != nil {
+		t.Fatalf("Parse(valid profile) failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		profile string
+		wantErr string
+	}{
+		{"invalid id", "id: Acme\nname: A\ndetect: [x]\ndirs: {global: x}\nartifacts: {skill: {path: s}}", "invalid client profile id"},
+		{"missing detect", "id: a\nname: A\ndirs: {global: x}\nartifacts: {skill: {path: s}}", "detect"},
+		{"missing global dir", "id: a\nname: A\ndetect: [x]\nartifacts: {skill: {path: s}}", "dirs.global"},
+		{"unsupported type", "id: a\nname: A\ndetect: [x]\ndirs: {global: x}\nartifacts: {hook: {path: h}}", "unsupported artifact type"},
+		{"mcp without config", "id: a\nname: A\ndetect: [x]\ndirs: {global: x}\nartifacts: {mcp-remote: {}}", "mcp.path"},
+		{"bad rules mode", "id: a\nname: A\ndetect: [x]\ndirs: {global: x}\nartifacts: {skill: {path: s}}\nrules: {path: r.md, mode: append}", "rules.mode"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.profile))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("SKILLS_CONFIG_DIR", configDir)
+
+	profilesDir := filepath.Join(configDir, ProfilesDirName)
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		t.Fatalf("Failed to create profiles directory: %v", err)
+	}
+	files := map[string]string{
+		"acme.yaml":    testProfile,
+		"roo.yml":      "id: roo-code\nname: My Roo\ndetect: [~/.roo]\ndirs: {global: ~/.roo}\nartifacts: {skill: {path: skills}}\n",
+		"broken.yaml":  "id: [",
+		"invalid.yaml": "id: nope\n",
+		"notes.txt":    "id: ignored\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(profilesDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	byID := make(map[string]*Profile)
+	for _, p := range Load() {
+		byID[p.ID] = p
+	}
+
+	for _, id := range []string{"acme", "amazon-q", "roo-code"} {
+		if byID[id] == nil {
+			t.Errorf("Expected profile %s to be loaded", id)
+		}
+	}
+	for _, id := range []string{"nope", "ignored"} {
+		if byID[id] != nil {
+			t.Errorf("Expected invalid profile %s to be skipped", id)
+		}
+	}
+	if p := byID["roo-code"]; p != nil && p.Name != "My Roo" {
+		t.Errorf("Expected custom profile to replace the built-in one, got %q", p.Name)
+	}
+
+	registry := clients.NewRegistry()
+	registry.Register(NewClient(&Profile{ID: "acme", Name: "Built-in Acme"}))
+	Register(registry, Load())
+	if client, _ := registry.Get("acme"); client.DisplayName() != "Built-in Acme" {
+		t.Error("Expected an existing client not to be replaced by a profile")
+	}
+	if _, err := registry.Get("amazon-q"); err != nil {
+		t.Errorf("Expected profile client to be registered: %v", err)
+	}
+}
+
+func TestClient_InstallVerifyUninstall(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	repoRoot := t.TempDir()
+
+	p, err := Parse([]byte(testProfile))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	client := NewClient(p)
+
+	if client.IsInstalled() {
+		t.Fatal("Expected client not to be detected without ~/.acme")
+	}
+	if err := os.MkdirAll(filepath.Join(homeDir, ".acme"), 0755); err != nil {
+		t.Fatalf("Failed to create .acme directory: %v", err)
+	}
+	if !client.IsInstalled() {
+		t.Fatal("Expected client to be detected")
+	}
+	if client.SupportsArtifactType(artifact.TypeHook) || !client.SupportsArtifactType(artifact.TypeMCPRemote) {
+		t.Error("Expected supported types to follow the profile's artifacts")
+	}
+
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repoRoot}
+	clienttest.InstallVerifyUninstall(t, client, scope, func() {
+		for _, path := range []string{
+			filepath.Join(repoRoot, ".acme", "skills", "review", "SKILL.md"),
+			filepath.Join(repoRoot, ".acme", "prompts", "deploy.md"),
+			filepath.Join(repoRoot, ".acme", "servers", "db", "index.js"),
+		} {
+			if !utils.FileExists(path) {
+				t.Errorf("Expected %s to be installed", path)
+			}
+		}
+		tools, _ := os.ReadFile(filepath.Join(homeDir, ".acme", "tools.json"))
+		if !strings.Contains(string(tools), `"tools"`) || !strings.Contains(string(tools), `"db"`) {
+			t.Errorf("Expected MCP server under the profile's key, got %s", tools)
+		}
+
+		// The block goes in the repository's ACME.md; no global skills means no global block
+		if err := os.WriteFile(filepath.Join(repoRoot, "ACME.md"), []byte("# Team notes\n"), 0644); err != nil {
+			t.Fatalf("Failed to write ACME.md: %v", err)
+		}
+		if err := client.EnsureSkillsSupport(context.Background(), scope); err != nil {
+			t.Fatalf("EnsureSkillsSupport failed: %v", err)
+		}
+		rules, _ := os.ReadFile(filepath.Join(repoRoot, "ACME.md"))
+		if !strings.HasPrefix(string(rules), "# Team notes\n") || !strings.Contains(string(rules), "Skills: review") {
+			t.Errorf("Unexpected ACME.md:\n%s", rules)
+		}
+		if utils.FileExists(filepath.Join(homeDir, ".acme", "ACME.md")) {
+			t.Error("Expected no global rules without global skills")
+		}
+		tools, _ = os.ReadFile(filepath.Join(homeDir, ".acme", "tools.json"))
+		if !strings.Contains(string(tools), `"skills"`) {
+			t.Errorf("Expected skills MCP server to be registered, got %s", tools)
+		}
+	})
+}
+
+func TestClient_GlobalLocations(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+
+	p, err := Parse([]byte(testProfile))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	client := NewClient(p)
+	global := &clients.InstallScope{Type: clients.ScopeGlobal}
+
+	if got, want := client.promptPath(artifact.TypeCommand, "deploy", global), filepath.Join(homeDir, ".acme", "global-prompts", "deploy.md"); got != want {
+		t.Errorf("global command path = %s, want %s", got, want)
+	}
+	if got, want := client.location(artifact.TypeSkill, global), filepath.Join(homeDir, ".acme", "skills"); got != want {
+		t.Errorf("global skills path = %s, want %s", got, want)
+	}
+}
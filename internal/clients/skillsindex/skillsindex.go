@@ -0,0 +1,152 @@
+package skillsindex
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/transaction"
+)
+
+// GeneratedNotice starts rules files that skills owns
+const GeneratedNotice = "<!-- AUTO-GENERATED by Sleuth Skills - Do not edit manually -->\n<!-- Run 'skills install' to regenerate this file -->\n\n"
+
+// Markers delimit the skills index inside files the user also edits, such as AGENTS.md
+const (
+	BeginMarker = "<!-- BEGIN SKILLS: AUTO-GENERATED by Sleuth Skills - run 'skills install' to regenerate -->"
+	EndMarker   = "<!-- END SKILLS -->"
+)
+
+// Lister lists the skills installed in a scope
+type Lister interface {
+	ListSkills(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error)
+}
+
+// Collect lists the skills installed in scopes, earlier scopes hiding skills of the same name in later ones
+func Collect(ctx context.Context, lister Lister, scopes ...*clients.InstallScope) []clients.InstalledSkill {
+	var all []clients.InstalledSkill
+	seen := make(map[string]bool)
+	for _, scope := range scopes {
+		skills, err := lister.ListSkills(ctx, scope)
+		if err != nil {
+			continue
+		}
+		for _, skill := range skills {
+			if !seen[skill.Name] {
+				seen[skill.Name] = true
+				all = append(all, skill)
+			}
+		}
+	}
+	return all
+}
+
+// Render returns markdown telling the assistant which skills exist and how to load them
+func Render(skills []clients.InstalledSkill) string {
+	var list strings.Builder
+	for _, skill := range skills {
+		fmt.Fprintf(&list, "\n<skill>\n<name>%s</name>\n<description>%s</description>\n</skill>\n", skill.Name, skill.Description)
+	}
+
+	return fmt.Sprintf(`## Available Skills
+
+You have access to the following skills. When a user's task matches a skill, use the %sread_skill%s MCP tool to load full instructions.
+
+<available_skills>
+%s
+</available_skills>
+
+## Usage
+
+Invoke %sread_skill(name: "skill-name")%s via the MCP tool when needed.
+
+The tool returns the skill content as markdown. Any %s@filename%s references in the content are automatically resolved to absolute paths.
+`, "`", "`", list.String(), "`", "`", "`", "`")
+}
+
+// Content returns header followed by the rendered index, or "" when there are no skills
+func Content(header string, skills []clients.InstalledSkill) string {
+	if len(skills) == 0 {
+		return ""
+	}
+	return header + Render(skills)
+}
+
+// RenderTemplate renders a text/template with the skills as .Skills and the
+// default rendering as .Index, or returns "" when there are no skills
+func RenderTemplate(text string, skills []clients.InstalledSkill) (string, error) {
+	if len(skills) == 0 {
+		return "", nil
+	}
+
+	tmpl, err := template.New("rules").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse rules template: %w", err)
+	}
+
+	var buf strings.Builder
+	data := struct {
+		Skills []clients.InstalledSkill
+		Index  string
+	}{skills, Render(skills)}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render rules template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// WriteFile writes a rules file owned by skills, removing it when content is empty
+func WriteFile(ctx context.Context, path, content string) error {
+	if content == "" {
+		return transaction.RemoveAll(ctx, path)
+	}
+	return transaction.WriteFile(ctx, path, []byte(content), 0644)
+}
+
+// WriteBlock keeps content in a marked block of a file the user may also edit,
+// replacing an existing block or appending one. The block is removed when content is
+// empty, along with the file if nothing else is left in it
+func WriteBlock(ctx context.Context, path, content string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	existing := string(data)
+
+	before, after := existing, ""
+	if start := strings.Index(existing, BeginMarker); start >= 0 {
+		before = existing[:start]
+		if end := strings.Index(existing[start:], EndMarker); end >= 0 {
+			after = existing[start+end+len(EndMarker):]
+		}
+	}
+	before = strings.TrimRight(before, "\n")
+	after = strings.TrimLeft(after, "\n")
+
+	var parts []string
+	if before != "" {
+		parts = append(parts, before)
+	}
+	if content != "" {
+		parts = append(parts, BeginMarker+"\n\n"+strings.TrimRight(content, "\n")+"\n\n"+EndMarker)
+	}
+	if after != "" {
+		parts = append(parts, strings.TrimRight(after, "\n"))
+	}
+
+	if len(parts) == 0 {
+		if existing == "" {
+			return nil
+		}
+		return transaction.RemoveAll(ctx, path)
+	}
+
+	updated := strings.Join(parts, "\n\n") + "\n"
+	if updated == existing {
+		return nil
+	}
+	return transaction.WriteFile(ctx, path, []byte(updated), 0644)
+}
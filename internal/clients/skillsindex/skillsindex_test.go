@@ -0,0 +1,64 @@
+package skillsindex
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/clients"
+)
+
+func TestWriteBlock(t *testing.T) {
+	skills := []clients.InstalledSkill{{Name: "review", Description: "Review code"}}
+	content := Content("", skills)
+	block := BeginMarker + "\n\n" + strings.TrimRight(content, "\n") + "\n\n" + EndMarker + "\n"
+
+	tests := []struct {
+		name     string
+		existing string // "" means no file
+		skills   []clients.InstalledSkill
+		want     string // "" means no file
+	}{
+		{"creates file", "", skills, block},
+		{"appends to user content", "# Notes\n", skills, "# Notes\n\n" + block},
+		{"replaces existing block", "# Notes\n\n" + BeginMarker + "\nold\n" + EndMarker + "\n\n# More\n", skills, "# Notes\n\n" + block + "\n# More\n"},
+		{"removes block", "# Notes\n\n" + block + "\n# More\n", nil, "# Notes\n\n# More\n"},
+		{"removes file left empty", block, nil, ""},
+		{"no skills and no file", "", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "AGENTS.md")
+			if tt.existing != "" {
+				if err := os.WriteFile(path, []byte(tt.existing), 0644); err != nil {
+					t.Fatalf("Failed to write file: %v", err)
+				}
+			}
+
+			if err := WriteBlock(context.Background(), path, Content("", tt.skills)); err != nil {
+				t.Fatalf("WriteBlock failed: %v", err)
+			}
+
+			data, err := os.ReadFile(path)
+			if tt.want == "" {
+				if !os.IsNotExist(err) {
+					t.Errorf("Expected no file, got %q", data)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to read file: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("WriteBlock() wrote:\n%s\nwant:\n%s", data, tt.want)
+			}
+		})
+	}
+
+	if !strings.Contains(block, "<name>review</name>") {
+		t.Errorf("Expected rendered skills in block, got %s", block)
+	}
+}
@@ -0,0 +1,165 @@
+package windsurf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/skillsindex"
+	"github.com/sleuth-io/skills/internal/handlers/mcpconfig"
+	"github.com/sleuth-io/skills/internal/handlers/shared"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/logger"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+// rulesHeader is the frontmatter that makes Windsurf apply the skills rule to every conversation
+const rulesHeader = "---\ntrigger: always_on\ndescription: Available skills for AI assistance\n---\n\n"
+
+// Client implements the clients.Client interface for Windsurf
+type Client struct {
+	clients.BaseClient
+	clients.NoHooks // Windsurf has no hooks to run installs from
+}
+
+// NewClient creates a new Windsurf client
+func NewClient() *Client {
+	return &Client{
+		BaseClient: clients.NewBaseClient(
+			"windsurf",
+			"Windsurf",
+			[]artifact.Type{
+				artifact.TypeMCP,
+				artifact.TypeMCPRemote,
+				artifact.TypeSkill,   // Listed in .windsurf/rules/skills.md, read via the skills MCP server
+				artifact.TypeCommand, // Installed as workflows
+			},
+		),
+	}
+}
+
+// GlobalDir returns Windsurf's global configuration directory
+func GlobalDir() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".codeium", "windsurf")
+}
+
+// IsInstalled checks if Windsurf is installed by checking for its global configuration directory
+func (c *Client) IsInstalled() bool {
+	stat, err := os.Stat(GlobalDir())
+	return err == nil && stat.IsDir()
+}
+
+// GetVersion returns the Windsurf version
+func (c *Client) GetVersion() string {
+	// Windsurf is an editor without a version command
+	return ""
+}
+
+// determinePaths returns where artifacts are installed for scope
+// Windsurf only reads MCP servers and global workflows from its global directory
+func (c *Client) determinePaths(scope *clients.InstallScope) shared.Paths {
+	global := GlobalDir()
+	paths := shared.Paths{
+		Base:      global,
+		Commands:  filepath.Join(global, "global_workflows"),
+		MCPConfig: mcpconfig.NewJSONFile(filepath.Join(global, "mcp_config.json"), mcpconfig.ServersKey),
+	}
+
+	switch scope.Type {
+	case clients.ScopeRepository:
+		paths.Base = filepath.Join(scope.RepoRoot, ".windsurf")
+		paths.Commands = filepath.Join(paths.Base, "workflows")
+	case clients.ScopePath:
+		paths.Base = filepath.Join(scope.RepoRoot, scope.Path, ".windsurf")
+		paths.Commands = filepath.Join(paths.Base, "workflows")
+	}
+
+	return paths
+}
+
+// newHandler creates the handler for an artifact: commands are installed as workflows
+func newHandler(meta *metadata.Metadata) (shared.Handler, error) {
+	switch meta.Artifact.Type {
+	case artifact.TypeSkill:
+		return shared.NewSkillHandler(meta), nil
+	case artifact.TypeCommand:
+		return NewWorkflowHandler(meta), nil
+	case artifact.TypeMCP:
+		return shared.NewMCPHandler(meta), nil
+	case artifact.TypeMCPRemote:
+		return shared.NewMCPRemoteHandler(meta), nil
+	default:
+		return nil, fmt.Errorf("unsupported artifact type: %s", meta.Artifact.Type.Key)
+	}
+}
+
+// InstallArtifacts installs artifacts to Windsurf using client-specific handlers
+func (c *Client) InstallArtifacts(ctx context.Context, req clients.InstallRequest) (clients.InstallResponse, error) {
+	return shared.InstallArtifacts(ctx, req, c.determinePaths(req.Scope), newHandler), nil
+}
+
+// UninstallArtifacts removes artifacts from Windsurf
+func (c *Client) UninstallArtifacts(ctx context.Context, req clients.UninstallRequest) (clients.UninstallResponse, error) {
+	return shared.UninstallArtifacts(ctx, req, c.determinePaths(req.Scope), newHandler), nil
+}
+
+// EnsureSkillsSupport registers the skills MCP server and writes .windsurf/rules/skills.md
+// listing the skills from every scope visible from the current context
+func (c *Client) EnsureSkillsSupport(ctx context.Context, scope *clients.InstallScope) error {
+	log := logger.Get()
+
+	entry, err := mcpconfig.SkillsServerEntry()
+	if err != nil {
+		return fmt.Errorf("failed to register MCP server: %w", err)
+	}
+	mcpConfig := c.determinePaths(&clients.InstallScope{Type: clients.ScopeGlobal}).MCPConfig
+	if err := mcpConfig.SetIfMissing(ctx, "skills", entry); err != nil {
+		return fmt.Errorf("failed to register MCP server: %w", err)
+	}
+
+	localTarget := determineLocalTarget(scope)
+	if localTarget == "" {
+		log.Warn("no local target for rules file", "scope_type", scope.Type, "repo_root", scope.RepoRoot)
+		return nil
+	}
+
+	skills := skillsindex.Collect(ctx, c, clients.ScopeChain(scope)...)
+	log.Debug("generating rules file", "target", localTarget, "skill_count", len(skills))
+
+	return skillsindex.WriteFile(ctx, filepath.Join(localTarget, "rules", "skills.md"), skillsindex.Content(rulesHeader+skillsindex.GeneratedNotice, skills))
+}
+
+// determineLocalTarget returns the .windsurf directory the rules file is written to
+func determineLocalTarget(scope *clients.InstallScope) string {
+	switch {
+	case scope.Type == clients.ScopePath && scope.RepoRoot != "" && scope.Path != "":
+		return filepath.Join(scope.RepoRoot, scope.Path, ".windsurf")
+	case scope.RepoRoot != "":
+		return filepath.Join(scope.RepoRoot, ".windsurf")
+	default:
+		cwd, err := os.Getwd()
+		if err != nil {
+			return ""
+		}
+		return filepath.Join(cwd, ".windsurf")
+	}
+}
+
+// ListSkills returns all installed skills for a given scope
+func (c *Client) ListSkills(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	return shared.ListSkills(c.determinePaths(scope).Base)
+}
+
+// ReadSkill reads the content of a specific skill by name
+func (c *Client) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	return shared.ReadSkill(c.determinePaths(scope).Base, name)
+}
+
+// VerifyArtifacts checks if artifacts are actually installed on the filesystem
+func (c *Client) VerifyArtifacts(ctx context.Context, artifacts []*lockfile.Artifact, scope *clients.InstallScope) []clients.VerifyResult {
+	return shared.VerifyArtifacts(artifacts, c.determinePaths(scope), newHandler)
+}
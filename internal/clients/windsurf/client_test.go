@@ -0,0 +1,89 @@
+package windsurf
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/clienttest"
+)
+
+func TestClient_InstallVerifyUninstall(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+
+	client := NewClient()
+	if client.IsInstalled() {
+		t.Fatal("Expected Windsurf not to be detected without ~/.codeium/windsurf")
+	}
+	if err := os.MkdirAll(filepath.Join(homeDir, ".codeium", "windsurf"), 0755); err != nil {
+		t.Fatalf("Failed to create windsurf directory: %v", err)
+	}
+	if !client.IsInstalled() {
+		t.Fatal("Expected Windsurf to be detected")
+	}
+
+	mcpConfigPath := filepath.Join(homeDir, ".codeium", "windsurf", "mcp_config.json")
+	if err := os.WriteFile(mcpConfigPath, []byte(`{"mcpServers": {"mine": {"command": "mine"}}}`), 0644); err != nil {
+		t.Fatalf("Failed to write mcp_config.json: %v", err)
+	}
+
+	scope := &clients.InstallScope{Type: clients.ScopeGlobal}
+	clienttest.InstallVerifyUninstall(t, client, scope, func() {
+		workflow, err := os.ReadFile(filepath.Join(homeDir, ".codeium", "windsurf", "global_workflows", "deploy.md"))
+		if err != nil {
+			t.Fatalf("Expected global workflow: %v", err)
+		}
+		if !strings.Contains(string(workflow), `description: "Deploy the app"`) || !strings.Contains(string(workflow), "Deploy $ARGUMENTS") {
+			t.Errorf("Unexpected workflow content:\n%s", workflow)
+		}
+		data, _ := os.ReadFile(mcpConfigPath)
+		if !strings.Contains(string(data), `"db"`) {
+			t.Errorf("Expected MCP server in mcp_config.json, got %s", data)
+		}
+	})
+
+	data, err := os.ReadFile(mcpConfigPath)
+	if err != nil {
+		t.Fatalf("Failed to read mcp_config.json: %v", err)
+	}
+	if !strings.Contains(string(data), `"mine"`) {
+		t.Errorf("Expected other MCP servers to be preserved, got %s", data)
+	}
+}
+
+func TestClient_EnsureSkillsSupport(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	repoRoot := t.TempDir()
+
+	client := NewClient()
+	scope := &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: repoRoot}
+	bundle := clienttest.BuildBundle(t, map[string]string{
+		"metadata.toml": "[artifact]\nname = \"review\"\nversion = \"1.0.0\"\ntype = \"skill\"\ndescription = \"Review code\"\n\n[skill]\nprompt-file = \"SKILL.md\"\n",
+		"SKILL.md":      "Review carefully",
+	})
+	if _, err := client.InstallArtifacts(context.Background(), clients.InstallRequest{Artifacts: []*clients.ArtifactBundle{bundle}, Scope: scope}); err != nil {
+		t.Fatalf("InstallArtifacts failed: %v", err)
+	}
+
+	if err := client.EnsureSkillsSupport(context.Background(), scope); err != nil {
+		t.Fatalf("EnsureSkillsSupport failed: %v", err)
+	}
+
+	rules, err := os.ReadFile(filepath.Join(repoRoot, ".windsurf", "rules", "skills.md"))
+	if err != nil {
+		t.Fatalf("Expected rules file: %v", err)
+	}
+	if !strings.Contains(string(rules), "trigger: always_on") || !strings.Contains(string(rules), "<name>review</name>") {
+		t.Errorf("Unexpected rules file:\n%s", rules)
+	}
+
+	data, err := os.ReadFile(filepath.Join(homeDir, ".codeium", "windsurf", "mcp_config.json"))
+	if err != nil || !strings.Contains(string(data), `"skills"`) {
+		t.Errorf("Expected skills MCP server to be registered, got %s (%v)", data, err)
+	}
+}
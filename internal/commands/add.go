@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/artifacts/detectors"
@@ -21,14 +22,36 @@ import (
 	"github.com/sleuth-io/skills/internal/github"
 	"github.com/sleuth-io/skills/internal/lockfile"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/policy"
 	"github.com/sleuth-io/skills/internal/repository"
+	"github.com/sleuth-io/skills/internal/signing"
 	"github.com/sleuth-io/skills/internal/ui"
 	"github.com/sleuth-io/skills/internal/ui/components"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
+// addOptions holds the flags of the add command
+type addOptions struct {
+	// repository is the configured repository to add to (empty for the default)
+	repository string
+
+	// pin records the artifact's repository in the config
+	pin bool
+
+	// signKey is the path of the key used to sign new artifacts (empty to not sign)
+	signKey string
+
+	// promptInstall asks to run install afterwards
+	promptInstall bool
+
+	// skipAudit skips the content audit of new artifacts
+	skipAudit bool
+}
+
 // NewAddCommand creates the add command
 func NewAddCommand() *cobra.Command {
+	opts := addOptions{promptInstall: true}
+
 	cmd := &cobra.Command{
 		Use:   "add [source-or-artifact-name]",
 		Short: "Add an artifact or configure an existing one",
@@ -39,43 +62,58 @@ Examples:
   skills add ./my-skill           # Add from local directory
   skills add https://...          # Add from URL
   skills add https://github.com/owner/repo/tree/main/path  # Add from GitHub
-  skills add my-skill             # Configure scope for existing artifact`,
+  skills add my-skill             # Configure scope for existing artifact
+  skills add ./my-skill --repository personal --pin  # Publish to a named repository and pin it there
+  skills add ./my-skill --sign-key ~/.minisign/minisign.key  # Sign the artifact`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			var zipFile string
 			if len(args) > 0 {
 				zipFile = args[0]
 			}
-			return runAdd(cmd, zipFile)
+			if opts.signKey == "" {
+				opts.signKey = os.Getenv("SKILLS_SIGNING_KEY")
+			}
+			return runAddWithOptions(cmd, zipFile, opts)
 		},
 	}
 
-	return cmd
-}
+	cmd.Flags().StringVar(&opts.repository, "repository", "", "Name of the configured repository to add to (default: highest priority)")
+	cmd.Flags().BoolVar(&opts.pin, "pin", false, "Pin the artifact to the repository so installs always take it from there")
+	cmd.Flags().StringVar(&opts.signKey, "sign-key", "", "Sign the artifact with an ed25519 PEM or minisign secret key (or set SKILLS_SIGNING_KEY)")
+	cmd.Flags().BoolVar(&opts.skipAudit, "skip-audit", false, "Don't scan the artifact for risky content before adding it")
 
-// runAdd executes the add command
-func runAdd(cmd *cobra.Command, zipFile string) error {
-	return runAddWithOptions(cmd, zipFile, true)
+	return cmd
 }
 
 // runAddSkipInstall executes the add command without prompting to install
 func runAddSkipInstall(cmd *cobra.Command, zipFile string) error {
-	return runAddWithOptions(cmd, zipFile, false)
+	return runAddWithOptions(cmd, zipFile, addOptions{})
 }
 
 // runAddWithOptions executes the add command with configurable options
-func runAddWithOptions(cmd *cobra.Command, input string, promptInstall bool) error {
+func runAddWithOptions(cmd *cobra.Command, input string, opts addOptions) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
 	defer cancel()
 
 	out := newOutputHelper(cmd)
+
+	// Load the signing key up front so a bad key or password fails before any prompts
+	var signer *signing.PrivateKey
+	if opts.signKey != "" {
+		var err error
+		signer, err = loadSigningKey(out, opts.signKey)
+		if err != nil {
+			return err
+		}
+	}
 	status := components.NewStatus(cmd.OutOrStdout())
 
 	// Check if input is an existing artifact name (not a file, directory, or URL)
 	if input != "" && !isURL(input) && !github.IsTreeURL(input) {
 		if _, err := os.Stat(input); os.IsNotExist(err) {
 			// Not a file/directory - check if it's an existing artifact
-			return configureExistingArtifact(ctx, cmd, out, status, input, promptInstall)
+			return configureExistingArtifact(ctx, cmd, out, status, input, opts)
 		}
 	}
 
@@ -85,6 +123,13 @@ func runAddWithOptions(cmd *cobra.Command, input string, promptInstall bool) err
 		return err
 	}
 
+	// Scan for risky content before anything is published
+	if !opts.skipAudit {
+		if err := auditBeforeAdd(out, zipData); err != nil {
+			return err
+		}
+	}
+
 	// Detect artifact name and type
 	name, artifactType, metadataExists, err := detectArtifactInfo(out, zipFile, zipData)
 	if err != nil {
@@ -92,7 +137,7 @@ func runAddWithOptions(cmd *cobra.Command, input string, promptInstall bool) err
 	}
 
 	// Create repository instance
-	repo, err := createRepository()
+	repo, err := createRepository(opts.repository, name)
 	if err != nil {
 		return err
 	}
@@ -109,15 +154,21 @@ func runAddWithOptions(cmd *cobra.Command, input string, promptInstall bool) err
 		addErr = handleIdenticalArtifact(ctx, out, status, repo, name, version, artifactType)
 	} else {
 		// Add new or updated artifact
-		addErr = addNewArtifact(ctx, out, status, repo, name, artifactType, version, zipFile, zipData, metadataExists)
+		addErr = addNewArtifact(ctx, out, status, repo, name, artifactType, version, zipFile, zipData, metadataExists, signer)
 	}
 
 	if addErr != nil {
 		return addErr
 	}
 
+	if opts.pin {
+		if err := pinArtifact(name, opts.repository); err != nil {
+			return fmt.Errorf("failed to pin %s: %w", name, err)
+		}
+	}
+
 	// Prompt to run install (if enabled)
-	if promptInstall {
+	if opts.promptInstall {
 		promptRunInstall(cmd, ctx, out)
 	}
 
@@ -125,9 +176,9 @@ func runAddWithOptions(cmd *cobra.Command, input string, promptInstall bool) err
 }
 
 // configureExistingArtifact handles configuring scope for an artifact that already exists in the repository
-func configureExistingArtifact(ctx context.Context, cmd *cobra.Command, out *outputHelper, status *components.Status, artifactName string, promptInstall bool) error {
+func configureExistingArtifact(ctx context.Context, cmd *cobra.Command, out *outputHelper, status *components.Status, artifactName string, opts addOptions) error {
 	// Create repository instance
-	repo, err := createRepository()
+	repo, err := createRepository(opts.repository, artifactName)
 	if err != nil {
 		return err
 	}
@@ -237,8 +288,14 @@ func configureExistingArtifact(ctx context.Context, cmd *cobra.Command, out *out
 			return fmt.Errorf("failed to update lock file: %w", err)
 		}
 
+		if opts.pin {
+			if err := pinArtifact(artifactName, opts.repository); err != nil {
+				return fmt.Errorf("failed to pin %s: %w", artifactName, err)
+			}
+		}
+
 		// Prompt to run install (if enabled)
-		if promptInstall {
+		if opts.promptInstall {
 			promptRunInstall(cmd, ctx, out)
 		}
 
@@ -277,10 +334,18 @@ func configureExistingArtifact(ctx context.Context, cmd *cobra.Command, out *out
 			if err := gitRepo.CommitAndPush(ctx, foundArtifact); err != nil {
 				return fmt.Errorf("failed to push removal: %w", err)
 			}
+		} else if updater, ok := repo.(repository.LockFileUpdater); ok {
+			err := updater.UpdateLockFile(ctx, func(lockFile *lockfile.LockFile) error {
+				lockFile.Remove(foundArtifact.Name, foundArtifact.Version)
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to remove artifact from lock file: %w", err)
+			}
 		}
 
 		// Prompt to run install to clean up the removed artifact (if enabled)
-		if promptInstall {
+		if opts.promptInstall {
 			out.println()
 			confirmed, err := components.ConfirmWithIO("Run install now to remove the artifact from clients?", true, cmd.InOrStdin(), cmd.OutOrStdout())
 			if err != nil {
@@ -289,7 +354,7 @@ func configureExistingArtifact(ctx context.Context, cmd *cobra.Command, out *out
 
 			if confirmed {
 				out.println()
-				if err := runInstall(cmd, nil, false, "", false); err != nil {
+				if err := runInstall(cmd, nil, false, "", false, false); err != nil {
 					out.printfErr("Install failed: %v\n", err)
 				}
 			} else {
@@ -307,8 +372,14 @@ func configureExistingArtifact(ctx context.Context, cmd *cobra.Command, out *out
 		return fmt.Errorf("failed to update lock file: %w", err)
 	}
 
+	if opts.pin {
+		if err := pinArtifact(artifactName, opts.repository); err != nil {
+			return fmt.Errorf("failed to pin %s: %w", artifactName, err)
+		}
+	}
+
 	// Prompt to run install (if enabled)
-	if promptInstall {
+	if opts.promptInstall {
 		promptRunInstall(cmd, ctx, out)
 	}
 
@@ -329,7 +400,7 @@ func promptRunInstall(cmd *cobra.Command, ctx context.Context, out *outputHelper
 	}
 
 	out.println()
-	if err := runInstall(cmd, nil, false, "", false); err != nil {
+	if err := runInstall(cmd, nil, false, "", false, false); err != nil {
 		out.printfErr("Install failed: %v\n", err)
 	}
 }
@@ -502,14 +573,94 @@ func detectArtifactInfo(out *outputHelper, zipFile string, zipData []byte) (name
 	return name, artifactType, metadataExists, nil
 }
 
-// createRepository loads config and creates a repository instance
-func createRepository() (repository.Repository, error) {
+// loadSigningKey loads a signing key, asking for its password if it is encrypted
+// The password can also be provided with SKILLS_SIGNING_PASSWORD
+func loadSigningKey(out *outputHelper, path string) (*signing.PrivateKey, error) {
+	path, err := expandPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	password := func() ([]byte, error) {
+		if pw := os.Getenv("SKILLS_SIGNING_PASSWORD"); pw != "" {
+			return []byte(pw), nil
+		}
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return nil, fmt.Errorf("signing key is encrypted; set SKILLS_SIGNING_PASSWORD")
+		}
+		out.printf("Password for %s: ", path)
+		pw, err := term.ReadPassword(int(os.Stdin.Fd()))
+		out.println()
+		return pw, err
+	}
+
+	key, err := signing.LoadPrivateKey(path, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load signing key: %w", err)
+	}
+	return key, nil
+}
+
+// createRepository loads config and creates the repository an artifact is added to
+// If repoName is empty, the artifact's pinned repository or else the highest-priority repository is used
+func createRepository(repoName, artifactName string) (repository.Repository, error) {
 	cfg, err := config.Load()
 	if err != nil {
 		return nil, fmt.Errorf("failed to load configuration: %w\nRun 'skills init' to configure", err)
 	}
 
-	return repository.NewFromConfig(cfg)
+	if repoName == "" {
+		repoName = cfg.Pins[artifactName]
+	}
+	if repoName == "" {
+		repos := cfg.GetRepositories()
+		if len(repos) == 0 {
+			return nil, fmt.Errorf("no repositories configured\nRun 'skills init' to configure")
+		}
+		repoName = repos[0].Name
+	}
+
+	repoCfg, err := cfg.GetRepository(repoName)
+	if err != nil {
+		return nil, err
+	}
+	return repository.NewFromConfig(repoCfg)
+}
+
+// createMultiRepository loads config and creates a repository combining every configured repository
+func createMultiRepository() (*repository.MultiRepository, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w\nRun 'skills init' to configure", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	return repository.NewFromConfigs(cfg.GetRepositories(), cfg.Pins)
+}
+
+// pinArtifact records in the config that an artifact must come from the named repository
+func pinArtifact(artifactName, repoName string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if repoName == "" {
+		repoName = cfg.GetRepositories()[0].Name
+	}
+	if _, err := cfg.GetRepository(repoName); err != nil {
+		return err
+	}
+
+	if cfg.Pins == nil {
+		cfg.Pins = make(map[string]string)
+	}
+	cfg.Pins[artifactName] = repoName
+
+	return config.Save(cfg)
 }
 
 // checkVersionAndContents queries repository for versions and checks if content is identical
@@ -572,7 +723,7 @@ func handleIdenticalArtifact(ctx context.Context, out *outputHelper, status *com
 }
 
 // addNewArtifact adds a new or updated artifact to the repository
-func addNewArtifact(ctx context.Context, out *outputHelper, status *components.Status, repo repository.Repository, name string, artifactType artifact.Type, version, zipFile string, zipData []byte, metadataExists bool) error {
+func addNewArtifact(ctx context.Context, out *outputHelper, status *components.Status, repo repository.Repository, name string, artifactType artifact.Type, version, zipFile string, zipData []byte, metadataExists bool, signer *signing.PrivateKey) error {
 	// Prompt user for version
 	version, err := promptForVersion(out, version)
 	if err != nil {
@@ -598,6 +749,24 @@ func addNewArtifact(ctx context.Context, out *outputHelper, status *components.S
 		},
 	}
 
+	// Refuse hooks and MCP servers the repository's policy forbids before uploading anything
+	policies, err := policy.Load(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to load policy: %w", err)
+	}
+	if err := policies.CheckMetadata(lockArtifact, meta).Err(); err != nil {
+		return err
+	}
+
+	// Sign the final zip contents so the signature covers the updated metadata
+	if signer != nil {
+		lockArtifact.Signature, err = signing.SignArtifact(signer, lockArtifact.Name, lockArtifact.Version, zipData)
+		if err != nil {
+			return fmt.Errorf("failed to sign artifact: %w", err)
+		}
+		out.printf("Signed with %s key %s\n", signer.Algorithm, signer.KeyID)
+	}
+
 	// Upload artifact files to repository
 	out.println()
 	status.Start("Adding artifact to repository")
@@ -607,6 +776,15 @@ func addNewArtifact(ctx context.Context, out *outputHelper, status *components.S
 	}
 	status.Done("")
 
+	// Record the content hash of artifacts stored in the repository, like 'skills lock' does
+	if lockArtifact.SourcePath != nil && len(lockArtifact.SourcePath.Hashes) == 0 {
+		contentHash, err := utils.ComputeZipContentSHA256(zipData)
+		if err != nil {
+			return fmt.Errorf("failed to hash artifact: %w", err)
+		}
+		lockArtifact.SourcePath.Hashes = map[string]string{"sha256": contentHash}
+	}
+
 	out.printf("✓ Successfully added %s@%s\n", meta.Artifact.Name, meta.Artifact.Version)
 
 	// Check if already in lock file to get current repositories
@@ -12,6 +12,11 @@ import (
 func updateLockFile(ctx context.Context, out *outputHelper, repo repository.Repository, artifact *lockfile.Artifact) error {
 	status := components.NewStatus(out.cmd.OutOrStdout())
 
+	// The entry is checked as it will be written, after the repository has set its source
+	if err := checkArtifactPolicy(ctx, repo, artifact); err != nil {
+		return err
+	}
+
 	// For git repos, update the lock file and commit
 	if gitRepo, ok := repo.(*repository.GitRepository); ok {
 		status.Start("Updating repository lock file")
@@ -53,5 +58,25 @@ func updateLockFile(ctx context.Context, out *outputHelper, repo repository.Repo
 		return nil
 	}
 
+	// For registries and object stores, rewrite the remote lock file
+	if updater, ok := repo.(repository.LockFileUpdater); ok {
+		status.Start("Updating repository lock file")
+		err := updater.UpdateLockFile(ctx, func(lockFile *lockfile.LockFile) error {
+			lockFile.AddOrUpdate(artifact)
+			return nil
+		})
+		if err != nil {
+			status.Fail("Failed to update lock file")
+			return err
+		}
+
+		if artifact.IsGlobal() {
+			status.Done("Updated lock file (global installation)")
+		} else {
+			status.Done("Updated lock file with repository installation(s)")
+		}
+		return nil
+	}
+
 	return nil
 }
@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/audit"
+	"github.com/sleuth-io/skills/internal/ui/components"
+)
+
+// NewAuditCommand creates the audit command
+func NewAuditCommand() *cobra.Command {
+	var format string
+	var failOn string
+	var maxFileSize uint64
+
+	cmd := &cobra.Command{
+		Use:   "audit <source>",
+		Short: "Scan an artifact for risky content",
+		Long: `Scan an artifact zip file, directory, URL, or GitHub path for content that could
+harm the developers who install it: paths escaping the artifact, symlinks, hidden files,
+executables, oversized files, network commands in hook scripts, suspicious MCP commands,
+arguments and environment, and prompt-injection text in prompt files.
+
+Each finding has a severity (info, low, medium, high) and lowers the artifact's score
+from 100. 'skills add' runs the same audit before publishing.`,
+		Example: `  skills audit ./my-skill
+  skills audit my-hook.zip --format json
+  skills audit https://github.com/owner/repo/tree/main/path --fail-on high`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAudit(cmd, args[0], format, failOn, maxFileSize)
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text or json")
+	cmd.Flags().StringVar(&failOn, "fail-on", "", "Exit with an error if there are findings of this severity or higher (info, low, medium, high)")
+	cmd.Flags().Uint64Var(&maxFileSize, "max-file-size", audit.DefaultMaxFileSize, "Largest file size in bytes before it is flagged")
+
+	return cmd
+}
+
+// runAudit executes the audit command
+func runAudit(cmd *cobra.Command, source, format, failOn string, maxFileSize uint64) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unknown format %q (expected text or json)", format)
+	}
+
+	var threshold audit.Severity
+	if failOn != "" {
+		var err error
+		threshold, err = audit.ParseSeverity(failOn)
+		if err != nil {
+			return err
+		}
+	}
+
+	out := newOutputHelper(cmd)
+	// Keep progress messages out of JSON output
+	out.silent = format == "json"
+
+	_, zipData, err := loadZipFile(out, source)
+	if err != nil {
+		return err
+	}
+
+	report, err := audit.Scan(zipData, audit.Options{MaxFileSize: maxFileSize})
+	if err != nil {
+		return fmt.Errorf("failed to scan artifact: %w", err)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		out.printlnAlways(string(data))
+	} else {
+		printAuditReport(out, report)
+	}
+
+	if highest, ok := report.Highest(); ok && failOn != "" && highest >= threshold {
+		return fmt.Errorf("audit found %s severity findings", highest)
+	}
+	return nil
+}
+
+// printAuditReport prints an audit report as text
+func printAuditReport(out *outputHelper, report *audit.Report) {
+	name := "artifact"
+	if report.Name != "" {
+		name = report.Name
+		if report.Version != "" {
+			name += "@" + report.Version
+		}
+	}
+
+	out.println()
+	if len(report.Findings) == 0 {
+		out.printf("✓ %s: no findings (score %d/100)\n", name, report.Score)
+		return
+	}
+
+	out.printf("Audit of %s: score %d/100 (%d high, %d medium, %d low, %d info)\n", name, report.Score,
+		report.Count(audit.SeverityHigh), report.Count(audit.SeverityMedium), report.Count(audit.SeverityLow), report.Count(audit.SeverityInfo))
+	for _, f := range report.Findings {
+		out.printf("  %s\n", f)
+	}
+}
+
+// auditBeforeAdd scans an artifact before it is added, asking whether to continue
+// if there are high severity findings
+func auditBeforeAdd(out *outputHelper, zipData []byte) error {
+	report, err := audit.Scan(zipData, audit.Options{})
+	if err != nil {
+		return fmt.Errorf("failed to scan artifact: %w", err)
+	}
+
+	highest, ok := report.Highest()
+	if !ok || highest == audit.SeverityInfo {
+		return nil
+	}
+
+	printAuditReport(out, report)
+	if highest < audit.SeverityHigh {
+		return nil
+	}
+
+	out.println()
+	confirmed, err := components.ConfirmWithIO("The artifact has high severity findings. Add it anyway?", false, out.cmd.InOrStdin(), out.cmd.OutOrStdout())
+	if err != nil {
+		return fmt.Errorf("failed to read confirmation: %w", err)
+	}
+	if !confirmed {
+		return fmt.Errorf("artifact not added: audit found high severity findings")
+	}
+	return nil
+}
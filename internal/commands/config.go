@@ -8,6 +8,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -17,9 +18,14 @@ import (
 	"github.com/sleuth-io/skills/internal/buildinfo"
 	"github.com/sleuth-io/skills/internal/cache"
 	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/cline"
+	"github.com/sleuth-io/skills/internal/clients/codex"
+	"github.com/sleuth-io/skills/internal/clients/gemini"
+	"github.com/sleuth-io/skills/internal/clients/windsurf"
 	"github.com/sleuth-io/skills/internal/config"
 	"github.com/sleuth-io/skills/internal/gitutil"
 	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/repository"
 	"github.com/sleuth-io/skills/internal/scope"
 	"github.com/sleuth-io/skills/internal/utils"
 )
@@ -54,6 +60,18 @@ type ConfigInfo struct {
 	Type          string `json:"type,omitempty"`
 	RepositoryURL string `json:"repositoryUrl,omitempty"`
 	ServerURL     string `json:"serverUrl,omitempty"`
+
+	// Repositories lists named repositories when more than one is configured
+	Repositories []RepositoryInfo  `json:"repositories,omitempty"`
+	Pins         map[string]string `json:"pins,omitempty"`
+}
+
+type RepositoryInfo struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Priority      int    `json:"priority"`
+	RepositoryURL string `json:"repositoryUrl,omitempty"`
+	ServerURL     string `json:"serverUrl,omitempty"`
 }
 
 type DirectoryInfo struct {
@@ -196,6 +214,22 @@ func gatherConfigDetails() ConfigInfo {
 		if cfg.Type == config.RepositoryTypeSleuth {
 			info.ServerURL = cfg.GetServerURL()
 		}
+
+		if len(cfg.Repositories) > 0 {
+			for _, repo := range cfg.GetRepositories() {
+				repoInfo := RepositoryInfo{
+					Name:          repo.Name,
+					Type:          string(repo.Type),
+					Priority:      repo.Priority,
+					RepositoryURL: repo.RepositoryURL,
+				}
+				if repo.Type == config.RepositoryTypeSleuth {
+					repoInfo.ServerURL = repo.GetServerURL()
+				}
+				info.Repositories = append(info.Repositories, repoInfo)
+			}
+			info.Pins = cfg.Pins
+		}
 	}
 
 	return info
@@ -256,6 +290,14 @@ func getClientDirectory(clientID string) string {
 		return filepath.Join(home, ".claude")
 	case "cursor":
 		return filepath.Join(home, ".cursor")
+	case "windsurf":
+		return windsurf.GlobalDir()
+	case "cline":
+		return cline.StorageDir()
+	case "gemini":
+		return gemini.GlobalDir()
+	case "codex":
+		return codex.GlobalDir()
 	default:
 		return ""
 	}
@@ -389,14 +431,14 @@ func gatherUnifiedArtifacts(currentScope *scope.Scope, showAll bool) []ScopeArti
 		return nil
 	}
 
-	// Load lock file
-	lockFileData, err := cache.LoadLockFile(cfg.RepositoryURL)
-	if err != nil || len(lockFileData) == 0 {
+	// Merge the lock files cached by the last install
+	repo, err := repository.NewFromConfigs(cfg.GetRepositories(), cfg.Pins)
+	if err != nil {
 		return nil
 	}
 
-	lf, err := lockfile.Parse(lockFileData)
-	if err != nil {
+	lf, err := repo.MergeCachedLockFiles()
+	if err != nil || len(lf.Artifacts) == 0 {
 		return nil
 	}
 
@@ -564,6 +606,27 @@ func printText(output ConfigOutput, showAll bool) error {
 	if output.Config.ServerURL != "" {
 		fmt.Printf("Server URL: %s\n", output.Config.ServerURL)
 	}
+	if len(output.Config.Repositories) > 0 {
+		fmt.Println("Repositories (highest priority first):")
+		for _, repo := range output.Config.Repositories {
+			url := repo.RepositoryURL
+			if repo.ServerURL != "" {
+				url = repo.ServerURL
+			}
+			fmt.Printf("  %s (%s, priority %d): %s\n", repo.Name, repo.Type, repo.Priority, url)
+		}
+	}
+	if len(output.Config.Pins) > 0 {
+		fmt.Println("Pins:")
+		names := make([]string, 0, len(output.Config.Pins))
+		for name := range output.Config.Pins {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("  %s → %s\n", name, output.Config.Pins[name])
+		}
+	}
 	fmt.Println()
 
 	// Directories
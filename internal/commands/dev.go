@@ -0,0 +1,324 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/artifacts"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/gitutil"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/logger"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// devDebounce is how long the directory has to be quiet before it's reinstalled,
+// so an editor saving several files triggers one reinstall
+const devDebounce = 300 * time.Millisecond
+
+// skipDevDir reports whether a directory isn't watched for changes: dependencies,
+// and hidden directories like .git or the .claude and .cursor directories clients
+// are installed into
+func skipDevDir(name string) bool {
+	return name == "node_modules" || (strings.HasPrefix(name, ".") && name != "." && name != "..")
+}
+
+// NewDevCommand creates the dev command
+func NewDevCommand() *cobra.Command {
+	var repoScope bool
+
+	cmd := &cobra.Command{
+		Use:   "dev [dir]",
+		Short: "Install an artifact directory into clients and reinstall it on every change",
+		Long: `Install an artifact you're working on into every detected client, then watch its
+directory and validate and reinstall it whenever a file changes. The artifact isn't
+added to a repository, lock file or the installed artifacts tracker.
+
+Each reinstall is applied as a whole or not at all, so a failed one leaves the last
+good version installed. Press Ctrl+C to stop and uninstall the artifact from the
+clients; anything installed in the meantime, e.g. by 'skills install', is kept. If
+the artifact was also installed from a repository, 'skills install --repair' puts
+that version back.
+
+The directory defaults to the current directory.`,
+		Example: `  skills dev ./code-review
+  skills dev --repo`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return runDev(cmd, dir, repoScope)
+		},
+	}
+
+	cmd.Flags().BoolVar(&repoScope, "repo", false, "Install into the current repository instead of globally")
+
+	return cmd
+}
+
+// runDev executes the dev command
+func runDev(cmd *cobra.Command, dir string, repoScope bool) error {
+	out := newOutputHelper(cmd)
+
+	dir, err := utils.NormalizePath(dir)
+	if err == nil {
+		dir, err = filepath.Abs(dir)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	if !utils.IsDirectory(dir) {
+		return fmt.Errorf("not a directory: %s", dir)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	gitContext, err := gitutil.DetectContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to detect git context: %w", err)
+	}
+
+	session, err := newDevSession(out, dir, gitContext, repoScope)
+	if err != nil {
+		return err
+	}
+	defer session.close()
+
+	// Keep watching after a failed first install, so it can be fixed in place
+	if err := session.sync(); err != nil {
+		out.printfErr("✗ %v\n", err)
+	}
+	out.printf("Watching %s for changes (press Ctrl+C to stop and clean up)\n", dir)
+
+	return session.watch(ctx)
+}
+
+// devSession installs an artifact directory into clients while it's being edited
+// Each reinstall is its own short transaction, so nothing is held open between changes
+// and installs run meanwhile aren't undone when the session ends
+type devSession struct {
+	out *outputHelper
+	dir string
+
+	repositories []lockfile.Repository // where the artifact is installed, empty for global
+	installScope *clients.InstallScope // scope the artifact is installed to
+	contextScope *clients.InstallScope // scope of the working directory, for EnsureSkillsSupport
+	installed    *metadata.Metadata    // last version installed, nil before the first install
+	targets      []clients.Client      // clients the last version was installed to
+}
+
+// newDevSession creates a dev session for an artifact directory
+func newDevSession(out *outputHelper, dir string, gitContext *gitutil.GitContext, repoScope bool) (*devSession, error) {
+	s := &devSession{
+		out:          out,
+		dir:          dir,
+		contextScope: buildInstallScope(scopeFromGitContext(gitContext), gitContext),
+	}
+
+	if repoScope {
+		if !gitContext.IsRepo || gitContext.RepoURL == "" {
+			return nil, fmt.Errorf("--repo requires a git repository with a remote")
+		}
+		s.repositories = []lockfile.Repository{{Repo: gitContext.RepoURL}}
+	}
+	s.installScope = buildInstallScopeForArtifact(&lockfile.Artifact{Repositories: s.repositories}, gitContext)
+	return s, nil
+}
+
+// sync validates the directory and installs it into the clients that support it
+func (s *devSession) sync() error {
+	// Leave out what the watcher ignores, so dependencies and client directories
+	// installed into the artifact aren't packed into it
+	zipData, err := metadata.CreateZipWithOptions(s.dir, utils.ZipOptions{SkipDir: skipDevDir})
+	if err != nil {
+		return err
+	}
+	meta, err := validateArtifactMetadata(zipData)
+	if err != nil {
+		return fmt.Errorf("metadata.toml: %w", err)
+	}
+
+	var targets []clients.Client
+	for _, client := range clients.Global().DetectInstalled() {
+		if client.SupportsArtifactType(meta.Artifact.Type) {
+			targets = append(targets, client)
+		}
+	}
+	if len(targets) == 0 {
+		return fmt.Errorf("no detected client installs %s artifacts", meta.Artifact.Type)
+	}
+
+	var problems []string
+	for _, result := range checkClientCompatibility(targets, meta, zipData) {
+		if result.validateErr != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", result.clientName, result.validateErr))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s %s is invalid, not reinstalling:\n  %s", meta.Artifact.Name, meta.Artifact.Version, strings.Join(problems, "\n  "))
+	}
+
+	tx, err := beginInstallTransaction(s.out)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }() // No-op once committed
+	txCtx := transaction.NewContext(context.Background(), tx)
+
+	// Renaming the artifact or changing its type would otherwise leave the old one installed
+	if s.installed != nil && (s.installed.Artifact.Name != meta.Artifact.Name || s.installed.Artifact.Type != meta.Artifact.Type) {
+		s.uninstall(txCtx)
+	}
+
+	bundle := &clients.ArtifactBundle{
+		Artifact: &lockfile.Artifact{
+			Name:         meta.Artifact.Name,
+			Version:      meta.Artifact.Version,
+			Type:         meta.Artifact.Type,
+			Repositories: s.repositories,
+		},
+		Metadata: meta,
+		ZipData:  zipData,
+	}
+
+	s.out.printf("[%s] Installing %s %s\n", time.Now().Format(time.TimeOnly), meta.Artifact.Name, meta.Artifact.Version)
+	results := runMultiClientInstallation(txCtx, []*clients.ArtifactBundle{bundle}, s.installScope, targets)
+	installResult := processInstallationResults(results, s.out)
+	if len(installResult.Failed) > 0 {
+		return fmt.Errorf("installation failed for one or more clients, kept the last version")
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit install: %w", err)
+	}
+	s.installed, s.targets = meta, targets
+
+	ensureSkillsSupport(context.Background(), targets, s.contextScope, s.out)
+	return nil
+}
+
+// uninstall removes the last installed version from the clients it went to
+func (s *devSession) uninstall(ctx context.Context) {
+	req := clients.UninstallRequest{
+		Artifacts: []artifact.Artifact{{
+			Name:    s.installed.Artifact.Name,
+			Version: s.installed.Artifact.Version,
+			Type:    s.installed.Artifact.Type,
+		}},
+		Scope: s.installScope,
+	}
+	for _, client := range s.targets {
+		if _, err := client.UninstallArtifacts(ctx, req); err != nil {
+			s.out.printfErr("Warning: failed to remove %s from %s: %v\n", s.installed.Artifact.Name, client.DisplayName(), err)
+		}
+	}
+}
+
+// watch reinstalls the directory whenever it changes, until ctx is done
+func (s *devSession) watch(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch directory: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watchTree(watcher, s.dir); err != nil {
+		return fmt.Errorf("failed to watch directory: %w", err)
+	}
+
+	debounce := time.NewTimer(devDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// New directories aren't watched until they're added
+			if event.Has(fsnotify.Create) && utils.IsDirectory(event.Name) && !skipDevDir(filepath.Base(event.Name)) {
+				if err := watchTree(watcher, event.Name); err != nil {
+					s.out.printfErr("Warning: failed to watch %s: %v\n", event.Name, err)
+				}
+			}
+			debounce.Reset(devDebounce)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			s.out.printfErr("Warning: %v\n", err)
+
+		case <-debounce.C:
+			if err := s.sync(); err != nil {
+				s.out.printfErr("✗ %v\n", err)
+			}
+		}
+	}
+}
+
+// watchTree adds a directory and its subdirectories to the watcher
+func watchTree(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		if path != root && skipDevDir(d.Name()) {
+			return filepath.SkipDir
+		}
+		return watcher.Add(path)
+	})
+}
+
+// close uninstalls the artifact from the clients and refreshes them, leaving the rest
+// of their configuration as it is
+func (s *devSession) close() {
+	if s.installed == nil {
+		return
+	}
+
+	tx, err := beginInstallTransaction(s.out)
+	if err != nil {
+		s.out.printfErr("Warning: failed to clean up: %v\n", err)
+		return
+	}
+	s.uninstall(transaction.NewContext(context.Background(), tx))
+	if err := tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		s.out.printfErr("Warning: failed to clean up: %v\n", err)
+		logger.Get().Error("failed to uninstall dev artifact", "error", err)
+		return
+	}
+
+	ensureSkillsSupport(context.Background(), s.targets, s.contextScope, s.out)
+	s.out.printf("Removed %s\n", s.installed.Artifact.Name)
+
+	// The session installed over any version of the artifact from a repository
+	if tracker, err := artifacts.LoadTracker(); err == nil {
+		for _, installed := range tracker.Artifacts {
+			if installed.Name == s.installed.Artifact.Name {
+				s.out.printf("Run 'skills install --repair' to restore %s %s from the repository\n", installed.Name, installed.Version)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/artifacts"
+	"github.com/sleuth-io/skills/internal/gitutil"
+	"github.com/sleuth-io/skills/internal/scaffold"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+func TestDevSession(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+	t.Chdir(t.TempDir())
+	for _, dir := range []string{".claude", ".cursor"} {
+		if err := os.MkdirAll(filepath.Join(home, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	settingsPath := filepath.Join(home, ".claude", "settings.json")
+	if err := os.WriteFile(settingsPath, []byte(`{"theme": "dark"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "lint-on-save")
+	if _, err := scaffold.Create(dir, artifact.TypeHook, "lint-on-save"); err != nil {
+		t.Fatalf("Failed to scaffold hook: %v", err)
+	}
+
+	// Dependencies and hidden directories aren't packed, as they aren't watched
+	for _, name := range []string{"node_modules/dep/index.js", ".cache/state"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cmd := &cobra.Command{}
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stdout)
+
+	session, err := newDevSession(newOutputHelper(cmd), dir, &gitutil.GitContext{}, false)
+	if err != nil {
+		t.Fatalf("newDevSession failed: %v", err)
+	}
+	if err := session.sync(); err != nil {
+		t.Fatalf("sync failed: %v\n%s", err, stdout.String())
+	}
+
+	installedScript := filepath.Join(home, ".claude", "hooks", "lint-on-save", "hook.sh")
+	if !utils.FileExists(installedScript) {
+		t.Fatalf("Expected the hook to be installed into Claude Code:\n%s", stdout.String())
+	}
+	for _, name := range []string{"node_modules", ".cache"} {
+		if utils.IsDirectory(filepath.Join(filepath.Dir(installedScript), name)) {
+			t.Errorf("Expected %s not to be installed", name)
+		}
+	}
+	if settings, _ := os.ReadFile(settingsPath); !strings.Contains(string(settings), "lint-on-save") {
+		t.Errorf("Expected the hook to be registered in settings.json, got %s", settings)
+	}
+
+	// Another install changes the same config while the session runs
+	var settings map[string]any
+	data, _ := os.ReadFile(settingsPath)
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("Failed to parse settings.json: %v", err)
+	}
+	settings["model"] = "opus"
+	data, _ = json.Marshal(settings)
+	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Editing the directory reinstalls it
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- session.watch(ctx) }()
+
+	edited := "#!/bin/sh\necho edited\n"
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		if err := os.WriteFile(filepath.Join(dir, "hook.sh"), []byte(edited), 0755); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Second)
+		if data, _ := os.ReadFile(installedScript); string(data) == edited {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the edited hook to be reinstalled:\n%s", stdout.String())
+		}
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("watch failed: %v", err)
+	}
+	session.close()
+
+	// Stopping removes the hook and keeps everything else in the client config
+	if utils.FileExists(installedScript) {
+		t.Error("Expected the hook to be removed")
+	}
+	data, _ = os.ReadFile(settingsPath)
+	if strings.Contains(string(data), "lint-on-save") || !strings.Contains(string(data), `"theme"`) || !strings.Contains(string(data), `"opus"`) {
+		t.Errorf("Expected only the hook to be removed from settings.json, got %s", data)
+	}
+	if trackerPath, _ := artifacts.GetTrackerPath(); utils.FileExists(trackerPath) {
+		t.Error("Expected the tracker not to be written")
+	}
+}
@@ -0,0 +1,227 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/artifacts"
+	"github.com/sleuth-io/skills/internal/config"
+	"github.com/sleuth-io/skills/internal/gitutil"
+)
+
+// doctorSeverity says whether a finding breaks skills or only degrades it
+type doctorSeverity int
+
+const (
+	doctorWarning doctorSeverity = iota
+	doctorError
+)
+
+// doctorFinding is a problem found by skills doctor
+type doctorFinding struct {
+	severity doctorSeverity
+	message  string
+
+	// fixDescription says what --fix does, or what to do by hand if the finding has no fix
+	fixDescription string
+
+	// fix repairs the problem (nil if it has to be fixed by hand or by reinstalling)
+	fix func(ctx context.Context) error
+
+	// reinstall means the problem is fixed by running 'skills install --repair' after the other fixes
+	reinstall bool
+}
+
+// fixable reports whether --fix can repair the finding
+func (f *doctorFinding) fixable() bool {
+	return f.fix != nil || f.reinstall
+}
+
+// doctorCheck is one area skills doctor diagnoses
+type doctorCheck struct {
+	name string
+	run  func(ctx context.Context, env *doctorEnv) []doctorFinding
+}
+
+// doctorEnv is the environment shared by the checks
+type doctorEnv struct {
+	cmd        *cobra.Command
+	home       string
+	gitContext *gitutil.GitContext // nil outside a git repository
+	cfg        *config.Config      // nil if the config can't be loaded
+	tracker    *artifacts.Tracker  // nil if the tracker can't be loaded
+}
+
+// doctorChecks lists the checks in the order they run
+var doctorChecks = []doctorCheck{
+	{"Configuration", checkDoctorConfig},
+	{"Repositories", checkDoctorRepositories},
+	{"Client hooks", checkDoctorClientHooks},
+	{"MCP servers", checkDoctorMCPServers},
+	{"Orphaned entries", checkDoctorOrphans},
+	{"Installed artifacts", checkDoctorTrackerDrift},
+	{"Version", checkDoctorVersion},
+}
+
+// NewDoctorCommand creates the doctor command
+func NewDoctorCommand() *cobra.Command {
+	var fix bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose and fix problems with the skills setup",
+		Long: `Check the configuration, repository access, cached lock files, the hooks skills
+registers in Claude Code and Cursor, MCP server commands, client config entries left
+behind by removed artifacts, installed artifacts against the filesystem, and whether
+a newer skills release is available.
+
+With --fix, repair what can be repaired automatically.`,
+		Example: `  skills doctor
+  skills doctor --fix`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd, fix)
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "Fix the problems that can be fixed automatically")
+
+	return cmd
+}
+
+// runDoctor executes the doctor command
+func runDoctor(cmd *cobra.Command, fix bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	out := newOutputHelper(cmd)
+
+	env := &doctorEnv{cmd: cmd}
+	env.home, _ = os.UserHomeDir()
+	if gitContext, err := gitutil.DetectContext(ctx); err == nil && gitContext.IsRepo {
+		env.gitContext = gitContext
+	}
+	env.cfg, _ = config.Load()
+	env.tracker, _ = artifacts.LoadTracker()
+
+	var findings []doctorFinding
+	for _, check := range doctorChecks {
+		results := check.run(ctx, env)
+		printDoctorCheck(out, check.name, results)
+		findings = append(findings, results...)
+	}
+
+	out.println()
+	if len(findings) == 0 {
+		out.println("✓ No problems found")
+		return nil
+	}
+
+	fixable := 0
+	for i := range findings {
+		if findings[i].fixable() {
+			fixable++
+		}
+	}
+
+	if !fix {
+		out.printf("%d problems found, %d can be fixed automatically\n", len(findings), fixable)
+		if fixable > 0 {
+			out.println("Run 'skills doctor --fix' to fix them")
+		}
+		return doctorResult(findings, nil)
+	}
+
+	fixed := applyDoctorFixes(ctx, cmd, out, findings)
+	return doctorResult(findings, fixed)
+}
+
+// printDoctorCheck prints a check's findings
+func printDoctorCheck(out *outputHelper, name string, findings []doctorFinding) {
+	if len(findings) == 0 {
+		out.printf("✓ %s\n", name)
+		return
+	}
+
+	symbol := "⚠"
+	for _, f := range findings {
+		if f.severity == doctorError {
+			symbol = "✗"
+		}
+	}
+	out.printf("%s %s\n", symbol, name)
+
+	for _, f := range findings {
+		out.printf("    %s\n", f.message)
+		switch {
+		case f.fixable():
+			out.printf("      fix: %s\n", f.fixDescription)
+		case f.fixDescription != "":
+			out.printf("      to fix by hand: %s\n", f.fixDescription)
+		}
+	}
+}
+
+// applyDoctorFixes runs the fixes, then reinstalls if any finding needs it
+// Returns which findings were fixed
+func applyDoctorFixes(ctx context.Context, cmd *cobra.Command, out *outputHelper, findings []doctorFinding) []bool {
+	fixed := make([]bool, len(findings))
+	fixFailed := make([]bool, len(findings))
+	reinstall := false
+
+	out.println()
+	out.println("Fixing...")
+	for i := range findings {
+		f := &findings[i]
+		if f.reinstall {
+			reinstall = true
+		}
+		if f.fix == nil {
+			continue
+		}
+		if err := f.fix(ctx); err != nil {
+			out.printf("  ✗ %s: %v\n", f.fixDescription, err)
+			fixFailed[i] = true
+			continue
+		}
+		out.printf("  ✓ %s\n", f.fixDescription)
+		// Findings that also need a reinstall are only fixed once it succeeds
+		fixed[i] = !f.reinstall
+	}
+
+	if !reinstall {
+		return fixed
+	}
+
+	out.println()
+	out.println("Reinstalling artifacts...")
+	if err := runInstall(cmd, nil, false, "", true, false); err != nil {
+		out.printf("  ✗ reinstall failed: %v\n", err)
+		return fixed
+	}
+	for i := range findings {
+		if findings[i].reinstall && !fixFailed[i] {
+			fixed[i] = true
+		}
+	}
+
+	return fixed
+}
+
+// doctorResult returns an error if errors remain after fixing
+func doctorResult(findings []doctorFinding, fixed []bool) error {
+	remaining := 0
+	for i, f := range findings {
+		if f.severity == doctorError && (fixed == nil || !fixed[i]) {
+			remaining++
+		}
+	}
+	if remaining > 0 {
+		return fmt.Errorf("%d problems need attention", remaining)
+	}
+	return nil
+}
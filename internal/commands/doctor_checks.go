@@ -0,0 +1,691 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/creativeprojects/go-selfupdate"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/artifacts"
+	"github.com/sleuth-io/skills/internal/buildinfo"
+	"github.com/sleuth-io/skills/internal/cache"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/config"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/repository"
+	"github.com/sleuth-io/skills/internal/scope"
+	"github.com/sleuth-io/skills/internal/transaction"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// checkDoctorConfig checks that the config exists, parses and is valid
+func checkDoctorConfig(ctx context.Context, env *doctorEnv) []doctorFinding {
+	configPath, _ := utils.GetConfigFile()
+
+	if !config.Exists() {
+		return []doctorFinding{{
+			severity:       doctorError,
+			message:        "skills is not configured",
+			fixDescription: "run 'skills init'",
+		}}
+	}
+	if env.cfg == nil {
+		_, err := config.Load()
+		return []doctorFinding{{
+			severity:       doctorError,
+			message:        fmt.Sprintf("failed to load the config: %v", err),
+			fixDescription: fmt.Sprintf("fix %s or run 'skills init' again", configPath),
+		}}
+	}
+
+	var findings []doctorFinding
+	for _, artifactName := range sortedKeys(env.cfg.Pins) {
+		repoName := env.cfg.Pins[artifactName]
+		if _, err := env.cfg.GetRepository(repoName); err == nil {
+			continue
+		}
+		findings = append(findings, doctorFinding{
+			severity:       doctorError,
+			message:        fmt.Sprintf("%s is pinned to unknown repository %s", artifactName, repoName),
+			fixDescription: fmt.Sprintf("remove the pin on %s", artifactName),
+			fix: func(ctx context.Context) error {
+				cfg, err := config.Load()
+				if err != nil {
+					return err
+				}
+				delete(cfg.Pins, artifactName)
+				return config.Save(cfg)
+			},
+		})
+	}
+
+	// Pins were checked above, with fixes
+	unpinned := *env.cfg
+	unpinned.Pins = nil
+	if err := unpinned.Validate(); err != nil {
+		findings = append(findings, doctorFinding{
+			severity:       doctorError,
+			message:        fmt.Sprintf("invalid config: %v", err),
+			fixDescription: fmt.Sprintf("fix %s or run 'skills init' again", configPath),
+		})
+	}
+
+	return findings
+}
+
+// checkDoctorRepositories checks that every repository answers with a valid lock file,
+// and that the cached copy is current
+func checkDoctorRepositories(ctx context.Context, env *doctorEnv) []doctorFinding {
+	if env.cfg == nil || env.cfg.Validate() != nil {
+		return nil
+	}
+
+	var findings []doctorFinding
+	for _, rc := range env.cfg.GetRepositories() {
+		if rc.Type == config.RepositoryTypeSleuth && rc.GetAuthToken() == "" {
+			findings = append(findings, doctorFinding{
+				severity:       doctorError,
+				message:        fmt.Sprintf("repository %s is not signed in", rc.Name),
+				fixDescription: "run 'skills init' to sign in",
+			})
+		}
+	}
+
+	multi, err := repository.NewFromConfigs(env.cfg.GetRepositories(), env.cfg.Pins)
+	if err != nil {
+		return append(findings, doctorFinding{
+			severity: doctorError,
+			message:  fmt.Sprintf("failed to set up repositories: %v", err),
+		})
+	}
+
+	for _, repo := range multi.Repositories() {
+		fetchCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		data, _, _, err := repo.GetLockFile(fetchCtx, "")
+		cancel()
+
+		if err != nil {
+			finding := doctorFinding{
+				severity:       doctorError,
+				message:        fmt.Sprintf("repository %s can't be reached: %v", repo.Name, err),
+				fixDescription: "check the network connection and the repository URL",
+			}
+			if strings.Contains(err.Error(), "HTTP 401") || strings.Contains(err.Error(), "HTTP 403") {
+				finding.message = fmt.Sprintf("repository %s rejected the credentials: %v", repo.Name, err)
+				finding.fixDescription = "run 'skills init' to sign in again"
+			}
+			findings = append(findings, finding)
+			continue
+		}
+
+		if _, err := lockfile.Parse(data); err != nil {
+			findings = append(findings, doctorFinding{
+				severity:       doctorError,
+				message:        fmt.Sprintf("repository %s has an invalid lock file: %v", repo.Name, err),
+				fixDescription: "fix the lock file in the repository",
+			})
+			continue
+		}
+
+		if repo.CacheKey == "" {
+			continue
+		}
+		cached, err := cache.LoadLockFile(repo.CacheKey)
+		switch {
+		case err != nil:
+			findings = append(findings, doctorFinding{
+				severity:       doctorWarning,
+				message:        fmt.Sprintf("repository %s has never been installed from", repo.Name),
+				fixDescription: "run 'skills install'",
+				reinstall:      true,
+			})
+		case !bytes.Equal(bytes.TrimSpace(cached), bytes.TrimSpace(data)):
+			findings = append(findings, doctorFinding{
+				severity:       doctorWarning,
+				message:        fmt.Sprintf("the cached lock file of repository %s is out of date", repo.Name),
+				fixDescription: "run 'skills install'",
+				reinstall:      true,
+			})
+		}
+	}
+
+	return findings
+}
+
+// checkDoctorClientHooks checks the hooks skills registers in Claude Code's settings.json
+// and Cursor's hooks.json
+func checkDoctorClientHooks(ctx context.Context, env *doctorEnv) []doctorFinding {
+	var findings []doctorFinding
+
+	if client, ok := installedClient("claude-code"); ok {
+		findings = append(findings, checkHookFile(client, filepath.Join(env.home, ".claude", "settings.json"), map[string]string{
+			"SessionStart": "skills install",
+			"PostToolUse":  "skills report-usage",
+		})...)
+	}
+	if client, ok := installedClient("cursor"); ok {
+		findings = append(findings, checkHookFile(client, filepath.Join(env.home, ".cursor", "hooks.json"), map[string]string{
+			"beforeSubmitPrompt": "skills install",
+		})...)
+	}
+
+	return findings
+}
+
+// checkHookFile checks that a client's hook config parses, has skills' own hooks for
+// each event (mapped to the command prefix), and that every entry is well-formed
+func checkHookFile(client clients.Client, path string, required map[string]string) []doctorFinding {
+	doc, err := readClientConfig(path)
+	if err != nil {
+		return []doctorFinding{{
+			severity:       doctorError,
+			message:        err.Error(),
+			fixDescription: fmt.Sprintf("fix the JSON in %s", path),
+		}}
+	}
+
+	hooks, ok := doc["hooks"].(map[string]interface{})
+	if doc["hooks"] != nil && !ok {
+		return []doctorFinding{{
+			severity:       doctorError,
+			message:        fmt.Sprintf("%s: \"hooks\" is not an object", path),
+			fixDescription: fmt.Sprintf("fix the hooks section of %s", path),
+		}}
+	}
+
+	var findings []doctorFinding
+	reinstallHooks := func(ctx context.Context) error { return client.InstallHooks(ctx) }
+
+	var missing []string
+	for _, event := range sortedKeys(required) {
+		if !hasHookCommand(hooks[event], required[event]) {
+			missing = append(missing, event)
+		}
+	}
+	if len(missing) > 0 {
+		findings = append(findings, doctorFinding{
+			severity:       doctorWarning,
+			message:        fmt.Sprintf("%s: the skills %s hooks are missing, so artifacts aren't kept up to date", path, strings.Join(missing, " and ")),
+			fixDescription: fmt.Sprintf("reinstall the skills hooks for %s", client.DisplayName()),
+			fix:            reinstallHooks,
+		})
+	}
+
+	for _, event := range sortedKeys(hooks) {
+		entries, ok := hooks[event].([]interface{})
+		if !ok {
+			findings = append(findings, doctorFinding{
+				severity:       doctorError,
+				message:        fmt.Sprintf("%s: hooks for %s are not a list", path, event),
+				fixDescription: fmt.Sprintf("fix the %s hooks in %s", event, path),
+			})
+			continue
+		}
+		for _, entry := range entries {
+			if hookEntryWellFormed(entry) {
+				continue
+			}
+			finding := doctorFinding{
+				severity:       doctorError,
+				message:        fmt.Sprintf("%s: malformed %s hook entry", path, event),
+				fixDescription: fmt.Sprintf("fix the %s hooks in %s", event, path),
+			}
+			if owner := entryOwner(entry); owner != "" {
+				finding.message = fmt.Sprintf("%s: malformed %s hook entry for %s", path, event, owner)
+				finding.fixDescription = fmt.Sprintf("remove the entry and reinstall %s", owner)
+				finding.fix = func(ctx context.Context) error {
+					if err := removeClientConfigEntries(ctx, path, owner); err != nil {
+						return err
+					}
+					return forgetTrackedClient(ctx, owner, client.ID())
+				}
+				finding.reinstall = true
+			}
+			findings = append(findings, finding)
+		}
+	}
+
+	return findings
+}
+
+// hasHookCommand reports whether an event's hook entries run a command with the prefix
+func hasHookCommand(value interface{}, prefix string) bool {
+	entries, _ := value.([]interface{})
+	for _, entry := range entries {
+		for _, command := range hookCommands(entry) {
+			if strings.HasPrefix(command, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hookCommands returns the commands of a hook entry: a Claude Code matcher group
+// ({"hooks": [{"type": "command", "command": ...}]}) or a Cursor entry ({"command": ...})
+func hookCommands(entry interface{}) []string {
+	m, ok := entry.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if command, ok := m["command"].(string); ok {
+		return []string{command}
+	}
+	var commands []string
+	hooks, _ := m["hooks"].([]interface{})
+	for _, h := range hooks {
+		if hm, ok := h.(map[string]interface{}); ok {
+			if command, ok := hm["command"].(string); ok {
+				commands = append(commands, command)
+			}
+		}
+	}
+	return commands
+}
+
+// hookEntryWellFormed reports whether a hook entry has a command to run, and in a
+// matcher group, whether every hook has one
+func hookEntryWellFormed(entry interface{}) bool {
+	m, ok := entry.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	if _, isGroup := m["hooks"]; !isGroup {
+		command, _ := m["command"].(string)
+		return command != ""
+	}
+
+	hooks, ok := m["hooks"].([]interface{})
+	if !ok || len(hooks) == 0 {
+		return false
+	}
+	for _, h := range hooks {
+		hm, ok := h.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if command, _ := hm["command"].(string); command == "" || hm["type"] != "command" {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDoctorMCPServers checks that the commands of MCP servers in client configs exist
+func checkDoctorMCPServers(ctx context.Context, env *doctorEnv) []doctorFinding {
+	var findings []doctorFinding
+	for _, file := range doctorConfigFiles(env) {
+		if !file.mcp {
+			continue
+		}
+		doc, err := readClientConfig(file.path)
+		if err != nil {
+			findings = append(findings, doctorFinding{
+				severity:       doctorError,
+				message:        err.Error(),
+				fixDescription: fmt.Sprintf("fix the JSON in %s", file.path),
+			})
+			continue
+		}
+
+		servers, _ := doc["mcpServers"].(map[string]interface{})
+		for _, name := range sortedKeys(servers) {
+			server, _ := servers[name].(map[string]interface{})
+			command, _ := server["command"].(string)
+			if command == "" || commandExists(command) {
+				// Servers without a command are remote
+				continue
+			}
+
+			finding := doctorFinding{
+				severity:       doctorWarning,
+				message:        fmt.Sprintf("%s: MCP server %s runs %s, which doesn't exist", file.path, name, command),
+				fixDescription: fmt.Sprintf("install %s or fix the %s entry", command, name),
+			}
+			if owner := entryOwner(server); owner != "" {
+				path, clientID := file.path, file.clientID
+				finding.severity = doctorError
+				finding.fixDescription = fmt.Sprintf("remove the entry and reinstall %s", owner)
+				finding.fix = func(ctx context.Context) error {
+					if err := removeClientConfigEntries(ctx, path, owner); err != nil {
+						return err
+					}
+					return forgetTrackedClient(ctx, owner, clientID)
+				}
+				finding.reinstall = true
+			}
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// commandExists reports whether a command is an existing file or on the PATH
+func commandExists(command string) bool {
+	if filepath.IsAbs(command) {
+		return utils.FileExists(command)
+	}
+	_, err := exec.LookPath(command)
+	return err == nil
+}
+
+// checkDoctorOrphans finds client config entries marked with an artifact that isn't installed
+func checkDoctorOrphans(ctx context.Context, env *doctorEnv) []doctorFinding {
+	if env.tracker == nil {
+		return nil
+	}
+	tracked := make(map[string]bool)
+	for _, a := range env.tracker.Artifacts {
+		tracked[a.Name] = true
+	}
+
+	var findings []doctorFinding
+	for _, file := range doctorConfigFiles(env) {
+		doc, err := readClientConfig(file.path)
+		if err != nil {
+			// Reported by the hook and MCP checks
+			continue
+		}
+
+		owners := make(map[string]bool)
+		collectEntryOwners(doc, owners)
+		for _, owner := range sortedKeys(owners) {
+			if tracked[owner] {
+				continue
+			}
+			path := file.path
+			findings = append(findings, doctorFinding{
+				severity:       doctorWarning,
+				message:        fmt.Sprintf("%s: entries for %s, which is not installed", path, owner),
+				fixDescription: fmt.Sprintf("remove the %s entries from %s", owner, filepath.Base(path)),
+				fix: func(ctx context.Context) error {
+					return removeClientConfigEntries(ctx, path, owner)
+				},
+			})
+		}
+	}
+	return findings
+}
+
+// checkDoctorTrackerDrift checks tracked artifacts against what is installed on disk
+// Only global artifacts and those of the current repository can be checked
+func checkDoctorTrackerDrift(ctx context.Context, env *doctorEnv) []doctorFinding {
+	if env.tracker == nil {
+		_, err := artifacts.LoadTracker()
+		return []doctorFinding{{
+			severity:       doctorError,
+			message:        fmt.Sprintf("the installed artifacts tracker can't be read: %v", err),
+			fixDescription: "reset the tracker and reinstall",
+			fix: func(ctx context.Context) error {
+				return artifacts.DeleteTracker()
+			},
+			reinstall: true,
+		}}
+	}
+
+	var findings []doctorFinding
+	for _, tracked := range env.tracker.Artifacts {
+		installScope := trackedInstallScope(&tracked, env)
+		if installScope == nil {
+			continue
+		}
+
+		art := &lockfile.Artifact{Name: tracked.Name, Version: tracked.Version, Type: artifact.FromString(tracked.Type)}
+		for _, clientID := range tracked.Clients {
+			client, err := clients.Global().Get(clientID)
+			if err != nil {
+				continue
+			}
+			for _, result := range client.VerifyArtifacts(ctx, []*lockfile.Artifact{art}, installScope) {
+				if result.Installed {
+					continue
+				}
+				name, id := tracked.Name, clientID
+				findings = append(findings, doctorFinding{
+					severity:       doctorWarning,
+					message:        fmt.Sprintf("%s@%s (%s) is tracked as installed for %s, but %s", tracked.Name, tracked.Version, tracked.ScopeDescription(), client.DisplayName(), result.Message),
+					fixDescription: fmt.Sprintf("reinstall %s", tracked.Name),
+					fix: func(ctx context.Context) error {
+						return forgetTrackedClient(ctx, name, id)
+					},
+					reinstall: true,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// trackedInstallScope returns where a tracked artifact is installed, or nil if it
+// belongs to a repository other than the current one
+func trackedInstallScope(tracked *artifacts.InstalledArtifact, env *doctorEnv) *clients.InstallScope {
+	if tracked.IsGlobal() {
+		return &clients.InstallScope{Type: clients.ScopeGlobal}
+	}
+	if env.gitContext == nil || !scope.MatchRepoURLs(tracked.Repository, env.gitContext.RepoURL) {
+		return nil
+	}
+	if tracked.Path != "" {
+		return &clients.InstallScope{Type: clients.ScopePath, RepoRoot: env.gitContext.RepoRoot, RepoURL: tracked.Repository, Path: tracked.Path}
+	}
+	return &clients.InstallScope{Type: clients.ScopeRepository, RepoRoot: env.gitContext.RepoRoot, RepoURL: tracked.Repository}
+}
+
+// checkDoctorVersion checks whether a newer skills release is available
+func checkDoctorVersion(ctx context.Context, env *doctorEnv) []doctorFinding {
+	currentVersion := buildinfo.Version
+	if currentVersion == "dev" || currentVersion == "" {
+		return nil
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	slug := selfupdate.ParseSlug(fmt.Sprintf("%s/%s", githubOwner, githubRepo))
+	latest, found, err := selfupdate.DetectLatest(checkCtx, slug)
+	if err != nil {
+		return []doctorFinding{{
+			severity:       doctorWarning,
+			message:        fmt.Sprintf("couldn't check for a newer release: %v", err),
+			fixDescription: "run 'skills update --check' when online",
+		}}
+	}
+	if !found || latest.LessOrEqual(currentVersion) {
+		return nil
+	}
+
+	return []doctorFinding{{
+		severity:       doctorWarning,
+		message:        fmt.Sprintf("skills %s is available (installed: %s)", latest.Version(), currentVersion),
+		fixDescription: fmt.Sprintf("update skills to %s", latest.Version()),
+		fix: func(ctx context.Context) error {
+			_, err := selfupdate.UpdateSelf(ctx, currentVersion, slug)
+			return err
+		},
+	}}
+}
+
+// installedClient returns a registered client if it is installed
+func installedClient(id string) (clients.Client, bool) {
+	client, err := clients.Global().Get(id)
+	if err != nil || !client.IsInstalled() {
+		return nil, false
+	}
+	return client, true
+}
+
+// doctorConfigFile is a client config file that can hold artifact entries
+type doctorConfigFile struct {
+	path     string
+	clientID string
+	mcp      bool // holds mcpServers
+}
+
+// doctorConfigFiles returns the existing client config files, globally and in the current repository
+func doctorConfigFiles(env *doctorEnv) []doctorConfigFile {
+	bases := []string{env.home}
+	if env.gitContext != nil && env.gitContext.RepoRoot != "" && env.gitContext.RepoRoot != env.home {
+		bases = append(bases, env.gitContext.RepoRoot)
+	}
+
+	var files []doctorConfigFile
+	for _, base := range bases {
+		for _, file := range []doctorConfigFile{
+			{path: filepath.Join(base, ".claude", "settings.json"), clientID: "claude-code"},
+			{path: filepath.Join(base, ".claude", ".mcp.json"), clientID: "claude-code", mcp: true},
+			{path: filepath.Join(base, ".cursor", "hooks.json"), clientID: "cursor"},
+			{path: filepath.Join(base, ".cursor", "mcp.json"), clientID: "cursor", mcp: true},
+		} {
+			if utils.FileExists(file.path) {
+				files = append(files, file)
+			}
+		}
+	}
+	return files
+}
+
+// readClientConfig reads a client's JSON config, returning an empty one if it doesn't exist
+func readClientConfig(path string) (map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return doc, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return doc, nil
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%s is not valid JSON: %w", path, err)
+	}
+	if doc == nil {
+		doc = make(map[string]interface{})
+	}
+	return doc, nil
+}
+
+// entryOwner returns the artifact that installed a client config entry, if any
+func entryOwner(entry interface{}) string {
+	m, _ := entry.(map[string]interface{})
+	owner, _ := m["_artifact"].(string)
+	return owner
+}
+
+// collectEntryOwners adds the owners of every artifact entry in a client config to owners
+func collectEntryOwners(value interface{}, owners map[string]bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if owner := entryOwner(v); owner != "" {
+			owners[owner] = true
+			return
+		}
+		for _, child := range v {
+			collectEntryOwners(child, owners)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectEntryOwners(child, owners)
+		}
+	}
+}
+
+// withoutEntries returns value with every entry owned by the artifact removed,
+// from lists and from objects keyed by name (such as mcpServers)
+func withoutEntries(value interface{}, owner string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if entryOwner(child) == owner {
+				delete(v, key)
+				continue
+			}
+			v[key] = withoutEntries(child, owner)
+		}
+		return v
+	case []interface{}:
+		kept := make([]interface{}, 0, len(v))
+		for _, child := range v {
+			if entryOwner(child) != owner {
+				kept = append(kept, withoutEntries(child, owner))
+			}
+		}
+		return kept
+	}
+	return value
+}
+
+// removeClientConfigEntries removes every entry owned by the artifact from a client config
+func removeClientConfigEntries(ctx context.Context, path, owner string) error {
+	doc, err := readClientConfig(path)
+	if err != nil {
+		return err
+	}
+	doc = withoutEntries(doc, owner).(map[string]interface{})
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", path, err)
+	}
+	if err := transaction.WriteFile(ctx, path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// forgetTrackedClient removes a client from an artifact's tracker entries so the
+// next install puts it back
+func forgetTrackedClient(ctx context.Context, name, clientID string) error {
+	tracker, err := artifacts.LoadTracker()
+	if err != nil {
+		return err
+	}
+
+	changed := false
+	for _, tracked := range append([]artifacts.InstalledArtifact(nil), tracker.Artifacts...) {
+		if tracked.Name != name {
+			continue
+		}
+		var remaining []string
+		for _, c := range tracked.Clients {
+			if c != clientID {
+				remaining = append(remaining, c)
+			}
+		}
+		if len(remaining) == len(tracked.Clients) {
+			continue
+		}
+		changed = true
+		if len(remaining) == 0 {
+			tracker.RemoveArtifact(tracked.Key())
+		} else {
+			tracked.Clients = remaining
+			tracker.UpsertArtifact(tracked)
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+	return artifacts.SaveTracker(ctx, tracker)
+}
+
+// sortedKeys returns a map's keys in order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,100 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDoctor(t *testing.T) {
+	tempDir := t.TempDir()
+	homeDir := filepath.Join(tempDir, "home")
+	workingDir := filepath.Join(tempDir, "working")
+	repoDir := filepath.Join(workingDir, "repo")
+
+	t.Setenv("HOME", homeDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(homeDir, ".cache"))
+	claudeDir := filepath.Join(homeDir, ".claude")
+
+	for _, dir := range []string{homeDir, workingDir, claudeDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory %s: %v", dir, err)
+		}
+	}
+
+	originalDir, _ := os.Getwd()
+	if err := os.Chdir(workingDir); err != nil {
+		t.Fatalf("Failed to change to working dir: %v", err)
+	}
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+
+	InitPathRepo(t, repoDir)
+	if err := os.WriteFile(filepath.Join(repoDir, "skill.lock"), []byte("lock-version = \"1.0\"\ncreated-by = \"test\"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write skill.lock: %v", err)
+	}
+
+	// No skills hooks, and a hook left behind by an artifact that is no longer installed
+	settings := `{
+  "hooks": {
+    "PreToolUse": [
+      {"_artifact": "removed-hook", "hooks": [{"type": "command", "command": "./lint.sh"}]},
+      {"hooks": [{"type": "command", "command": "echo mine"}]}
+    ]
+  }
+}`
+	settingsPath := filepath.Join(claudeDir, "settings.json")
+	if err := os.WriteFile(settingsPath, []byte(settings), 0644); err != nil {
+		t.Fatalf("Failed to write settings.json: %v", err)
+	}
+
+	runDoctorCmd := func(args ...string) string {
+		cmd := NewDoctorCommand()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stdout)
+		cmd.SilenceUsage = true
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected doctor to report only warnings: %v\n%s", err, stdout.String())
+		}
+		return stdout.String()
+	}
+
+	output := runDoctorCmd()
+	for _, want := range []string{"PostToolUse and SessionStart hooks are missing", "has never been installed from", "entries for removed-hook, which is not installed", "Run 'skills doctor --fix'"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in output:\n%s", want, output)
+		}
+	}
+
+	runDoctorCmd("--fix")
+
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatalf("Failed to read settings.json: %v", err)
+	}
+	if strings.Contains(string(data), "removed-hook") {
+		t.Errorf("Expected the orphaned hook to be removed:\n%s", data)
+	}
+	if !strings.Contains(string(data), "echo mine") {
+		t.Errorf("Expected the user's own hook to be kept:\n%s", data)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Expected valid JSON after fixing: %v", err)
+	}
+	hooks, _ := doc["hooks"].(map[string]interface{})
+	if !hasHookCommand(hooks["SessionStart"], "skills install") || !hasHookCommand(hooks["PostToolUse"], "skills report-usage") {
+		t.Errorf("Expected the skills hooks to be installed:\n%s", data)
+	}
+
+	if output := runDoctorCmd(); !strings.Contains(output, "No problems found") {
+		t.Errorf("Expected no problems after fixing:\n%s", output)
+	}
+}
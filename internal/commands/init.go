@@ -12,6 +12,7 @@ import (
 
 	"github.com/sleuth-io/skills/internal/config"
 	"github.com/sleuth-io/skills/internal/registry"
+	"github.com/sleuth-io/skills/internal/repository"
 	"github.com/sleuth-io/skills/internal/ui"
 	"github.com/sleuth-io/skills/internal/ui/components"
 )
@@ -26,30 +27,32 @@ func NewInitCommand() *cobra.Command {
 		repoType  string
 		serverURL string
 		repoURL   string
+		authToken string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "init",
-		Short: "Initialize configuration (local path, Git repo, or Sleuth server)",
+		Short: "Initialize configuration (local path, Git repo, OCI registry, or Sleuth server)",
 		Long: `Initialize skills configuration using a local directory, Git repository,
-or Sleuth server as the artifact source.
+OCI registry, or Sleuth server as the artifact source.
 
 By default, runs in interactive mode with local path as the default option.
 Use flags for non-interactive mode.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runInit(cmd, args, repoType, serverURL, repoURL)
+			return runInit(cmd, args, repoType, serverURL, repoURL, authToken)
 		},
 	}
 
-	cmd.Flags().StringVar(&repoType, "type", "", "Repository type: 'path', 'git', or 'sleuth'")
+	cmd.Flags().StringVar(&repoType, "type", "", "Repository type: 'path', 'git', 'oci', 's3', or 'sleuth'")
 	cmd.Flags().StringVar(&serverURL, "server-url", "", "Sleuth server URL (for type=sleuth)")
-	cmd.Flags().StringVar(&repoURL, "repo-url", "", "Repository URL (git URL, file:// URL, or directory path)")
+	cmd.Flags().StringVar(&repoURL, "repo-url", "", "Repository URL (git URL, oci:// URL, s3:// URL, file:// URL, or directory path)")
+	cmd.Flags().StringVar(&authToken, "auth-token", "", "Credentials as 'username:password' or a token (type=oci), or 'ACCESS_KEY_ID:SECRET_ACCESS_KEY' (type=s3)")
 
 	return cmd
 }
 
 // runInit executes the init command
-func runInit(cmd *cobra.Command, args []string, repoType, serverURL, repoURL string) error {
+func runInit(cmd *cobra.Command, args []string, repoType, serverURL, repoURL, authToken string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 	defer cancel()
 
@@ -69,7 +72,7 @@ func runInit(cmd *cobra.Command, args []string, repoType, serverURL, repoURL str
 
 	var err error
 	if nonInteractive {
-		err = runInitNonInteractive(cmd, ctx, repoType, serverURL, repoURL)
+		err = runInitNonInteractive(cmd, ctx, repoType, serverURL, repoURL, authToken)
 	} else {
 		err = runInitInteractive(cmd, ctx)
 	}
@@ -104,7 +107,7 @@ func runInitInteractive(cmd *cobra.Command, ctx context.Context) error {
 
 	options := []components.Option{
 		{Label: "Just for myself", Value: "personal", Description: "Local repository"},
-		{Label: "Share with my team", Value: "team", Description: "Git or Sleuth server"},
+		{Label: "Share with my team", Value: "team", Description: "Git, OCI registry, or Sleuth server"},
 	}
 
 	selected, err := components.SelectWithDefault("How will you use skills?", options, 0)
@@ -133,11 +136,12 @@ func initPersonalRepository(cmd *cobra.Command, ctx context.Context) error {
 	return configurePathRepo(cmd, ctx, repoPath)
 }
 
-// initTeamRepository prompts for team repository options (git or sleuth)
+// initTeamRepository prompts for team repository options (sleuth, git, or oci)
 func initTeamRepository(cmd *cobra.Command, ctx context.Context) error {
 	options := []components.Option{
 		{Label: "Sleuth", Value: "sleuth", Description: "Managed skills platform"},
 		{Label: "Git repository", Value: "git", Description: "Self-hosted Git repo"},
+		{Label: "OCI registry", Value: "oci", Description: "Container registry (ghcr.io, ECR, Harbor, ...)"},
 	}
 
 	selected, err := components.SelectWithDefault("Choose how to share with your team:", options, 0)
@@ -150,13 +154,15 @@ func initTeamRepository(cmd *cobra.Command, ctx context.Context) error {
 		return initSleuthServer(cmd, ctx)
 	case "git":
 		return initGitRepository(cmd, ctx)
+	case "oci":
+		return initOCIRepository(cmd, ctx)
 	default:
 		return fmt.Errorf("invalid choice: %s", selected.Value)
 	}
 }
 
 // runInitNonInteractive runs the init command in non-interactive mode
-func runInitNonInteractive(cmd *cobra.Command, ctx context.Context, repoType, serverURL, repoURL string) error {
+func runInitNonInteractive(cmd *cobra.Command, ctx context.Context, repoType, serverURL, repoURL, authToken string) error {
 	switch repoType {
 	case "sleuth":
 		if serverURL == "" {
@@ -176,8 +182,20 @@ func runInitNonInteractive(cmd *cobra.Command, ctx context.Context, repoType, se
 		}
 		return configurePathRepo(cmd, ctx, repoURL)
 
+	case "oci":
+		if repoURL == "" {
+			return fmt.Errorf("--repo-url is required for type=oci")
+		}
+		return configureOCIRepo(cmd, ctx, repoURL, authToken)
+
+	case "s3":
+		if repoURL == "" {
+			return fmt.Errorf("--repo-url is required for type=s3")
+		}
+		return configureS3Repo(cmd, ctx, repoURL, authToken)
+
 	default:
-		return fmt.Errorf("invalid repository type: %s (must be 'path', 'git', or 'sleuth')", repoType)
+		return fmt.Errorf("invalid repository type: %s (must be 'path', 'git', 'oci', 's3', or 'sleuth')", repoType)
 	}
 }
 
@@ -295,6 +313,92 @@ func configureGitRepo(cmd *cobra.Command, ctx context.Context, repoURL string) e
 	return nil
 }
 
+// initOCIRepository initializes OCI registry configuration
+func initOCIRepository(cmd *cobra.Command, ctx context.Context) error {
+	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+	styledOut.Newline()
+
+	repoURL, err := components.Input("Enter registry repository (e.g. oci://ghcr.io/acme/skills)")
+	if err != nil {
+		return err
+	}
+
+	if repoURL == "" {
+		return fmt.Errorf("repository URL is required")
+	}
+
+	authToken, err := components.Input("Enter registry credentials as username:password or token (leave empty for anonymous)")
+	if err != nil {
+		return err
+	}
+
+	return configureOCIRepo(cmd, ctx, repoURL, authToken)
+}
+
+// configureOCIRepo configures an OCI registry repository
+func configureOCIRepo(cmd *cobra.Command, ctx context.Context, repoURL, authToken string) error {
+	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+	if !strings.HasPrefix(repoURL, "oci://") {
+		repoURL = "oci://" + repoURL
+	}
+
+	// Check the URL parses before saving it
+	if _, err := repository.NewOCIRepository(repoURL, authToken); err != nil {
+		return err
+	}
+
+	styledOut.Newline()
+	styledOut.Muted("Configuring OCI registry...")
+
+	// Save configuration
+	cfg := &config.Config{
+		Type:          config.RepositoryTypeOCI,
+		RepositoryURL: repoURL,
+		AuthToken:     authToken,
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	styledOut.Newline()
+	styledOut.Success("Configuration saved!")
+	styledOut.KeyValue("OCI registry", repoURL)
+
+	return nil
+}
+
+// configureS3Repo configures an S3 bucket repository
+func configureS3Repo(cmd *cobra.Command, ctx context.Context, repoURL, authToken string) error {
+	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+
+	// Check the URL parses before saving it
+	if _, err := repository.NewS3Repository(repoURL, authToken); err != nil {
+		return err
+	}
+
+	styledOut.Newline()
+	styledOut.Muted("Configuring S3 bucket...")
+
+	// Save configuration
+	cfg := &config.Config{
+		Type:          config.RepositoryTypeS3,
+		RepositoryURL: repoURL,
+		AuthToken:     authToken,
+	}
+
+	if err := config.Save(cfg); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	styledOut.Newline()
+	styledOut.Success("Configuration saved!")
+	styledOut.KeyValue("S3 bucket", repoURL)
+
+	return nil
+}
+
 // configurePathRepo configures a local path repository
 func configurePathRepo(cmd *cobra.Command, ctx context.Context, repoPath string) error {
 	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
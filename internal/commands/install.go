@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/artifacts"
@@ -20,8 +21,13 @@ import (
 	"github.com/sleuth-io/skills/internal/gitutil"
 	"github.com/sleuth-io/skills/internal/lockfile"
 	"github.com/sleuth-io/skills/internal/logger"
+	mcpserver "github.com/sleuth-io/skills/internal/mcp"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/policy"
 	"github.com/sleuth-io/skills/internal/repository"
 	"github.com/sleuth-io/skills/internal/scope"
+	"github.com/sleuth-io/skills/internal/secrets"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/ui"
 	"github.com/sleuth-io/skills/internal/ui/components"
 )
@@ -31,20 +37,25 @@ func NewInstallCommand() *cobra.Command {
 	var hookMode bool
 	var clientID string
 	var fixMode bool
+	var unpin bool
 
 	cmd := &cobra.Command{
 		Use:   "install",
 		Short: "Read lock file, fetch artifacts, and install locally",
 		Long: fmt.Sprintf(`Read the %s file, fetch artifacts from the configured repository,
-and install them to ~/.claude/ directory.`, constants.SkillLockFile),
+and install them to ~/.claude/ directory.
+
+Scopes rolled back with 'skills rollback' keep their installed generation
+until you run 'skills install --unpin'.`, constants.SkillLockFile),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runInstall(cmd, args, hookMode, clientID, fixMode)
+			return runInstall(cmd, args, hookMode, clientID, fixMode, unpin)
 		},
 	}
 
 	cmd.Flags().BoolVar(&hookMode, "hook-mode", false, "Run in hook mode (outputs JSON for Claude Code)")
 	cmd.Flags().StringVar(&clientID, "client", "", "Client ID that triggered the hook (used with --hook-mode)")
 	cmd.Flags().BoolVar(&fixMode, "repair", false, "Verify artifacts are actually installed and fix any discrepancies")
+	cmd.Flags().BoolVar(&unpin, "unpin", false, "Release scopes held by 'skills rollback' and install the lock file versions again")
 	_ = cmd.Flags().MarkHidden("hook-mode") // Hide from help output since it's internal
 	_ = cmd.Flags().MarkHidden("client")    // Hide from help output since it's internal
 
@@ -52,7 +63,7 @@ and install them to ~/.claude/ directory.`, constants.SkillLockFile),
 }
 
 // runInstall executes the install command
-func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID string, repairMode bool) error {
+func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID string, repairMode bool, unpin bool) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
 	defer cancel()
 
@@ -91,42 +102,22 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	// Create repository instance
-	repo, err := repository.NewFromConfig(cfg)
+	// Create a repository combining every configured repository
+	repo, err := repository.NewFromConfigs(cfg.GetRepositories(), cfg.Pins)
 	if err != nil {
 		return fmt.Errorf("failed to create repository: %w", err)
 	}
 
-	// Fetch lock file with spinner
+	// Fetch and merge lock files with spinner
+	// Each repository's ETag and lock file are cached by the multi-repository
 	status.Start("Fetching lock file")
 
-	cachedETag, _ := cache.LoadETag(cfg.RepositoryURL)
-
-	lockFileData, newETag, notModified, err := repo.GetLockFile(ctx, cachedETag)
+	lockFileData, _, _, err := repo.GetLockFile(ctx, "")
 	if err != nil {
 		status.Fail("Failed to fetch lock file")
 		return fmt.Errorf("failed to fetch lock file: %w", err)
 	}
 
-	if notModified {
-		lockFileData, err = cache.LoadLockFile(cfg.RepositoryURL)
-		if err != nil {
-			status.Fail("Failed to load cached lock file")
-			return fmt.Errorf("failed to load cached lock file: %w", err)
-		}
-	} else {
-		// Save ETag and lock file content
-		log := logger.Get()
-		if newETag != "" {
-			if err := cache.SaveETag(cfg.RepositoryURL, newETag); err != nil {
-				log.Error("failed to save ETag", "error", err)
-			}
-		}
-		if err := cache.SaveLockFile(cfg.RepositoryURL, lockFileData); err != nil {
-			log.Error("failed to cache lock file", "error", err)
-		}
-	}
-
 	// Parse lock file
 	lockFile, err := lockfile.Parse(lockFileData)
 	if err != nil {
@@ -140,6 +131,17 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 		return fmt.Errorf("lock file validation failed: %w", err)
 	}
 
+	// Enforce the policies shipped by the repositories before anything is downloaded
+	policies, err := policy.Load(ctx, repo)
+	if err != nil {
+		status.Fail("Failed to load policy")
+		return fmt.Errorf("failed to load policy: %w", err)
+	}
+	if err := policies.CheckLockFile(lockFile).Err(); err != nil {
+		status.Fail("Lock file violates policy")
+		return err
+	}
+
 	status.Clear() // Clear the spinner, no permanent message needed
 
 	// Detect Git context (transient)
@@ -151,27 +153,7 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 	}
 
 	// Build scope and matcher
-	var currentScope *scope.Scope
-	if gitContext.IsRepo {
-		if gitContext.RelativePath == "." {
-			currentScope = &scope.Scope{
-				Type:     scope.TypeRepo,
-				RepoURL:  gitContext.RepoURL,
-				RepoPath: "",
-			}
-		} else {
-			currentScope = &scope.Scope{
-				Type:     scope.TypePath,
-				RepoURL:  gitContext.RepoURL,
-				RepoPath: gitContext.RelativePath,
-			}
-		}
-	} else {
-		currentScope = &scope.Scope{
-			Type: scope.TypeGlobal,
-		}
-	}
-
+	currentScope := scopeFromGitContext(gitContext)
 	matcherScope := scope.NewMatcher(currentScope)
 
 	// Detect installed clients
@@ -222,6 +204,7 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 		supported := false
 		for _, client := range targetClients {
 			if artifact.MatchesClient(client.ID()) &&
+				policies.AllowsClient(client.ID(), artifact.Type) &&
 				client.SupportsArtifactType(artifact.Type) &&
 				matcherScope.MatchesArtifact(artifact) {
 				supported = true
@@ -246,9 +229,24 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 		return fmt.Errorf("dependency resolution failed: %w", err)
 	}
 
-	// Load tracker
+	// Stage every change to client configurations and the tracker in one transaction,
+	// so a failure or crash never leaves them half-written or out of step with each other
+	tx, err := beginInstallTransaction(out)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }() // No-op once committed
+	txCtx := transaction.NewContext(ctx, tx)
+
+	// Load tracker (after any interrupted install has been rolled back)
 	tracker := loadTracker(out)
 
+	// Scopes held by 'skills rollback' keep their generation until --unpin releases them
+	if unpin {
+		unpinScopes(tracker, currentScope, out)
+	}
+	sortedArtifacts = holdPinnedArtifacts(tracker, sortedArtifacts, currentScope, out)
+
 	// Determine which artifacts need to be installed (new or changed versions or missing from clients)
 	targetClientIDs := make([]string, len(targetClients))
 	for i, client := range targetClients {
@@ -263,12 +261,14 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 	artifactsToInstall := determineArtifactsToInstall(tracker, sortedArtifacts, currentScope, targetClientIDs, out)
 
 	// Clean up artifacts that were removed from lock file
-	cleanupRemovedArtifacts(ctx, tracker, sortedArtifacts, gitContext, currentScope, targetClients, out)
+	cleanupRemovedArtifacts(txCtx, tracker, sortedArtifacts, gitContext, currentScope, targetClients, out)
 
 	// Early exit if nothing to install
 	if len(artifactsToInstall) == 0 {
 		// Save state even if nothing changed
-		saveInstallationState(tracker, sortedArtifacts, currentScope, targetClientIDs, out)
+		if err := commitInstallation(txCtx, tx, tracker, sortedArtifacts, currentScope, targetClientIDs); err != nil {
+			return err
+		}
 
 		// Install client-specific hooks (e.g., auto-update, usage tracking)
 		installClientHooks(ctx, targetClients, out)
@@ -310,9 +310,11 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 	// Check for download errors
 	var downloadErrors []error
 	var successfulDownloads []*artifacts.ArtifactWithMetadata
+	failedDownloads := make(map[string]bool)
 	for _, result := range results {
 		if result.Error != nil {
 			downloadErrors = append(downloadErrors, fmt.Errorf("%s: %w", result.Artifact.Name, result.Error))
+			failedDownloads[result.Artifact.Name] = true
 		} else {
 			successfulDownloads = append(successfulDownloads, &artifacts.ArtifactWithMetadata{
 				Artifact: result.Artifact,
@@ -324,6 +326,20 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 
 	status.Clear()
 
+	// Hook events and MCP commands are only known once the metadata is downloaded
+	successfulDownloads, policyErrors := checkDownloadPolicies(policies, successfulDownloads)
+	for name, err := range policyErrors {
+		downloadErrors = append(downloadErrors, err)
+		failedDownloads[name] = true
+	}
+
+	// MCP servers can't be installed until their declared inputs have values
+	successfulDownloads, inputErrors := resolveMCPInputs(successfulDownloads, !hookMode && ui.IsStdinTTY(), out)
+	for name, err := range inputErrors {
+		downloadErrors = append(downloadErrors, err)
+		failedDownloads[name] = true
+	}
+
 	if len(downloadErrors) > 0 {
 		log := logger.Get()
 		for _, err := range downloadErrors {
@@ -338,10 +354,38 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 	}
 
 	// Install artifacts to their appropriate locations
-	installResult := installArtifacts(ctx, successfulDownloads, gitContext, currentScope, targetClients, out)
+	installResult := installArtifacts(txCtx, successfulDownloads, gitContext, currentScope, targetClients, policies, out)
+
+	// Any failure rolls back every client, so nothing is left partly installed
+	if len(installResult.Failed) > 0 {
+		rollbackErr := tx.Rollback()
+
+		styledOut.Error(fmt.Sprintf("Failed to install %d skills", len(installResult.Failed)))
+		for i, name := range installResult.Failed {
+			styledOut.ErrorItem(fmt.Sprintf("%s: %v", name, installResult.Errors[i]))
+			log.Error("artifact installation failed", "name", name, "error", installResult.Errors[i])
+		}
+
+		if rollbackErr != nil {
+			log.Error("install rollback failed", "error", rollbackErr)
+			return fmt.Errorf("some artifacts failed to install, and rolling back failed (it will be retried on the next install): %w", rollbackErr)
+		}
+		styledOut.Muted("All changes were rolled back.")
+		log.Info("install rolled back", "failed", len(installResult.Failed))
+		return fmt.Errorf("some artifacts failed to install")
+	}
 
 	// Save new installation state (saves ALL artifacts from lock file, not just changed ones)
-	saveInstallationState(tracker, sortedArtifacts, currentScope, targetClientIDs, out)
+	// Artifacts that failed to download keep their previous entry, matching what is on disk
+	var installedArtifacts []*lockfile.Artifact
+	for _, art := range sortedArtifacts {
+		if !failedDownloads[art.Name] {
+			installedArtifacts = append(installedArtifacts, art)
+		}
+	}
+	if err := commitInstallation(txCtx, tx, tracker, installedArtifacts, currentScope, targetClientIDs); err != nil {
+		return err
+	}
 
 	// Ensure skills support is configured for all clients (creates local rules files, etc.)
 	ensureSkillsSupport(ctx, targetClients, buildInstallScope(currentScope, gitContext), out)
@@ -361,20 +405,11 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 		}
 	}
 
-	if len(installResult.Failed) > 0 {
-		styledOut.Error(fmt.Sprintf("Failed to install %d skills", len(installResult.Failed)))
-		for i, name := range installResult.Failed {
-			styledOut.ErrorItem(fmt.Sprintf("%s: %v", name, installResult.Errors[i]))
-			log.Error("artifact installation failed", "name", name, "error", installResult.Errors[i])
-		}
-		return fmt.Errorf("some artifacts failed to install")
-	}
-
 	// Install client-specific hooks (e.g., auto-update, usage tracking)
 	installClientHooks(ctx, targetClients, out)
 
 	// Log summary
-	log.Info("install completed", "installed", len(installResult.Installed), "failed", len(installResult.Failed))
+	log.Info("install completed", "installed", len(installResult.Installed))
 
 	// If in hook mode and artifacts were installed, output JSON message
 	if hookMode && len(installResult.Installed) > 0 {
@@ -442,6 +477,113 @@ func runInstall(cmd *cobra.Command, args []string, hookMode bool, hookClientID s
 	return nil
 }
 
+// beginInstallTransaction rolls back installs left unfinished by a crash and starts a new transaction
+func beginInstallTransaction(out *outputHelper) (*transaction.Transaction, error) {
+	dir, err := cache.GetTransactionsDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions directory: %w", err)
+	}
+
+	recovered, err := transaction.Recover(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back interrupted install: %w", err)
+	}
+	if recovered > 0 {
+		out.printf("Rolled back %d interrupted install(s)\n", recovered)
+		log := logger.Get()
+		log.Warn("rolled back interrupted install", "count", recovered)
+	}
+
+	tx, err := transaction.Begin(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start install transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// commitInstallation saves the tracker as part of the transaction and commits it
+// If either step fails, everything is rolled back so the tracker still matches disk
+func commitInstallation(ctx context.Context, tx *transaction.Transaction, tracker *artifacts.Tracker, installedArtifacts []*lockfile.Artifact, currentScope *scope.Scope, targetClientIDs []string) error {
+	log := logger.Get()
+
+	if err := saveInstallationState(ctx, tracker, installedArtifacts, currentScope, targetClientIDs); err != nil {
+		log.Error("failed to save tracker", "error", err)
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to save installation state: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Error("failed to commit install", "error", err)
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to commit installation: %w", err)
+	}
+
+	return nil
+}
+
+// scopeFromGitContext returns the scope for the current directory
+func scopeFromGitContext(gitContext *gitutil.GitContext) *scope.Scope {
+	if !gitContext.IsRepo {
+		return &scope.Scope{
+			Type: scope.TypeGlobal,
+		}
+	}
+	if gitContext.RelativePath == "." {
+		return &scope.Scope{
+			Type:     scope.TypeRepo,
+			RepoURL:  gitContext.RepoURL,
+			RepoPath: "",
+		}
+	}
+	return &scope.Scope{
+		Type:     scope.TypePath,
+		RepoURL:  gitContext.RepoURL,
+		RepoPath: gitContext.RelativePath,
+	}
+}
+
+// installScopeKeys returns the tracker scopes an install from the current scope touches:
+// the global scope, plus the current repository or path scope
+func installScopeKeys(currentScope *scope.Scope) []artifacts.ArtifactKey {
+	keys := []artifacts.ArtifactKey{{}}
+	if key := artifacts.NewArtifactKey("", currentScope.Type, currentScope.RepoURL, currentScope.RepoPath); key != (artifacts.ArtifactKey{}) {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// unpinScopes releases the scopes touched by this install from generations pinned by rollback
+func unpinScopes(tracker *artifacts.Tracker, currentScope *scope.Scope, out *outputHelper) {
+	for _, key := range installScopeKeys(currentScope) {
+		if pinned := tracker.PinnedGeneration(key.Repository, key.Path); pinned != nil {
+			tracker.Unpin(key.Repository, key.Path)
+			out.printf("Unpinned %s from generation %d\n", pinned.ScopeDescription(), pinned.Number)
+			log := logger.Get()
+			log.Info("scope unpinned", "scope", pinned.ScopeDescription(), "generation", pinned.Number)
+		}
+	}
+}
+
+// holdPinnedArtifacts drops artifacts whose scope is pinned by rollback, so installs leave them alone
+func holdPinnedArtifacts(tracker *artifacts.Tracker, sortedArtifacts []*lockfile.Artifact, currentScope *scope.Scope, out *outputHelper) []*lockfile.Artifact {
+	held := make(map[artifacts.ArtifactKey]bool)
+	var result []*lockfile.Artifact
+	for _, art := range sortedArtifacts {
+		key := artifactKeyForInstall(art, currentScope)
+		scopeKey := artifacts.ArtifactKey{Repository: key.Repository, Path: key.Path}
+		pinned := tracker.PinnedGeneration(key.Repository, key.Path)
+		if pinned == nil {
+			result = append(result, art)
+			continue
+		}
+		if !held[scopeKey] {
+			held[scopeKey] = true
+			out.printf("Holding %s at generation %d (run 'skills install --unpin' to update)\n", pinned.ScopeDescription(), pinned.Number)
+		}
+	}
+	return result
+}
+
 // loadTracker loads the global tracker
 func loadTracker(out *outputHelper) *artifacts.Tracker {
 	tracker, err := artifacts.LoadTracker()
@@ -488,6 +630,9 @@ func artifactKeyForInstall(art *lockfile.Artifact, currentScope *scope.Scope) ar
 func cleanupRemovedArtifacts(ctx context.Context, tracker *artifacts.Tracker, sortedArtifacts []*lockfile.Artifact, gitContext *gitutil.GitContext, currentScope *scope.Scope, targetClients []clients.Client, out *outputHelper) {
 	// Find artifacts in tracker for this scope that are no longer in lock file
 	key := artifacts.NewArtifactKey("", currentScope.Type, currentScope.RepoURL, currentScope.RepoPath)
+	if tracker.PinnedGeneration(key.Repository, key.Path) != nil {
+		return // Held by rollback
+	}
 	currentInScope := tracker.FindByScope(key.Repository, key.Path)
 
 	lockFileNames := make(map[string]bool)
@@ -632,7 +777,7 @@ func repairTracker(ctx context.Context, tracker *artifacts.Tracker, sortedArtifa
 }
 
 // installArtifacts installs artifacts to all detected clients using the orchestrator
-func installArtifacts(ctx context.Context, successfulDownloads []*artifacts.ArtifactWithMetadata, gitContext *gitutil.GitContext, currentScope *scope.Scope, targetClients []clients.Client, out *outputHelper) *artifacts.InstallResult {
+func installArtifacts(ctx context.Context, successfulDownloads []*artifacts.ArtifactWithMetadata, gitContext *gitutil.GitContext, currentScope *scope.Scope, targetClients []clients.Client, policies policy.Set, out *outputHelper) *artifacts.InstallResult {
 	out.println("Installing artifacts...")
 
 	// Install each artifact to its proper scope
@@ -649,8 +794,8 @@ func installArtifacts(ctx context.Context, successfulDownloads []*artifacts.Arti
 		// Determine installation scope based on the ARTIFACT's scope, not current directory
 		installScope := buildInstallScopeForArtifact(download.Artifact, gitContext)
 
-		// Run installation for this artifact
-		results := runMultiClientInstallation(ctx, []*clients.ArtifactBundle{bundle}, installScope, targetClients)
+		// Run installation for this artifact on the clients that may receive it
+		results := runMultiClientInstallation(ctx, []*clients.ArtifactBundle{bundle}, installScope, allowedClients(download.Artifact, targetClients, policies))
 
 		// Merge results
 		for clientID, resp := range results {
@@ -775,10 +920,15 @@ func ensureSkillsSupport(ctx context.Context, targetClients []clients.Client, sc
 			log.Error("failed to ensure skills support", "client", client.ID(), "error", err)
 		}
 	}
+
+	// Let running 'skills serve' instances refresh their skill resources
+	if err := mcpserver.NotifyCatalogChanged(); err != nil {
+		log.Warn("failed to notify MCP servers of skill changes", "error", err)
+	}
 }
 
 // saveInstallationState saves the current installation state to tracker file
-func saveInstallationState(tracker *artifacts.Tracker, sortedArtifacts []*lockfile.Artifact, currentScope *scope.Scope, targetClientIDs []string, out *outputHelper) {
+func saveInstallationState(ctx context.Context, tracker *artifacts.Tracker, sortedArtifacts []*lockfile.Artifact, currentScope *scope.Scope, targetClientIDs []string) error {
 	for _, art := range sortedArtifacts {
 		key := artifactKeyForInstall(art, currentScope)
 		tracker.UpsertArtifact(artifacts.InstalledArtifact{
@@ -791,9 +941,69 @@ func saveInstallationState(tracker *artifacts.Tracker, sortedArtifacts []*lockfi
 		})
 	}
 
-	if err := artifacts.SaveTracker(tracker); err != nil {
-		out.printfErr("Warning: failed to save installation state: %v\n", err)
-		log := logger.Get()
-		log.Error("failed to save tracker", "error", err)
+	// Record the resulting installed set of each scope, so 'skills rollback' can return to it
+	for _, key := range installScopeKeys(currentScope) {
+		if tracker.PinnedGeneration(key.Repository, key.Path) == nil {
+			tracker.RecordGeneration(key.Repository, key.Path, time.Now())
+		}
 	}
+
+	return artifacts.SaveTracker(ctx, tracker)
+}
+
+// resolveMCPInputs makes sure every MCP server's declared inputs have values, prompting
+// for missing ones when interactive and otherwise reading them from the environment
+// It returns the downloads that can be installed and an error for each that can't, by name
+func resolveMCPInputs(downloads []*artifacts.ArtifactWithMetadata, interactive bool, out *outputHelper) ([]*artifacts.ArtifactWithMetadata, map[string]error) {
+	var store secrets.Store
+	var ready []*artifacts.ArtifactWithMetadata
+	errs := make(map[string]error)
+
+	for _, download := range downloads {
+		mcpConfig := download.Metadata.MCP
+		if mcpConfig == nil || len(mcpConfig.Inputs) == 0 {
+			ready = append(ready, download)
+			continue
+		}
+
+		if store == nil {
+			var err error
+			if store, err = secrets.Open(); err != nil {
+				errs[download.Artifact.Name] = fmt.Errorf("%s: failed to open secrets store: %w", download.Artifact.Name, err)
+				continue
+			}
+		}
+
+		var prompt secrets.PromptFunc
+		if interactive {
+			prompt = func(artifactName string, input metadata.MCPInput) (string, error) {
+				return promptMCPInput(artifactName, input, store, out)
+			}
+		}
+
+		if err := secrets.NewResolver(store, prompt).Resolve(download.Artifact.Name, mcpConfig.Inputs); err != nil {
+			errs[download.Artifact.Name] = fmt.Errorf("%s: %w", download.Artifact.Name, err)
+			continue
+		}
+		ready = append(ready, download)
+	}
+
+	return ready, errs
+}
+
+// promptMCPInput asks for one input's value, without echoing secrets
+func promptMCPInput(artifactName string, input metadata.MCPInput, store secrets.Store, out *outputHelper) (string, error) {
+	label := input.Name
+	if input.Description != "" {
+		label = fmt.Sprintf("%s (%s)", input.Name, input.Description)
+	}
+
+	if !input.Secret {
+		return out.prompter.PromptWithDefault(fmt.Sprintf("%s needs %s", artifactName, label), input.Default)
+	}
+
+	out.printf("%s needs %s, stored in the %s: ", artifactName, label, store.Description())
+	value, err := term.ReadPassword(int(os.Stdin.Fd()))
+	out.println()
+	return string(value), err
 }
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/signing"
+)
+
+// TestLockedSignedArtifactInstallsWithRequiredSignatures tests that skills lock keeps
+// the signature given at publish, so the regenerated lock passes require-signatures
+func TestLockedSignedArtifactInstallsWithRequiredSignatures(t *testing.T) {
+	tempDir := t.TempDir()
+	homeDir := filepath.Join(tempDir, "home")
+	workingDir := filepath.Join(tempDir, "working")
+	repoDir := filepath.Join(workingDir, "repo")
+	skillDir := filepath.Join(workingDir, "skill")
+
+	t.Setenv("HOME", homeDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(homeDir, ".cache"))
+	claudeDir := filepath.Join(homeDir, ".claude")
+
+	for _, dir := range []string{homeDir, workingDir, skillDir, claudeDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(claudeDir, "settings.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to create settings.json: %v", err)
+	}
+
+	originalDir, _ := os.Getwd()
+	if err := os.Chdir(workingDir); err != nil {
+		t.Fatalf("Failed to change to working dir: %v", err)
+	}
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+
+	skillMetadata := `[artifact]
+name = "test-skill"
+type = "skill"
+
+[skill]
+prompt-file = "SKILL.md"
+`
+	if err := os.WriteFile(filepath.Join(skillDir, "metadata.toml"), []byte(skillMetadata), 0644); err != nil {
+		t.Fatalf("Failed to write metadata.toml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("You are a signed skill."), 0644); err != nil {
+		t.Fatalf("Failed to write SKILL.md: %v", err)
+	}
+
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	keyPath := filepath.Join(tempDir, "signing.pem")
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		t.Fatalf("Failed to write signing key: %v", err)
+	}
+	key, err := signing.ParsePrivateKey(keyPEM, nil)
+	if err != nil {
+		t.Fatalf("Failed to parse signing key: %v", err)
+	}
+
+	InitPathRepo(t, repoDir)
+
+	mockPrompter := NewMockPrompter().
+		ExpectConfirm("correct", true).
+		ExpectPrompt("Version", "1.0.0").
+		ExpectPrompt("Choose an option", "1")
+
+	addCmd := NewAddCommand()
+	addCmd.SetArgs([]string{skillDir, "--sign-key", keyPath})
+	if err := ExecuteWithPrompter(addCmd, mockPrompter); err != nil {
+		t.Fatalf("Failed to add skill: %v", err)
+	}
+
+	// Regenerate the repository's lock file from requirements
+	if err := os.WriteFile("skill.txt", []byte("test-skill\n"), 0644); err != nil {
+		t.Fatalf("Failed to write skill.txt: %v", err)
+	}
+	lockPath := filepath.Join(repoDir, "skill.lock")
+	lockCmd := NewLockCommand()
+	lockCmd.SetArgs([]string{"-o", lockPath})
+	if err := lockCmd.Execute(); err != nil {
+		t.Fatalf("Failed to lock: %v", err)
+	}
+
+	locked, ok := lockfile.FindArtifact(lockPath, "test-skill")
+	if !ok {
+		t.Fatal("Expected test-skill in the regenerated lock file")
+	}
+	if locked.Signature == nil || locked.Signature.KeyID != key.Public().KeyID {
+		t.Fatalf("Expected the publish signature in the regenerated lock file, got %+v", locked.Signature)
+	}
+
+	policyPath, err := signing.GetTrustPolicyPath()
+	if err != nil {
+		t.Fatalf("Failed to get trust policy path: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(policyPath), 0755); err != nil {
+		t.Fatalf("Failed to create config dir: %v", err)
+	}
+	policy := fmt.Sprintf("require-signatures = true\nkeys = [%q]\n", key.Public().String())
+	if err := os.WriteFile(policyPath, []byte(policy), 0644); err != nil {
+		t.Fatalf("Failed to write trust policy: %v", err)
+	}
+
+	installCmd := NewInstallCommand()
+	if err := installCmd.Execute(); err != nil {
+		t.Fatalf("Failed to install: %v", err)
+	}
+	assertFileContent(t, filepath.Join(claudeDir, "skills", "test-skill", "SKILL.md"), "You are a signed skill.")
+}
@@ -168,3 +168,193 @@ prompt-file = "SKILL.md"
 
 	t.Log("✓ Integration test passed!")
 }
+
+// TestInstallRollsBackAllClientsOnFailure tests that a failure in one client undoes
+// the changes already made to every other client
+func TestInstallRollsBackAllClientsOnFailure(t *testing.T) {
+	tempDir := t.TempDir()
+	homeDir := filepath.Join(tempDir, "home")
+	workingDir := filepath.Join(tempDir, "working")
+	repoDir := filepath.Join(workingDir, "repo")
+	hookDir := filepath.Join(workingDir, "hook")
+
+	t.Setenv("HOME", homeDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(homeDir, ".cache"))
+	claudeDir := filepath.Join(homeDir, ".claude")
+	cursorDir := filepath.Join(homeDir, ".cursor")
+
+	for _, dir := range []string{homeDir, workingDir, hookDir, claudeDir, cursorDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory %s: %v", dir, err)
+		}
+	}
+
+	settingsPath := filepath.Join(claudeDir, "settings.json")
+	originalSettings := `{"model": "opus"}`
+	if err := os.WriteFile(settingsPath, []byte(originalSettings), 0644); err != nil {
+		t.Fatalf("Failed to create settings.json: %v", err)
+	}
+
+	originalDir, _ := os.Getwd()
+	if err := os.Chdir(workingDir); err != nil {
+		t.Fatalf("Failed to change to working dir: %v", err)
+	}
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+
+	// post-push is valid for Claude Code but has no Cursor equivalent, so Cursor fails
+	// after Claude Code has already written settings.json
+	hookMetadata := `[artifact]
+name = "push-hook"
+version = "1.0.0"
+type = "hook"
+
+[hook]
+event = "post-push"
+script-file = "hook.sh"
+`
+	if err := os.WriteFile(filepath.Join(hookDir, "metadata.toml"), []byte(hookMetadata), 0644); err != nil {
+		t.Fatalf("Failed to write metadata.toml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(hookDir, "hook.sh"), []byte("#!/bin/bash\nexit 0\n"), 0755); err != nil {
+		t.Fatalf("Failed to write hook.sh: %v", err)
+	}
+
+	InitPathRepo(t, repoDir)
+
+	mockPrompter := NewMockPrompter().
+		ExpectConfirm("correct", true).
+		ExpectPrompt("Version", "1.0.0").
+		ExpectPrompt("Choose an option", "1")
+
+	addCmd := NewAddCommand()
+	addCmd.SetArgs([]string{hookDir})
+	if err := ExecuteWithPrompter(addCmd, mockPrompter); err != nil {
+		t.Fatalf("Failed to add hook: %v", err)
+	}
+
+	installCmd := NewInstallCommand()
+	if err := installCmd.Execute(); err == nil {
+		t.Fatal("Expected install to fail for Cursor")
+	}
+
+	// Claude Code's changes were rolled back
+	settings, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatalf("Failed to read settings.json: %v", err)
+	}
+	if string(settings) != originalSettings {
+		t.Errorf("settings.json was not restored, got: %s", settings)
+	}
+	for _, dir := range []string{filepath.Join(claudeDir, "hooks"), filepath.Join(cursorDir, "hooks")} {
+		if _, err := os.Stat(dir); !os.IsNotExist(err) {
+			t.Errorf("Expected %s to be removed by rollback", dir)
+		}
+	}
+
+	// The tracker doesn't claim the hook was installed
+	tracker, err := os.ReadFile(filepath.Join(homeDir, ".cache", "skills", "installed.json"))
+	if err == nil && strings.Contains(string(tracker), "push-hook") {
+		t.Errorf("Tracker records rolled back artifact: %s", tracker)
+	}
+}
+
+// TestRollbackRestoresPreviousGeneration tests rolling back to an earlier install and holding it
+func TestRollbackRestoresPreviousGeneration(t *testing.T) {
+	tempDir := t.TempDir()
+	homeDir := filepath.Join(tempDir, "home")
+	workingDir := filepath.Join(tempDir, "working")
+	repoDir := filepath.Join(workingDir, "repo")
+	skillDir := filepath.Join(workingDir, "skill")
+
+	t.Setenv("HOME", homeDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(homeDir, ".cache"))
+	claudeDir := filepath.Join(homeDir, ".claude")
+
+	for _, dir := range []string{homeDir, workingDir, skillDir, claudeDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create directory %s: %v", dir, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(claudeDir, "settings.json"), []byte("{}"), 0644); err != nil {
+		t.Fatalf("Failed to create settings.json: %v", err)
+	}
+
+	originalDir, _ := os.Getwd()
+	if err := os.Chdir(workingDir); err != nil {
+		t.Fatalf("Failed to change to working dir: %v", err)
+	}
+	defer func() {
+		_ = os.Chdir(originalDir)
+	}()
+
+	skillMetadata := `[artifact]
+name = "test-skill"
+type = "skill"
+description = "A test skill"
+
+[skill]
+prompt-file = "SKILL.md"
+`
+	if err := os.WriteFile(filepath.Join(skillDir, "metadata.toml"), []byte(skillMetadata), 0644); err != nil {
+		t.Fatalf("Failed to write metadata.toml: %v", err)
+	}
+
+	InitPathRepo(t, repoDir)
+	installedPrompt := filepath.Join(claudeDir, "skills", "test-skill", "SKILL.md")
+
+	// Publish and install two versions, recording two generations
+	for _, version := range []string{"1.0.0", "2.0.0"} {
+		if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("prompt "+version), 0644); err != nil {
+			t.Fatalf("Failed to write SKILL.md: %v", err)
+		}
+		mockPrompter := NewMockPrompter().
+			ExpectConfirm("correct", true).
+			ExpectPrompt("Version", version).
+			ExpectPrompt("Choose an option", "1").
+			ExpectConfirm("Run install now", false)
+		addCmd := NewAddCommand()
+		addCmd.SetArgs([]string{skillDir})
+		if err := ExecuteWithPrompter(addCmd, mockPrompter); err != nil {
+			t.Fatalf("Failed to add %s: %v", version, err)
+		}
+		if err := NewInstallCommand().Execute(); err != nil {
+			t.Fatalf("Failed to install %s: %v", version, err)
+		}
+		assertFileContent(t, installedPrompt, "prompt "+version)
+	}
+
+	rollbackCmd := NewRollbackCommand()
+	rollbackCmd.SetArgs([]string{})
+	if err := rollbackCmd.Execute(); err != nil {
+		t.Fatalf("Failed to roll back: %v", err)
+	}
+	assertFileContent(t, installedPrompt, "prompt 1.0.0")
+
+	// The rolled back generation is held until --unpin
+	if err := NewInstallCommand().Execute(); err != nil {
+		t.Fatalf("Failed to install while pinned: %v", err)
+	}
+	assertFileContent(t, installedPrompt, "prompt 1.0.0")
+
+	unpinCmd := NewInstallCommand()
+	unpinCmd.SetArgs([]string{"--unpin"})
+	if err := unpinCmd.Execute(); err != nil {
+		t.Fatalf("Failed to install with --unpin: %v", err)
+	}
+	assertFileContent(t, installedPrompt, "prompt 2.0.0")
+}
+
+func assertFileContent(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	if string(data) != want {
+		t.Errorf("%s = %q, want %q", path, data, want)
+	}
+}
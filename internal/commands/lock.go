@@ -8,9 +8,9 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/sleuth-io/skills/internal/config"
 	"github.com/sleuth-io/skills/internal/constants"
 	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/policy"
 	"github.com/sleuth-io/skills/internal/repository"
 	"github.com/sleuth-io/skills/internal/requirements"
 	"github.com/sleuth-io/skills/internal/resolver"
@@ -67,19 +67,8 @@ func runLock(cmd *cobra.Command, args []string, requirementsFile, outputFile str
 	}
 	out.println()
 
-	// Load configuration
-	cfg, err := config.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w\nRun 'skills init' to configure", err)
-	}
-
-	// Validate configuration
-	if err := cfg.Validate(); err != nil {
-		return fmt.Errorf("invalid configuration: %w", err)
-	}
-
-	// Create repository instance
-	repo, err := repository.NewFromConfig(cfg)
+	// Create a repository combining every configured repository
+	repo, err := createMultiRepository()
 	if err != nil {
 		return fmt.Errorf("failed to create repository: %w", err)
 	}
@@ -102,6 +91,11 @@ func runLock(cmd *cobra.Command, args []string, requirementsFile, outputFile str
 	}
 	out.println()
 
+	// Refuse to write a lock file that breaks the repositories' policies
+	if err := checkLockFilePolicy(ctx, repo, lockFile); err != nil {
+		return err
+	}
+
 	// Write lock file
 	out.printf("Writing lock file to %s...\n", outputFile)
 	if err := lockfile.Write(lockFile, outputFile); err != nil {
@@ -116,3 +110,27 @@ func runLock(cmd *cobra.Command, args []string, requirementsFile, outputFile str
 
 	return nil
 }
+
+// checkLockFilePolicy checks a resolved lock file against the repositories' policies
+// Hook events and MCP commands are checked for artifacts the repositories have metadata for
+func checkLockFilePolicy(ctx context.Context, repo repository.Repository, lockFile *lockfile.LockFile) error {
+	policies, err := policy.Load(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to load policy: %w", err)
+	}
+	if policies.IsEmpty() {
+		return nil
+	}
+
+	violations := policies.CheckLockFile(lockFile)
+	for i := range lockFile.Artifacts {
+		art := &lockFile.Artifacts[i]
+		meta, err := repo.GetMetadata(ctx, art.Name, art.Version)
+		if err != nil {
+			// Direct git, path and http requirements aren't published to a repository
+			continue
+		}
+		violations = append(violations, policies.CheckMetadata(art, meta)...)
+	}
+	return violations.Err()
+}
@@ -0,0 +1,103 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/secrets"
+)
+
+// NewMCPExecCommand creates the mcp-exec command
+func NewMCPExecCommand() *cobra.Command {
+	var artifactName string
+	var secretNames []string
+	var envEntries []string
+
+	cmd := &cobra.Command{
+		Use:   secrets.ExecCommand + " --artifact NAME [--secret NAME]... [--env KEY=VALUE]... -- COMMAND [ARGS...]",
+		Short: "Start an MCP server with its stored secret inputs",
+		Long: `Start an MCP server with the secret inputs provided at install time.
+
+Client configs reference this command instead of containing secrets. Each --secret is read
+from the secrets store and set as an environment variable of the same name, and ${NAME}
+references in --env values and the server's arguments are expanded before it starts.`,
+		Hidden: true, // Hide from help output as it's for internal use
+		Args:   cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMCPExec(artifactName, secretNames, envEntries, args)
+		},
+	}
+
+	cmd.Flags().StringVar(&artifactName, "artifact", "", "Artifact the server belongs to")
+	cmd.Flags().StringArrayVar(&secretNames, "secret", nil, "Secret input to set in the server's environment")
+	cmd.Flags().StringArrayVar(&envEntries, "env", nil, "Environment variable whose value references inputs")
+	_ = cmd.MarkFlagRequired("artifact")
+
+	return cmd
+}
+
+// runMCPExec resolves the server's secrets and runs it, exiting with its exit code
+func runMCPExec(artifactName string, secretNames, envEntries, args []string) error {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			env[k] = v
+		}
+	}
+
+	if len(secretNames) > 0 {
+		store, err := secrets.Open()
+		if err != nil {
+			return fmt.Errorf("failed to open secrets store: %w", err)
+		}
+		for _, name := range secretNames {
+			value, ok, err := store.Get(secrets.Key(artifactName, name))
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return fmt.Errorf("secret %s for %s is not set (run 'skills install' to provide it)", name, artifactName)
+			}
+			env[name] = value
+		}
+	}
+
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+	for _, entry := range envEntries {
+		k, v, ok := strings.Cut(entry, "=")
+		if !ok {
+			return fmt.Errorf("invalid --env %q (must be KEY=VALUE)", entry)
+		}
+		env[k] = secrets.Expand(v, lookup)
+	}
+
+	expanded := make([]string, len(args))
+	for i, arg := range args {
+		expanded[i] = secrets.Expand(arg, lookup)
+	}
+
+	child := exec.Command(expanded[0], expanded[1:]...)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+	for k, v := range env {
+		child.Env = append(child.Env, k+"="+v)
+	}
+
+	if err := child.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.ExitCode())
+		}
+		return fmt.Errorf("failed to start MCP server: %w", err)
+	}
+	return nil
+}
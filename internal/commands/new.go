@@ -0,0 +1,72 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/scaffold"
+)
+
+// NewNewCommand creates the new command
+func NewNewCommand() *cobra.Command {
+	var dir string
+
+	var typeKeys []string
+	for _, t := range artifact.AllTypes() {
+		typeKeys = append(typeKeys, t.Key)
+	}
+
+	cmd := &cobra.Command{
+		Use:   "new <type> <name>",
+		Short: "Scaffold a new artifact",
+		Long: `Create a directory with a metadata.toml and starter files for a new artifact:
+a SKILL.md, AGENT.md or COMMAND.md prompt with frontmatter, a hook script, or an MCP
+server stub. Remote MCP servers only need a metadata.toml.
+
+Types: ` + strings.Join(typeKeys, ", "),
+		Example: `  skills new skill code-review
+  skills new hook lint-on-save --dir ./hooks/lint-on-save
+  skills new mcp jira-tools`,
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: typeKeys,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runNew(cmd, args[0], args[1], dir)
+		},
+	}
+
+	cmd.Flags().StringVar(&dir, "dir", "", "Directory to create the artifact in (default ./<name>)")
+
+	return cmd
+}
+
+// runNew executes the new command
+func runNew(cmd *cobra.Command, typeKey, name, dir string) error {
+	artifactType := artifact.FromString(typeKey)
+	if !artifactType.IsValid() {
+		return fmt.Errorf("unknown artifact type %q (expected one of: skill, command, agent, hook, mcp, mcp-remote)", typeKey)
+	}
+	if dir == "" {
+		dir = name
+	}
+
+	files, err := scaffold.Create(dir, artifactType, name)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", artifactType.Key, err)
+	}
+
+	out := newOutputHelper(cmd)
+	out.printf("Created %s %q in %s\n", artifactType.Key, name, dir)
+	for _, file := range files {
+		out.printf("  %s\n", filepath.ToSlash(filepath.Join(filepath.Base(dir), file)))
+	}
+	out.println()
+	out.println("Next steps:")
+	out.println("  1. Edit the files, starting with the description in metadata.toml")
+	out.printf("  2. Check the artifact: skills validate %s\n", dir)
+	out.printf("  3. Publish it: skills add %s (or build a zip with: skills pack %s)\n", dir, dir)
+	return nil
+}
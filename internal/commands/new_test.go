@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/clients/claude_code"
+	"github.com/sleuth-io/skills/internal/clients/windsurf"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/scaffold"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// runAuthoringCmd runs a command, returning its output and error
+func runAuthoringCmd(cmd *cobra.Command, args ...string) (string, error) {
+	var stdout bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stdout)
+	cmd.SetArgs(args)
+	cmd.SilenceUsage = true
+	err := cmd.Execute()
+	return stdout.String(), err
+}
+
+func TestNewValidatePack(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := filepath.Join(t.TempDir(), "lint-on-save")
+
+	if output, err := runAuthoringCmd(NewNewCommand(), "hook", "lint-on-save", "--dir", dir); err != nil {
+		t.Fatalf("new failed: %v\n%s", err, output)
+	}
+
+	output, err := runAuthoringCmd(NewValidateCommand(), dir)
+	if err != nil {
+		t.Fatalf("validate failed: %v\n%s", err, output)
+	}
+	for _, want := range []string{"✓ metadata.toml: lint-on-save 0.1.0 (hook)", "claude-code   ok", "cursor        ok"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected %q in validate output:\n%s", want, output)
+		}
+	}
+
+	// The script is packed executable because the metadata runs it, not because of its mode on disk
+	if err := os.Chmod(filepath.Join(dir, "hook.sh"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Packing twice gives the same zip, even with the first zip in the directory
+	zipPath := filepath.Join(dir, "lint-on-save-0.1.0.zip")
+	if output, err := runAuthoringCmd(NewPackCommand(), dir, "--output", zipPath); err != nil {
+		t.Fatalf("pack failed: %v\n%s", err, output)
+	}
+	first, err := os.ReadFile(zipPath)
+	if err != nil {
+		t.Fatalf("Expected the zip to be written: %v", err)
+	}
+	if output, err := runAuthoringCmd(NewPackCommand(), dir, "--output", zipPath); err != nil {
+		t.Fatalf("pack failed: %v\n%s", err, output)
+	}
+	second, _ := os.ReadFile(zipPath)
+	if !bytes.Equal(first, second) {
+		t.Error("Expected packing the same files to produce the same zip")
+	}
+
+	entries, err := utils.ListZipEntries(first)
+	if err != nil {
+		t.Fatalf("ListZipEntries failed: %v", err)
+	}
+	for _, entry := range entries {
+		want := os.FileMode(0644)
+		if entry.Name == "hook.sh" {
+			want = 0755
+		}
+		if entry.Mode.Perm() != want {
+			t.Errorf("Expected %s packed as %v, got %v", entry.Name, want, entry.Mode.Perm())
+		}
+	}
+
+	if output, err := runAuthoringCmd(NewValidateCommand(), zipPath); err != nil {
+		t.Errorf("validate of the packed zip failed: %v\n%s", err, output)
+	}
+
+	// A missing script is caught by the clients that install hooks
+	if err := os.Remove(filepath.Join(dir, "hook.sh")); err != nil {
+		t.Fatal(err)
+	}
+	output, err = runAuthoringCmd(NewValidateCommand(), dir)
+	if err == nil || !strings.Contains(output, "script file not found in zip: hook.sh") {
+		t.Errorf("Expected validation to fail for the missing script, got %v:\n%s", err, output)
+	}
+}
+
+func TestCheckClientCompatibilityUnchecked(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "code-review")
+	if _, err := scaffold.Create(dir, artifact.TypeSkill, "code-review"); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	zipData, err := metadata.CreateZip(dir)
+	if err != nil {
+		t.Fatalf("CreateZip failed: %v", err)
+	}
+	meta, err := validateArtifactMetadata(zipData)
+	if err != nil {
+		t.Fatalf("validateArtifactMetadata failed: %v", err)
+	}
+
+	// Clients without a validator install the skill but don't claim it passed
+	results := checkClientCompatibility([]clients.Client{windsurf.NewClient(), claude_code.NewClient()}, meta, zipData)
+	statuses := make(map[string]string)
+	for _, result := range results {
+		statuses[result.clientID] = result.status()
+	}
+	want := map[string]string{"claude-code": "ok", "windsurf": "not checked"}
+	if !reflect.DeepEqual(statuses, want) {
+		t.Errorf("Statuses = %v, want %v", statuses, want)
+	}
+}
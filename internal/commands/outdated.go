@@ -0,0 +1,102 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/constants"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/requirements"
+	"github.com/sleuth-io/skills/internal/resolver"
+)
+
+// NewOutdatedCommand creates the outdated command
+func NewOutdatedCommand() *cobra.Command {
+	var requirementsFile string
+	var lockFilePath string
+
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "Show locked artifacts with newer versions available",
+		Long: `Compare each artifact in the lock file against the versions available in the repository.
+
+For every outdated artifact, shows the locked version, the latest version allowed by the
+requirements file (and the artifacts that depend on it), and the latest version available.
+Run 'skills upgrade' to move to the allowed versions.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOutdated(cmd, requirementsFile, lockFilePath)
+		},
+	}
+
+	cmd.Flags().StringVarP(&requirementsFile, "requirements", "r", constants.SkillRequirementsFile, "Requirements file to read")
+	cmd.Flags().StringVarP(&lockFilePath, "lock-file", "l", constants.SkillLockFile, "Lock file to check")
+
+	return cmd
+}
+
+// runOutdated executes the outdated command
+func runOutdated(cmd *cobra.Command, requirementsFile, lockFilePath string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	out := newOutputHelper(cmd)
+
+	reqs, lockFile, err := loadRequirementsAndLock(requirementsFile, lockFilePath)
+	if err != nil {
+		return err
+	}
+
+	repo, err := createMultiRepository()
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	outdated, err := resolver.New(ctx, repo).Outdated(reqs, lockFile)
+	if err != nil {
+		return fmt.Errorf("failed to check for updates: %w", err)
+	}
+
+	if len(outdated) == 0 {
+		out.println("All artifacts are up to date")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCURRENT\tALLOWED\tLATEST")
+	for _, o := range outdated {
+		wanted := o.Wanted
+		if wanted == "" {
+			wanted = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", o.Name, o.Current, wanted, o.Latest)
+	}
+	return w.Flush()
+}
+
+// loadRequirementsAndLock parses the requirements file and an existing lock file
+func loadRequirementsAndLock(requirementsFile, lockFilePath string) ([]requirements.Requirement, *lockfile.LockFile, error) {
+	if _, err := os.Stat(requirementsFile); err != nil {
+		return nil, nil, fmt.Errorf("requirements file not found: %s", requirementsFile)
+	}
+
+	reqs, err := requirements.Parse(requirementsFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse requirements: %w", err)
+	}
+
+	if _, err := os.Stat(lockFilePath); err != nil {
+		return nil, nil, fmt.Errorf("lock file not found: %s\nRun 'skills lock' to generate it", lockFilePath)
+	}
+
+	lockFile, err := lockfile.ParseFile(lockFilePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse lock file: %w", err)
+	}
+
+	return reqs, lockFile, nil
+}
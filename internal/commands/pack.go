@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// NewPackCommand creates the pack command
+func NewPackCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "pack [dir]",
+		Short: "Build a reproducible zip of an artifact directory",
+		Long: `Validate an artifact directory's metadata.toml and zip it. Entries are sorted and
+timestamps fixed, so packing the same files always produces the same zip and the
+same hash. The zip is written to <name>-<version>.zip unless --output is given.
+
+The directory defaults to the current directory.`,
+		Example: `  skills pack
+  skills pack ./code-review
+  skills pack ./code-review --output dist/code-review.zip`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			dir := "."
+			if len(args) > 0 {
+				dir = args[0]
+			}
+			return runPack(cmd, dir, output)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Path to write the zip to (default <name>-<version>.zip)")
+
+	return cmd
+}
+
+// runPack executes the pack command
+func runPack(cmd *cobra.Command, dir, output string) error {
+	dir, err := utils.NormalizePath(dir)
+	if err == nil {
+		dir, err = filepath.Abs(dir)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid path: %w", err)
+	}
+	if !utils.IsDirectory(dir) {
+		return fmt.Errorf("not a directory: %s", dir)
+	}
+
+	meta, err := metadata.ParseFile(filepath.Join(dir, "metadata.toml"))
+	if err != nil {
+		return fmt.Errorf("%w (run 'skills new' to scaffold an artifact)", err)
+	}
+	if err := meta.Validate(); err != nil {
+		return fmt.Errorf("invalid metadata.toml: %w", err)
+	}
+
+	if output == "" {
+		output = fmt.Sprintf("%s-%s.zip", meta.Artifact.Name, meta.Artifact.Version)
+	}
+	output, err = filepath.Abs(output)
+	if err != nil {
+		return fmt.Errorf("invalid output path: %w", err)
+	}
+
+	// Leave a previous pack written inside the directory out of the zip
+	var exclude []string
+	if rel, err := filepath.Rel(dir, output); err == nil && !strings.HasPrefix(rel, "..") {
+		exclude = append(exclude, filepath.ToSlash(rel))
+	}
+	zipData, err := utils.CreateReproducibleZip(dir, utils.ZipOptions{Executables: meta.ExecutableFiles(), Exclude: exclude})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	if err := os.WriteFile(output, zipData, 0644); err != nil {
+		return fmt.Errorf("failed to write zip: %w", err)
+	}
+
+	out := newOutputHelper(cmd)
+	out.printf("Packed %s %s to %s\n", meta.Artifact.Name, meta.Artifact.Version, output)
+	out.printf("sha256: %s\n", utils.ComputeSHA256(zipData))
+	return nil
+}
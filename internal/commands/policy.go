@@ -0,0 +1,211 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/artifacts"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/constants"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/policy"
+	"github.com/sleuth-io/skills/internal/repository"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// NewPolicyCommand creates the policy command
+func NewPolicyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy",
+		Short: "Check lock files against organization policy",
+		Long: `Repositories can ship a skill-policy.toml next to their lock file to restrict
+artifact sources, hook events, MCP commands and which clients receive which types.
+The policy is enforced by 'skills install', 'skills add' and 'skills lock'.`,
+	}
+
+	cmd.AddCommand(newPolicyCheckCommand())
+
+	return cmd
+}
+
+// newPolicyCheckCommand creates the policy check command
+func newPolicyCheckCommand() *cobra.Command {
+	var lockFilePath string
+	var policyPath string
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check a lock file against policy",
+		Long: `Check every artifact in a lock file against policy, for use in CI.
+
+The policy is read from --policy, or else from skill-policy.toml next to the lock file,
+or else from the configured repositories. Hook events and MCP commands are checked
+for artifacts whose metadata is in the lock file's directory (artifacts/<name>/<version>/
+or a source-path). Exits with an error if there are violations.`,
+		Example: `  skills policy check
+  skills policy check --lock-file repo/skill.lock --policy org-policy.toml`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPolicyCheck(cmd, lockFilePath, policyPath)
+		},
+	}
+
+	cmd.Flags().StringVarP(&lockFilePath, "lock-file", "l", constants.SkillLockFile, "Lock file to check")
+	cmd.Flags().StringVarP(&policyPath, "policy", "p", "", "Policy file (default: skill-policy.toml next to the lock file, or the repositories' policies)")
+
+	return cmd
+}
+
+// runPolicyCheck executes the policy check command
+func runPolicyCheck(cmd *cobra.Command, lockFilePath, policyPath string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	out := newOutputHelper(cmd)
+
+	lockFile, err := lockfile.ParseFile(lockFilePath)
+	if err != nil {
+		return fmt.Errorf("failed to parse lock file: %w", err)
+	}
+	if err := lockFile.Validate(); err != nil {
+		return fmt.Errorf("lock file validation failed: %w", err)
+	}
+
+	lockDir := filepath.Dir(lockFilePath)
+	policies, err := loadPoliciesForCheck(ctx, lockDir, policyPath)
+	if err != nil {
+		return err
+	}
+	if policies.IsEmpty() {
+		return fmt.Errorf("no policy found: pass --policy or add %s next to the lock file", constants.SkillPolicyFile)
+	}
+
+	out.printf("Checking %s against %s...\n", lockFilePath, strings.Join(policies.Sources(), ", "))
+
+	violations := policies.CheckLockFile(lockFile)
+	var unchecked []string
+	for i := range lockFile.Artifacts {
+		art := &lockFile.Artifacts[i]
+		meta := localMetadata(lockDir, art)
+		if meta == nil {
+			unchecked = append(unchecked, art.Key())
+			continue
+		}
+		violations = append(violations, policies.CheckMetadata(art, meta)...)
+	}
+
+	if len(unchecked) > 0 {
+		out.printf("Metadata not available locally, hooks and MCP commands not checked: %s\n", strings.Join(unchecked, ", "))
+	}
+
+	if err := violations.Err(); err != nil {
+		out.printErr("✗ " + err.Error())
+		return fmt.Errorf("lock file violates policy")
+	}
+
+	out.printf("✓ %d artifacts comply with policy\n", len(lockFile.Artifacts))
+	return nil
+}
+
+// loadPoliciesForCheck loads the policy file, the one next to the lock file,
+// or else the configured repositories' policies
+func loadPoliciesForCheck(ctx context.Context, lockDir, policyPath string) (policy.Set, error) {
+	if policyPath == "" {
+		if local := filepath.Join(lockDir, constants.SkillPolicyFile); utils.FileExists(local) {
+			policyPath = local
+		}
+	}
+
+	if policyPath != "" {
+		p, err := policy.LoadFile(policyPath)
+		if err != nil {
+			return nil, err
+		}
+		return policy.Set{p}, nil
+	}
+
+	repo, err := createMultiRepository()
+	if err != nil {
+		return nil, fmt.Errorf("no %s next to the lock file, and no repositories to load a policy from: %w", constants.SkillPolicyFile, err)
+	}
+	policies, err := policy.Load(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load policy: %w", err)
+	}
+	return policies, nil
+}
+
+// localMetadata reads an artifact's metadata from an exploded artifacts/<name>/<version>
+// directory or a source-path relative to the lock file, returning nil if neither is available
+func localMetadata(lockDir string, art *lockfile.Artifact) *metadata.Metadata {
+	exploded := filepath.Join(lockDir, "artifacts", art.Name, art.Version, "metadata.toml")
+	if utils.FileExists(exploded) {
+		meta, err := metadata.ParseFile(exploded)
+		if err == nil {
+			return meta
+		}
+	}
+
+	if art.SourcePath == nil {
+		return nil
+	}
+	zipData, err := repository.NewPathSourceHandler(lockDir).Fetch(context.Background(), art)
+	if err != nil {
+		return nil
+	}
+	data, err := utils.ReadZipFile(zipData, "metadata.toml")
+	if err != nil {
+		return nil
+	}
+	meta, err := metadata.Parse(data)
+	if err != nil {
+		return nil
+	}
+	return meta
+}
+
+// checkDownloadPolicies checks downloaded artifacts' metadata against policy
+// Returns the downloads that comply, and an error for each that doesn't
+func checkDownloadPolicies(policies policy.Set, downloads []*artifacts.ArtifactWithMetadata) ([]*artifacts.ArtifactWithMetadata, map[string]error) {
+	if policies.IsEmpty() {
+		return downloads, nil
+	}
+
+	var allowed []*artifacts.ArtifactWithMetadata
+	errs := make(map[string]error)
+	for _, download := range downloads {
+		if err := policies.CheckMetadata(download.Artifact, download.Metadata).Err(); err != nil {
+			errs[download.Artifact.Name] = err
+			continue
+		}
+		allowed = append(allowed, download)
+	}
+	return allowed, errs
+}
+
+// allowedClients returns the target clients an artifact may be installed to,
+// according to its clients list and the policies
+func allowedClients(art *lockfile.Artifact, targetClients []clients.Client, policies policy.Set) []clients.Client {
+	var allowed []clients.Client
+	for _, client := range targetClients {
+		if art.MatchesClient(client.ID()) && policies.AllowsClient(client.ID(), art.Type) {
+			allowed = append(allowed, client)
+		}
+	}
+	return allowed
+}
+
+// checkArtifactPolicy returns an error if a lock file entry breaks the repository's policy
+func checkArtifactPolicy(ctx context.Context, repo repository.Repository, art *lockfile.Artifact) error {
+	policies, err := policy.Load(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to load policy: %w", err)
+	}
+	return policies.CheckArtifact(art).Err()
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	repoDir := t.TempDir()
+
+	lockFile := `lock-version = "1.0"
+version = "1"
+created-by = "test"
+
+[[artifacts]]
+name = "review"
+version = "1.0.0"
+type = "skill"
+
+[artifacts.source-path]
+path = "./artifacts/review/1.0.0"
+
+[[artifacts]]
+name = "guard"
+version = "1.0.0"
+type = "hook"
+
+[artifacts.source-path]
+path = "./artifacts/guard/1.0.0"
+`
+	files := map[string]string{
+		"skill.lock":                              lockFile,
+		"artifacts/review/1.0.0/metadata.toml":    "[artifact]\nname = \"review\"\nversion = \"1.0.0\"\ntype = \"skill\"\n\n[skill]\nprompt-file = \"SKILL.md\"\n",
+		"artifacts/review/1.0.0/SKILL.md":         "Review",
+		"artifacts/guard/1.0.0/metadata.toml":     "[artifact]\nname = \"guard\"\nversion = \"1.0.0\"\ntype = \"hook\"\n\n[hook]\nevent = \"PreToolUse\"\ncommand = \"true\"\n",
+		"org-policy.toml":                         "[hooks]\nallowed-events = [\"SessionStart\"]\n",
+		"permissive/skill-policy.toml":            "[sources]\ndeny = [\"oci\"]\n",
+		"permissive/skill.lock":                   lockFile,
+		"permissive/artifacts/review/1.0.0/x.txt": "",
+	}
+	for name, content := range files {
+		path := filepath.Join(repoDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	t.Run("violations fail the check", func(t *testing.T) {
+		cmd := NewPolicyCommand()
+		var stdout, stderr bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stderr)
+		cmd.SetArgs([]string{"check", "--lock-file", filepath.Join(repoDir, "skill.lock"), "--policy", filepath.Join(repoDir, "org-policy.toml")})
+
+		if err := cmd.Execute(); err == nil {
+			t.Fatal("Expected policy check to fail")
+		}
+		if !strings.Contains(stderr.String(), `guard@1.0.0: hook event "PreToolUse" is not allowed`) {
+			t.Errorf("Expected the hook violation to be reported, got:\n%s", stderr.String())
+		}
+		if strings.Contains(stderr.String(), "review@1.0.0") {
+			t.Errorf("Expected the skill to comply, got:\n%s", stderr.String())
+		}
+	})
+
+	t.Run("policy next to the lock file", func(t *testing.T) {
+		cmd := NewPolicyCommand()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stdout)
+		cmd.SetArgs([]string{"check", "--lock-file", filepath.Join(repoDir, "permissive", "skill.lock")})
+
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("Expected policy check to pass: %v\n%s", err, stdout.String())
+		}
+		if !strings.Contains(stdout.String(), "guard@1.0.0") || !strings.Contains(stdout.String(), "2 artifacts comply") {
+			t.Errorf("Expected unchecked metadata to be listed and the check to pass, got:\n%s", stdout.String())
+		}
+	})
+}
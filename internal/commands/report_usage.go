@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -13,13 +15,17 @@ import (
 	"github.com/sleuth-io/skills/internal/artifacts"
 	"github.com/sleuth-io/skills/internal/artifacts/detectors"
 	"github.com/sleuth-io/skills/internal/config"
+	"github.com/sleuth-io/skills/internal/gitutil"
 	"github.com/sleuth-io/skills/internal/logger"
 	"github.com/sleuth-io/skills/internal/repository"
+	"github.com/sleuth-io/skills/internal/scope"
 	"github.com/sleuth-io/skills/internal/stats"
 )
 
 // NewReportUsageCommand creates the report-usage command
 func NewReportUsageCommand() *cobra.Command {
+	var clientID string
+
 	cmd := &cobra.Command{
 		Use:   "report-usage",
 		Short: "Report artifact usage from tool calls (PostToolUse hook)",
@@ -27,23 +33,53 @@ func NewReportUsageCommand() *cobra.Command {
 and report it to the repository. Intended to be called from Claude Code hooks.`,
 		Hidden: true, // Hide from help output as it's for internal use
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runReportUsage(cmd, args)
+			return runReportUsage(cmd, clientID)
 		},
 	}
 
+	cmd.Flags().StringVar(&clientID, "client", "", "Client ID that triggered the hook")
+
 	return cmd
 }
 
 // PostToolUseEvent represents the JSON payload from Claude Code PostToolUse hook
 type PostToolUseEvent struct {
-	ToolName  string                 `json:"tool_name"`
-	ToolInput map[string]interface{} `json:"tool_input"`
+	SessionID    string                 `json:"session_id"`
+	Cwd          string                 `json:"cwd"`
+	ToolName     string                 `json:"tool_name"`
+	ToolInput    map[string]interface{} `json:"tool_input"`
+	ToolResponse interface{}            `json:"tool_response"`
+
+	// DurationMs and Duration (Cursor) are the tool call's duration in milliseconds, if given
+	DurationMs float64 `json:"duration_ms"`
+	Duration   float64 `json:"duration"`
+}
+
+// Success reports whether the tool call succeeded, or nil if the payload doesn't say
+func (e *PostToolUseEvent) Success() *bool {
+	if e.ToolResponse == nil {
+		return nil
+	}
+
+	success := true
+	if response, ok := e.ToolResponse.(map[string]interface{}); ok {
+		if isError, ok := response["is_error"].(bool); ok && isError {
+			success = false
+		}
+		if ok, isBool := response["success"].(bool); isBool && !ok {
+			success = false
+		}
+		if message, ok := response["error"].(string); ok && message != "" {
+			success = false
+		}
+	}
+	return &success
 }
 
 // runReportUsage executes the report-usage command
-func runReportUsage(cmd *cobra.Command, args []string) error {
+func runReportUsage(cmd *cobra.Command, clientID string) error {
 	// Read JSON from stdin
-	data, err := io.ReadAll(os.Stdin)
+	data, err := io.ReadAll(cmd.InOrStdin())
 	if err != nil {
 		return fmt.Errorf("failed to read stdin: %w", err)
 	}
@@ -93,28 +129,44 @@ func runReportUsage(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	// Check if artifact is in tracker
-	var artifactVersion string
-	found := false
-	for _, installed := range tracker.Artifacts {
-		if installed.Name == artifactName {
-			artifactVersion = installed.Version
-			found = true
-			break
-		}
-	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
 
-	if !found {
+	installed := findUsedArtifact(ctx, tracker, artifactName, event.Cwd)
+	if installed == nil {
 		// Artifact not installed by us, exit silently
 		return nil
 	}
 
+	// Config not initialized means default usage settings, and the queue is flushed later
+	cfg, cfgErr := config.Load()
+
 	// Create usage event
 	usageEvent := stats.UsageEvent{
+		SchemaVersion:   stats.EventSchemaVersion,
 		ArtifactName:    artifactName,
-		ArtifactVersion: artifactVersion,
+		ArtifactVersion: installed.Version,
 		ArtifactType:    artifactType,
 		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+		ClientID:        clientID,
+		SessionID:       stats.HashSessionID(event.SessionID, cfg.GetUsage()),
+		RepositoryURL:   installed.Repository,
+		Path:            installed.Path,
+		Success:         event.Success(),
+		DurationMs:      int64(max(event.DurationMs, event.Duration)),
+	}
+	switch {
+	case installed.IsGlobal():
+		usageEvent.ScopeType = stats.ScopeTypeGlobal
+	case installed.Path != "":
+		usageEvent.ScopeType = stats.ScopeTypePath
+	default:
+		usageEvent.ScopeType = stats.ScopeTypeRepository
+	}
+
+	usageEvent, record := stats.Anonymize(usageEvent, cfg.GetUsage())
+	if !record {
+		return nil
 	}
 
 	// Enqueue event
@@ -125,21 +177,14 @@ func runReportUsage(cmd *cobra.Command, args []string) error {
 
 	// Log successful usage tracking
 	log := logger.Get()
-	log.Info("artifact usage tracked", "name", artifactName, "version", artifactVersion, "type", artifactType)
-
-	// Try to flush queue
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	log.Info("artifact usage tracked", "name", artifactName, "version", installed.Version, "type", artifactType, "client", clientID)
 
-	// Load config to get repository
-	cfg, err := config.Load()
-	if err != nil {
-		// Config not initialized, queue will be flushed later
+	if cfgErr != nil {
 		return nil
 	}
 
 	// Create repository instance
-	repo, err := repository.NewFromConfig(cfg)
+	repo, err := repository.NewFromConfigs(cfg.GetRepositories(), cfg.Pins)
 	if err != nil {
 		// Unknown repo type, queue will be flushed later
 		return nil
@@ -153,3 +198,48 @@ func runReportUsage(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// findUsedArtifact returns the installation of an artifact that applies in a directory:
+// the most specific of a path scope containing it, its repository, or global.
+// Falls back to any installation of the artifact, and returns nil if it isn't tracked.
+func findUsedArtifact(ctx context.Context, tracker *artifacts.Tracker, name, cwd string) *artifacts.InstalledArtifact {
+	var gitContext *gitutil.GitContext
+	if cwd != "" {
+		gitContext, _ = gitutil.DetectContextForPath(ctx, cwd)
+	} else {
+		gitContext, _ = gitutil.DetectContext(ctx)
+	}
+
+	var best *artifacts.InstalledArtifact
+	bestRank := -1
+	for i := range tracker.Artifacts {
+		installed := &tracker.Artifacts[i]
+		if installed.Name != name {
+			continue
+		}
+
+		rank := 0
+		switch {
+		case installed.IsGlobal():
+			rank = 1
+		case gitContext == nil || !gitContext.IsRepo || !scope.MatchRepoURLs(installed.Repository, gitContext.RepoURL):
+			rank = 0
+		case installed.Path == "":
+			rank = 2
+		case pathContains(installed.Path, gitContext.RelativePath):
+			rank = 3
+		}
+
+		if rank > bestRank {
+			best, bestRank = installed, rank
+		}
+	}
+	return best
+}
+
+// pathContains reports whether a repository-relative path is scope or inside it
+func pathContains(scopePath, path string) bool {
+	scopePath = strings.Trim(filepath.ToSlash(scopePath), "/")
+	path = strings.Trim(filepath.ToSlash(path), "/")
+	return path == scopePath || strings.HasPrefix(path, scopePath+"/")
+}
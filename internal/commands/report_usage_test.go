@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/artifacts"
+	"github.com/sleuth-io/skills/internal/stats"
+)
+
+func TestReportUsage(t *testing.T) {
+	homeDir := t.TempDir()
+	t.Setenv("HOME", homeDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(homeDir, ".config"))
+	t.Setenv("SKILLS_CACHE_DIR", filepath.Join(homeDir, ".cache"))
+
+	tracker := &artifacts.Tracker{Version: "4"}
+	tracker.UpsertArtifact(artifacts.InstalledArtifact{Name: "review", Version: "1.2.0", Type: "skill", Clients: []string{"claude-code"}})
+	if err := artifacts.SaveTracker(context.Background(), tracker); err != nil {
+		t.Fatalf("Failed to save tracker: %v", err)
+	}
+
+	payload := `{
+  "session_id": "abc-123",
+  "cwd": "` + homeDir + `",
+  "tool_name": "Skill",
+  "tool_input": {"skill": "review"},
+  "tool_response": {"is_error": true, "content": "boom"}
+}`
+
+	cmd := NewReportUsageCommand()
+	cmd.SetIn(strings.NewReader(payload))
+	cmd.SetArgs([]string{"--client=claude-code"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("report-usage failed: %v", err)
+	}
+
+	events, _, err := stats.DequeueEvents(10)
+	if err != nil {
+		t.Fatalf("Failed to read queue: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 queued event, got %d", len(events))
+	}
+	got := events[0]
+	if got.SchemaVersion != stats.EventSchemaVersion || got.ArtifactVersion != "1.2.0" || got.ClientID != "claude-code" || got.ScopeType != stats.ScopeTypeGlobal {
+		t.Errorf("Unexpected event: %+v", got)
+	}
+	if got.SessionID == "" || got.SessionID == "abc-123" {
+		t.Errorf("Expected a hashed session ID, got %q", got.SessionID)
+	}
+	if got.Success == nil || *got.Success {
+		t.Errorf("Expected the failed tool call to be recorded, got %v", got.Success)
+	}
+}
+
+func TestFindUsedArtifact(t *testing.T) {
+	tracker := &artifacts.Tracker{}
+	for _, a := range []artifacts.InstalledArtifact{
+		{Name: "review", Version: "1.0.0"},
+		{Name: "review", Version: "2.0.0", Repository: "https://github.com/acme/other"},
+		{Name: "lint", Version: "3.0.0", Repository: "https://github.com/acme/other"},
+	} {
+		tracker.UpsertArtifact(a)
+	}
+
+	// Outside any repository, the global installation applies
+	cwd := t.TempDir()
+	if got := findUsedArtifact(context.Background(), tracker, "review", cwd); got == nil || got.Version != "1.0.0" {
+		t.Errorf("Expected the global installation, got %+v", got)
+	}
+	// Without a matching scope, any installation is used
+	if got := findUsedArtifact(context.Background(), tracker, "lint", cwd); got == nil || got.Version != "3.0.0" {
+		t.Errorf("Expected the only installation, got %+v", got)
+	}
+	if got := findUsedArtifact(context.Background(), tracker, "missing", cwd); got != nil {
+		t.Errorf("Expected nil for an untracked artifact, got %+v", got)
+	}
+}
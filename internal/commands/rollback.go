@@ -0,0 +1,287 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/artifacts"
+	"github.com/sleuth-io/skills/internal/cache"
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/gitutil"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/logger"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/scope"
+	"github.com/sleuth-io/skills/internal/transaction"
+	"github.com/sleuth-io/skills/internal/ui"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// NewRollbackCommand creates the rollback command
+func NewRollbackCommand() *cobra.Command {
+	var to int
+	var global bool
+	var list bool
+
+	cmd := &cobra.Command{
+		Use:   "rollback",
+		Short: "Reinstall a previously installed set of artifacts",
+		Long: fmt.Sprintf(`Reinstall the artifacts that were installed in the current scope by an earlier install.
+
+Every install that changes a scope records a generation: the name, version and clients
+of each artifact installed there. The newest %d generations per scope are kept, and their
+artifacts are reinstalled from the local artifact cache without contacting the repository.
+
+After a rollback, installs leave the scope at that generation until you run
+'skills install --unpin'.
+
+Examples:
+  # Roll back to the generation before the current one
+  skills rollback
+
+  # List generations, then roll back to a specific one
+  skills rollback --list
+  skills rollback --to 3
+
+  # Roll back globally installed artifacts from inside a repository
+  skills rollback --global`, artifacts.MaxGenerationsPerScope),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRollback(cmd, to, global, list)
+		},
+	}
+
+	cmd.Flags().IntVar(&to, "to", 0, "Generation to roll back to (defaults to the previous one)")
+	cmd.Flags().BoolVar(&global, "global", false, "Roll back globally installed artifacts instead of the current repository's")
+	cmd.Flags().BoolVar(&list, "list", false, "List the recorded generations without changing anything")
+
+	return cmd
+}
+
+// runRollback executes the rollback command
+func runRollback(cmd *cobra.Command, to int, global, list bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	log := logger.Get()
+	styledOut := ui.NewOutput(cmd.OutOrStdout(), cmd.ErrOrStderr())
+	out := newOutputHelper(cmd)
+
+	gitContext, err := gitutil.DetectContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to detect git context: %w", err)
+	}
+
+	currentScope := scopeFromGitContext(gitContext)
+	if global {
+		currentScope = &scope.Scope{Type: scope.TypeGlobal}
+	}
+	key := artifacts.NewArtifactKey("", currentScope.Type, currentScope.RepoURL, currentScope.RepoPath)
+
+	if list {
+		return printGenerations(cmd, loadTracker(out), key)
+	}
+
+	targetClients := clients.Global().DetectInstalled()
+	if len(targetClients) == 0 {
+		return fmt.Errorf("no AI coding clients detected")
+	}
+
+	tx, err := beginInstallTransaction(out)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }() // No-op once committed
+	txCtx := transaction.NewContext(ctx, tx)
+
+	tracker := loadTracker(out)
+
+	var target *artifacts.Generation
+	if to > 0 {
+		target = tracker.FindGeneration(key.Repository, key.Path, to)
+		if target == nil {
+			return fmt.Errorf("generation %d not found (run 'skills rollback --list' to see recorded generations)", to)
+		}
+	} else {
+		target = tracker.PreviousGeneration(key.Repository, key.Path)
+		if target == nil {
+			return fmt.Errorf("no earlier generation to roll back to")
+		}
+	}
+	generation := *target
+
+	bundles, err := loadGenerationBundles(tracker, &generation)
+	if err != nil {
+		return err
+	}
+
+	// Remove artifacts installed after the generation was recorded
+	// The scope is released first so cleanup doesn't treat it as held
+	tracker.Unpin(key.Repository, key.Path)
+	kept := make([]*lockfile.Artifact, len(generation.Artifacts))
+	for i, installed := range generation.Artifacts {
+		kept[i] = generationLockArtifact(installed)
+	}
+	cleanupRemovedArtifacts(txCtx, tracker, kept, gitContext, currentScope, targetClients, out)
+
+	installResult := installGenerationBundles(txCtx, bundles, &generation, gitContext, targetClients, out)
+	if len(installResult.Failed) > 0 {
+		rollbackErr := tx.Rollback()
+
+		styledOut.Error(fmt.Sprintf("Failed to reinstall %d skills", len(installResult.Failed)))
+		for i, name := range installResult.Failed {
+			styledOut.ErrorItem(fmt.Sprintf("%s: %v", name, installResult.Errors[i]))
+			log.Error("artifact reinstall failed", "name", name, "error", installResult.Errors[i])
+		}
+
+		if rollbackErr != nil {
+			return fmt.Errorf("rollback failed, and restoring the previous state failed (it will be retried on the next install): %w", rollbackErr)
+		}
+		styledOut.Muted("All changes were rolled back.")
+		return fmt.Errorf("rollback to generation %d failed", generation.Number)
+	}
+
+	// The tracker now matches the generation, which stays pinned until install --unpin
+	for _, installed := range generation.Artifacts {
+		tracker.UpsertArtifact(installed)
+	}
+	if err := tracker.PinGeneration(key.Repository, key.Path, generation.Number); err != nil {
+		return err
+	}
+	if err := artifacts.SaveTracker(txCtx, tracker); err != nil {
+		return fmt.Errorf("failed to save installation state: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit rollback: %w", err)
+	}
+
+	ensureSkillsSupport(ctx, targetClients, buildInstallScope(currentScope, gitContext), out)
+
+	styledOut.Success(fmt.Sprintf("Rolled back %s to generation %d", generation.ScopeDescription(), generation.Number))
+	for _, installed := range generation.Artifacts {
+		styledOut.SuccessItem(fmt.Sprintf("%s@%s", installed.Name, installed.Version))
+	}
+	styledOut.Muted("Installs will keep this generation until you run 'skills install --unpin'.")
+	log.Info("rollback completed", "scope", generation.ScopeDescription(), "generation", generation.Number, "reinstalled", len(bundles))
+
+	return nil
+}
+
+// printGenerations lists the recorded generations of a scope, marking the active one
+func printGenerations(cmd *cobra.Command, tracker *artifacts.Tracker, key artifacts.ArtifactKey) error {
+	generations := tracker.Generations(key.Repository, key.Path)
+	if len(generations) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No generations recorded for this scope")
+		return nil
+	}
+
+	active := tracker.ActiveGeneration(key.Repository, key.Path)
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "GENERATION\tINSTALLED\tARTIFACTS\t")
+	for _, g := range generations {
+		names := make([]string, len(g.Artifacts))
+		for i, a := range g.Artifacts {
+			names[i] = fmt.Sprintf("%s@%s", a.Name, a.Version)
+		}
+		marker := ""
+		if g.Number == active.Number {
+			marker = "(current)"
+			if g.Pinned {
+				marker = "(current, pinned)"
+			}
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", g.Number, g.CreatedAt.Local().Format("2006-01-02 15:04"), strings.Join(names, ", "), marker)
+	}
+	return w.Flush()
+}
+
+// loadGenerationBundles loads the cached zips for the generation's artifacts that
+// aren't already installed at the same version for the same clients
+func loadGenerationBundles(tracker *artifacts.Tracker, generation *artifacts.Generation) ([]*clients.ArtifactBundle, error) {
+	var bundles []*clients.ArtifactBundle
+	for _, installed := range generation.Artifacts {
+		if !tracker.NeedsInstall(installed.Key(), installed.Version, installed.Clients) {
+			continue
+		}
+
+		zipData, err := cache.LoadArtifactFromDisk(installed.Name, installed.Version)
+		if err != nil {
+			return nil, fmt.Errorf("%s@%s is no longer in the artifact cache: %w", installed.Name, installed.Version, err)
+		}
+		metadataBytes, err := utils.ReadZipFile(zipData, "metadata.toml")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read metadata.toml from cached %s@%s: %w", installed.Name, installed.Version, err)
+		}
+		meta, err := metadata.Parse(metadataBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse metadata for cached %s@%s: %w", installed.Name, installed.Version, err)
+		}
+
+		art := generationLockArtifact(installed)
+		art.Type = meta.Artifact.Type
+		bundles = append(bundles, &clients.ArtifactBundle{
+			Artifact: art,
+			Metadata: meta,
+			ZipData:  zipData,
+		})
+	}
+	return bundles, nil
+}
+
+// generationLockArtifact rebuilds the lock file entry a generation's artifact was installed from
+func generationLockArtifact(installed artifacts.InstalledArtifact) *lockfile.Artifact {
+	art := &lockfile.Artifact{
+		Name:    installed.Name,
+		Version: installed.Version,
+		Type:    artifact.FromString(installed.Type),
+	}
+	if installed.Repository != "" {
+		repo := lockfile.Repository{Repo: installed.Repository}
+		if installed.Path != "" {
+			repo.Paths = []string{installed.Path}
+		}
+		art.Repositories = []lockfile.Repository{repo}
+	}
+	return art
+}
+
+// installGenerationBundles reinstalls each bundle to the clients it was installed to in the generation
+func installGenerationBundles(ctx context.Context, bundles []*clients.ArtifactBundle, generation *artifacts.Generation, gitContext *gitutil.GitContext, targetClients []clients.Client, out *outputHelper) *artifacts.InstallResult {
+	allResults := make(map[string]clients.InstallResponse)
+
+	for _, bundle := range bundles {
+		var recorded []string
+		for _, installed := range generation.Artifacts {
+			if installed.Name == bundle.Artifact.Name {
+				recorded = installed.Clients
+			}
+		}
+
+		var bundleClients []clients.Client
+		for _, client := range targetClients {
+			for _, id := range recorded {
+				if client.ID() == id {
+					bundleClients = append(bundleClients, client)
+				}
+			}
+		}
+
+		installScope := buildInstallScopeForArtifact(bundle.Artifact, gitContext)
+		results := runMultiClientInstallation(ctx, []*clients.ArtifactBundle{bundle}, installScope, bundleClients)
+
+		for clientID, resp := range results {
+			existing := allResults[clientID]
+			existing.Results = append(existing.Results, resp.Results...)
+			allResults[clientID] = existing
+		}
+	}
+
+	return processInstallationResults(allResults, out)
+}
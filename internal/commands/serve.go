@@ -2,6 +2,8 @@ package commands
 
 import (
 	"context"
+	"fmt"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
@@ -14,26 +16,54 @@ import (
 
 // NewServeCommand creates the serve command
 func NewServeCommand() *cobra.Command {
+	var httpAddr string
+	var token string
+
 	cmd := &cobra.Command{
 		Use:   "serve",
-		Short: "Start the MCP server over stdio",
+		Short: "Start the MCP server over stdio or HTTP",
 		Long: `Start an MCP (Model Context Protocol) server that exposes skill operations.
 
 The server runs over stdio and provides tools for AI clients to read installed skills.
 This enables AI coding assistants like Cursor to access skills installed by the skills CLI.
 
 Tools provided:
-  - read_skill: Read a skill's content and base directory for resolving file references`,
+  - read_skill: Read a skill's content and base directory for resolving file references
+  - list_skills: List the skills installed for the current directory, with their scope
+  - search_skills: Find skills by name, description, keywords and triggers
+  - read_skill_file: Read a file a skill references with @path
+
+Each installed skill is also published as a skill://<name> resource. Clients are
+notified when the list changes, for example after 'skills install'.
+
+With --http, one server handles many sessions over streamable HTTP at /mcp. Clients
+send their working directory in the Skills-Working-Directory header (or the cwd query
+parameter) to see that repository's skills; without it they see global skills only.
+Set --token or SKILLS_SERVE_TOKEN to require it as a bearer token.
+
+Examples:
+  # Serve over stdio (as configured in client MCP settings)
+  skills serve
+
+  # Serve many sessions from one process
+  skills serve --http 127.0.0.1:7333
+
+  # Share a read-only skills endpoint with a team
+  SKILLS_SERVE_TOKEN=secret skills serve --http :7333`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runServe(cmd, args)
+			return runServe(cmd, httpAddr, token)
 		},
 	}
 
+	cmd.Flags().StringVar(&httpAddr, "http", "", "Serve streamable HTTP on this address (e.g. :7333) instead of stdio")
+	cmd.Flags().StringVar(&token, "token", "", "Bearer token HTTP clients must send (defaults to SKILLS_SERVE_TOKEN)")
+
 	return cmd
 }
 
 // runServe executes the serve command
-func runServe(cmd *cobra.Command, args []string) error {
+func runServe(cmd *cobra.Command, httpAddr, token string) error {
 	// Create context that cancels on interrupt
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -49,6 +79,35 @@ func runServe(cmd *cobra.Command, args []string) error {
 	// Create the MCP server with the global client registry
 	server := mcpserver.NewServer(clients.Global())
 
-	// Run the server (blocks until context is cancelled or error)
-	return server.Run(ctx)
+	if httpAddr == "" {
+		if token != "" {
+			return fmt.Errorf("--token requires --http")
+		}
+		// Run the server (blocks until context is cancelled or error)
+		return server.Run(ctx)
+	}
+
+	if token == "" {
+		token = os.Getenv("SKILLS_SERVE_TOKEN")
+	}
+	out := newOutputHelper(cmd)
+	out.printfErr("Serving MCP over HTTP at http://%s%s\n", httpAddr, mcpserver.HTTPPath)
+	if token == "" && !isLoopbackAddr(httpAddr) {
+		out.printErr("Warning: no --token set; anyone who can reach this address can read your skills")
+	}
+
+	return server.RunHTTP(ctx, httpAddr, token)
+}
+
+// isLoopbackAddr reports whether a listen address only accepts local connections
+func isLoopbackAddr(addr string) bool {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
 }
@@ -0,0 +1,303 @@
+package commands
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/artifacts"
+	"github.com/sleuth-io/skills/internal/config"
+	"github.com/sleuth-io/skills/internal/repository"
+	"github.com/sleuth-io/skills/internal/stats"
+)
+
+// unusedArtifact is an installed artifact with no recorded uses
+type unusedArtifact struct {
+	Name    string `json:"artifact_name"`
+	Version string `json:"artifact_version"`
+	Type    string `json:"artifact_type"`
+	Scope   string `json:"scope"`
+}
+
+// statsReport is the JSON form of the stats report
+type statsReport struct {
+	Since  string           `json:"since,omitempty"`
+	By     stats.Grouping   `json:"by"`
+	Usage  []stats.Total    `json:"usage"`
+	Unused []unusedArtifact `json:"unused"`
+}
+
+// NewStatsCommand creates the stats command
+func NewStatsCommand() *cobra.Command {
+	var by string
+	var since string
+	var format string
+	var unusedOnly bool
+	var fromRepo bool
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Report how installed artifacts are used",
+		Long: `Report artifact usage recorded on this machine, broken down by artifact, version,
+installation scope or day, and list installed artifacts that haven't been used so
+they can be pruned.
+
+Usage is recorded locally whatever the repository type, so this works for git,
+path, OCI and S3 repositories as well as Sleuth. With --repo, report the usage
+every contributor has published to the configured git repositories instead
+(see publishUsage in the config).`,
+		Example: `  skills stats
+  skills stats --by day --since 30d
+  skills stats --by version --format csv > usage.csv
+  skills stats --unused --since 2026-01-01 --format json
+  skills stats --repo --by version`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStats(cmd, by, since, format, unusedOnly, fromRepo)
+		},
+	}
+
+	cmd.Flags().StringVar(&by, "by", "artifact", "Break usage down by artifact, version, scope or day")
+	cmd.Flags().StringVar(&since, "since", "", "Only count usage since a date (YYYY-MM-DD) or a number of days or weeks ago (30d, 4w)")
+	cmd.Flags().StringVar(&format, "format", "text", "Output format: text, csv or json")
+	cmd.Flags().BoolVar(&unusedOnly, "unused", false, "Only list installed artifacts that haven't been used")
+	cmd.Flags().BoolVar(&fromRepo, "repo", false, "Report usage published to git repositories by every contributor")
+
+	return cmd
+}
+
+// runStats executes the stats command
+func runStats(cmd *cobra.Command, by, since, format string, unusedOnly, fromRepo bool) error {
+	if format != "text" && format != "csv" && format != "json" {
+		return fmt.Errorf("unknown format %q (expected text, csv or json)", format)
+	}
+	grouping, err := stats.ParseGrouping(by)
+	if err != nil {
+		return err
+	}
+	sinceDay, err := parseSince(since, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var records []stats.Record
+	if fromRepo {
+		records, err = loadRepositoryUsage()
+	} else {
+		records, err = stats.LoadRecords()
+	}
+	if err != nil {
+		return err
+	}
+
+	report := statsReport{
+		Since:  sinceDay,
+		By:     grouping,
+		Usage:  stats.Totals(records, grouping, sinceDay),
+		Unused: []unusedArtifact{},
+	}
+
+	// Without a tracker nothing is installed, so nothing is unused
+	if tracker, err := artifacts.LoadTracker(); err == nil {
+		report.Unused = findUnusedArtifacts(tracker, stats.Totals(records, stats.GroupByArtifact, sinceDay))
+	}
+	if unusedOnly {
+		report.Usage = []stats.Total{}
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+		newOutputHelper(cmd).printlnAlways(string(data))
+		return nil
+	case "csv":
+		return writeStatsCSV(cmd, &report, unusedOnly)
+	}
+	return printStatsReport(cmd, &report, unusedOnly)
+}
+
+// loadRepositoryUsage reads the usage published to the configured repositories
+func loadRepositoryUsage() ([]stats.Record, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w\nRun 'skills init' to configure", err)
+	}
+	multi, err := repository.NewFromConfigs(cfg.GetRepositories(), cfg.Pins)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	var records []stats.Record
+	found := false
+	for _, repo := range multi.Repositories() {
+		provider, ok := repo.Repository.(repository.UsageStatsProvider)
+		if !ok {
+			continue
+		}
+		found = true
+
+		data, err := provider.GetUsageStats(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read usage from repository %s: %w", repo.Name, err)
+		}
+		records = append(records, stats.RecordsFromJSONL(data)...)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no configured repository stores usage stats (only git repositories do)")
+	}
+	return records, nil
+}
+
+// parseSince turns a date or a relative period into the first day to count,
+// returning "" to count everything
+func parseSince(since string, now time.Time) (string, error) {
+	if since == "" {
+		return "", nil
+	}
+
+	unitDays := 0
+	switch since[len(since)-1] {
+	case 'd':
+		unitDays = 1
+	case 'w':
+		unitDays = 7
+	}
+	if n, err := strconv.Atoi(since[:len(since)-1]); err == nil && n >= 0 && unitDays > 0 {
+		return now.UTC().AddDate(0, 0, -n*unitDays).Format(time.DateOnly), nil
+	}
+
+	if t, err := time.Parse(time.DateOnly, since); err == nil {
+		return t.Format(time.DateOnly), nil
+	}
+	return "", fmt.Errorf("invalid --since %q (expected YYYY-MM-DD, or a number of days or weeks like 30d or 4w)", since)
+}
+
+// findUnusedArtifacts returns tracked artifacts without any uses in the totals
+func findUnusedArtifacts(tracker *artifacts.Tracker, totals []stats.Total) []unusedArtifact {
+	used := make(map[string]bool)
+	for _, total := range totals {
+		used[total.Name] = true
+	}
+
+	unused := []unusedArtifact{}
+	for _, a := range tracker.Artifacts {
+		if used[a.Name] {
+			continue
+		}
+		unused = append(unused, unusedArtifact{Name: a.Name, Version: a.Version, Type: a.Type, Scope: a.ScopeDescription()})
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].Name != unused[j].Name {
+			return unused[i].Name < unused[j].Name
+		}
+		return unused[i].Scope < unused[j].Scope
+	})
+	return unused
+}
+
+// statsColumns returns the column headers and values of a total for a grouping
+func statsColumns(by stats.Grouping, total stats.Total) ([]string, []string) {
+	headers := []string{"NAME", "TYPE"}
+	values := []string{total.Name, total.Type}
+	switch by {
+	case stats.GroupByVersion:
+		headers = append(headers, "VERSION")
+		values = append(values, total.Version)
+	case stats.GroupByScope:
+		headers = append(headers, "SCOPE")
+		values = append(values, total.Scope)
+	case stats.GroupByDay:
+		headers = append([]string{"DAY"}, headers...)
+		values = append([]string{total.Day}, values...)
+	}
+	headers = append(headers, "USES", "LAST USED")
+	values = append(values, strconv.Itoa(total.Uses), total.LastUsed)
+	return headers, values
+}
+
+// printStatsReport prints the stats report as tables
+func printStatsReport(cmd *cobra.Command, report *statsReport, unusedOnly bool) error {
+	out := newOutputHelper(cmd)
+
+	if !unusedOnly {
+		if len(report.Usage) == 0 {
+			out.println("No usage recorded yet")
+		} else {
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+			headers, _ := statsColumns(report.By, stats.Total{})
+			fmt.Fprintln(w, strings.Join(headers, "\t"))
+			for _, total := range report.Usage {
+				_, values := statsColumns(report.By, total)
+				fmt.Fprintln(w, strings.Join(values, "\t"))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(report.Unused) == 0 {
+		if unusedOnly {
+			out.println("Every installed artifact has been used")
+		}
+		return nil
+	}
+
+	if !unusedOnly {
+		out.println()
+	}
+	if report.Since != "" {
+		out.printf("Installed but not used since %s:\n", report.Since)
+	} else {
+		out.println("Installed but never used:")
+	}
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tVERSION\tTYPE\tSCOPE")
+	for _, a := range report.Unused {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Name, a.Version, a.Type, a.Scope)
+	}
+	return w.Flush()
+}
+
+// writeStatsCSV writes the usage totals, or the unused artifacts, as CSV
+func writeStatsCSV(cmd *cobra.Command, report *statsReport, unusedOnly bool) error {
+	w := csv.NewWriter(cmd.OutOrStdout())
+
+	if unusedOnly {
+		_ = w.Write([]string{"name", "version", "type", "scope"})
+		for _, a := range report.Unused {
+			_ = w.Write([]string{a.Name, a.Version, a.Type, a.Scope})
+		}
+	} else {
+		headers, _ := statsColumns(report.By, stats.Total{})
+		for i := range headers {
+			headers[i] = strings.ReplaceAll(strings.ToLower(headers[i]), " ", "_")
+		}
+		_ = w.Write(headers)
+		for _, total := range report.Usage {
+			_, values := statsColumns(report.By, total)
+			_ = w.Write(values)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return nil
+}
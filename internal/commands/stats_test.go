@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sleuth-io/skills/internal/artifacts"
+	"github.com/sleuth-io/skills/internal/stats"
+)
+
+func TestStats(t *testing.T) {
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	tracker := &artifacts.Tracker{Version: "4"}
+	tracker.UpsertArtifact(artifacts.InstalledArtifact{Name: "review", Version: "1.0.0", Type: "skill", Clients: []string{"claude-code"}})
+	tracker.UpsertArtifact(artifacts.InstalledArtifact{Name: "stale", Version: "0.1.0", Type: "command", Repository: "https://github.com/acme/app", Clients: []string{"claude-code"}})
+	if err := artifacts.SaveTracker(context.Background(), tracker); err != nil {
+		t.Fatalf("Failed to save tracker: %v", err)
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	for range 3 {
+		if err := stats.RecordEvent(stats.UsageEvent{ArtifactName: "review", ArtifactVersion: "1.0.0", ArtifactType: "skill", Timestamp: now, ScopeType: stats.ScopeTypeGlobal}); err != nil {
+			t.Fatalf("RecordEvent failed: %v", err)
+		}
+	}
+
+	runStatsCmd := func(args ...string) string {
+		cmd := NewStatsCommand()
+		var stdout bytes.Buffer
+		cmd.SetOut(&stdout)
+		cmd.SetErr(&stdout)
+		cmd.SetArgs(args)
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("stats %v failed: %v", args, err)
+		}
+		return stdout.String()
+	}
+
+	output := runStatsCmd("--since", "7d")
+	if !strings.Contains(output, "review") || !strings.Contains(output, "Installed but not used since") || !strings.Contains(output, "stale") {
+		t.Errorf("Expected usage and the unused artifact, got:\n%s", output)
+	}
+
+	if got, want := runStatsCmd("--by", "version", "--format", "csv"), "name,type,version,uses,last_used\nreview,skill,1.0.0,3,"; !strings.HasPrefix(got, want) {
+		t.Errorf("CSV output = %q, want prefix %q", got, want)
+	}
+
+	var report statsReport
+	if err := json.Unmarshal([]byte(runStatsCmd("--unused", "--format", "json")), &report); err != nil {
+		t.Fatalf("Expected JSON output: %v", err)
+	}
+	if len(report.Usage) != 0 || len(report.Unused) != 1 || report.Unused[0].Name != "stale" || report.Unused[0].Scope != "https://github.com/acme/app" {
+		t.Errorf("Unexpected unused report: %+v", report)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		since   string
+		want    string
+		wantErr bool
+	}{
+		{since: "", want: ""},
+		{since: "30d", want: "2026-02-13"},
+		{since: "2w", want: "2026-03-01"},
+		{since: "2026-01-01", want: "2026-01-01"},
+		{since: "d", wantErr: true},
+		{since: "yesterday", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.since, now)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseSince(%q) = %q, %v; want %q, error %v", tt.since, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
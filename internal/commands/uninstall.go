@@ -13,12 +13,12 @@ import (
 
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/artifacts"
-	"github.com/sleuth-io/skills/internal/cache"
 	"github.com/sleuth-io/skills/internal/clients"
 	"github.com/sleuth-io/skills/internal/config"
 	"github.com/sleuth-io/skills/internal/gitutil"
 	"github.com/sleuth-io/skills/internal/lockfile"
 	"github.com/sleuth-io/skills/internal/logger"
+	mcpserver "github.com/sleuth-io/skills/internal/mcp"
 	"github.com/sleuth-io/skills/internal/repository"
 )
 
@@ -175,7 +175,7 @@ func runUninstall(cmd *cobra.Command, args []string, opts UninstallOptions) erro
 	results := executeUninstall(ctx, plan, opts, out)
 
 	// Step 6: Update tracker
-	if err := updateTracker(results, plan, out); err != nil {
+	if err := updateTracker(ctx, results, plan, out); err != nil {
 		out.printfErr("Warning: failed to update tracker: %v\n", err)
 		logger.Get().Error("failed to update tracker", "error", err)
 	}
@@ -227,32 +227,19 @@ func loadLockFileForUninstall(ctx context.Context, out *outputHelper) (*lockfile
 		return nil, fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	repo, err := repository.NewFromConfig(cfg)
+	repo, err := repository.NewFromConfigs(cfg.GetRepositories(), cfg.Pins)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create repository: %w", err)
 	}
 
 	out.println("Fetching lock file...")
 
-	var cachedETag string
-	var repoURL string
-	if cfg.Type == config.RepositoryTypeSleuth {
-		repoURL = cfg.GetServerURL()
-		cachedETag, _ = cache.LoadETag(repoURL)
-	}
-
-	lockFileData, _, notModified, err := repo.GetLockFile(ctx, cachedETag)
+	// Each repository's ETag and lock file are cached by the multi-repository
+	lockFileData, _, _, err := repo.GetLockFile(ctx, "")
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch lock file: %w", err)
 	}
 
-	if notModified && repoURL != "" {
-		lockFileData, err = cache.LoadLockFile(repoURL)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load cached lock file: %w", err)
-		}
-	}
-
 	lockFile, err := lockfile.Parse(lockFileData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse lock file: %w", err)
@@ -425,7 +412,7 @@ func buildScopeForArtifact(artPlan ArtifactUninstallPlan, gitContext *gitutil.Gi
 }
 
 // updateTracker removes successfully uninstalled artifacts from tracker
-func updateTracker(results []UninstallResult, plan UninstallPlan, out *outputHelper) error {
+func updateTracker(ctx context.Context, results []UninstallResult, plan UninstallPlan, out *outputHelper) error {
 	out.println("\nUpdating installation state...")
 
 	fullyRemoved := findFullyRemovedArtifacts(results)
@@ -453,7 +440,7 @@ func updateTracker(results []UninstallResult, plan UninstallPlan, out *outputHel
 		return artifacts.DeleteTracker()
 	}
 
-	return artifacts.SaveTracker(tracker)
+	return artifacts.SaveTracker(ctx, tracker)
 }
 
 // findFullyRemovedArtifacts returns artifacts where all client removals succeeded
@@ -514,6 +501,13 @@ func regenerateClientSupport(ctx context.Context, plan UninstallPlan, results []
 			out.printfErr("Warning: failed to regenerate support for %s: %v\n", client.DisplayName(), err)
 		}
 	}
+
+	// Let running 'skills serve' instances refresh their skill resources
+	if len(affectedClients) > 0 {
+		if err := mcpserver.NotifyCatalogChanged(); err != nil {
+			logger.Get().Warn("failed to notify MCP servers of skill changes", "error", err)
+		}
+	}
 }
 
 // displayUninstallPlan shows what will be uninstalled
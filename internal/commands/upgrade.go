@@ -0,0 +1,107 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/constants"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/resolver"
+)
+
+// NewUpgradeCommand creates the upgrade command
+func NewUpgradeCommand() *cobra.Command {
+	var requirementsFile string
+	var lockFilePath string
+
+	cmd := &cobra.Command{
+		Use:   "upgrade [name...]",
+		Short: "Upgrade locked artifacts within the requirements specifiers",
+		Long: `Re-resolve the named artifacts to the latest versions allowed by the requirements file
+and rewrite the lock file. Other artifacts keep their locked versions unless a new
+dependency requires a change. With no names, every artifact is upgraded.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUpgrade(cmd, args, requirementsFile, lockFilePath)
+		},
+	}
+
+	cmd.Flags().StringVarP(&requirementsFile, "requirements", "r", constants.SkillRequirementsFile, "Requirements file to read")
+	cmd.Flags().StringVarP(&lockFilePath, "lock-file", "l", constants.SkillLockFile, "Lock file to upgrade")
+
+	return cmd
+}
+
+// runUpgrade executes the upgrade command
+func runUpgrade(cmd *cobra.Command, names []string, requirementsFile, lockFilePath string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	out := newOutputHelper(cmd)
+
+	reqs, current, err := loadRequirementsAndLock(requirementsFile, lockFilePath)
+	if err != nil {
+		return err
+	}
+
+	repo, err := createMultiRepository()
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+
+	out.println("Resolving artifacts and dependencies...")
+	upgraded, err := resolver.New(ctx, repo).Upgrade(reqs, current, names)
+	if err != nil {
+		return fmt.Errorf("failed to resolve requirements: %w", err)
+	}
+
+	changes := diffLockFiles(current, upgraded)
+	if len(changes) == 0 && reflect.DeepEqual(current.Artifacts, upgraded.Artifacts) {
+		out.println("Already up to date")
+		return nil
+	}
+
+	for _, change := range changes {
+		out.printf("  %s\n", change)
+	}
+
+	if err := lockfile.Write(upgraded, lockFilePath); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+
+	out.println()
+	out.printf("✓ Updated %s\n", lockFilePath)
+	return nil
+}
+
+// diffLockFiles describes the artifacts that were added, removed or changed version
+func diffLockFiles(before, after *lockfile.LockFile) []string {
+	beforeVersions := make(map[string]string)
+	for _, art := range before.Artifacts {
+		beforeVersions[art.Name] = art.Version
+	}
+
+	afterVersions := make(map[string]string)
+	var changes []string
+	for _, art := range after.Artifacts {
+		afterVersions[art.Name] = art.Version
+		old, ok := beforeVersions[art.Name]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("+ %s %s", art.Name, art.Version))
+		case old != art.Version:
+			changes = append(changes, fmt.Sprintf("~ %s %s → %s", art.Name, old, art.Version))
+		}
+	}
+
+	for _, art := range before.Artifacts {
+		if _, ok := afterVersions[art.Name]; !ok {
+			changes = append(changes, fmt.Sprintf("- %s %s", art.Name, art.Version))
+		}
+	}
+
+	return changes
+}
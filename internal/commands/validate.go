@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// clientCompatibility is whether one client can install an artifact
+type clientCompatibility struct {
+	clientID    string
+	clientName  string
+	supported   bool
+	checked     bool // whether the client validated the artifact, see clients.ArtifactValidator
+	validateErr error
+}
+
+// status describes the compatibility in a word
+func (c *clientCompatibility) status() string {
+	switch {
+	case !c.supported:
+		return "unsupported"
+	case c.validateErr != nil:
+		return "invalid"
+	case !c.checked:
+		return "not checked"
+	default:
+		return "ok"
+	}
+}
+
+// NewValidateCommand creates the validate command
+func NewValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [source]",
+		Short: "Check an artifact's metadata and which clients can install it",
+		Long: `Validate an artifact directory, zip file or URL: check its metadata.toml, then check
+the artifact with each client's installer and report which clients support it.
+Clients whose installers can't check artifacts ahead of time are reported as not
+checked.
+
+The source defaults to the current directory.`,
+		Example: `  skills validate
+  skills validate ./code-review
+  skills validate code-review-0.1.0.zip`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source := "."
+			if len(args) > 0 {
+				source = args[0]
+			}
+			return runValidate(cmd, source)
+		},
+	}
+
+	return cmd
+}
+
+// runValidate executes the validate command
+func runValidate(cmd *cobra.Command, source string) error {
+	out := newOutputHelper(cmd)
+
+	_, zipData, err := loadZipFile(out, source)
+	if err != nil {
+		return err
+	}
+
+	meta, err := validateArtifactMetadata(zipData)
+	if err != nil {
+		out.printf("✗ metadata.toml: %v\n", err)
+		return fmt.Errorf("artifact metadata is invalid")
+	}
+	out.printf("✓ metadata.toml: %s %s (%s)\n", meta.Artifact.Name, meta.Artifact.Version, meta.Artifact.Type)
+	out.println()
+
+	results := checkClientCompatibility(clients.Global().GetAll(), meta, zipData)
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "CLIENT\tSTATUS\tDETAILS")
+	supported, invalid := 0, 0
+	for _, result := range results {
+		details := ""
+		switch {
+		case !result.supported:
+			details = fmt.Sprintf("%s doesn't install %s artifacts", result.clientName, meta.Artifact.Type)
+		case result.validateErr != nil:
+			details = result.validateErr.Error()
+			invalid++
+		case !result.checked:
+			details = fmt.Sprintf("%s doesn't check artifacts before installing them", result.clientName)
+			supported++
+		default:
+			supported++
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.clientID, result.status(), details)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("artifact failed validation for %d client(s)", invalid)
+	}
+	if supported == 0 {
+		return fmt.Errorf("no client can install %s artifacts", meta.Artifact.Type)
+	}
+	return nil
+}
+
+// validateArtifactMetadata reads and validates the metadata.toml of an artifact zip
+func validateArtifactMetadata(zipData []byte) (*metadata.Metadata, error) {
+	data, err := utils.ReadZipFile(zipData, "metadata.toml")
+	if err != nil {
+		return nil, fmt.Errorf("not found (run 'skills new' to scaffold an artifact)")
+	}
+	meta, err := metadata.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// checkClientCompatibility checks the artifact against each client, ordered by client ID
+func checkClientCompatibility(targetClients []clients.Client, meta *metadata.Metadata, zipData []byte) []clientCompatibility {
+	sorted := slices.Clone(targetClients)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID() < sorted[j].ID() })
+
+	results := make([]clientCompatibility, 0, len(sorted))
+	for _, client := range sorted {
+		result := clientCompatibility{
+			clientID:   client.ID(),
+			clientName: client.DisplayName(),
+			supported:  client.SupportsArtifactType(meta.Artifact.Type),
+		}
+		if validator, ok := client.(clients.ArtifactValidator); ok && result.supported {
+			result.checked = true
+			result.validateErr = validator.ValidateArtifact(meta, zipData)
+		}
+		results = append(results, result)
+	}
+	return results
+}
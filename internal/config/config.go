@@ -1,38 +1,187 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
-// RepositoryType represents the type of repository (sleuth, git, or path)
+// RepositoryType represents the type of repository (sleuth, git, path, oci, or s3)
 type RepositoryType string
 
 const (
 	RepositoryTypeSleuth RepositoryType = "sleuth"
 	RepositoryTypeGit    RepositoryType = "git"
 	RepositoryTypePath   RepositoryType = "path"
+	RepositoryTypeOCI    RepositoryType = "oci"
+	RepositoryTypeS3     RepositoryType = "s3"
 )
 
 // Config represents the configuration for the skills CLI
 type Config struct {
-	// Type of repository: "sleuth", "git", or "path"
+	// Type of repository: "sleuth", "git", "path", "oci", or "s3"
 	Type RepositoryType `json:"type"`
 
 	// ServerURL is the Sleuth server URL (only for type=sleuth)
 	ServerURL string `json:"serverUrl,omitempty"`
 
-	// AuthToken is the OAuth token for Sleuth server (only for type=sleuth)
+	// AuthToken is the OAuth token for Sleuth server (type=sleuth), the registry
+	// credentials as "username:password" or a bearer token (type=oci), or the
+	// access keys as "ACCESS_KEY_ID:SECRET_ACCESS_KEY" (type=s3)
 	AuthToken string `json:"authToken,omitempty"`
 
 	// RepositoryURL is the repository URL
 	// - For git: git repository URL (https://github.com/org/repo.git)
 	// - For path: file:// URL pointing to local directory (file:///path/to/repo)
+	// - For oci: registry repository (oci://ghcr.io/org/skills)
+	// - For s3: bucket and prefix (s3://bucket/skills?region=eu-west-1&endpoint=http://localhost:9000)
 	RepositoryURL string `json:"repositoryUrl,omitempty"`
+
+	// PublishUsage commits usage stats to the repository (only for type=git)
+	PublishUsage bool `json:"publishUsage,omitempty"`
+
+	// Repositories lists additional named repositories
+	// The top-level repository above, if set, is included as "default" with priority 0
+	Repositories []RepositoryConfig `json:"repositories,omitempty"`
+
+	// Pins maps artifact names to the repository they must come from,
+	// overriding priority when lock files are merged
+	Pins map[string]string `json:"pins,omitempty"`
+
+	// Usage controls what is recorded about artifact usage
+	Usage *UsageConfig `json:"usage,omitempty"`
+}
+
+// Usage repository recording modes
+const (
+	UsageRepositoryFull   = "full"
+	UsageRepositoryHashed = "hashed"
+	UsageRepositoryNone   = "none"
+)
+
+// UsageConfig controls what usage events record, for anonymity
+type UsageConfig struct {
+	// Disabled stops recording and reporting usage altogether
+	Disabled bool `json:"disabled,omitempty"`
+
+	// Repository is how the repository URL and path are recorded:
+	// "full" (the default), "hashed", or "none"
+	Repository string `json:"repository,omitempty"`
+
+	// OmitSession leaves the hashed session ID out of events
+	OmitSession bool `json:"omitSession,omitempty"`
+
+	// OmitClient leaves the client ID out of events
+	OmitClient bool `json:"omitClient,omitempty"`
+
+	// OmitOutcome leaves the tool call's success and duration out of events
+	OmitOutcome bool `json:"omitOutcome,omitempty"`
+
+	// HashKey is the secret hashed repositories, paths, sessions and usage shards are
+	// keyed with. Share one across an organization to compare hashes between users;
+	// without it each install generates its own
+	HashKey string `json:"hashKey,omitempty"`
+}
+
+// GetUsage returns the usage settings, with defaults if none are configured
+func (c *Config) GetUsage() *UsageConfig {
+	if c == nil || c.Usage == nil {
+		return &UsageConfig{}
+	}
+	return c.Usage
+}
+
+// Validate validates the usage settings
+func (u *UsageConfig) Validate() error {
+	switch u.Repository {
+	case "", UsageRepositoryFull, UsageRepositoryHashed, UsageRepositoryNone:
+		return nil
+	}
+	return fmt.Errorf("invalid usage.repository: %s (must be 'full', 'hashed', or 'none')", u.Repository)
+}
+
+// usageKeyFile holds the generated hash key of this install, in the config directory
+const usageKeyFile = "usage.key"
+
+// GetHashKey returns the key usage values are hashed with: the configured hash key,
+// or else this install's own, generated on first use
+func (u *UsageConfig) GetHashKey() ([]byte, error) {
+	if u.HashKey != "" {
+		return []byte(u.HashKey), nil
+	}
+
+	configDir, err := utils.GetConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config directory: %w", err)
+	}
+	keyFile := filepath.Join(configDir, usageKeyFile)
+	if key, err := os.ReadFile(keyFile); err == nil && len(key) > 0 {
+		return key, nil
+	}
+
+	random := make([]byte, 32)
+	if _, err := rand.Read(random); err != nil {
+		return nil, fmt.Errorf("failed to generate hash key: %w", err)
+	}
+	key := []byte(hex.EncodeToString(random))
+	if err := utils.EnsureDir(configDir); err != nil {
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	// Another process may generate one at the same time, and the first one written wins
+	f, err := os.OpenFile(keyFile, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if errors.Is(err, os.ErrExist) {
+		if key, err := os.ReadFile(keyFile); err == nil && len(key) > 0 {
+			return key, nil
+		}
+		return nil, fmt.Errorf("failed to read hash key")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write hash key: %w", err)
+	}
+	_, err = f.Write(key)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to write hash key: %w", err)
+	}
+	return key, nil
+}
+
+// DefaultRepositoryName is the name given to the top-level repository
+const DefaultRepositoryName = "default"
+
+// RepositoryConfig represents one named repository
+type RepositoryConfig struct {
+	// Name identifies the repository in pins and on the command line
+	Name string `json:"name"`
+
+	// Type of repository: "sleuth", "git", "path", "oci", or "s3"
+	Type RepositoryType `json:"type"`
+
+	// ServerURL is the Sleuth server URL (only for type=sleuth)
+	ServerURL string `json:"serverUrl,omitempty"`
+
+	// AuthToken is the Sleuth OAuth token, or OCI or S3 credentials (see Config.AuthToken)
+	AuthToken string `json:"authToken,omitempty"`
+
+	// RepositoryURL is the repository URL (see Config.RepositoryURL)
+	RepositoryURL string `json:"repositoryUrl,omitempty"`
+
+	// PublishUsage commits usage stats to the repository (only for type=git)
+	PublishUsage bool `json:"publishUsage,omitempty"`
+
+	// Priority decides which repository wins when several provide the same artifact
+	// Higher priorities win; ties are broken by name
+	Priority int `json:"priority,omitempty"`
 }
 
 // getLegacyConfigFile returns the old config file path for backwards compatibility
@@ -124,31 +273,117 @@ func Exists() bool {
 
 // Validate validates the configuration
 func (c *Config) Validate() error {
-	if c.Type != RepositoryTypeSleuth && c.Type != RepositoryTypeGit && c.Type != RepositoryTypePath {
-		return fmt.Errorf("invalid repository type: %s (must be 'sleuth', 'git', or 'path')", c.Type)
+	if c.Type == "" && len(c.Repositories) == 0 {
+		return fmt.Errorf("invalid repository type: %s (must be 'sleuth', 'git', 'path', 'oci', or 's3')", c.Type)
 	}
 
-	switch c.Type {
+	names := make(map[string]bool)
+	for _, repo := range c.GetRepositories() {
+		if repo.Name == "" {
+			return fmt.Errorf("repository name is required")
+		}
+		if names[repo.Name] {
+			return fmt.Errorf("duplicate repository name: %s", repo.Name)
+		}
+		names[repo.Name] = true
+
+		if err := repo.Validate(); err != nil {
+			if repo.Name == DefaultRepositoryName && len(c.Repositories) == 0 {
+				// Single-repository configs keep their original error messages
+				return err
+			}
+			return fmt.Errorf("repository %s: %w", repo.Name, err)
+		}
+	}
+
+	for artifactName, repoName := range c.Pins {
+		if !names[repoName] {
+			return fmt.Errorf("artifact %s is pinned to unknown repository %s", artifactName, repoName)
+		}
+	}
+
+	if err := c.GetUsage().Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Validate validates a single repository configuration
+func (r *RepositoryConfig) Validate() error {
+	if r.Type != RepositoryTypeSleuth && r.Type != RepositoryTypeGit && r.Type != RepositoryTypePath && r.Type != RepositoryTypeOCI && r.Type != RepositoryTypeS3 {
+		return fmt.Errorf("invalid repository type: %s (must be 'sleuth', 'git', 'path', 'oci', or 's3')", r.Type)
+	}
+
+	if r.PublishUsage && r.Type != RepositoryTypeGit {
+		return fmt.Errorf("publishUsage is only supported for git repositories")
+	}
+
+	switch r.Type {
 	case RepositoryTypeSleuth:
-		if c.RepositoryURL == "" && c.ServerURL == "" {
+		if r.RepositoryURL == "" && r.ServerURL == "" {
 			return fmt.Errorf("repositoryUrl is required for sleuth repository type")
 		}
-		if c.AuthToken == "" {
+		if r.AuthToken == "" {
 			return fmt.Errorf("authToken is required for sleuth repository type")
 		}
 	case RepositoryTypeGit:
-		if c.RepositoryURL == "" {
+		if r.RepositoryURL == "" {
 			return fmt.Errorf("repositoryUrl is required for git repository type")
 		}
 	case RepositoryTypePath:
-		if c.RepositoryURL == "" {
+		if r.RepositoryURL == "" {
 			return fmt.Errorf("repositoryUrl is required for path repository type")
 		}
+	case RepositoryTypeOCI:
+		if r.RepositoryURL == "" {
+			return fmt.Errorf("repositoryUrl is required for oci repository type")
+		}
+	case RepositoryTypeS3:
+		if r.RepositoryURL == "" {
+			return fmt.Errorf("repositoryUrl is required for s3 repository type")
+		}
 	}
 
 	return nil
 }
 
+// GetRepositories returns every configured repository, highest priority first
+// Repositories with equal priority are ordered by name so merges are deterministic
+func (c *Config) GetRepositories() []RepositoryConfig {
+	var repos []RepositoryConfig
+	if c.Type != "" {
+		repos = append(repos, RepositoryConfig{
+			Name:          DefaultRepositoryName,
+			Type:          c.Type,
+			ServerURL:     c.ServerURL,
+			AuthToken:     c.AuthToken,
+			RepositoryURL: c.RepositoryURL,
+			PublishUsage:  c.PublishUsage,
+		})
+	}
+	repos = append(repos, c.Repositories...)
+
+	sort.SliceStable(repos, func(i, j int) bool {
+		if repos[i].Priority != repos[j].Priority {
+			return repos[i].Priority > repos[j].Priority
+		}
+		return repos[i].Name < repos[j].Name
+	})
+
+	return repos
+}
+
+// GetRepository returns the named repository
+func (c *Config) GetRepository(name string) (*RepositoryConfig, error) {
+	for _, repo := range c.GetRepositories() {
+		if repo.Name == name {
+			return &repo, nil
+		}
+	}
+	return nil, fmt.Errorf("repository not found: %s", name)
+}
+
 // GetType returns the repository type
 func (c *Config) GetType() string {
 	return string(c.Type)
@@ -176,6 +411,53 @@ func (c *Config) GetRepositoryURL() string {
 	return c.RepositoryURL
 }
 
+// GetPublishUsage returns whether usage stats are committed to the repository
+func (c *Config) GetPublishUsage() bool {
+	return c.PublishUsage
+}
+
+// GetName returns the repository name
+func (r RepositoryConfig) GetName() string {
+	return r.Name
+}
+
+// GetPriority returns the repository priority
+func (r RepositoryConfig) GetPriority() int {
+	return r.Priority
+}
+
+// GetType returns the repository type
+func (r RepositoryConfig) GetType() string {
+	return string(r.Type)
+}
+
+// GetServerURL returns the Sleuth server URL, with environment override for the default repository
+// For backwards compatibility, falls back to ServerURL if RepositoryURL is empty
+func (r RepositoryConfig) GetServerURL() string {
+	if envURL := os.Getenv("SLEUTH_SERVER_URL"); envURL != "" && r.Name == DefaultRepositoryName {
+		return envURL
+	}
+	if r.RepositoryURL != "" {
+		return r.RepositoryURL
+	}
+	return r.ServerURL
+}
+
+// GetAuthToken returns the auth token
+func (r RepositoryConfig) GetAuthToken() string {
+	return r.AuthToken
+}
+
+// GetRepositoryURL returns the repository URL
+func (r RepositoryConfig) GetRepositoryURL() string {
+	return r.RepositoryURL
+}
+
+// GetPublishUsage returns whether usage stats are committed to the repository
+func (r RepositoryConfig) GetPublishUsage() bool {
+	return r.PublishUsage
+}
+
 // IsSilent checks if silent mode is enabled via environment variable
 func IsSilent() bool {
 	return os.Getenv("SKILLS_SYNC_SILENT") == "true"
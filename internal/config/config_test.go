@@ -132,3 +132,156 @@ func TestLoadNoConfig(t *testing.T) {
 		t.Errorf("Expected 'configuration not found' error, got: %v", err)
 	}
 }
+
+func TestGetRepositoriesOrdering(t *testing.T) {
+	cfg := &Config{
+		Type:          RepositoryTypeSleuth,
+		ServerURL:     "https://skills.example.com",
+		AuthToken:     "token",
+		RepositoryURL: "",
+		Repositories: []RepositoryConfig{
+			{Name: "personal", Type: RepositoryTypePath, RepositoryURL: "file:///tmp/personal", Priority: 10},
+			{Name: "archive", Type: RepositoryTypePath, RepositoryURL: "file:///tmp/archive"},
+		},
+	}
+
+	var names []string
+	for _, repo := range cfg.GetRepositories() {
+		names = append(names, repo.Name)
+	}
+
+	// Highest priority first, ties broken by name
+	expected := []string{"personal", "archive", DefaultRepositoryName}
+	if len(names) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, names)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, names)
+			break
+		}
+	}
+}
+
+func TestValidateRepositories(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name: "single legacy repository",
+			cfg:  Config{Type: RepositoryTypeGit, RepositoryURL: "git@github.com:test/repo"},
+		},
+		{
+			name: "named repositories only",
+			cfg: Config{Repositories: []RepositoryConfig{
+				{Name: "personal", Type: RepositoryTypePath, RepositoryURL: "file:///tmp/personal"},
+			}},
+		},
+		{
+			name:    "no repositories",
+			cfg:     Config{},
+			wantErr: true,
+		},
+		{
+			name: "duplicate names",
+			cfg: Config{Repositories: []RepositoryConfig{
+				{Name: "personal", Type: RepositoryTypePath, RepositoryURL: "file:///tmp/a"},
+				{Name: "personal", Type: RepositoryTypePath, RepositoryURL: "file:///tmp/b"},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "duplicate default",
+			cfg: Config{
+				Type:          RepositoryTypeGit,
+				RepositoryURL: "git@github.com:test/repo",
+				Repositories: []RepositoryConfig{
+					{Name: DefaultRepositoryName, Type: RepositoryTypePath, RepositoryURL: "file:///tmp/a"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid named repository",
+			cfg: Config{Repositories: []RepositoryConfig{
+				{Name: "personal", Type: RepositoryTypePath},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "pin to unknown repository",
+			cfg: Config{
+				Type:          RepositoryTypeGit,
+				RepositoryURL: "git@github.com:test/repo",
+				Pins:          map[string]string{"code-review": "personal"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "pin to configured repository",
+			cfg: Config{
+				Type:          RepositoryTypeGit,
+				RepositoryURL: "git@github.com:test/repo",
+				Repositories: []RepositoryConfig{
+					{Name: "personal", Type: RepositoryTypePath, RepositoryURL: "file:///tmp/personal"},
+				},
+				Pins: map[string]string{"code-review": "personal"},
+			},
+		},
+		{
+			name: "publish usage to git repository",
+			cfg:  Config{Type: RepositoryTypeGit, RepositoryURL: "git@github.com:test/repo", PublishUsage: true},
+		},
+		{
+			name: "publish usage to path repository",
+			cfg: Config{Repositories: []RepositoryConfig{
+				{Name: "personal", Type: RepositoryTypePath, RepositoryURL: "file:///tmp/personal", PublishUsage: true},
+			}},
+			wantErr: true,
+		},
+		{
+			name: "invalid usage repository mode",
+			cfg: Config{
+				Type:          RepositoryTypeGit,
+				RepositoryURL: "git@github.com:test/repo",
+				Usage:         &UsageConfig{Repository: "partial"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetHashKey(t *testing.T) {
+	configDir := t.TempDir()
+	t.Setenv("SKILLS_CONFIG_DIR", configDir)
+
+	// Each install generates its own key once and keeps it
+	key, err := (&UsageConfig{}).GetHashKey()
+	if err != nil {
+		t.Fatalf("GetHashKey() failed: %v", err)
+	}
+	again, err := (&UsageConfig{}).GetHashKey()
+	if err != nil || string(again) != string(key) || len(key) == 0 {
+		t.Errorf("Expected the generated key to be reused, got %q and %q (%v)", key, again, err)
+	}
+	if saved, err := os.ReadFile(filepath.Join(configDir, usageKeyFile)); err != nil || string(saved) != string(key) {
+		t.Errorf("Expected the key to be saved in the config directory, got %q (%v)", saved, err)
+	}
+
+	// A configured key, e.g. shared by an organization, takes precedence
+	configured, err := (&UsageConfig{HashKey: "acme"}).GetHashKey()
+	if err != nil || string(configured) != "acme" {
+		t.Errorf("GetHashKey() = %q, %v; want the configured key", configured, err)
+	}
+}
@@ -6,4 +6,7 @@ const (
 
 	// SkillRequirementsFile is the default name for the requirements file
 	SkillRequirementsFile = "skill.txt"
+
+	// SkillPolicyFile is the name of the policy file a repository can ship next to its lock file
+	SkillPolicyFile = "skill-policy.toml"
 )
@@ -2,8 +2,10 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -244,6 +246,135 @@ func (c *Client) Commit(ctx context.Context, repoPath, message string) error {
 	return nil
 }
 
+// CommitAs creates a commit with the given message, authored and committed by a fixed
+// identity instead of the user's configured one
+func (c *Client) CommitAs(ctx context.Context, repoPath, message, name, email string) error {
+	cmd := execGitCommand(ctx, c.sshKeyPath, "commit", "-m", message)
+	cmd.Dir = repoPath
+	setIdentity(cmd, name, email)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git commit failed: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// InitWithRemote creates an empty repository on the given branch with origin set to repoURL
+func (c *Client) InitWithRemote(ctx context.Context, repoPath, repoURL, branch string) error {
+	if err := os.MkdirAll(repoPath, 0755); err != nil {
+		return fmt.Errorf("failed to create repository directory: %w", err)
+	}
+
+	cmd := execGitCommand(ctx, c.sshKeyPath, "init", "--quiet", "--initial-branch="+branch)
+	cmd.Dir = repoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git init failed: %w\nOutput: %s", err, string(output))
+	}
+
+	cmd, _, err := execGitCommandWithURL(ctx, c.sshKeyPath, repoURL, "remote", "add", "origin")
+	if err != nil {
+		return err
+	}
+	cmd.Dir = repoPath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git remote add failed: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// FetchBranch fetches a branch from origin into origin/<branch>
+// Returns false if origin doesn't have the branch
+func (c *Client) FetchBranch(ctx context.Context, repoPath, branch string) (bool, error) {
+	refspec := fmt.Sprintf("+refs/heads/%s:refs/remotes/origin/%s", branch, branch)
+	cmd := execGitCommand(ctx, c.sshKeyPath, "fetch", "--quiet", "origin", refspec)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if strings.Contains(string(output), "couldn't find remote ref") {
+			return false, nil
+		}
+		return false, fmt.Errorf("git fetch failed: %w\nOutput: %s", err, string(output))
+	}
+
+	return true, nil
+}
+
+// ResetHard resets the current branch and working tree to ref
+func (c *Client) ResetHard(ctx context.Context, repoPath, ref string) error {
+	cmd := execGitCommand(ctx, c.sshKeyPath, "reset", "--quiet", "--hard", ref)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git reset failed: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// PushBranch pushes the current branch to the named branch on origin
+func (c *Client) PushBranch(ctx context.Context, repoPath, branch string) error {
+	cmd := execGitCommand(ctx, c.sshKeyPath, "push", "--quiet", "origin", "HEAD:refs/heads/"+branch)
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git push failed: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// PullRebaseAs rebases local commits onto the named branch on origin, committing the
+// rebased commits as a fixed identity
+// On failure the rebase may be left in progress; see RebaseAbort
+func (c *Client) PullRebaseAs(ctx context.Context, repoPath, branch, name, email string) error {
+	cmd := execGitCommand(ctx, c.sshKeyPath, "pull", "--quiet", "--rebase", "origin", branch)
+	cmd.Dir = repoPath
+	setIdentity(cmd, name, email)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git pull --rebase failed: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// RebaseAbort abandons a rebase in progress
+func (c *Client) RebaseAbort(ctx context.Context, repoPath string) error {
+	cmd := execGitCommand(ctx, c.sshKeyPath, "rebase", "--abort")
+	cmd.Dir = repoPath
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git rebase --abort failed: %w\nOutput: %s", err, string(output))
+	}
+
+	return nil
+}
+
+// GetConfig returns a git config value as seen from the repository, or "" if it isn't set
+func (c *Client) GetConfig(ctx context.Context, repoPath, key string) (string, error) {
+	cmd := execGitCommand(ctx, c.sshKeyPath, "config", "--get", key)
+	cmd.Dir = repoPath
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", fmt.Errorf("git config failed: %w", err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
 // isHexString checks if a string contains only hexadecimal characters
 func isHexString(s string) bool {
 	for _, c := range s {
@@ -26,6 +26,20 @@ func execGitCommand(ctx context.Context, sshKeyPath string, args ...string) *exe
 	return cmd
 }
 
+// setIdentity makes a git command author and commit as name and email
+// The environment overrides both the user's git config and their own GIT_AUTHOR_* variables
+func setIdentity(cmd *exec.Cmd, name, email string) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env,
+		"GIT_AUTHOR_NAME="+name,
+		"GIT_AUTHOR_EMAIL="+email,
+		"GIT_COMMITTER_NAME="+name,
+		"GIT_COMMITTER_EMAIL="+email,
+	)
+}
+
 // execGitCommandWithURL prepares a git command with URL conversion if needed
 // If sshKeyPath is provided and URL is HTTPS, converts URL to SSH
 // Returns the command, the final URL used, and any error
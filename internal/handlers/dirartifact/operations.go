@@ -8,6 +8,7 @@ import (
 
 	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
 	"github.com/sleuth-io/skills/internal/utils"
 )
 
@@ -30,23 +31,15 @@ func NewOperations(subdir string, expectedType *artifact.Type) *Operations {
 }
 
 // Install extracts an artifact zip to {targetBase}/{subdir}/{name}/
+// The zip is extracted into a staging directory and swapped in with a rename,
+// so a failed extraction leaves any existing installation untouched
 func (o *Operations) Install(ctx context.Context, zipData []byte, targetBase string, artifactName string) error {
 	artifactDir := filepath.Join(targetBase, o.subdir, artifactName)
 
-	// Remove existing installation if present
-	if utils.IsDirectory(artifactDir) {
-		if err := os.RemoveAll(artifactDir); err != nil {
-			return fmt.Errorf("failed to remove existing installation: %w", err)
-		}
-	}
-
-	// Create installation directory
-	if err := utils.EnsureDir(artifactDir); err != nil {
-		return fmt.Errorf("failed to create installation directory: %w", err)
-	}
-
-	// Extract entire zip to artifact directory
-	if err := utils.ExtractZip(zipData, artifactDir); err != nil {
+	err := transaction.ReplaceDir(ctx, artifactDir, func(dir string) error {
+		return metadata.ExtractZip(zipData, dir)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to extract artifact: %w", err)
 	}
 
@@ -62,7 +55,7 @@ func (o *Operations) Remove(ctx context.Context, targetBase string, artifactName
 		return nil
 	}
 
-	if err := os.RemoveAll(artifactDir); err != nil {
+	if err := transaction.RemoveAll(ctx, artifactDir); err != nil {
 		return fmt.Errorf("failed to remove artifact: %w", err)
 	}
 
@@ -99,13 +92,20 @@ func (o *Operations) ScanInstalled(targetBase string) ([]InstalledArtifactInfo,
 			continue
 		}
 
-		artifacts = append(artifacts, InstalledArtifactInfo{
+		info := InstalledArtifactInfo{
 			Name:        meta.Artifact.Name,
 			Description: meta.Artifact.Description,
 			Version:     meta.Artifact.Version,
 			Type:        meta.Artifact.Type,
 			InstallPath: filepath.Join(o.subdir, dir.Name()),
-		})
+			Keywords:    meta.Artifact.Keywords,
+		}
+		if meta.Skill != nil {
+			info.Triggers = meta.Skill.Triggers
+		} else if meta.Agent != nil {
+			info.Triggers = meta.Agent.Triggers
+		}
+		artifacts = append(artifacts, info)
 	}
 
 	return artifacts, nil
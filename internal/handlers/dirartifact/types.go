@@ -11,4 +11,6 @@ type InstalledArtifactInfo struct {
 	Version     string
 	Type        artifact.Type
 	InstallPath string
+	Keywords    []string // Keywords from the [artifact] section
+	Triggers    []string // Triggers from the [skill] or [agent] section
 }
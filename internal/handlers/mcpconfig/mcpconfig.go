@@ -0,0 +1,193 @@
+package mcpconfig
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/secrets"
+	"github.com/sleuth-io/skills/internal/transaction"
+)
+
+// ServersKey is the key most clients keep their MCP servers under
+const ServersKey = "mcpServers"
+
+// Entry builds the command, args and env entry that launches an MCP server
+// For packaged servers, serverDir is where the server was extracted and relative
+// command and argument paths are resolved against it; remote servers pass ""
+func Entry(meta *metadata.Metadata, serverDir string) (map[string]interface{}, error) {
+	mcpConfig := meta.MCP
+	if mcpConfig == nil {
+		return nil, fmt.Errorf("no [mcp] section in metadata")
+	}
+
+	command := mcpConfig.Command
+	args := make([]string, len(mcpConfig.Args))
+	copy(args, mcpConfig.Args)
+
+	if serverDir != "" {
+		if !filepath.IsAbs(command) {
+			command = filepath.Join(serverDir, command)
+		}
+		for i, arg := range args {
+			// If arg looks like a relative path (contains / or \), make it absolute
+			if !filepath.IsAbs(arg) && filepath.Base(arg) != arg {
+				args[i] = filepath.Join(serverDir, arg)
+			}
+		}
+	}
+
+	// Declared inputs are referenced rather than written into the client's config
+	launch, err := secrets.ApplyInputs(meta.Artifact.Name, secrets.Launch{Command: command, Args: args, Env: mcpConfig.Env}, mcpConfig.Inputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply MCP inputs: %w", err)
+	}
+	if launch.Args == nil {
+		launch.Args = []string{}
+	}
+
+	entry := map[string]interface{}{
+		"command": launch.Command,
+		"args":    launch.Args,
+	}
+	if len(launch.Env) > 0 {
+		entry["env"] = launch.Env
+	}
+
+	return entry, nil
+}
+
+// SkillsServerEntry builds the entry that launches 'skills serve'
+func SkillsServerEntry() (map[string]interface{}, error) {
+	skillsBinary, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"command": skillsBinary,
+		"args":    []string{"serve"},
+	}, nil
+}
+
+// File is a client settings file that keeps MCP servers in a table under Key
+// Other settings in the file are preserved when servers are added or removed
+type File struct {
+	Path string
+	Key  string
+	toml bool // TOML rather than JSON
+}
+
+// NewJSONFile creates a File for a JSON file at path with servers under key
+func NewJSONFile(path, key string) *File {
+	return &File{Path: path, Key: key}
+}
+
+// NewTOMLFile creates a File for a TOML file at path with servers under key
+// Comments and formatting aren't preserved when the file is rewritten
+func NewTOMLFile(path, key string) *File {
+	return &File{Path: path, Key: key, toml: true}
+}
+
+// Name returns the file's base name, for messages
+func (f *File) Name() string {
+	return filepath.Base(f.Path)
+}
+
+// Read reads the file and returns it along with its servers table, creating empty
+// ones if the file or key doesn't exist
+func (f *File) Read() (map[string]interface{}, map[string]interface{}, error) {
+	doc := make(map[string]interface{})
+
+	data, err := os.ReadFile(f.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	if len(data) > 0 {
+		if f.toml {
+			err = toml.Unmarshal(data, &doc)
+		} else {
+			err = json.Unmarshal(data, &doc)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", f.Path, err)
+		}
+	}
+
+	servers, ok := doc[f.Key].(map[string]interface{})
+	if !ok {
+		servers = make(map[string]interface{})
+		doc[f.Key] = servers
+	}
+	return doc, servers, nil
+}
+
+// Write writes doc back to the file
+func (f *File) Write(ctx context.Context, doc map[string]interface{}) error {
+	var data []byte
+	if f.toml {
+		var buf bytes.Buffer
+		if err := toml.NewEncoder(&buf).Encode(doc); err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	} else {
+		indented, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return err
+		}
+		data = append(indented, '\n')
+	}
+	return transaction.WriteFile(ctx, f.Path, data, 0644)
+}
+
+// Set adds or replaces the server called name
+func (f *File) Set(ctx context.Context, name string, entry map[string]interface{}) error {
+	doc, servers, err := f.Read()
+	if err != nil {
+		return err
+	}
+	servers[name] = entry
+	return f.Write(ctx, doc)
+}
+
+// SetIfMissing adds the server called name unless one is already configured
+func (f *File) SetIfMissing(ctx context.Context, name string, entry map[string]interface{}) error {
+	doc, servers, err := f.Read()
+	if err != nil {
+		return err
+	}
+	if _, exists := servers[name]; exists {
+		return nil
+	}
+	servers[name] = entry
+	return f.Write(ctx, doc)
+}
+
+// Delete removes the server called name, leaving the file untouched if it isn't configured
+func (f *File) Delete(ctx context.Context, name string) error {
+	doc, servers, err := f.Read()
+	if err != nil {
+		return err
+	}
+	if _, exists := servers[name]; !exists {
+		return nil
+	}
+	delete(servers, name)
+	return f.Write(ctx, doc)
+}
+
+// Has reports whether the server called name is configured
+func (f *File) Has(name string) (bool, error) {
+	_, servers, err := f.Read()
+	if err != nil {
+		return false, err
+	}
+	_, exists := servers[name]
+	return exists, nil
+}
@@ -0,0 +1,148 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sleuth-io/skills/internal/clients"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+// InstallArtifacts installs artifacts into paths using the handlers newHandler creates
+func InstallArtifacts(ctx context.Context, req clients.InstallRequest, paths Paths, newHandler NewHandlerFunc) clients.InstallResponse {
+	resp := clients.InstallResponse{
+		Results: make([]clients.ArtifactResult, 0, len(req.Artifacts)),
+	}
+
+	for _, bundle := range req.Artifacts {
+		result := clients.ArtifactResult{
+			ArtifactName: bundle.Artifact.Name,
+		}
+
+		handler, err := newHandler(bundle.Metadata)
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = fmt.Sprintf("Unsupported artifact type: %s", bundle.Metadata.Artifact.Type.Key)
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		if err := handler.Install(ctx, bundle.ZipData, paths); err != nil {
+			result.Status = clients.StatusFailed
+			result.Error = err
+			result.Message = fmt.Sprintf("Installation failed: %v", err)
+		} else {
+			result.Status = clients.StatusSuccess
+			result.Message = fmt.Sprintf("Installed to %s", paths.Base)
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp
+}
+
+// UninstallArtifacts removes artifacts from paths using the handlers newHandler creates
+func UninstallArtifacts(ctx context.Context, req clients.UninstallRequest, paths Paths, newHandler NewHandlerFunc) clients.UninstallResponse {
+	resp := clients.UninstallResponse{
+		Results: make([]clients.ArtifactResult, 0, len(req.Artifacts)),
+	}
+
+	for _, art := range req.Artifacts {
+		result := clients.ArtifactResult{
+			ArtifactName: art.Name,
+		}
+
+		// Create minimal metadata for removal
+		handler, err := newHandler(&metadata.Metadata{
+			Artifact: metadata.Artifact{
+				Name: art.Name,
+				Type: art.Type,
+			},
+		})
+		if err != nil {
+			result.Status = clients.StatusSkipped
+			result.Message = fmt.Sprintf("Unsupported artifact type: %s", art.Type.Key)
+			resp.Results = append(resp.Results, result)
+			continue
+		}
+
+		if err := handler.Remove(ctx, paths); err != nil {
+			result.Status = clients.StatusFailed
+			result.Error = err
+		} else {
+			result.Status = clients.StatusSuccess
+			result.Message = "Uninstalled successfully"
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	return resp
+}
+
+// VerifyArtifacts checks if artifacts are actually installed in paths
+func VerifyArtifacts(artifacts []*lockfile.Artifact, paths Paths, newHandler NewHandlerFunc) []clients.VerifyResult {
+	results := make([]clients.VerifyResult, 0, len(artifacts))
+
+	for _, art := range artifacts {
+		result := clients.VerifyResult{
+			Artifact: art,
+		}
+
+		handler, err := newHandler(&metadata.Metadata{
+			Artifact: metadata.Artifact{
+				Name:    art.Name,
+				Version: art.Version,
+				Type:    art.Type,
+			},
+		})
+		if err != nil {
+			result.Message = err.Error()
+		} else {
+			result.Installed, result.Message = handler.VerifyInstalled(paths)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// ListSkills returns the skills SkillHandler installed under base
+func ListSkills(base string) ([]clients.InstalledSkill, error) {
+	installed, err := skillOps.ScanInstalled(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan installed skills: %w", err)
+	}
+
+	skills := make([]clients.InstalledSkill, 0, len(installed))
+	for _, info := range installed {
+		skills = append(skills, clients.InstalledSkill{
+			Name:        info.Name,
+			Description: info.Description,
+			Version:     info.Version,
+			Keywords:    info.Keywords,
+			Triggers:    info.Triggers,
+		})
+	}
+
+	return skills, nil
+}
+
+// ReadSkill reads the content of a skill SkillHandler installed under base
+func ReadSkill(base, name string) (*clients.SkillContent, error) {
+	result, err := skillOps.ReadPromptContent(base, name, "SKILL.md", func(m *metadata.Metadata) string { return m.Skill.PromptFile })
+	if err != nil {
+		return nil, err
+	}
+
+	return &clients.SkillContent{
+		Name:        name,
+		Description: result.Description,
+		Version:     result.Version,
+		Content:     result.Content,
+		BaseDir:     result.BaseDir,
+	}, nil
+}
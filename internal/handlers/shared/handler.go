@@ -0,0 +1,34 @@
+package shared
+
+import (
+	"context"
+
+	"github.com/sleuth-io/skills/internal/handlers/mcpconfig"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+// Paths are where a client installs the artifacts of one scope
+type Paths struct {
+	Base      string          // Skills and MCP server files go under this directory
+	Commands  string          // Directory commands are written to
+	MCPConfig *mcpconfig.File // Settings file MCP servers are registered in
+}
+
+// Handler installs one artifact into a client
+// The skill and MCP handlers here are shared by clients that keep skills in a
+// directory and MCP servers in a settings file; clients add their own command handler
+type Handler interface {
+	// Install installs the artifact from zip data
+	Install(ctx context.Context, zipData []byte, paths Paths) error
+
+	// Remove removes the artifact
+	Remove(ctx context.Context, paths Paths) error
+
+	// VerifyInstalled checks if the artifact is properly installed
+	// Returns (installed bool, message string)
+	VerifyInstalled(paths Paths) (bool, string)
+}
+
+// NewHandlerFunc creates a client's handler for an artifact, or returns an error if
+// the client doesn't support its type
+type NewHandlerFunc func(meta *metadata.Metadata) (Handler, error)
@@ -0,0 +1,82 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/handlers/dirartifact"
+	"github.com/sleuth-io/skills/internal/handlers/mcpconfig"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/transaction"
+)
+
+var mcpOps = dirartifact.NewOperations("mcp-servers", &artifact.TypeMCP)
+
+// MCPHandler registers MCP servers in the client's settings file
+// Packaged servers are extracted to mcp-servers/{name}/; remote servers are configuration only
+type MCPHandler struct {
+	metadata *metadata.Metadata
+	remote   bool
+}
+
+// NewMCPHandler creates a handler for MCP servers packaged with the artifact
+func NewMCPHandler(meta *metadata.Metadata) *MCPHandler {
+	return &MCPHandler{metadata: meta}
+}
+
+// NewMCPRemoteHandler creates a handler for MCP servers launched from outside the artifact
+func NewMCPRemoteHandler(meta *metadata.Metadata) *MCPHandler {
+	return &MCPHandler{metadata: meta, remote: true}
+}
+
+// Install registers the server in the settings file, extracting its files first if packaged
+func (h *MCPHandler) Install(ctx context.Context, zipData []byte, paths Paths) error {
+	serverDir := ""
+	if !h.remote {
+		if err := mcpOps.Install(ctx, zipData, paths.Base, h.metadata.Artifact.Name); err != nil {
+			return fmt.Errorf("failed to extract MCP server: %w", err)
+		}
+		serverDir = mcpOps.GetArtifactDir(paths.Base, h.metadata.Artifact.Name)
+	}
+
+	entry, err := mcpconfig.Entry(h.metadata, serverDir)
+	if err != nil {
+		return err
+	}
+
+	if err := paths.MCPConfig.Set(ctx, h.metadata.Artifact.Name, entry); err != nil {
+		return fmt.Errorf("failed to update %s: %w", paths.MCPConfig.Name(), err)
+	}
+	return nil
+}
+
+// Remove unregisters the server and removes any extracted files
+func (h *MCPHandler) Remove(ctx context.Context, paths Paths) error {
+	if err := paths.MCPConfig.Delete(ctx, h.metadata.Artifact.Name); err != nil {
+		return fmt.Errorf("failed to update %s: %w", paths.MCPConfig.Name(), err)
+	}
+
+	serverDir := mcpOps.GetArtifactDir(paths.Base, h.metadata.Artifact.Name)
+	_ = transaction.RemoveAll(ctx, serverDir) // Remote servers have no directory
+
+	return nil
+}
+
+// VerifyInstalled checks if the server is registered and, if packaged, extracted
+func (h *MCPHandler) VerifyInstalled(paths Paths) (bool, string) {
+	if !h.remote {
+		if installed, message := mcpOps.VerifyInstalled(paths.Base, h.metadata.Artifact.Name, h.metadata.Artifact.Version); !installed {
+			return false, message
+		}
+	}
+
+	registered, err := paths.MCPConfig.Has(h.metadata.Artifact.Name)
+	if err != nil {
+		return false, "failed to read " + paths.MCPConfig.Name() + ": " + err.Error()
+	}
+	if !registered {
+		return false, "MCP server not registered"
+	}
+	return true, "installed"
+}
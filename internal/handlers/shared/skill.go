@@ -0,0 +1,44 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/handlers/dirartifact"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+var skillOps = dirartifact.NewOperations("skills", &artifact.TypeSkill)
+
+// SkillHandler extracts skills to skills/{name}/, where the client's skills index
+// and the skills MCP server find them
+type SkillHandler struct {
+	metadata *metadata.Metadata
+}
+
+// NewSkillHandler creates a new skill handler
+func NewSkillHandler(meta *metadata.Metadata) *SkillHandler {
+	return &SkillHandler{metadata: meta}
+}
+
+// Install extracts a skill to skills/{name}/
+func (h *SkillHandler) Install(ctx context.Context, zipData []byte, paths Paths) error {
+	if err := skillOps.Install(ctx, zipData, paths.Base, h.metadata.Artifact.Name); err != nil {
+		return fmt.Errorf("failed to install skill: %w", err)
+	}
+	return nil
+}
+
+// Remove removes a skill from skills/
+func (h *SkillHandler) Remove(ctx context.Context, paths Paths) error {
+	if err := skillOps.Remove(ctx, paths.Base, h.metadata.Artifact.Name); err != nil {
+		return fmt.Errorf("failed to remove skill: %w", err)
+	}
+	return nil
+}
+
+// VerifyInstalled checks if the skill is properly installed
+func (h *SkillHandler) VerifyInstalled(paths Paths) (bool, string) {
+	return skillOps.VerifyInstalled(paths.Base, h.metadata.Artifact.Name, h.metadata.Artifact.Version)
+}
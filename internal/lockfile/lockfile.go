@@ -27,6 +27,10 @@ type Artifact struct {
 	SourceHTTP *SourceHTTP `toml:"source-http,omitempty"`
 	SourcePath *SourcePath `toml:"source-path,omitempty"`
 	SourceGit  *SourceGit  `toml:"source-git,omitempty"`
+	SourceOCI  *SourceOCI  `toml:"source-oci,omitempty"`
+
+	// Signature over the artifact's name, version and content hash (optional)
+	Signature *Signature `toml:"signature,omitempty"`
 
 	// Installation configurations - array of repository installations
 	// If empty, artifact is installed globally
@@ -90,14 +94,29 @@ type SourceHTTP struct {
 
 // SourcePath represents a local path source for an artifact
 type SourcePath struct {
-	Path string `toml:"path"`
+	Path   string            `toml:"path"`
+	Hashes map[string]string `toml:"hashes,omitempty"` // Content hashes computed at lock time
 }
 
 // SourceGit represents a Git repository source for an artifact
 type SourceGit struct {
-	URL          string `toml:"url"`
-	Ref          string `toml:"ref"`
-	Subdirectory string `toml:"subdirectory,omitempty"`
+	URL          string            `toml:"url"`
+	Ref          string            `toml:"ref"`
+	Subdirectory string            `toml:"subdirectory,omitempty"`
+	Hashes       map[string]string `toml:"hashes,omitempty"` // Content hashes computed at lock time
+}
+
+// SourceOCI represents an artifact stored in an OCI registry
+type SourceOCI struct {
+	Reference string `toml:"reference"` // registry/repository:tag
+	Digest    string `toml:"digest"`    // Manifest digest, e.g. sha256:...
+}
+
+// Signature records who signed an artifact
+type Signature struct {
+	Algorithm string `toml:"algorithm"` // "ed25519" or "minisign"
+	KeyID     string `toml:"key-id"`
+	Value     string `toml:"value"` // Base64 signature, or the full .minisig file for minisign
 }
 
 // Dependency represents a dependency reference
@@ -117,6 +136,9 @@ func (a *Artifact) GetSourceType() string {
 	if a.SourceGit != nil {
 		return "git"
 	}
+	if a.SourceOCI != nil {
+		return "oci"
+	}
 	return "unknown"
 }
 
@@ -144,6 +166,10 @@ func (a *Artifact) GetSourceConfig() map[string]interface{} {
 		if a.SourceGit.Subdirectory != "" {
 			config["subdirectory"] = a.SourceGit.Subdirectory
 		}
+	} else if a.SourceOCI != nil {
+		config["type"] = "oci"
+		config["reference"] = a.SourceOCI.Reference
+		config["digest"] = a.SourceOCI.Digest
 	}
 
 	return config
@@ -75,6 +75,45 @@ func TestValidateLockFile(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid oci source",
+			lockFile: &LockFile{
+				LockVersion: "1.0",
+				Version:     "abc",
+				CreatedBy:   "test",
+				Artifacts: []Artifact{
+					{
+						Name:    "test",
+						Version: "1.0.0",
+						Type:    artifact.TypeSkill,
+						SourceOCI: &SourceOCI{
+							Reference: "ghcr.io/acme/skills/test:1.0.0",
+							Digest:    "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "oci source without digest",
+			lockFile: &LockFile{
+				LockVersion: "1.0",
+				Version:     "abc",
+				CreatedBy:   "test",
+				Artifacts: []Artifact{
+					{
+						Name:    "test",
+						Version: "1.0.0",
+						Type:    artifact.TypeSkill,
+						SourceOCI: &SourceOCI{
+							Reference: "ghcr.io/acme/skills/test:1.0.0",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "missing lock-version",
 			lockFile: &LockFile{
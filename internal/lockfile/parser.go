@@ -75,6 +75,16 @@ func FindArtifact(lockFilePath, name string) (*Artifact, bool) {
 	return nil, false
 }
 
+// New returns an empty lock file stamped with the current version
+func New() *LockFile {
+	return &LockFile{
+		LockVersion: "1.0",
+		Version:     "1",
+		CreatedBy:   buildinfo.GetCreatedBy(),
+		Artifacts:   []Artifact{},
+	}
+}
+
 // AddOrUpdateArtifact adds or updates an artifact in the lock file
 // Replaces any existing artifact with the same name@version
 func AddOrUpdateArtifact(lockFilePath string, artifact *Artifact) error {
@@ -86,30 +96,21 @@ func AddOrUpdateArtifact(lockFilePath string, artifact *Artifact) error {
 			return fmt.Errorf("failed to parse lock file: %w", err)
 		}
 	} else {
-		lockFile = &LockFile{
-			LockVersion: "1.0",
-			Version:     "1",
-			CreatedBy:   buildinfo.GetCreatedBy(),
-			Artifacts:   []Artifact{},
-		}
+		lockFile = New()
 	}
 
-	// Remove existing artifact with same name@version
-	var filteredArtifacts []Artifact
-	for _, existing := range lockFile.Artifacts {
-		if existing.Name != artifact.Name || existing.Version != artifact.Version {
-			filteredArtifacts = append(filteredArtifacts, existing)
-		}
-	}
-	lockFile.Artifacts = filteredArtifacts
-
-	// Add the artifact
-	lockFile.Artifacts = append(lockFile.Artifacts, *artifact)
+	lockFile.AddOrUpdate(artifact)
 
 	// Write lock file
 	return Write(lockFile, lockFilePath)
 }
 
+// AddOrUpdate replaces any artifact with the same name@version and appends the given one
+func (l *LockFile) AddOrUpdate(artifact *Artifact) {
+	l.Remove(artifact.Name, artifact.Version)
+	l.Artifacts = append(l.Artifacts, *artifact)
+}
+
 // RemoveArtifact removes an artifact and all its installations from a lock file
 func RemoveArtifact(lockFilePath string, name, version string) error {
 	lockFile, err := ParseFile(lockFilePath)
@@ -117,15 +118,18 @@ func RemoveArtifact(lockFilePath string, name, version string) error {
 		return fmt.Errorf("failed to parse lock file: %w", err)
 	}
 
-	// Filter out the artifact
+	lockFile.Remove(name, version)
+
+	return Write(lockFile, lockFilePath)
+}
+
+// Remove drops the artifact with the given name@version
+func (l *LockFile) Remove(name, version string) {
 	var newArtifacts []Artifact
-	for _, artifact := range lockFile.Artifacts {
+	for _, artifact := range l.Artifacts {
 		if artifact.Name != name || artifact.Version != version {
 			newArtifacts = append(newArtifacts, artifact)
 		}
 	}
-
-	lockFile.Artifacts = newArtifacts
-
-	return Write(lockFile, lockFilePath)
+	l.Artifacts = newArtifacts
 }
@@ -11,6 +11,9 @@ var (
 	// gitCommitSHARegex matches full 40-character Git commit SHAs
 	gitCommitSHARegex = regexp.MustCompile(`^[0-9a-f]{40}$`)
 
+	// ociDigestRegex matches OCI content digests
+	ociDigestRegex = regexp.MustCompile(`^(sha256:[0-9a-f]{64}|sha512:[0-9a-f]{128})$`)
+
 	// nameRegex matches valid artifact names (alphanumeric, dashes, underscores)
 	nameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 )
@@ -97,9 +100,12 @@ func (a *Artifact) Validate() error {
 	if a.SourceGit != nil {
 		sourceCount++
 	}
+	if a.SourceOCI != nil {
+		sourceCount++
+	}
 
 	if sourceCount == 0 {
-		return fmt.Errorf("exactly one source must be specified (http, path, or git)")
+		return fmt.Errorf("exactly one source must be specified (http, path, git, or oci)")
 	}
 	if sourceCount > 1 {
 		return fmt.Errorf("only one source type can be specified")
@@ -121,6 +127,17 @@ func (a *Artifact) Validate() error {
 			return fmt.Errorf("source-git: %w", err)
 		}
 	}
+	if a.SourceOCI != nil {
+		if err := a.SourceOCI.Validate(); err != nil {
+			return fmt.Errorf("source-oci: %w", err)
+		}
+	}
+
+	if a.Signature != nil {
+		if err := a.Signature.Validate(); err != nil {
+			return fmt.Errorf("signature: %w", err)
+		}
+	}
 
 	// Validate repositories
 	for i, repo := range a.Repositories {
@@ -152,8 +169,12 @@ func (s *SourceHTTP) Validate() error {
 		return fmt.Errorf("hashes are required for HTTP sources")
 	}
 
-	// Validate hash algorithms
-	for algo := range s.Hashes {
+	return validateHashAlgorithms(s.Hashes)
+}
+
+// validateHashAlgorithms checks that only supported hash algorithms are used
+func validateHashAlgorithms(hashes map[string]string) error {
+	for algo := range hashes {
 		if algo != "sha256" && algo != "sha512" {
 			return fmt.Errorf("unsupported hash algorithm: %s (must be sha256 or sha512)", algo)
 		}
@@ -167,7 +188,7 @@ func (s *SourcePath) Validate() error {
 	if s.Path == "" {
 		return fmt.Errorf("path is required")
 	}
-	return nil
+	return validateHashAlgorithms(s.Hashes)
 }
 
 // Validate validates a Git source
@@ -185,6 +206,34 @@ func (s *SourceGit) Validate() error {
 		return fmt.Errorf("ref must be a full 40-character commit SHA (got %q)", s.Ref)
 	}
 
+	return validateHashAlgorithms(s.Hashes)
+}
+
+// Validate validates an OCI source
+func (s *SourceOCI) Validate() error {
+	if s.Reference == "" {
+		return fmt.Errorf("reference is required")
+	}
+
+	// The digest pins the manifest, so tags can't be moved under the lock file
+	if !ociDigestRegex.MatchString(s.Digest) {
+		return fmt.Errorf("digest must be a sha256 or sha512 digest (got %q)", s.Digest)
+	}
+
+	return nil
+}
+
+// Validate validates a signature entry
+func (s *Signature) Validate() error {
+	if s.Algorithm != "ed25519" && s.Algorithm != "minisign" {
+		return fmt.Errorf("unsupported signature algorithm: %s (must be 'ed25519' or 'minisign')", s.Algorithm)
+	}
+	if s.KeyID == "" {
+		return fmt.Errorf("key-id is required")
+	}
+	if s.Value == "" {
+		return fmt.Errorf("value is required")
+	}
 	return nil
 }
 
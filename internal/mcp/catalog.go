@@ -0,0 +1,165 @@
+package mcpserver
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sleuth-io/skills/internal/cache"
+	"github.com/sleuth-io/skills/internal/clients"
+)
+
+// catalogStampFile is touched after installs change which skills are installed
+const catalogStampFile = "catalog-changed"
+
+// SkillSummary describes an installed skill for list_skills and search_skills
+type SkillSummary struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Version     string   `json:"version,omitempty"`
+	Scope       string   `json:"scope" jsonschema:"where the skill is installed: global, repository or path"`
+	Keywords    []string `json:"keywords,omitempty"`
+	Triggers    []string `json:"triggers,omitempty"`
+}
+
+// catalogEntry is an installed skill and where to read it from
+type catalogEntry struct {
+	summary SkillSummary
+	client  clients.Client
+	scope   *clients.InstallScope
+}
+
+// collectCatalog lists the skills installed for any client in the scopes visible
+// from scope, sorted by name; a skill in a more specific scope hides one in a broader scope
+func (s *Server) collectCatalog(ctx context.Context, scope *clients.InstallScope) []catalogEntry {
+	var entries []catalogEntry
+	seen := make(map[string]bool)
+
+	installedClients := s.registry.DetectInstalled()
+	for _, chainScope := range clients.ScopeChain(scope) {
+		for _, client := range installedClients {
+			skills, err := client.ListSkills(ctx, chainScope)
+			if err != nil {
+				continue
+			}
+			for _, skill := range skills {
+				if seen[skill.Name] {
+					continue
+				}
+				seen[skill.Name] = true
+				entries = append(entries, catalogEntry{
+					summary: SkillSummary{
+						Name:        skill.Name,
+						Description: skill.Description,
+						Version:     skill.Version,
+						Scope:       string(chainScope.Type),
+						Keywords:    skill.Keywords,
+						Triggers:    skill.Triggers,
+					},
+					client: client,
+					scope:  chainScope,
+				})
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].summary.Name < entries[j].summary.Name })
+	return entries
+}
+
+// searchCatalog returns the entries matching every word of query, best matches first
+// Name matches count most, then keywords and triggers, then the description
+func searchCatalog(entries []catalogEntry, query string) []catalogEntry {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil
+	}
+
+	type scored struct {
+		entry catalogEntry
+		score int
+	}
+	var matches []scored
+	for _, entry := range entries {
+		total := 0
+		for _, word := range words {
+			score := scoreWord(entry.summary, word)
+			if score == 0 {
+				total = 0
+				break
+			}
+			total += score
+		}
+		if total > 0 {
+			matches = append(matches, scored{entry, total})
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score > matches[j].score })
+	results := make([]catalogEntry, len(matches))
+	for i, m := range matches {
+		results[i] = m.entry
+	}
+	return results
+}
+
+// scoreWord scores how well a single lowercase query word matches a skill
+func scoreWord(skill SkillSummary, word string) int {
+	score := 0
+	if strings.Contains(strings.ToLower(skill.Name), word) {
+		score += 4
+	}
+	for _, keyword := range skill.Keywords {
+		if strings.Contains(strings.ToLower(keyword), word) {
+			score += 3
+			break
+		}
+	}
+	for _, trigger := range skill.Triggers {
+		if strings.Contains(strings.ToLower(trigger), word) {
+			score += 2
+			break
+		}
+	}
+	if strings.Contains(strings.ToLower(skill.Description), word) {
+		score++
+	}
+	return score
+}
+
+// NotifyCatalogChanged tells running MCP servers that installed skills have changed,
+// so they can update their resources and notify connected clients
+func NotifyCatalogChanged() error {
+	path, err := catalogStampPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(time.Now().UTC().Format(time.RFC3339Nano)), 0644)
+}
+
+// catalogStamp returns the time the catalog was last changed, or empty if never
+func catalogStamp() string {
+	path, err := catalogStampPath()
+	if err != nil {
+		return ""
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func catalogStampPath() (string, error) {
+	cacheDir, err := cache.GetCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, catalogStampFile), nil
+}
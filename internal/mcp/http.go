@@ -0,0 +1,189 @@
+package mcpserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/sleuth-io/skills/internal/logger"
+)
+
+// WorkingDirHeader tells an HTTP server which directory a client is working in,
+// which determines the repository and path scopes whose skills it sees
+const WorkingDirHeader = "Skills-Working-Directory"
+
+// workingDirParam is the query parameter equivalent of WorkingDirHeader, for
+// clients that can't set headers
+const workingDirParam = "cwd"
+
+// HTTPPath is the path the streamable HTTP endpoint is served at
+const HTTPPath = "/mcp"
+
+// maxWorkDirServers is how many working directories keep an MCP server at once
+// The least recently used one is dropped to make room for a new directory
+const maxWorkDirServers = 32
+
+// httpHandler serves MCP sessions over streamable HTTP
+// Sessions are grouped by the working directory their first request names, and each
+// group shares an MCP server whose resources follow that directory's skills
+type httpHandler struct {
+	server *Server
+	ctx    context.Context
+	limit  int // maximum number of servers kept
+
+	mu      sync.Mutex
+	servers map[string]*workDirServer
+	uses    uint64 // counts serverFor calls, to order servers by last use
+}
+
+// workDirServer is the MCP server shared by the sessions of one working directory
+type workDirServer struct {
+	mcpServer *mcp.Server
+	stop      context.CancelFunc // stops watching the catalog
+	lastUsed  uint64
+}
+
+// HTTPHandler returns an http.Handler serving MCP over streamable HTTP at HTTPPath
+// If token is set, requests must carry it as a bearer token
+func (s *Server) HTTPHandler(ctx context.Context, token string) http.Handler {
+	h := &httpHandler{
+		server:  s,
+		ctx:     ctx,
+		limit:   maxWorkDirServers,
+		servers: make(map[string]*workDirServer),
+	}
+
+	streamable := mcp.NewStreamableHTTPHandler(h.serverFor, nil)
+
+	mux := http.NewServeMux()
+	mux.Handle(HTTPPath, withWorkingDirParam(streamable))
+	if token == "" {
+		return mux
+	}
+	return requireBearerToken(token, mux)
+}
+
+// RunHTTP serves MCP over streamable HTTP on addr until ctx is cancelled
+func (s *Server) RunHTTP(ctx context.Context, addr, token string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	// HTTP clients can be anywhere, so without a working directory they only see global skills
+	shared := s.forWorkDir("")
+	httpServer := &http.Server{
+		Handler:           shared.HTTPHandler(ctx, token),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	logger.Get().Info("serving MCP over HTTP", "addr", listener.Addr().String(), "auth", token != "")
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("failed to serve HTTP: %w", err)
+	}
+	return nil
+}
+
+// serverFor returns the MCP server for a new session, creating one the first time a
+// working directory is seen
+func (h *httpHandler) serverFor(r *http.Request) *mcp.Server {
+	dir := r.Header.Get(WorkingDirHeader)
+	if dir != "" && checkWorkingDir(dir) != nil {
+		return nil // The SDK rejects the session
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.uses++
+	if entry, ok := h.servers[dir]; ok {
+		entry.lastUsed = h.uses
+		return entry.mcpServer
+	}
+
+	if len(h.servers) >= h.limit {
+		h.evictLeastRecentlyUsed()
+	}
+
+	// Each server tracks the resources it published, so a directory recreated after
+	// eviction must not share the evicted one's
+	server := h.server.forWorkDir(dir)
+	stamp := catalogStamp()
+	mcpServer := server.newMCPServer(h.ctx)
+	watchCtx, stop := context.WithCancel(h.ctx)
+	go server.watchCatalog(watchCtx, mcpServer, stamp, catalogPollInterval)
+
+	h.servers[dir] = &workDirServer{mcpServer: mcpServer, stop: stop, lastUsed: h.uses}
+	return mcpServer
+}
+
+// evictLeastRecentlyUsed drops the server handed out longest ago
+// Its open sessions keep working, but no longer see their skills change
+// Must be called with h.mu held
+func (h *httpHandler) evictLeastRecentlyUsed() {
+	var oldest string
+	var oldestEntry *workDirServer
+	for dir, entry := range h.servers {
+		if oldestEntry == nil || entry.lastUsed < oldestEntry.lastUsed {
+			oldest, oldestEntry = dir, entry
+		}
+	}
+	if oldestEntry == nil {
+		return
+	}
+	oldestEntry.stop()
+	delete(h.servers, oldest)
+}
+
+// checkWorkingDir rejects working directories that aren't absolute paths to directories
+func checkWorkingDir(dir string) error {
+	if !filepath.IsAbs(dir) {
+		return fmt.Errorf("working directory must be an absolute path: %s", dir)
+	}
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("working directory not found: %s", dir)
+	}
+	return nil
+}
+
+// withWorkingDirParam copies the working directory query parameter to its header
+func withWorkingDirParam(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if dir := r.URL.Query().Get(workingDirParam); dir != "" && r.Header.Get(WorkingDirHeader) == "" {
+			r = r.Clone(r.Context())
+			r.Header.Set(WorkingDirHeader, dir)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireBearerToken rejects requests that don't carry token as a bearer token
+func requireBearerToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
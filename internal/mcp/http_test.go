@@ -0,0 +1,238 @@
+package mcpserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/sleuth-io/skills/internal/clients"
+)
+
+// scopedMockClient lists a different skill in each scope
+type scopedMockClient struct {
+	*mockClient
+}
+
+func (m *scopedMockClient) ListSkills(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	name := "global-skill"
+	if scope.Type != clients.ScopeGlobal {
+		name = filepath.Base(scope.RepoRoot) + "-skill"
+	}
+	return []clients.InstalledSkill{{Name: name}}, nil
+}
+
+// headerTransport adds headers to every request
+type headerTransport struct {
+	header http.Header
+}
+
+func (h *headerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	for k, v := range h.header {
+		r.Header[k] = v
+	}
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func connectHTTP(t *testing.T, endpoint string, header http.Header) *mcp.ClientSession {
+	t.Helper()
+	transport := &mcp.StreamableClientTransport{
+		Endpoint:   endpoint,
+		HTTPClient: &http.Client{Transport: &headerTransport{header: header}},
+		MaxRetries: -1,
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), transport, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func listSkillNames(t *testing.T, session *mcp.ClientSession) string {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: "list_skills", Arguments: map[string]any{}})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Tool returned error: %v", result.Content)
+	}
+	return result.Content[0].(*mcp.TextContent).Text
+}
+
+func TestServer_HTTP(t *testing.T) {
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	repoDir := filepath.Join(t.TempDir(), "webapp")
+	if out, err := exec.Command("git", "init", repoDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+
+	registry := clients.NewRegistry()
+	registry.Register(&scopedMockClient{newMockClient()})
+	server := NewServer(registry).forWorkDir("")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	httpServer := httptest.NewServer(server.HTTPHandler(ctx, "s3cret"))
+	defer httpServer.Close()
+	endpoint := httpServer.URL + HTTPPath
+
+	auth := func(extra ...string) http.Header {
+		header := http.Header{"Authorization": {"Bearer s3cret"}}
+		for i := 0; i+1 < len(extra); i += 2 {
+			header.Set(extra[i], extra[i+1])
+		}
+		return header
+	}
+
+	t.Run("rejects missing or wrong token", func(t *testing.T) {
+		for _, header := range []string{"", "Bearer wrong"} {
+			req, _ := http.NewRequest(http.MethodPost, endpoint, strings.NewReader("{}"))
+			if header != "" {
+				req.Header.Set("Authorization", header)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				t.Fatalf("Request failed: %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Errorf("Authorization %q: status = %d, want 401", header, resp.StatusCode)
+			}
+		}
+	})
+
+	t.Run("no working directory sees global skills", func(t *testing.T) {
+		session := connectHTTP(t, endpoint, auth())
+		if got := listSkillNames(t, session); !strings.Contains(got, "global-skill") || strings.Contains(got, "webapp-skill") {
+			t.Errorf("Expected only global skills, got %s", got)
+		}
+	})
+
+	t.Run("working directory header selects the repository", func(t *testing.T) {
+		session := connectHTTP(t, endpoint, auth(WorkingDirHeader, repoDir))
+		if got := listSkillNames(t, session); !strings.Contains(got, "webapp-skill") || !strings.Contains(got, "global-skill") {
+			t.Errorf("Expected repository and global skills, got %s", got)
+		}
+	})
+
+	t.Run("working directory query parameter", func(t *testing.T) {
+		session := connectHTTP(t, endpoint+"?cwd="+repoDir, auth())
+		if got := listSkillNames(t, session); !strings.Contains(got, "webapp-skill") {
+			t.Errorf("Expected repository skills, got %s", got)
+		}
+	})
+
+	t.Run("rejects relative working directory", func(t *testing.T) {
+		transport := &mcp.StreamableClientTransport{
+			Endpoint:   endpoint,
+			HTTPClient: &http.Client{Transport: &headerTransport{header: auth(WorkingDirHeader, "webapp")}},
+			MaxRetries: -1,
+		}
+		client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v1.0.0"}, nil)
+		if session, err := client.Connect(context.Background(), transport, nil); err == nil {
+			session.Close()
+			t.Error("Expected connecting with a relative working directory to fail")
+		}
+	})
+}
+
+func TestCheckWorkingDir(t *testing.T) {
+	if err := checkWorkingDir(t.TempDir()); err != nil {
+		t.Errorf("checkWorkingDir(temp dir) = %v", err)
+	}
+	for _, dir := range []string{"relative", filepath.Join(t.TempDir(), "missing")} {
+		if err := checkWorkingDir(dir); err == nil {
+			t.Errorf("checkWorkingDir(%q) = nil, want error", dir)
+		}
+	}
+}
+
+func TestHTTPHandlerEvictsLeastRecentlyUsed(t *testing.T) {
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	registry := clients.NewRegistry()
+	registry.Register(newMockClient())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := &httpHandler{
+		server:  NewServer(registry).forWorkDir(""),
+		ctx:     ctx,
+		limit:   2,
+		servers: make(map[string]*workDirServer),
+	}
+
+	a, b, c := t.TempDir(), t.TempDir(), t.TempDir()
+	serverFor := func(dir string) *mcp.Server {
+		req := httptest.NewRequest(http.MethodPost, HTTPPath, nil)
+		req.Header.Set(WorkingDirHeader, dir)
+		return h.serverFor(req)
+	}
+
+	first := serverFor(a)
+	serverFor(b)
+	if serverFor(a) != first {
+		t.Error("Expected the same server for a working directory")
+	}
+	serverFor(c)
+
+	if len(h.servers) != 2 {
+		t.Errorf("Expected 2 servers, got %d", len(h.servers))
+	}
+	if _, ok := h.servers[b]; ok {
+		t.Error("Expected the least recently used directory to be dropped")
+	}
+	if _, ok := h.servers[a]; !ok {
+		t.Error("Expected the recently used directory to be kept")
+	}
+}
+
+func TestHTTPHandlerRecreatesEvictedServerWithResources(t *testing.T) {
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	mock := newMockClient()
+	mock.addSkill("pdf-tools", "PDF forms", "1.0.0", "# PDF Tools", "/tmp/skills/pdf-tools")
+	registry := clients.NewRegistry()
+	registry.Register(mock)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := &httpHandler{
+		server:  NewServer(registry).forWorkDir(""),
+		ctx:     ctx,
+		limit:   1,
+		servers: make(map[string]*workDirServer),
+	}
+
+	serverFor := func(dir string) *mcp.Server {
+		req := httptest.NewRequest(http.MethodPost, HTTPPath, nil)
+		if dir != "" {
+			req.Header.Set(WorkingDirHeader, dir)
+		}
+		return h.serverFor(req)
+	}
+
+	// The handler's own directory is evicted and recreated like any other
+	first := serverFor("")
+	serverFor(t.TempDir())
+	recreated := serverFor("")
+	if recreated == first {
+		t.Fatal("Expected a new server after eviction")
+	}
+
+	session := connectMCPServer(t, recreated, nil)
+	list, err := session.ListResources(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	if len(list.Resources) != 1 || list.Resources[0].URI != "skill://pdf-tools" {
+		t.Errorf("Expected the recreated server to publish its skills, got %v", list.Resources)
+	}
+}
@@ -3,9 +3,11 @@ package mcpserver
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -22,21 +24,42 @@ type UsageReporter interface {
 	ReportSkillUsage(skillName, skillVersion string)
 }
 
+// catalogPollInterval is how often a running server checks whether installs changed its skills
+const catalogPollInterval = 2 * time.Second
+
+// maxSkillFileSize limits the files read_skill_file returns
+const maxSkillFileSize = 1 << 20
+
 // Server provides an MCP server that exposes skill operations
 type Server struct {
 	registry      *clients.Registry
 	usageReporter UsageReporter
+	workDir       string            // Client working directory that determines the scope
+	resources     map[string]string // Published skill resource URIs, to their version
 }
 
-// NewServer creates a new MCP server
+// NewServer creates a new MCP server for the current working directory
 func NewServer(registry *clients.Registry) *Server {
+	workDir, _ := os.Getwd()
 	s := &Server{
-		registry: registry,
+		registry:  registry,
+		workDir:   workDir,
+		resources: make(map[string]string),
 	}
 	s.usageReporter = s // Server implements UsageReporter by default
 	return s
 }
 
+// forWorkDir returns a server sharing this one's clients and reporter for another working directory
+func (s *Server) forWorkDir(workDir string) *Server {
+	return &Server{
+		registry:      s.registry,
+		usageReporter: s.usageReporter,
+		workDir:       workDir,
+		resources:     make(map[string]string),
+	}
+}
+
 // SetUsageReporter sets a custom usage reporter (for testing)
 func (s *Server) SetUsageReporter(reporter UsageReporter) {
 	s.usageReporter = reporter
@@ -47,11 +70,43 @@ type ReadSkillInput struct {
 	Name string `json:"name" jsonschema:"name of the skill to read"`
 }
 
+// ListSkillsInput is the input type for list_skills tool
+type ListSkillsInput struct{}
+
+// SearchSkillsInput is the input type for search_skills tool
+type SearchSkillsInput struct {
+	Query string `json:"query" jsonschema:"words to look for in skill names, descriptions, keywords and triggers"`
+}
+
+// SkillsOutput is the output of list_skills and search_skills
+type SkillsOutput struct {
+	Skills []SkillSummary `json:"skills"`
+}
+
+// ReadSkillFileInput is the input type for read_skill_file tool
+type ReadSkillFileInput struct {
+	Skill string `json:"skill" jsonschema:"name of the skill the file belongs to"`
+	Path  string `json:"path" jsonschema:"file referenced by the skill, as @path, a path relative to the skill, or the absolute path returned by read_skill"`
+}
+
 // fileRefPattern matches @filename or @path/to/file patterns in skill content
 var fileRefPattern = regexp.MustCompile(`@([a-zA-Z0-9_\-./]+\.[a-zA-Z0-9]+)`)
 
 // Run starts the MCP server over stdio
 func (s *Server) Run(ctx context.Context) error {
+	// Read the stamp first, so an install during startup isn't missed
+	stamp := catalogStamp()
+	mcpServer := s.newMCPServer(ctx)
+
+	// Keep skill resources current as installs change them
+	go s.watchCatalog(ctx, mcpServer, stamp, catalogPollInterval)
+
+	// Run over stdio
+	return mcpServer.Run(ctx, &mcp.StdioTransport{})
+}
+
+// newMCPServer creates the MCP server with all tools registered and skills published as resources
+func (s *Server) newMCPServer(ctx context.Context) *mcp.Server {
 	impl := &mcp.Implementation{
 		Name:    "skills",
 		Version: "1.0.0",
@@ -65,8 +120,24 @@ func (s *Server) Run(ctx context.Context) error {
 		Description: "Read a skill's full instructions and content. Returns the skill content as markdown with @file references resolved to absolute paths.",
 	}, s.handleReadSkill)
 
-	// Run over stdio
-	return mcpServer.Run(ctx, &mcp.StdioTransport{})
+	mcp.AddTool(mcpServer, &mcp.Tool{
+		Name:        "list_skills",
+		Description: "List the installed skills available in the current directory, with their descriptions and the scope they're installed in.",
+	}, s.handleListSkills)
+
+	mcp.AddTool(mcpServer, &mcp.Tool{
+		Name:        "search_skills",
+		Description: "Find installed skills relevant to a task by searching their names, descriptions, keywords and triggers. Use read_skill to read a result.",
+	}, s.handleSearchSkills)
+
+	mcp.AddTool(mcpServer, &mcp.Tool{
+		Name:        "read_skill_file",
+		Description: "Read a file that a skill references with @path, such as a template or reference document.",
+	}, s.handleReadSkillFile)
+
+	s.syncResources(ctx, mcpServer)
+
+	return mcpServer
 }
 
 // handleReadSkill handles the read_skill tool invocation
@@ -76,33 +147,214 @@ func (s *Server) handleReadSkill(ctx context.Context, req *mcp.CallToolRequest,
 		return nil, nil, fmt.Errorf("skill name is required")
 	}
 
-	// Determine scope from current working directory
-	scope, err := s.detectScope(ctx)
+	resolvedContent, err := s.readSkill(ctx, req, input.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Return plain markdown text
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: resolvedContent},
+		},
+	}, nil, nil
+}
+
+// handleListSkills handles the list_skills tool invocation
+func (s *Server) handleListSkills(ctx context.Context, req *mcp.CallToolRequest, input ListSkillsInput) (*mcp.CallToolResult, SkillsOutput, error) {
+	scope, err := s.detectScope(ctx, req)
+	if err != nil {
+		return nil, SkillsOutput{}, fmt.Errorf("failed to detect scope: %w", err)
+	}
+	return nil, summarize(s.collectCatalog(ctx, scope)), nil
+}
+
+// handleSearchSkills handles the search_skills tool invocation
+func (s *Server) handleSearchSkills(ctx context.Context, req *mcp.CallToolRequest, input SearchSkillsInput) (*mcp.CallToolResult, SkillsOutput, error) {
+	if strings.TrimSpace(input.Query) == "" {
+		return nil, SkillsOutput{}, fmt.Errorf("query is required")
+	}
+	scope, err := s.detectScope(ctx, req)
+	if err != nil {
+		return nil, SkillsOutput{}, fmt.Errorf("failed to detect scope: %w", err)
+	}
+	return nil, summarize(searchCatalog(s.collectCatalog(ctx, scope), input.Query)), nil
+}
+
+// handleReadSkillFile handles the read_skill_file tool invocation
+// Only files inside the skill's directory can be read
+func (s *Server) handleReadSkillFile(ctx context.Context, req *mcp.CallToolRequest, input ReadSkillFileInput) (*mcp.CallToolResult, any, error) {
+	if input.Skill == "" || input.Path == "" {
+		return nil, nil, fmt.Errorf("skill and path are required")
+	}
+
+	content, err := s.findSkill(ctx, req, input.Skill)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filePath, err := skillFilePath(content.BaseDir, input.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := os.Stat(filePath)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to detect scope: %w", err)
+		return nil, nil, fmt.Errorf("file not found in skill %s: %s", input.Skill, input.Path)
+	}
+	if info.IsDir() {
+		return nil, nil, fmt.Errorf("%s is a directory", input.Path)
+	}
+	if info.Size() > maxSkillFileSize {
+		return nil, nil, fmt.Errorf("%s is too large to read (%d bytes)", input.Path, info.Size())
+	}
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", input.Path, err)
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			&mcp.TextContent{Text: string(data)},
+		},
+	}, nil, nil
+}
+
+// skillFilePath resolves a file reference against a skill's directory, rejecting
+// references that leave it
+func skillFilePath(baseDir, ref string) (string, error) {
+	ref = strings.TrimPrefix(ref, "@")
+	if filepath.IsAbs(ref) {
+		rel, err := filepath.Rel(baseDir, ref)
+		if err != nil {
+			return "", fmt.Errorf("%s is not in the skill's directory", ref)
+		}
+		ref = rel
+	}
+	if !filepath.IsLocal(ref) {
+		return "", fmt.Errorf("%s is not in the skill's directory", ref)
+	}
+	return filepath.Join(baseDir, ref), nil
+}
+
+// readSkill reads a skill's content with @file references resolved, and reports its use
+func (s *Server) readSkill(ctx context.Context, req mcp.Request, name string) (string, error) {
+	content, err := s.findSkill(ctx, req, name)
+	if err != nil {
+		return "", err
+	}
+
+	// Report usage (best-effort, won't fail the MCP call)
+	go s.usageReporter.ReportSkillUsage(content.Name, content.Version)
+
+	// Resolve @file references to absolute paths
+	return resolveFileReferences(content.Content, content.BaseDir), nil
+}
+
+// findSkill reads a skill from the most specific scope it's installed in
+func (s *Server) findSkill(ctx context.Context, req mcp.Request, name string) (*clients.SkillContent, error) {
+	// Determine scope from the client's working directory
+	scope, err := s.detectScope(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect scope: %w", err)
 	}
 
 	// Try each installed client until we find the skill
 	installedClients := s.registry.DetectInstalled()
-	for _, client := range installedClients {
-		content, err := client.ReadSkill(ctx, input.Name, scope)
-		if err == nil {
-			// Report usage (best-effort, won't fail the MCP call)
-			go s.usageReporter.ReportSkillUsage(content.Name, content.Version)
-
-			// Resolve @file references to absolute paths
-			resolvedContent := resolveFileReferences(content.Content, content.BaseDir)
-
-			// Return plain markdown text
-			return &mcp.CallToolResult{
-				Content: []mcp.Content{
-					&mcp.TextContent{Text: resolvedContent},
-				},
-			}, nil, nil
+	for _, chainScope := range clients.ScopeChain(scope) {
+		for _, client := range installedClients {
+			content, err := client.ReadSkill(ctx, name, chainScope)
+			if err == nil {
+				return content, nil
+			}
 		}
 	}
 
-	return nil, nil, fmt.Errorf("skill not found: %s", input.Name)
+	return nil, fmt.Errorf("skill not found: %s", name)
+}
+
+// summarize returns the summaries of catalog entries
+func summarize(entries []catalogEntry) SkillsOutput {
+	out := SkillsOutput{Skills: make([]SkillSummary, len(entries))}
+	for i, entry := range entries {
+		out.Skills[i] = entry.summary
+	}
+	return out
+}
+
+// skillURI returns the resource URI a skill is published at
+func skillURI(name string) string {
+	return "skill://" + url.PathEscape(name)
+}
+
+// syncResources publishes each installed skill as a resource, removing those no
+// longer installed; the MCP server notifies clients when the list changes
+func (s *Server) syncResources(ctx context.Context, mcpServer *mcp.Server) {
+	scope, err := s.detectScope(ctx, nil)
+	if err != nil {
+		logger.Get().Warn("failed to detect scope for skill resources", "error", err)
+		return
+	}
+
+	current := make(map[string]SkillSummary)
+	for _, entry := range s.collectCatalog(ctx, scope) {
+		current[skillURI(entry.summary.Name)] = entry.summary
+	}
+
+	var removed []string
+	for uri, version := range s.resources {
+		if skill, ok := current[uri]; !ok || skill.Version != version {
+			removed = append(removed, uri)
+			delete(s.resources, uri)
+		}
+	}
+	if len(removed) > 0 {
+		mcpServer.RemoveResources(removed...)
+	}
+
+	for uri, skill := range current {
+		if _, ok := s.resources[uri]; ok {
+			continue
+		}
+		s.resources[uri] = skill.Version
+		name := skill.Name
+		mcpServer.AddResource(&mcp.Resource{
+			URI:         uri,
+			Name:        name,
+			Description: skill.Description,
+			MIMEType:    "text/markdown",
+		}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			text, err := s.readSkill(ctx, req, name)
+			if err != nil {
+				return nil, mcp.ResourceNotFoundError(req.Params.URI)
+			}
+			return &mcp.ReadResourceResult{
+				Contents: []*mcp.ResourceContents{
+					{URI: req.Params.URI, MIMEType: "text/markdown", Text: text},
+				},
+			}, nil
+		})
+	}
+}
+
+// watchCatalog resyncs resources whenever an install reports that skills changed
+// since the stamp last was read
+func (s *Server) watchCatalog(ctx context.Context, mcpServer *mcp.Server, last string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if stamp := catalogStamp(); stamp != last {
+				last = stamp
+				s.syncResources(ctx, mcpServer)
+			}
+		}
+	}
 }
 
 // resolveFileReferences replaces @file references with absolute paths
@@ -125,9 +377,26 @@ func resolveFileReferences(content string, baseDir string) string {
 	})
 }
 
-// detectScope determines the current scope using gitutil
-func (s *Server) detectScope(ctx context.Context) (*clients.InstallScope, error) {
-	gitContext, err := gitutil.DetectContext(ctx)
+// detectScope determines the scope of the client's working directory using gitutil
+// The directory comes from the request's working directory header, falling back to the
+// server's; with neither, only global skills are visible
+func (s *Server) detectScope(ctx context.Context, req mcp.Request) (*clients.InstallScope, error) {
+	dir := s.workDir
+	if req != nil {
+		if extra := req.GetExtra(); extra != nil && extra.Header != nil {
+			if headerDir := extra.Header.Get(WorkingDirHeader); headerDir != "" {
+				dir = headerDir
+			}
+		}
+	}
+	if dir == "" {
+		return &clients.InstallScope{Type: clients.ScopeGlobal}, nil
+	}
+	if err := checkWorkingDir(dir); err != nil {
+		return nil, err
+	}
+
+	gitContext, err := gitutil.DetectContextForPath(ctx, dir)
 	if err != nil {
 		return nil, err
 	}
@@ -157,12 +426,19 @@ func (s *Server) detectScope(ctx context.Context) (*clients.InstallScope, error)
 func (s *Server) ReportSkillUsage(skillName, skillVersion string) {
 	log := logger.Get()
 
+	// Config not initialized means default usage settings, and the queue is flushed later
+	cfg, cfgErr := config.Load()
+
 	// Create usage event
-	usageEvent := stats.UsageEvent{
+	usageEvent, record := stats.Anonymize(stats.UsageEvent{
+		SchemaVersion:   stats.EventSchemaVersion,
 		ArtifactName:    skillName,
 		ArtifactVersion: skillVersion,
 		ArtifactType:    "skill",
 		Timestamp:       time.Now().UTC().Format(time.RFC3339),
+	}, cfg.GetUsage())
+	if !record {
+		return
 	}
 
 	// Enqueue event (fast, local file write)
@@ -173,18 +449,16 @@ func (s *Server) ReportSkillUsage(skillName, skillVersion string) {
 
 	log.Debug("skill usage enqueued", "name", skillName, "version", skillVersion)
 
+	if cfgErr != nil {
+		return
+	}
+
 	// Try to flush queue with timeout (network call, but we're already in a goroutine)
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Load config to get repository
-	cfg, err := config.Load()
-	if err != nil {
-		return
-	}
-
 	// Create repository instance
-	repo, err := repository.NewFromConfig(cfg)
+	repo, err := repository.NewFromConfigs(cfg.GetRepositories(), cfg.Pins)
 	if err != nil {
 		return
 	}
@@ -2,11 +2,13 @@ package mcpserver
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/sleuth-io/skills/internal/clients"
@@ -16,13 +18,18 @@ import (
 // mockClient implements clients.Client for testing
 type mockClient struct {
 	clients.BaseClient
-	skills map[string]*clients.SkillContent
+	mu       sync.Mutex
+	skills   map[string]*clients.SkillContent
+	keywords map[string][]string
+	triggers map[string][]string
 }
 
 func newMockClient() *mockClient {
 	return &mockClient{
 		BaseClient: clients.NewBaseClient("mock", "Mock Client", nil),
 		skills:     make(map[string]*clients.SkillContent),
+		keywords:   make(map[string][]string),
+		triggers:   make(map[string][]string),
 	}
 }
 
@@ -38,17 +45,24 @@ func (m *mockClient) UninstallArtifacts(ctx context.Context, req clients.Uninsta
 }
 
 func (m *mockClient) ListSkills(ctx context.Context, scope *clients.InstallScope) ([]clients.InstalledSkill, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	skills := make([]clients.InstalledSkill, 0, len(m.skills))
 	for _, s := range m.skills {
 		skills = append(skills, clients.InstalledSkill{
 			Name:        s.Name,
 			Description: s.Description,
+			Version:     s.Version,
+			Keywords:    m.keywords[s.Name],
+			Triggers:    m.triggers[s.Name],
 		})
 	}
 	return skills, nil
 }
 
 func (m *mockClient) ReadSkill(ctx context.Context, name string, scope *clients.InstallScope) (*clients.SkillContent, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if skill, ok := m.skills[name]; ok {
 		return skill, nil
 	}
@@ -76,6 +90,8 @@ func (m *mockClient) VerifyArtifacts(ctx context.Context, artifacts []*lockfile.
 }
 
 func (m *mockClient) addSkill(name, description, version, content, baseDir string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.skills[name] = &clients.SkillContent{
 		Name:        name,
 		Description: description,
@@ -445,3 +461,198 @@ func TestServer_ReadSkill_ReportsUsage(t *testing.T) {
 		t.Errorf("Expected skill version '2.0.0', got %q", reports[0].skillVersion)
 	}
 }
+
+// connectTestServer connects a client session to the server's full MCP server
+func connectTestServer(t *testing.T, server *Server, opts *mcp.ClientOptions) (*mcp.Server, *mcp.ClientSession) {
+	t.Helper()
+	ctx := context.Background()
+	mcpServer := server.newMCPServer(ctx)
+	return mcpServer, connectMCPServer(t, mcpServer, opts)
+}
+
+func connectMCPServer(t *testing.T, mcpServer *mcp.Server, opts *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+
+	t1, t2 := mcp.NewInMemoryTransports()
+	if _, err := mcpServer.Connect(ctx, t1, nil); err != nil {
+		t.Fatalf("Failed to connect server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "v1.0.0"}, opts)
+	session, err := client.Connect(ctx, t2, nil)
+	if err != nil {
+		t.Fatalf("Failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+func callSkillsTool(t *testing.T, session *mcp.ClientSession, name string, args map[string]any) []string {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("Tool returned error: %v", result.Content)
+	}
+	data, err := json.Marshal(result.StructuredContent)
+	if err != nil {
+		t.Fatalf("Failed to marshal structured content: %v", err)
+	}
+	var out SkillsOutput
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("Failed to parse structured content: %v", err)
+	}
+	names := make([]string, len(out.Skills))
+	for i, skill := range out.Skills {
+		names[i] = skill.Name
+		if skill.Scope != string(clients.ScopeGlobal) {
+			t.Errorf("Expected %s to be listed in the global scope, got %q", skill.Name, skill.Scope)
+		}
+	}
+	return names
+}
+
+func TestServer_ListAndSearchSkills(t *testing.T) {
+	t.Chdir(t.TempDir()) // Outside a repository, so only the global scope applies
+
+	mock := newMockClient()
+	mock.addSkill("pdf-tools", "Fill and merge PDF forms", "1.0.0", "# PDF", "/tmp/skills/pdf-tools")
+	mock.addSkill("release-notes", "Write release notes from merged PRs", "1.0.0", "# Notes", "/tmp/skills/release-notes")
+	mock.addSkill("changelog", "Maintain CHANGELOG.md", "1.0.0", "# Changelog", "/tmp/skills/changelog")
+	mock.keywords["changelog"] = []string{"release", "docs"}
+	mock.triggers["pdf-tools"] = []string{"fill out this form"}
+
+	registry := clients.NewRegistry()
+	registry.Register(mock)
+	_, session := connectTestServer(t, NewServer(registry), nil)
+
+	if got := callSkillsTool(t, session, "list_skills", map[string]any{}); strings.Join(got, ",") != "changelog,pdf-tools,release-notes" {
+		t.Errorf("list_skills = %v", got)
+	}
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"release", "release-notes,changelog"}, // Name match ranks above keyword match
+		{"form", "pdf-tools"},                  // Description and trigger
+		{"release PRs", "release-notes"},       // Every word must match
+		{"kubernetes", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := callSkillsTool(t, session, "search_skills", map[string]any{"query": tt.query})
+			if strings.Join(got, ",") != tt.want {
+				t.Errorf("search_skills(%q) = %v, want %s", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServer_ReadSkillFile(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	skillDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(skillDir, "templates"), 0755); err != nil {
+		t.Fatalf("Failed to create templates dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "templates", "form.md"), []byte("template body"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	mock := newMockClient()
+	mock.addSkill("pdf-tools", "PDF forms", "1.0.0", "Use @templates/form.md", skillDir)
+	registry := clients.NewRegistry()
+	registry.Register(mock)
+	_, session := connectTestServer(t, NewServer(registry), nil)
+
+	tests := []struct {
+		name    string
+		path    string
+		wantErr bool
+	}{
+		{name: "at reference", path: "@templates/form.md"},
+		{name: "relative path", path: "templates/form.md"},
+		{name: "absolute path from read_skill", path: filepath.Join(skillDir, "templates", "form.md")},
+		{name: "escapes skill directory", path: "../../etc/passwd", wantErr: true},
+		{name: "absolute path outside skill", path: "/etc/passwd", wantErr: true},
+		{name: "missing file", path: "@templates/missing.md", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+				Name:      "read_skill_file",
+				Arguments: map[string]any{"skill": "pdf-tools", "path": tt.path},
+			})
+			if err != nil {
+				t.Fatalf("CallTool failed: %v", err)
+			}
+			if result.IsError != tt.wantErr {
+				t.Fatalf("IsError = %v, want %v: %v", result.IsError, tt.wantErr, result.Content)
+			}
+			if !tt.wantErr {
+				if text := result.Content[0].(*mcp.TextContent).Text; text != "template body" {
+					t.Errorf("Expected template body, got %q", text)
+				}
+			}
+		})
+	}
+}
+
+func TestServer_SkillResources(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	mock := newMockClient()
+	mock.addSkill("pdf-tools", "PDF forms", "1.0.0", "# PDF Tools", "/tmp/skills/pdf-tools")
+	registry := clients.NewRegistry()
+	registry.Register(mock)
+	server := NewServer(registry)
+	server.SetUsageReporter(newMockUsageReporter())
+
+	changed := make(chan struct{}, 10)
+	mcpServer, session := connectTestServer(t, server, &mcp.ClientOptions{
+		ResourceListChangedHandler: func(context.Context, *mcp.ResourceListChangedRequest) {
+			changed <- struct{}{}
+		},
+	})
+	ctx := context.Background()
+
+	result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: "skill://pdf-tools"})
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	if result.Contents[0].Text != "# PDF Tools" {
+		t.Errorf("Expected skill content, got %q", result.Contents[0].Text)
+	}
+
+	// An install adds a skill and notifies running servers
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go server.watchCatalog(watchCtx, mcpServer, catalogStamp(), 10*time.Millisecond)
+
+	mock.addSkill("changelog", "Maintain CHANGELOG.md", "1.0.0", "# Changelog", "/tmp/skills/changelog")
+	if err := NotifyCatalogChanged(); err != nil {
+		t.Fatalf("NotifyCatalogChanged failed: %v", err)
+	}
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for resources/list_changed")
+	}
+
+	list, err := session.ListResources(ctx, nil)
+	if err != nil {
+		t.Fatalf("ListResources failed: %v", err)
+	}
+	var uris []string
+	for _, r := range list.Resources {
+		uris = append(uris, r.URI)
+	}
+	if strings.Join(uris, ",") != "skill://changelog,skill://pdf-tools" {
+		t.Errorf("Expected both skills as resources, got %v", uris)
+	}
+}
@@ -0,0 +1,51 @@
+package metadata
+
+import (
+	"path"
+	"path/filepath"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// ExecutableFiles returns the files in the artifact that are run directly: the hook's
+// script-file and a packaged MCP server's relative command
+func (m *Metadata) ExecutableFiles() []string {
+	var files []string
+	if m.Hook != nil && m.Hook.ScriptFile != "" {
+		files = append(files, m.Hook.ScriptFile)
+	}
+	if m.Artifact.Type == artifact.TypeMCP && m.MCP != nil && m.MCP.Command != "" && !path.IsAbs(m.MCP.Command) {
+		files = append(files, m.MCP.Command)
+	}
+	return files
+}
+
+// CreateZip reproducibly zips an artifact directory, making executable only the files
+// its metadata.toml runs directly
+// Without a readable metadata.toml nothing is executable
+func CreateZip(dir string, exclude ...string) ([]byte, error) {
+	return CreateZipWithOptions(dir, utils.ZipOptions{Exclude: exclude})
+}
+
+// CreateZipWithOptions is CreateZip with the files left out given by opts
+// The executables always come from metadata.toml
+func CreateZipWithOptions(dir string, opts utils.ZipOptions) ([]byte, error) {
+	opts.Executables = nil
+	if meta, err := ParseFile(filepath.Join(dir, "metadata.toml")); err == nil {
+		opts.Executables = meta.ExecutableFiles()
+	}
+	return utils.CreateReproducibleZip(dir, opts)
+}
+
+// ExtractZip safely extracts an artifact zip, keeping executable permissions only on
+// the files its metadata.toml runs directly
+func ExtractZip(zipData []byte, targetDir string) error {
+	var opts utils.ExtractOptions
+	if data, err := utils.ReadZipFile(zipData, "metadata.toml"); err == nil {
+		if meta, err := Parse(data); err == nil {
+			opts.Executables = meta.ExecutableFiles()
+		}
+	}
+	return utils.ExtractZipWithOptions(zipData, targetDir, opts)
+}
@@ -0,0 +1,30 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+)
+
+func TestExecutableFiles(t *testing.T) {
+	tests := []struct {
+		name string
+		meta Metadata
+		want []string
+	}{
+		{"skill", Metadata{Artifact: Artifact{Type: artifact.TypeSkill}, Skill: &SkillConfig{PromptFile: "SKILL.md"}}, nil},
+		{"hook script", Metadata{Artifact: Artifact{Type: artifact.TypeHook}, Hook: &HookConfig{ScriptFile: "hook.sh"}}, []string{"hook.sh"}},
+		{"hook command", Metadata{Artifact: Artifact{Type: artifact.TypeHook}, Hook: &HookConfig{Command: "echo hi"}}, nil},
+		{"packaged mcp", Metadata{Artifact: Artifact{Type: artifact.TypeMCP}, MCP: &MCPConfig{Command: "bin/server"}}, []string{"bin/server"}},
+		{"absolute mcp command", Metadata{Artifact: Artifact{Type: artifact.TypeMCP}, MCP: &MCPConfig{Command: "/usr/bin/node"}}, nil},
+		{"remote mcp", Metadata{Artifact: Artifact{Type: artifact.TypeMCPRemote}, MCP: &MCPConfig{Command: "npx"}}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.meta.ExecutableFiles(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExecutableFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/BurntSushi/toml"
 	"github.com/sleuth-io/skills/internal/artifact"
@@ -63,12 +65,16 @@ type AgentConfig struct {
 }
 
 // HookConfig represents the [hook] section
+// A hook runs either a script-file shipped in the artifact or an inline command
 type HookConfig struct {
-	Event       string `toml:"event"`
-	ScriptFile  string `toml:"script-file"`
-	Async       bool   `toml:"async,omitempty"`
-	FailOnError bool   `toml:"fail-on-error,omitempty"`
-	Timeout     int    `toml:"timeout,omitempty"`
+	Event        string            `toml:"event"`
+	Matcher      string            `toml:"matcher,omitempty"`      // Tool name pattern for tool events, e.g. "Bash" or "Edit|Write"
+	ScriptFile   string            `toml:"script-file,omitempty"`  // Script inside the artifact to run
+	Command      string            `toml:"command,omitempty"`      // Shell command to run instead of a script-file
+	Interpreters map[string]string `toml:"interpreters,omitempty"` // Interpreter for script-file by platform (linux, darwin, windows or default)
+	Async        bool              `toml:"async,omitempty"`
+	FailOnError  bool              `toml:"fail-on-error,omitempty"`
+	Timeout      int               `toml:"timeout,omitempty"`
 }
 
 // MCPConfig represents the [mcp] section (for both mcp and mcp-remote)
@@ -78,6 +84,17 @@ type MCPConfig struct {
 	Env          map[string]string `toml:"env,omitempty"`
 	Timeout      int               `toml:"timeout,omitempty"`
 	Capabilities []string          `toml:"capabilities,omitempty"`
+	Inputs       []MCPInput        `toml:"inputs,omitempty"`
+}
+
+// MCPInput is a value the developer must provide at install time, such as an API token
+// It is passed to the server as the environment variable Name, and can be referenced
+// as ${Name} in env values and args
+type MCPInput struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description,omitempty"`
+	Secret      bool   `toml:"secret,omitempty"`
+	Default     string `toml:"default,omitempty"`
 }
 
 // Parse parses metadata from bytes
@@ -143,3 +160,34 @@ func (m *Metadata) GetTypeConfig() interface{} {
 	}
 	return nil
 }
+
+// Interpreter returns the interpreter configured for script-file on the given platform (a GOOS value)
+// Falls back to the "default" entry, and returns empty if the script should be executed directly
+func (h *HookConfig) Interpreter(goos string) string {
+	if interpreter, ok := h.Interpreters[goos]; ok {
+		return interpreter
+	}
+	return h.Interpreters["default"]
+}
+
+// CommandLine returns the shell command that runs the hook on the given platform
+// scriptDir is the directory the artifact was installed to, used to locate script-file
+func (h *HookConfig) CommandLine(scriptDir, goos string) string {
+	if h.Command != "" {
+		return h.Command
+	}
+
+	scriptPath := quoteCommandArg(filepath.Join(scriptDir, h.ScriptFile))
+	if interpreter := h.Interpreter(goos); interpreter != "" {
+		return interpreter + " " + scriptPath
+	}
+	return scriptPath
+}
+
+// quoteCommandArg double-quotes a path for the shell if it contains spaces or special characters
+func quoteCommandArg(arg string) string {
+	if !strings.ContainsAny(arg, " \t'\"$`&|;<>()*?[]#~!{}") {
+		return arg
+	}
+	return `"` + strings.NewReplacer(`"`, `\"`, "$", `\$`, "`", "\\`").Replace(arg) + `"`
+}
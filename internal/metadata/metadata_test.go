@@ -133,3 +133,155 @@ dependencies = ["dep1", "dep2"]
 		t.Errorf("Expected second dependency 'dep2', got %s", meta.Artifact.Dependencies[1])
 	}
 }
+
+func TestValidateHookConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		hook    HookConfig
+		wantErr bool
+	}{
+		{
+			name: "script-file with generic event",
+			hook: HookConfig{Event: "pre-commit", ScriptFile: "hook.sh"},
+		},
+		{
+			name: "command with Claude Code event and matcher",
+			hook: HookConfig{Event: "PreToolUse", Matcher: "Edit|Write", Command: "guard --strict"},
+		},
+		{
+			name: "script-file with interpreters",
+			hook: HookConfig{Event: "Stop", ScriptFile: "hook.py", Interpreters: map[string]string{"default": "python3", "windows": "py"}},
+		},
+		{
+			name:    "unknown event",
+			hook:    HookConfig{Event: "BeforeLunch", Command: "true"},
+			wantErr: true,
+		},
+		{
+			name:    "neither script-file nor command",
+			hook:    HookConfig{Event: "PreToolUse"},
+			wantErr: true,
+		},
+		{
+			name:    "both script-file and command",
+			hook:    HookConfig{Event: "PreToolUse", ScriptFile: "hook.sh", Command: "true"},
+			wantErr: true,
+		},
+		{
+			name:    "interpreters with command",
+			hook:    HookConfig{Event: "PreToolUse", Command: "true", Interpreters: map[string]string{"default": "bash"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown interpreter platform",
+			hook:    HookConfig{Event: "PreToolUse", ScriptFile: "hook.sh", Interpreters: map[string]string{"plan9": "rc"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid matcher",
+			hook:    HookConfig{Event: "PreToolUse", Matcher: "Edit(", Command: "true"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hook.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateMCPInputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		inputs  []MCPInput
+		wantErr bool
+	}{
+		{
+			name:   "secret and non-secret with default",
+			inputs: []MCPInput{{Name: "GITHUB_TOKEN", Secret: true}, {Name: "GITHUB_HOST", Default: "github.com"}},
+		},
+		{
+			name:    "name isn't an environment variable",
+			inputs:  []MCPInput{{Name: "github-token"}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name",
+			inputs:  []MCPInput{{Name: "TOKEN"}, {Name: "TOKEN", Secret: true}},
+			wantErr: true,
+		},
+		{
+			name:    "secret with default",
+			inputs:  []MCPInput{{Name: "TOKEN", Secret: true, Default: "abc"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mcp := MCPConfig{Command: "npx", Args: []string{"server"}, Inputs: tt.inputs}
+			err := mcp.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHookCommandLine(t *testing.T) {
+	tests := []struct {
+		name      string
+		hook      HookConfig
+		scriptDir string
+		goos      string
+		want      string
+	}{
+		{
+			name:      "script run directly",
+			hook:      HookConfig{ScriptFile: "hook.sh"},
+			scriptDir: "/home/me/.claude/hooks/guard",
+			goos:      "linux",
+			want:      "/home/me/.claude/hooks/guard/hook.sh",
+		},
+		{
+			name:      "platform interpreter",
+			hook:      HookConfig{ScriptFile: "hook.py", Interpreters: map[string]string{"default": "python3", "darwin": "/usr/bin/python3"}},
+			scriptDir: "/Users/me/.claude/hooks/guard",
+			goos:      "darwin",
+			want:      "/usr/bin/python3 /Users/me/.claude/hooks/guard/hook.py",
+		},
+		{
+			name:      "default interpreter",
+			hook:      HookConfig{ScriptFile: "hook.py", Interpreters: map[string]string{"default": "python3"}},
+			scriptDir: "/home/me/.claude/hooks/guard",
+			goos:      "linux",
+			want:      "python3 /home/me/.claude/hooks/guard/hook.py",
+		},
+		{
+			name:      "path with spaces is quoted",
+			hook:      HookConfig{ScriptFile: "hook.sh"},
+			scriptDir: "/home/Jo Smith/.claude/hooks/guard",
+			goos:      "linux",
+			want:      `"/home/Jo Smith/.claude/hooks/guard/hook.sh"`,
+		},
+		{
+			name:      "inline command",
+			hook:      HookConfig{Command: "npx guard --strict"},
+			scriptDir: "/home/me/.claude/hooks/guard",
+			goos:      "linux",
+			want:      "npx guard --strict",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hook.CommandLine(tt.scriptDir, tt.goos); got != tt.want {
+				t.Errorf("CommandLine() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,9 @@ var (
 	// nameRegex matches valid artifact names (alphanumeric, dashes, underscores)
 	nameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 
+	// inputNameRegex matches MCP input names, which are used as environment variable names
+	inputNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 	// Valid hook events
 	validHookEvents = map[string]bool{
 		"pre-commit":  true,
@@ -21,8 +24,34 @@ var (
 		"pre-merge":   true,
 		"post-merge":  true,
 	}
+
+	// Claude Code lifecycle events, which hooks may also target directly
+	claudeCodeHookEvents = map[string]bool{
+		"PreToolUse":       true,
+		"PostToolUse":      true,
+		"UserPromptSubmit": true,
+		"Notification":     true,
+		"Stop":             true,
+		"SubagentStop":     true,
+		"SessionStart":     true,
+		"SessionEnd":       true,
+		"PreCompact":       true,
+	}
+
+	// Platforms that may have their own hook interpreter
+	validInterpreterPlatforms = map[string]bool{
+		"default": true,
+		"linux":   true,
+		"darwin":  true,
+		"windows": true,
+	}
 )
 
+// IsClaudeCodeHookEvent returns true if event is a Claude Code lifecycle event rather than a generic one
+func IsClaudeCodeHookEvent(event string) bool {
+	return claudeCodeHookEvents[event]
+}
+
 // Validate validates the entire metadata structure
 func (m *Metadata) Validate() error {
 	// Validate artifact section
@@ -133,12 +162,30 @@ func (h *HookConfig) Validate() error {
 		return fmt.Errorf("event is required")
 	}
 
-	if !validHookEvents[h.Event] {
-		return fmt.Errorf("invalid hook event: %s (must be one of: pre-commit, post-commit, pre-push, post-push, pre-merge, post-merge)", h.Event)
+	if !validHookEvents[h.Event] && !claudeCodeHookEvents[h.Event] {
+		return fmt.Errorf("invalid hook event: %s (must be one of: pre-commit, post-commit, pre-push, post-push, pre-merge, post-merge, or a Claude Code event such as PreToolUse)", h.Event)
+	}
+
+	if h.Matcher != "" {
+		if _, err := regexp.Compile(h.Matcher); err != nil {
+			return fmt.Errorf("invalid matcher %q: %w", h.Matcher, err)
+		}
+	}
+
+	if h.ScriptFile == "" && h.Command == "" {
+		return fmt.Errorf("script-file or command is required")
+	}
+	if h.ScriptFile != "" && h.Command != "" {
+		return fmt.Errorf("script-file and command are mutually exclusive")
 	}
 
-	if h.ScriptFile == "" {
-		return fmt.Errorf("script-file is required")
+	if len(h.Interpreters) > 0 && h.ScriptFile == "" {
+		return fmt.Errorf("interpreters only apply to script-file hooks")
+	}
+	for platform := range h.Interpreters {
+		if !validInterpreterPlatforms[platform] {
+			return fmt.Errorf("invalid interpreter platform: %s (must be one of: linux, darwin, windows, default)", platform)
+		}
 	}
 
 	if h.Timeout < 0 {
@@ -162,6 +209,20 @@ func (m *MCPConfig) Validate() error {
 		return fmt.Errorf("timeout must be non-negative")
 	}
 
+	seen := make(map[string]bool)
+	for _, input := range m.Inputs {
+		if !inputNameRegex.MatchString(input.Name) {
+			return fmt.Errorf("invalid input name %q (must be a valid environment variable name)", input.Name)
+		}
+		if seen[input.Name] {
+			return fmt.Errorf("duplicate input: %s", input.Name)
+		}
+		seen[input.Name] = true
+		if input.Secret && input.Default != "" {
+			return fmt.Errorf("secret input %s can't have a default", input.Name)
+		}
+	}
+
 	return nil
 }
 
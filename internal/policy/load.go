@@ -0,0 +1,90 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/sleuth-io/skills/internal/cache"
+	"github.com/sleuth-io/skills/internal/repository"
+)
+
+// Load returns the policies shipped by repo, or by each repository of a multi-repository
+//
+// A repository whose policy can't be fetched falls back to the policy cached by the
+// last fetch. If none is cached, Load fails rather than silently dropping the guardrails.
+func Load(ctx context.Context, repo repository.Repository) (Set, error) {
+	named := []repository.NamedRepository{{Repository: repo}}
+	if multi, ok := repo.(*repository.MultiRepository); ok {
+		named = multi.Repositories()
+	}
+
+	var set Set
+	for _, r := range named {
+		p, err := loadRepository(ctx, r)
+		if err != nil {
+			if r.Name != "" {
+				return nil, fmt.Errorf("repository %s: %w", r.Name, err)
+			}
+			return nil, err
+		}
+		if p != nil {
+			set = append(set, p)
+		}
+	}
+
+	return set, nil
+}
+
+// loadRepository fetches and parses one repository's policy, returning nil if it has none
+func loadRepository(ctx context.Context, repo repository.NamedRepository) (*Policy, error) {
+	provider, ok := repo.Repository.(repository.PolicyProvider)
+	if !ok {
+		return nil, nil
+	}
+
+	data, err := provider.GetPolicy(ctx)
+	if err != nil {
+		if repo.CacheKey == "" {
+			return nil, err
+		}
+		cached, cacheErr := cache.LoadPolicy(repo.CacheKey)
+		if cacheErr != nil {
+			return nil, err
+		}
+		data = cached
+	} else if repo.CacheKey != "" {
+		// Cache failures only matter if the repository is unreachable next time
+		_ = cache.SavePolicy(repo.CacheKey, data)
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, nil
+	}
+
+	p, err := Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	p.Source = "repository"
+	if repo.Name != "" {
+		p.Source = "repository " + repo.Name
+	}
+	return p, nil
+}
+
+// LoadFile loads a policy from a file
+func LoadFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	p.Source = path
+	return p, nil
+}
@@ -0,0 +1,318 @@
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+// sourceTypes are the lock file source types a policy can deny
+var sourceTypes = map[string]bool{
+	"http": true,
+	"path": true,
+	"git":  true,
+	"oci":  true,
+}
+
+// Policy is an organization's guardrails for the artifacts in a lock file
+//
+// Example skill-policy.toml:
+//
+//	[sources]
+//	deny = ["path", "git"]
+//	require-hashes = true
+//
+//	[hooks]
+//	allowed-events = ["SessionStart", "pre-commit"]
+//
+//	[mcp]
+//	allowed-commands = ["npx", "uvx"]
+//
+//	[clients]
+//	cursor = ["skill", "mcp-remote"]
+type Policy struct {
+	Sources SourcesPolicy `toml:"sources"`
+	Hooks   HooksPolicy   `toml:"hooks"`
+	MCP     MCPPolicy     `toml:"mcp"`
+
+	// Clients maps client IDs to the artifact types they may receive
+	// Clients that aren't listed may receive any type
+	Clients map[string][]artifact.Type `toml:"clients"`
+
+	// Source names where the policy came from, e.g. a repository name or file path
+	Source string `toml:"-"`
+}
+
+// SourcesPolicy restricts where artifacts come from
+type SourcesPolicy struct {
+	// Deny lists forbidden source types: http, path, git or oci
+	Deny []string `toml:"deny"`
+
+	// RequireHashes refuses path and git sources without content hashes
+	// (http sources always have hashes and oci sources always have a digest)
+	RequireHashes bool `toml:"require-hashes"`
+}
+
+// HooksPolicy restricts hook artifacts
+type HooksPolicy struct {
+	// AllowedEvents lists the events hooks may run on
+	// If unset, hooks may use any event; an empty list forbids hooks entirely
+	AllowedEvents []string `toml:"allowed-events"`
+}
+
+// MCPPolicy restricts MCP servers
+type MCPPolicy struct {
+	// AllowedCommands lists the commands MCP servers may be launched with
+	// If unset, any command is allowed; an empty list forbids MCP servers that run a command
+	AllowedCommands []string `toml:"allowed-commands"`
+}
+
+// Violation is an artifact breaking one of a policy's rules
+type Violation struct {
+	Artifact string // name@version
+	Rule     string // Policy setting that was broken, e.g. sources.deny
+	Message  string
+	Source   string // Where the policy came from
+}
+
+// String returns a one-line description of the violation
+func (v Violation) String() string {
+	if v.Source != "" {
+		return fmt.Sprintf("%s: %s [%s, policy from %s]", v.Artifact, v.Message, v.Rule, v.Source)
+	}
+	return fmt.Sprintf("%s: %s [%s]", v.Artifact, v.Message, v.Rule)
+}
+
+// Violations is a list of policy violations
+type Violations []Violation
+
+// Err returns an error listing the violations, or nil if there are none
+func (v Violations) Err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &ViolationError{Violations: v}
+}
+
+// ViolationError reports policy violations
+type ViolationError struct {
+	Violations Violations
+}
+
+// Error lists every violation on its own line
+func (e *ViolationError) Error() string {
+	var sb strings.Builder
+	if len(e.Violations) == 1 {
+		sb.WriteString("policy violation:")
+	} else {
+		fmt.Fprintf(&sb, "%d policy violations:", len(e.Violations))
+	}
+	for _, v := range e.Violations {
+		sb.WriteString("\n  - ")
+		sb.WriteString(v.String())
+	}
+	return sb.String()
+}
+
+// Parse parses and validates a policy
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	if err := toml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks that the policy only refers to known source and artifact types
+func (p *Policy) Validate() error {
+	for _, source := range p.Sources.Deny {
+		if !sourceTypes[source] {
+			return fmt.Errorf("sources.deny: unknown source type %q (must be http, path, git or oci)", source)
+		}
+	}
+	for clientID, types := range p.Clients {
+		for _, t := range types {
+			if !t.IsValid() {
+				return fmt.Errorf("clients.%s: unknown artifact type %q", clientID, t.Key)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckLockFile checks every artifact in a lock file against the rules that only need
+// the lock file (sources, hashes and clients)
+func (p *Policy) CheckLockFile(lf *lockfile.LockFile) Violations {
+	var violations Violations
+	for i := range lf.Artifacts {
+		violations = append(violations, p.CheckArtifact(&lf.Artifacts[i])...)
+	}
+	return violations
+}
+
+// CheckArtifact checks a lock file entry against the source, hash and client rules
+func (p *Policy) CheckArtifact(art *lockfile.Artifact) Violations {
+	var violations Violations
+	add := func(rule, format string, args ...any) {
+		violations = append(violations, Violation{
+			Artifact: art.Key(),
+			Rule:     rule,
+			Message:  fmt.Sprintf(format, args...),
+			Source:   p.Source,
+		})
+	}
+
+	sourceType := art.GetSourceType()
+	for _, denied := range p.Sources.Deny {
+		if sourceType == denied {
+			add("sources.deny", "source-%s entries are not allowed", sourceType)
+		}
+	}
+
+	if p.Sources.RequireHashes && !hasHashes(art) {
+		add("sources.require-hashes", "source-%s has no hashes", sourceType)
+	}
+
+	for _, clientID := range art.Clients {
+		if !p.AllowsClient(clientID, art.Type) {
+			add("clients."+clientID, "%s artifacts may not be installed to %s", art.Type, clientID)
+		}
+	}
+
+	return violations
+}
+
+// CheckMetadata checks an artifact's metadata against the hook and MCP rules
+func (p *Policy) CheckMetadata(art *lockfile.Artifact, meta *metadata.Metadata) Violations {
+	var violations Violations
+	add := func(rule, format string, args ...any) {
+		violations = append(violations, Violation{
+			Artifact: art.Key(),
+			Rule:     rule,
+			Message:  fmt.Sprintf(format, args...),
+			Source:   p.Source,
+		})
+	}
+
+	if meta.Hook != nil && p.Hooks.AllowedEvents != nil && !contains(p.Hooks.AllowedEvents, meta.Hook.Event) {
+		if len(p.Hooks.AllowedEvents) == 0 {
+			add("hooks.allowed-events", "hooks are not allowed")
+		} else {
+			add("hooks.allowed-events", "hook event %q is not allowed (allowed: %s)", meta.Hook.Event, strings.Join(p.Hooks.AllowedEvents, ", "))
+		}
+	}
+
+	if meta.MCP != nil && meta.MCP.Command != "" && p.MCP.AllowedCommands != nil && !contains(p.MCP.AllowedCommands, meta.MCP.Command) {
+		if len(p.MCP.AllowedCommands) == 0 {
+			add("mcp.allowed-commands", "MCP servers may not run commands")
+		} else {
+			add("mcp.allowed-commands", "MCP command %q is not allowed (allowed: %s)", meta.MCP.Command, strings.Join(p.MCP.AllowedCommands, ", "))
+		}
+	}
+
+	return violations
+}
+
+// AllowsClient reports whether artifacts of type t may be installed to the client
+func (p *Policy) AllowsClient(clientID string, t artifact.Type) bool {
+	allowed, ok := p.Clients[clientID]
+	if !ok {
+		return true
+	}
+	for _, a := range allowed {
+		if a.Key == t.Key {
+			return true
+		}
+	}
+	return false
+}
+
+// Set is the combination of several policies, such as one per configured repository
+// An artifact must satisfy every policy in the set
+type Set []*Policy
+
+// IsEmpty returns true if the set has no policies
+func (s Set) IsEmpty() bool {
+	return len(s) == 0
+}
+
+// Sources returns where the set's policies came from
+func (s Set) Sources() []string {
+	sources := make([]string, 0, len(s))
+	for _, p := range s {
+		sources = append(sources, p.Source)
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// CheckLockFile checks a lock file against every policy
+func (s Set) CheckLockFile(lf *lockfile.LockFile) Violations {
+	var violations Violations
+	for _, p := range s {
+		violations = append(violations, p.CheckLockFile(lf)...)
+	}
+	return violations
+}
+
+// CheckArtifact checks a lock file entry against every policy
+func (s Set) CheckArtifact(art *lockfile.Artifact) Violations {
+	var violations Violations
+	for _, p := range s {
+		violations = append(violations, p.CheckArtifact(art)...)
+	}
+	return violations
+}
+
+// CheckMetadata checks an artifact's metadata against every policy
+func (s Set) CheckMetadata(art *lockfile.Artifact, meta *metadata.Metadata) Violations {
+	var violations Violations
+	for _, p := range s {
+		violations = append(violations, p.CheckMetadata(art, meta)...)
+	}
+	return violations
+}
+
+// AllowsClient reports whether every policy allows artifacts of type t on the client
+func (s Set) AllowsClient(clientID string, t artifact.Type) bool {
+	for _, p := range s {
+		if !p.AllowsClient(clientID, t) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasHashes reports whether an artifact's source pins its content
+func hasHashes(art *lockfile.Artifact) bool {
+	switch {
+	case art.SourceHTTP != nil:
+		return len(art.SourceHTTP.Hashes) > 0
+	case art.SourcePath != nil:
+		return len(art.SourcePath.Hashes) > 0
+	case art.SourceGit != nil:
+		return len(art.SourceGit.Hashes) > 0
+	case art.SourceOCI != nil:
+		return art.SourceOCI.Digest != ""
+	}
+	return false
+}
+
+// contains reports whether values contains value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/constants"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/repository"
+)
+
+const testPolicy = `
+[sources]
+deny = ["path"]
+require-hashes = true
+
+[hooks]
+allowed-events = ["SessionStart"]
+
+[mcp]
+allowed-commands = ["npx"]
+
+[clients]
+cursor = ["skill", "mcp"]
+`
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if p.Hooks.AllowedEvents == nil || p.MCP.AllowedCommands == nil {
+		t.Error("Expected allowlists to be set")
+	}
+
+	empty, err := Parse([]byte("[hooks]\nallowed-events = []\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if empty.Hooks.AllowedEvents == nil || empty.MCP.AllowedCommands != nil {
+		t.Error("Expected an empty list to be distinguished from an unset one")
+	}
+
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr string
+	}{
+		{"unknown source", "[sources]\ndeny = [\"ftp\"]\n", "unknown source type"},
+		{"unknown artifact type", "[clients]\ncursor = [\"plugin\"]\n", "unknown artifact type"},
+		{"invalid toml", "[sources\n", "failed to parse policy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.policy))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckArtifact(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	hashes := map[string]string{"sha256": "abc"}
+	tests := []struct {
+		name      string
+		artifact  lockfile.Artifact
+		wantRules []string
+	}{
+		{
+			name:     "http with hashes",
+			artifact: lockfile.Artifact{Type: artifact.TypeSkill, SourceHTTP: &lockfile.SourceHTTP{URL: "https://x", Hashes: hashes}},
+		},
+		{
+			name:      "denied path source",
+			artifact:  lockfile.Artifact{Type: artifact.TypeSkill, SourcePath: &lockfile.SourcePath{Path: "./a", Hashes: hashes}},
+			wantRules: []string{"sources.deny"},
+		},
+		{
+			name:      "git without hashes",
+			artifact:  lockfile.Artifact{Type: artifact.TypeSkill, SourceGit: &lockfile.SourceGit{URL: "https://x", Ref: "main"}},
+			wantRules: []string{"sources.require-hashes"},
+		},
+		{
+			name:     "oci digest counts as hash",
+			artifact: lockfile.Artifact{Type: artifact.TypeSkill, SourceOCI: &lockfile.SourceOCI{Reference: "r", Digest: "sha256:abc"}},
+		},
+		{
+			name:      "type not allowed for client",
+			artifact:  lockfile.Artifact{Type: artifact.TypeHook, Clients: []string{"claude-code", "cursor"}, SourceGit: &lockfile.SourceGit{Hashes: hashes}},
+			wantRules: []string{"clients.cursor"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.artifact.Name, tt.artifact.Version = "a", "1.0.0"
+			violations := p.CheckArtifact(&tt.artifact)
+			if len(violations) != len(tt.wantRules) {
+				t.Fatalf("CheckArtifact() = %v, want rules %v", violations, tt.wantRules)
+			}
+			for i, v := range violations {
+				if v.Rule != tt.wantRules[i] {
+					t.Errorf("violation %d rule = %s, want %s", i, v.Rule, tt.wantRules[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckMetadata(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	noHooks, err := Parse([]byte("[hooks]\nallowed-events = []\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	art := &lockfile.Artifact{Name: "a", Version: "1.0.0"}
+	tests := []struct {
+		name    string
+		policy  *Policy
+		meta    metadata.Metadata
+		wantErr string
+	}{
+		{"allowed hook", p, metadata.Metadata{Hook: &metadata.HookConfig{Event: "SessionStart"}}, ""},
+		{"disallowed hook", p, metadata.Metadata{Hook: &metadata.HookConfig{Event: "PreToolUse"}}, `hook event "PreToolUse" is not allowed`},
+		{"hooks forbidden", noHooks, metadata.Metadata{Hook: &metadata.HookConfig{Event: "SessionStart"}}, "hooks are not allowed"},
+		{"allowed mcp command", p, metadata.Metadata{MCP: &metadata.MCPConfig{Command: "npx"}}, ""},
+		{"disallowed mcp command", p, metadata.Metadata{MCP: &metadata.MCPConfig{Command: "bash"}}, `MCP command "bash" is not allowed`},
+		{"unrestricted mcp", noHooks, metadata.Metadata{MCP: &metadata.MCPConfig{Command: "bash"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.CheckMetadata(art, &tt.meta).Err()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckMetadata() = %v, want no violations", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckMetadata() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSet(t *testing.T) {
+	a, _ := Parse([]byte("[clients]\ncursor = [\"skill\"]\n"))
+	b, _ := Parse([]byte("[sources]\ndeny = [\"git\"]\n"))
+	a.Source, b.Source = "repository a", "repository b"
+	set := Set{a, b}
+
+	if set.AllowsClient("cursor", artifact.TypeMCP) || !set.AllowsClient("claude-code", artifact.TypeMCP) {
+		t.Error("Expected every policy's client restrictions to apply")
+	}
+
+	lf := &lockfile.LockFile{Artifacts: []lockfile.Artifact{
+		{Name: "a", Version: "1.0.0", Type: artifact.TypeMCP, Clients: []string{"cursor"}, SourceGit: &lockfile.SourceGit{}},
+	}}
+	err := set.CheckLockFile(lf).Err()
+	var violationErr *ViolationError
+	if !errors.As(err, &violationErr) || len(violationErr.Violations) != 2 {
+		t.Fatalf("Expected a violation from each policy, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "policy from repository b") {
+		t.Errorf("Expected violations to name their policy, got %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	withPolicy := t.TempDir()
+	if err := os.WriteFile(filepath.Join(withPolicy, constants.SkillPolicyFile), []byte(testPolicy), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+	withoutPolicy := t.TempDir()
+
+	repoA, err := repository.NewPathRepository(withPolicy)
+	if err != nil {
+		t.Fatalf("NewPathRepository failed: %v", err)
+	}
+	repoB, err := repository.NewPathRepository(withoutPolicy)
+	if err != nil {
+		t.Fatalf("NewPathRepository failed: %v", err)
+	}
+
+	multi, err := repository.NewMultiRepository([]repository.NamedRepository{
+		{Name: "team", Repository: repoA},
+		{Name: "personal", Repository: repoB},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewMultiRepository failed: %v", err)
+	}
+
+	set, err := Load(context.Background(), multi)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(set) != 1 || set[0].Source != "repository team" {
+		t.Fatalf("Expected only the team repository's policy, got %v", set.Sources())
+	}
+
+	if err := os.WriteFile(filepath.Join(withoutPolicy, constants.SkillPolicyFile), []byte("[sources\n"), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+	if _, err := Load(context.Background(), multi); err == nil || !strings.Contains(err.Error(), "repository personal") {
+		t.Errorf("Expected an invalid policy to fail loading, got %v", err)
+	}
+}
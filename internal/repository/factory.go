@@ -13,6 +13,12 @@ type Config interface {
 	GetRepositoryURL() string
 }
 
+// UsagePublishingConfig is implemented by configurations that can opt a git
+// repository into publishing usage stats
+type UsagePublishingConfig interface {
+	GetPublishUsage() bool
+}
+
 // NewFromConfig creates a repository instance from configuration
 // This factory function eliminates repetitive switch statements across commands
 func NewFromConfig(cfg Config) (Repository, error) {
@@ -20,9 +26,20 @@ func NewFromConfig(cfg Config) (Repository, error) {
 	case "sleuth":
 		return NewSleuthRepository(cfg.GetServerURL(), cfg.GetAuthToken()), nil
 	case "git":
-		return NewGitRepository(cfg.GetRepositoryURL())
+		repo, err := NewGitRepository(cfg.GetRepositoryURL())
+		if err != nil {
+			return nil, err
+		}
+		if usageCfg, ok := cfg.(UsagePublishingConfig); ok {
+			repo.publishUsage = usageCfg.GetPublishUsage()
+		}
+		return repo, nil
 	case "path":
 		return NewPathRepository(cfg.GetRepositoryURL())
+	case "oci":
+		return NewOCIRepository(cfg.GetRepositoryURL(), cfg.GetAuthToken())
+	case "s3":
+		return NewS3Repository(cfg.GetRepositoryURL(), cfg.GetAuthToken())
 	default:
 		return nil, fmt.Errorf("unsupported repository type: %s", cfg.GetType())
 	}
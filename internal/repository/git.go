@@ -25,12 +25,25 @@ func NewGitSourceHandler(gitClient *git.Client) *GitSourceHandler {
 	}
 }
 
-// Fetch clones/fetches a git repository and retrieves the artifact
+// Fetch clones/fetches a git repository and retrieves the artifact, checking it
+// against the content hashes it was locked with
 func (g *GitSourceHandler) Fetch(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
 	if artifact.SourceGit == nil {
 		return nil, fmt.Errorf("artifact does not have source-git")
 	}
 
+	data, err := g.fetchArtifact(ctx, artifact)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyContentHashes(data, artifact.SourceGit.Hashes); err != nil {
+		return nil, fmt.Errorf("%s@%s: %w", artifact.SourceGit.URL, artifact.SourceGit.Ref, err)
+	}
+	return data, nil
+}
+
+// fetchArtifact checks out the locked commit and reads the artifact's zip, or zips its directory
+func (g *GitSourceHandler) fetchArtifact(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
 	source := artifact.SourceGit
 
 	// Get cache path for this repository
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/cache"
+	"github.com/sleuth-io/skills/internal/git"
+	"github.com/sleuth-io/skills/internal/lockfile"
+)
+
+func TestGitSourceHandlerVerifiesHashes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+	ctx := context.Background()
+
+	repoDir := t.TempDir()
+	writePathRepoArtifact(t, repoDir, "db-server", "1.0.0")
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"add", "."},
+		{"-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "--quiet", "-m", "Add db-server"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+	sha, err := exec.Command("git", "-C", repoDir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("Failed to resolve HEAD: %v", err)
+	}
+
+	subdirectory := "artifacts/db-server/1.0.0"
+	contentHash, err := hashArtifactDir(filepath.Join(repoDir, filepath.FromSlash(subdirectory)))
+	if err != nil {
+		t.Fatalf("hashArtifactDir failed: %v", err)
+	}
+	artifact := &lockfile.Artifact{
+		Name: "db-server",
+		SourceGit: &lockfile.SourceGit{
+			URL:          repoDir,
+			Ref:          strings.TrimSpace(string(sha)),
+			Subdirectory: subdirectory,
+			Hashes:       map[string]string{"sha256": contentHash},
+		},
+	}
+
+	handler := NewGitSourceHandler(git.NewClient())
+	if _, err := handler.Fetch(ctx, artifact); err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+
+	// A cached checkout changed since it was locked isn't installed
+	repoCache, err := cache.GetGitRepoCachePath(repoDir)
+	if err != nil {
+		t.Fatalf("GetGitRepoCachePath failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoCache, filepath.FromSlash(subdirectory), "server.js"), []byte("// tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := handler.Fetch(ctx, artifact); err == nil || !strings.Contains(err.Error(), "content hash mismatch") {
+		t.Errorf("Expected a content hash mismatch, got %v", err)
+	}
+}
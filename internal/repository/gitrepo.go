@@ -1,12 +1,10 @@
 package repository
 
 import (
-	"archive/zip"
 	"bytes"
 	"context"
 	_ "embed"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -47,6 +45,10 @@ type GitRepository struct {
 	httpHandler *HTTPSourceHandler
 	pathHandler *PathSourceHandler
 	gitHandler  *GitSourceHandler
+	ociHandler  *OCISourceHandler
+
+	// publishUsage commits posted usage stats to the usage branch (opt-in)
+	publishUsage bool
 }
 
 // NewGitRepository creates a new Git repository
@@ -67,6 +69,7 @@ func NewGitRepository(repoURL string) (*GitRepository, error) {
 		httpHandler: NewHTTPSourceHandler(""),       // No auth token for git repos
 		pathHandler: NewPathSourceHandler(repoPath), // Use repo path for relative paths
 		gitHandler:  NewGitSourceHandler(gitClient),
+		ociHandler:  NewOCISourceHandler("", ""), // Registries are accessed anonymously
 	}, nil
 }
 
@@ -138,6 +141,24 @@ func (g *GitRepository) GetLockFile(ctx context.Context, cachedETag string) (con
 	return data, "", false, nil
 }
 
+// GetPolicy reads skill-policy.toml from the repository root
+// The clone is only created here if needed; GetLockFile already pulls before policies are checked
+func (g *GitRepository) GetPolicy(ctx context.Context) ([]byte, error) {
+	fileLock, err := g.acquireFileLock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	if !utils.IsDirectory(filepath.Join(g.repoPath, ".git")) {
+		if err := g.cloneOrUpdate(ctx); err != nil {
+			return nil, fmt.Errorf("failed to clone/update repository: %w", err)
+		}
+	}
+
+	return readPolicyFile(filepath.Join(g.repoPath, constants.SkillPolicyFile))
+}
+
 // GetArtifact downloads an artifact using its source configuration
 func (g *GitRepository) GetArtifact(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
 	// Lock only for path-based artifacts that read from the repository
@@ -157,6 +178,8 @@ func (g *GitRepository) GetArtifact(ctx context.Context, artifact *lockfile.Arti
 		return g.pathHandler.Fetch(ctx, artifact)
 	case "git":
 		return g.gitHandler.Fetch(ctx, artifact)
+	case "oci":
+		return g.ociHandler.Fetch(ctx, artifact)
 	default:
 		return nil, fmt.Errorf("unsupported source type: %s", artifact.GetSourceType())
 	}
@@ -184,7 +207,7 @@ func (g *GitRepository) AddArtifact(ctx context.Context, artifact *lockfile.Arti
 
 	// For Git repositories, store artifacts exploded (not as zip)
 	// This makes them easier to browse and diff in Git
-	if err := extractZipToDir(zipData, artifactDir); err != nil {
+	if err := metadata.ExtractZip(zipData, artifactDir); err != nil {
 		return fmt.Errorf("failed to extract zip to directory: %w", err)
 	}
 
@@ -262,18 +285,71 @@ func (g *GitRepository) GetArtifactByVersion(ctx context.Context, name, version
 }
 
 // GetMetadata retrieves metadata for a specific artifact version
-// Not applicable for Git repositories (metadata is inside the zip)
+// Reads metadata.toml from the exploded artifact directory in the cached clone
 func (g *GitRepository) GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error) {
-	return nil, fmt.Errorf("GetMetadata not supported for Git repositories")
+	// Only clone if needed; GetVersionList already pulls before versions are chosen
+	if !utils.IsDirectory(filepath.Join(g.repoPath, ".git")) {
+		if err := g.cloneOrUpdate(ctx); err != nil {
+			return nil, fmt.Errorf("failed to clone/update repository: %w", err)
+		}
+	}
+
+	artifactDir := filepath.Join(g.repoPath, "artifacts", name, version)
+	if _, err := os.Stat(artifactDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("artifact %s@%s not found", name, version)
+	}
+
+	return metadata.ParseFile(filepath.Join(artifactDir, "metadata.toml"))
 }
 
-// VerifyIntegrity checks hashes and sizes for downloaded artifacts
-func (g *GitRepository) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
-	// For Git repos, integrity is verified by Git's commit history
-	// No additional verification needed
+// ResolveSource sets source-git for a published artifact version
+// The ref is pinned to the current commit of the repository and the subdirectory
+// points at the exploded artifact, so the entry can be fetched without this repository configured
+// The signature is copied from the repository's lock file
+func (g *GitRepository) ResolveSource(ctx context.Context, artifact *lockfile.Artifact) error {
+	fileLock, err := g.acquireFileLock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	if !utils.IsDirectory(filepath.Join(g.repoPath, ".git")) {
+		if err := g.cloneOrUpdate(ctx); err != nil {
+			return fmt.Errorf("failed to clone/update repository: %w", err)
+		}
+	}
+
+	subdirectory := fmt.Sprintf("artifacts/%s/%s", artifact.Name, artifact.Version)
+	contentHash, err := hashArtifactDir(filepath.Join(g.repoPath, filepath.FromSlash(subdirectory)))
+	if err != nil {
+		return fmt.Errorf("failed to hash %s@%s: %w", artifact.Name, artifact.Version, err)
+	}
+
+	commitSHA, err := g.gitClient.RevParse(ctx, g.repoPath, "HEAD")
+	if err != nil {
+		return fmt.Errorf("failed to resolve repository commit: %w", err)
+	}
+
+	artifact.SourceGit = &lockfile.SourceGit{
+		URL:          g.repoURL,
+		Ref:          commitSHA,
+		Subdirectory: subdirectory,
+		Hashes:       map[string]string{"sha256": contentHash},
+	}
+
+	// The file lock is already held, so read the lock file directly rather than through GetLockFile
+	if data, err := os.ReadFile(g.GetLockFilePath()); err == nil {
+		artifact.Signature = signatureFromLockFile(data, artifact.Name, artifact.Version)
+	}
 	return nil
 }
 
+// VerifyIntegrity checks an artifact against the content hashes of its source-git
+// The commit pins the history, but not a checkout changed since it was locked
+func (g *GitRepository) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
+	return verifyContentHashes(data, hashes)
+}
+
 // cloneOrUpdate clones the repository if it doesn't exist, or pulls updates if it does
 func (g *GitRepository) cloneOrUpdate(ctx context.Context) error {
 	if _, err := os.Stat(filepath.Join(g.repoPath, ".git")); os.IsNotExist(err) {
@@ -531,74 +607,6 @@ func (g *GitRepository) commitAndPush(ctx context.Context, artifact *lockfile.Ar
 	return nil
 }
 
-// extractZipToDir extracts a zip file to a directory
-func extractZipToDir(zipData []byte, targetDir string) error {
-	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
-	if err != nil {
-		return fmt.Errorf("failed to read zip: %w", err)
-	}
-
-	for _, file := range reader.File {
-		// Build target path
-		targetPath := filepath.Join(targetDir, file.Name)
-
-		// Prevent zip slip vulnerability
-		cleanTarget := filepath.Clean(targetPath)
-		cleanDir := filepath.Clean(targetDir)
-		relPath, err := filepath.Rel(cleanDir, cleanTarget)
-		if err != nil || strings.HasPrefix(relPath, "..") {
-			return fmt.Errorf("illegal file path: %s", file.Name)
-		}
-
-		if file.FileInfo().IsDir() {
-			if err := os.MkdirAll(targetPath, file.Mode()); err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", file.Name, err)
-			}
-			continue
-		}
-
-		// Ensure parent directory exists with proper permissions
-		parentDir := filepath.Dir(targetPath)
-		if err := os.MkdirAll(parentDir, 0755); err != nil {
-			return fmt.Errorf("failed to create parent directory for %s: %w", file.Name, err)
-		}
-		// Fix permissions on parent directory if it already existed
-		if err := os.Chmod(parentDir, 0755); err != nil {
-			return fmt.Errorf("failed to set permissions on parent directory for %s: %w", file.Name, err)
-		}
-
-		// Extract file
-		rc, err := file.Open()
-		if err != nil {
-			return fmt.Errorf("failed to open file %s in zip: %w", file.Name, err)
-		}
-
-		// Use 0644 for files instead of preserving zip permissions
-		// Zip files may have restrictive permissions that cause issues
-		fileMode := os.FileMode(0644)
-		if file.Mode()&0111 != 0 {
-			// If executable bit is set, use 0755
-			fileMode = 0755
-		}
-
-		outFile, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileMode)
-		if err != nil {
-			rc.Close()
-			return fmt.Errorf("failed to create file %s: %w", file.Name, err)
-		}
-
-		_, err = io.Copy(outFile, rc)
-		rc.Close()
-		outFile.Close()
-
-		if err != nil {
-			return fmt.Errorf("failed to write file %s: %w", file.Name, err)
-		}
-	}
-
-	return nil
-}
-
 // updateVersionList updates the list.txt file with a new version
 func (g *GitRepository) updateVersionList(listPath, newVersion string) error {
 	var versions []string
@@ -632,9 +640,3 @@ func (g *GitRepository) updateVersionList(listPath, newVersion string) error {
 
 	return os.WriteFile(listPath, buf.Bytes(), 0644)
 }
-
-// PostUsageStats is a no-op for Git repositories
-// Git repositories don't support stats collection
-func (r *GitRepository) PostUsageStats(ctx context.Context, jsonlData string) error {
-	return nil
-}
@@ -0,0 +1,258 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sleuth-io/skills/internal/config"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+const (
+	// UsageBranch is the branch usage stats are committed to, kept apart from the artifacts
+	UsageBranch = "skills-usage"
+
+	// usageDir holds the shards, as usage/<yyyy-mm>/<user-hash>.jsonl
+	usageDir = "usage"
+
+	// usagePushAttempts is how many times a rejected push is rebased and retried
+	usagePushAttempts = 3
+
+	// usageAuthorName authors usage commits, so they don't name the user their shards hide
+	usageAuthorName = "skills"
+)
+
+// usageAuthorEmail is the email usage commits are authored with, which doesn't need
+// the user to have git's user.email set
+func usageAuthorEmail(userHash string) string {
+	return userHash + "@users.noreply.invalid"
+}
+
+// usageRepoPath returns the clone used for the usage branch, next to the artifacts clone
+func (g *GitRepository) usageRepoPath() string {
+	return g.repoPath + "-usage"
+}
+
+// PostUsageStats commits usage events to the user's shards on the usage branch and pushes them
+// This is a no-op unless publishing usage is enabled for the repository
+func (g *GitRepository) PostUsageStats(ctx context.Context, jsonlData string) error {
+	if !g.publishUsage || strings.TrimSpace(jsonlData) == "" {
+		return nil
+	}
+
+	fileLock, err := g.acquireFileLock(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	if _, err := g.syncUsageClone(ctx); err != nil {
+		return err
+	}
+
+	userHash, err := g.usageUserHash(ctx)
+	if err != nil {
+		return err
+	}
+	shards := groupUsageByMonth(jsonlData, time.Now())
+	if err := g.commitUsageShards(ctx, userHash, shards); err != nil {
+		return err
+	}
+	return g.pushUsageShards(ctx, userHash, shards)
+}
+
+// pushUsageShards pushes the committed shards, rebasing onto the remote branch when
+// the push is rejected
+func (g *GitRepository) pushUsageShards(ctx context.Context, userHash string, shards map[string][]string) error {
+	for attempt := 1; ; attempt++ {
+		err := g.gitClient.PushBranch(ctx, g.usageRepoPath(), UsageBranch)
+		if err == nil {
+			return nil
+		}
+		if attempt == usagePushAttempts {
+			return fmt.Errorf("failed to push usage stats: %w", err)
+		}
+
+		// Someone else pushed first; replay our commit on top of theirs
+		if rebaseErr := g.gitClient.PullRebaseAs(ctx, g.usageRepoPath(), UsageBranch, usageAuthorName, usageAuthorEmail(userHash)); rebaseErr != nil {
+			// Our own shard changed remotely (the same user on another machine), so start
+			// again from the remote branch
+			_ = g.gitClient.RebaseAbort(ctx, g.usageRepoPath())
+			if _, err := g.syncUsageClone(ctx); err != nil {
+				return err
+			}
+			if err := g.commitUsageShards(ctx, userHash, shards); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetUsageStats returns the events in every contributor's shards on the usage branch
+func (g *GitRepository) GetUsageStats(ctx context.Context) ([]byte, error) {
+	fileLock, err := g.acquireFileLock(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock: %w", err)
+	}
+	defer func() { _ = fileLock.Unlock() }()
+
+	exists, err := g.syncUsageClone(ctx)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	root := filepath.Join(g.usageRepoPath(), usageDir)
+	var shards []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() && strings.HasSuffix(path, ".jsonl") {
+			shards = append(shards, path)
+		}
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to list usage shards: %w", err)
+	}
+	sort.Strings(shards)
+
+	var buf bytes.Buffer
+	for _, shard := range shards {
+		data, err := os.ReadFile(shard)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read usage shard: %w", err)
+		}
+		buf.Write(data)
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// syncUsageClone creates the usage clone if needed and resets it to the remote usage branch,
+// discarding anything that was committed but never pushed
+// Returns false if the remote has no usage branch yet
+func (g *GitRepository) syncUsageClone(ctx context.Context) (bool, error) {
+	path := g.usageRepoPath()
+	if !utils.IsDirectory(filepath.Join(path, ".git")) {
+		if err := g.gitClient.InitWithRemote(ctx, path, g.repoURL, UsageBranch); err != nil {
+			return false, fmt.Errorf("failed to create usage clone: %w", err)
+		}
+	}
+
+	exists, err := g.gitClient.FetchBranch(ctx, path, UsageBranch)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch usage branch: %w", err)
+	}
+	if !exists {
+		// Start the branch afresh, dropping any commit whose push failed
+		if err := os.RemoveAll(path); err != nil {
+			return false, fmt.Errorf("failed to reset usage clone: %w", err)
+		}
+		if err := g.gitClient.InitWithRemote(ctx, path, g.repoURL, UsageBranch); err != nil {
+			return false, fmt.Errorf("failed to create usage clone: %w", err)
+		}
+		return false, nil
+	}
+
+	if err := g.gitClient.ResetHard(ctx, path, "origin/"+UsageBranch); err != nil {
+		return false, fmt.Errorf("failed to reset usage branch: %w", err)
+	}
+	return true, nil
+}
+
+// commitUsageShards appends events to the user's shard for each month and commits them
+func (g *GitRepository) commitUsageShards(ctx context.Context, userHash string, shards map[string][]string) error {
+	path := g.usageRepoPath()
+
+	months := make([]string, 0, len(shards))
+	for month := range shards {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+
+	var changed []string
+	for _, month := range months {
+		rel := filepath.Join(usageDir, month, userHash+".jsonl")
+		shardPath := filepath.Join(path, rel)
+		if err := os.MkdirAll(filepath.Dir(shardPath), 0755); err != nil {
+			return fmt.Errorf("failed to create usage directory: %w", err)
+		}
+
+		f, err := os.OpenFile(shardPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open usage shard: %w", err)
+		}
+		_, err = f.WriteString(strings.Join(shards[month], "\n") + "\n")
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to write usage shard: %w", err)
+		}
+		changed = append(changed, filepath.ToSlash(rel))
+	}
+
+	if err := g.gitClient.Add(ctx, path, changed...); err != nil {
+		return err
+	}
+	return g.gitClient.CommitAs(ctx, path, fmt.Sprintf("Record usage from %s", userHash), usageAuthorName, usageAuthorEmail(userHash))
+}
+
+// usageUserHash identifies the user's shards without recording who they are
+// The identity is keyed with the usage hash key, so it can't be matched against
+// guessed emails, and the same user maps to the same shards wherever the key is shared
+func (g *GitRepository) usageUserHash(ctx context.Context) (string, error) {
+	identity, _ := g.gitClient.GetConfig(ctx, g.usageRepoPath(), "user.email")
+	if identity == "" {
+		hostname, _ := os.Hostname()
+		username := ""
+		if u, err := user.Current(); err == nil {
+			username = u.Username
+		}
+		identity = username + "@" + hostname
+	}
+
+	// Config not initialized means the install's own key
+	cfg, _ := config.Load()
+	key, err := cfg.GetUsage().GetHashKey()
+	if err != nil {
+		return "", err
+	}
+	return utils.ComputeHMACSHA256(key, []byte(strings.ToLower(strings.TrimSpace(identity))))[:16], nil
+}
+
+// groupUsageByMonth splits JSONL events by the month (yyyy-mm) of their timestamp
+// Events without a readable timestamp go in the current month
+func groupUsageByMonth(jsonlData string, now time.Time) map[string][]string {
+	shards := make(map[string][]string)
+	for _, line := range strings.Split(jsonlData, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		month := now.UTC().Format("2006-01")
+		var event struct {
+			Timestamp string `json:"timestamp"`
+		}
+		if err := json.Unmarshal([]byte(line), &event); err == nil {
+			if t, err := time.Parse(time.RFC3339, event.Timestamp); err == nil {
+				month = t.UTC().Format("2006-01")
+			}
+		}
+		shards[month] = append(shards[month], line)
+	}
+	return shards
+}
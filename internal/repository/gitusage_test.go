@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// usageTestUser is a contributor with their own cache and git identity
+type usageTestUser struct {
+	dir    string
+	remote string
+}
+
+// newUsageTestUser returns a contributor to a bare remote
+func newUsageTestUser(t *testing.T, remote, email string) *usageTestUser {
+	t.Helper()
+	u := &usageTestUser{dir: t.TempDir(), remote: remote}
+	if err := os.WriteFile(filepath.Join(u.dir, "gitconfig"), []byte("[user]\n\tname = Test\n\temail = "+email+"\n"), 0644); err != nil {
+		t.Fatalf("Failed to write git config: %v", err)
+	}
+	return u
+}
+
+// as makes git and the cache act as this contributor and returns their repository
+func (u *usageTestUser) as(t *testing.T) *GitRepository {
+	t.Helper()
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(u.dir, "gitconfig"))
+	t.Setenv("SKILLS_CACHE_DIR", filepath.Join(u.dir, "cache"))
+	t.Setenv("SKILLS_CONFIG_DIR", filepath.Join(u.dir, "config"))
+
+	repo, err := NewGitRepository(u.remote)
+	if err != nil {
+		t.Fatalf("NewGitRepository failed: %v", err)
+	}
+	repo.publishUsage = true
+	return repo
+}
+
+func TestGitUsageShards(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	ctx := context.Background()
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	if output, err := exec.Command("git", "init", "--quiet", "--bare", remote).CombinedOutput(); err != nil {
+		t.Fatalf("Failed to create remote: %v\n%s", err, output)
+	}
+
+	alice := newUsageTestUser(t, remote, "alice@example.com")
+	bob := newUsageTestUser(t, remote, "bob@example.com")
+
+	// Publishing is opt-in
+	repo := bob.as(t)
+	repo.publishUsage = false
+	if err := repo.PostUsageStats(ctx, `{"artifact_name":"review","timestamp":"2026-03-01T09:00:00Z"}`); err != nil {
+		t.Fatalf("PostUsageStats failed: %v", err)
+	}
+	if data, err := alice.as(t).GetUsageStats(ctx); err != nil || len(data) != 0 {
+		t.Fatalf("Expected no usage branch before publishing, got %q, %v", data, err)
+	}
+
+	// Bob's clone falls behind while Alice creates the branch, so his push is rejected and rebased
+	repo = bob.as(t)
+	if _, err := repo.syncUsageClone(ctx); err != nil {
+		t.Fatalf("syncUsageClone failed: %v", err)
+	}
+	bobHash, err := repo.usageUserHash(ctx)
+	if err != nil {
+		t.Fatalf("usageUserHash failed: %v", err)
+	}
+
+	repo = alice.as(t)
+	aliceHash, err := repo.usageUserHash(ctx)
+	if err != nil {
+		t.Fatalf("usageUserHash failed: %v", err)
+	}
+	if err := repo.PostUsageStats(ctx, `{"artifact_name":"review","timestamp":"2026-03-01T09:00:00Z"}`+"\n"+`{"artifact_name":"lint","timestamp":"2026-04-02T10:00:00Z"}`); err != nil {
+		t.Fatalf("PostUsageStats failed: %v", err)
+	}
+
+	repo = bob.as(t)
+	shards := groupUsageByMonth(`{"artifact_name":"review","timestamp":"2026-03-05T09:00:00Z"}`, time.Now())
+	if err := repo.commitUsageShards(ctx, bobHash, shards); err != nil {
+		t.Fatalf("commitUsageShards failed: %v", err)
+	}
+	if err := repo.pushUsageShards(ctx, bobHash, shards); err != nil {
+		t.Fatalf("pushUsageShards failed: %v", err)
+	}
+
+	data, err := alice.as(t).GetUsageStats(ctx)
+	if err != nil {
+		t.Fatalf("GetUsageStats failed: %v", err)
+	}
+	if got := strings.Count(string(data), "\n"); got != 3 {
+		t.Errorf("Expected 3 events from both contributors, got %d:\n%s", got, data)
+	}
+
+	files, err := exec.Command("git", "--git-dir", remote, "ls-tree", "-r", "--name-only", UsageBranch).Output()
+	if err != nil {
+		t.Fatalf("Failed to list usage branch: %v", err)
+	}
+	want := []string{
+		"usage/2026-03/" + aliceHash + ".jsonl",
+		"usage/2026-03/" + bobHash + ".jsonl",
+		"usage/2026-04/" + aliceHash + ".jsonl",
+	}
+	if aliceHash == bobHash || sortedLines(strings.Join(want, "\n")) != sortedLines(string(files)) {
+		t.Errorf("Expected shards %v on the usage branch, got:\n%s", want, files)
+	}
+
+	// Commits name the shard's hash, never the contributor's own git identity
+	authors, err := exec.Command("git", "--git-dir", remote, "log", "--format=%an <%ae> %cn <%ce>", UsageBranch).Output()
+	if err != nil {
+		t.Fatalf("Failed to list usage commits: %v", err)
+	}
+	wantAuthors := []string{aliceHash, bobHash}
+	for i, hash := range wantAuthors {
+		email := usageAuthorEmail(hash)
+		wantAuthors[i] = "skills <" + email + "> skills <" + email + ">"
+	}
+	if sortedLines(strings.Join(wantAuthors, "\n")) != sortedLines(string(authors)) {
+		t.Errorf("Expected usage commits by %v, got:\n%s", wantAuthors, authors)
+	}
+}
+
+// sortedLines sorts the lines of s
+func sortedLines(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	sort.Strings(lines)
+	return strings.Join(lines, "\n") + "\n"
+}
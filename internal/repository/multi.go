@@ -0,0 +1,472 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/sleuth-io/skills/internal/cache"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// NamedConfig is the configuration of one repository in a multi-repository setup
+type NamedConfig interface {
+	Config
+	GetName() string
+	GetPriority() int
+}
+
+// NamedRepository is a repository configured under a name and priority
+type NamedRepository struct {
+	Name     string
+	Priority int
+
+	// CacheKey identifies the repository's lock file and ETag in the cache
+	// If empty, the lock file is not cached
+	CacheKey string
+
+	Repository
+}
+
+// MultiRepository combines several named repositories into a single view
+//
+// Lock files are merged with these rules:
+//   - an artifact pinned to a repository only comes from that repository
+//   - otherwise the highest-priority repository providing the artifact wins
+//   - repositories with equal priority are ordered by name
+//
+// Operations on an artifact are routed to the repository that provided it.
+type MultiRepository struct {
+	repos []NamedRepository
+	pins  map[string]string
+
+	mu      sync.Mutex
+	origins map[string]string // artifact name -> repository name
+}
+
+// NewMultiRepository creates a repository over the given named repositories
+// pins maps artifact names to the name of the repository they must come from
+func NewMultiRepository(repos []NamedRepository, pins map[string]string) (*MultiRepository, error) {
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repositories configured")
+	}
+
+	sorted := make([]NamedRepository, len(repos))
+	copy(sorted, repos)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	names := make(map[string]bool)
+	for _, repo := range sorted {
+		if names[repo.Name] {
+			return nil, fmt.Errorf("duplicate repository name: %s", repo.Name)
+		}
+		names[repo.Name] = true
+	}
+
+	m := &MultiRepository{
+		repos:   sorted,
+		pins:    make(map[string]string),
+		origins: make(map[string]string),
+	}
+	for artifactName, repoName := range pins {
+		if err := m.Pin(artifactName, repoName); err != nil {
+			return nil, err
+		}
+	}
+
+	return m, nil
+}
+
+// NewFromConfigs creates a multi-repository from a list of named repository configurations
+func NewFromConfigs[T NamedConfig](cfgs []T, pins map[string]string) (*MultiRepository, error) {
+	var repos []NamedRepository
+	for _, cfg := range cfgs {
+		repo, err := NewFromConfig(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("repository %s: %w", cfg.GetName(), err)
+		}
+
+		repoURL := cfg.GetRepositoryURL()
+		if repoURL == "" {
+			repoURL = cfg.GetServerURL()
+		}
+
+		repos = append(repos, NamedRepository{
+			Name:       cfg.GetName(),
+			Priority:   cfg.GetPriority(),
+			CacheKey:   cache.RepositoryCacheKey(cfg.GetName(), repoURL),
+			Repository: repo,
+		})
+	}
+
+	return NewMultiRepository(repos, pins)
+}
+
+// Pin requires an artifact to come from the named repository
+func (m *MultiRepository) Pin(artifactName, repoName string) error {
+	if m.Repository(repoName) == nil {
+		return fmt.Errorf("artifact %s is pinned to unknown repository %s", artifactName, repoName)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.pins[artifactName]; ok && existing != repoName {
+		return fmt.Errorf("artifact %s is pinned to both %s and %s", artifactName, existing, repoName)
+	}
+	m.pins[artifactName] = repoName
+	return nil
+}
+
+// Repository returns the named repository, or nil if it isn't configured
+func (m *MultiRepository) Repository(name string) Repository {
+	for _, repo := range m.repos {
+		if repo.Name == name {
+			return repo.Repository
+		}
+	}
+	return nil
+}
+
+// Repositories returns the named repositories, highest priority first
+func (m *MultiRepository) Repositories() []NamedRepository {
+	return m.repos
+}
+
+// Primary returns the highest-priority repository
+func (m *MultiRepository) Primary() NamedRepository {
+	return m.repos[0]
+}
+
+// Authenticate performs authentication with every repository
+// Returns the token of the primary repository
+func (m *MultiRepository) Authenticate(ctx context.Context) (string, error) {
+	var primaryToken string
+	for i, repo := range m.repos {
+		token, err := repo.Authenticate(ctx)
+		if err != nil {
+			return "", fmt.Errorf("repository %s: %w", repo.Name, err)
+		}
+		if i == 0 {
+			primaryToken = token
+		}
+	}
+	return primaryToken, nil
+}
+
+// GetLockFile fetches every repository's lock file and returns the merged view
+// ETags are cached per repository, so the cachedETag parameter is ignored and
+// notModified is always false
+func (m *MultiRepository) GetLockFile(ctx context.Context, cachedETag string) (content []byte, etag string, notModified bool, err error) {
+	lockFiles := make([]*lockfile.LockFile, len(m.repos))
+	for i, repo := range m.repos {
+		data, err := m.fetchLockFile(ctx, repo)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("repository %s: %w", repo.Name, err)
+		}
+
+		lockFiles[i], err = lockfile.Parse(data)
+		if err != nil {
+			return nil, "", false, fmt.Errorf("repository %s: failed to parse lock file: %w", repo.Name, err)
+		}
+	}
+
+	merged, err := m.merge(lockFiles)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	data, err := lockfile.Marshal(merged)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to marshal merged lock file: %w", err)
+	}
+	return data, "", false, nil
+}
+
+// MergeCachedLockFiles merges the lock files cached by previous fetches without
+// contacting any repository. Repositories with no cached lock file are skipped.
+func (m *MultiRepository) MergeCachedLockFiles() (*lockfile.LockFile, error) {
+	lockFiles := make([]*lockfile.LockFile, len(m.repos))
+	for i, repo := range m.repos {
+		if repo.CacheKey == "" {
+			continue
+		}
+		data, err := cache.LoadLockFile(repo.CacheKey)
+		if err != nil {
+			continue
+		}
+		lockFiles[i], err = lockfile.Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("repository %s: failed to parse cached lock file: %w", repo.Name, err)
+		}
+	}
+	return m.merge(lockFiles)
+}
+
+// fetchLockFile fetches a repository's lock file using its cached ETag
+// Falls back to the cached lock file if the repository can't be reached
+func (m *MultiRepository) fetchLockFile(ctx context.Context, repo NamedRepository) ([]byte, error) {
+	if repo.CacheKey == "" {
+		data, _, _, err := repo.GetLockFile(ctx, "")
+		return data, err
+	}
+
+	cachedETag, _ := cache.LoadETag(repo.CacheKey)
+
+	data, newETag, notModified, err := repo.GetLockFile(ctx, cachedETag)
+	if err != nil {
+		cached, cacheErr := cache.LoadLockFile(repo.CacheKey)
+		if cacheErr != nil {
+			return nil, fmt.Errorf("failed to fetch lock file: %w", err)
+		}
+		return cached, nil
+	}
+
+	if notModified {
+		data, err = cache.LoadLockFile(repo.CacheKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cached lock file: %w", err)
+		}
+		return data, nil
+	}
+
+	// Cache failures only cost a refetch next time
+	if newETag != "" {
+		_ = cache.SaveETag(repo.CacheKey, newETag)
+	}
+	_ = cache.SaveLockFile(repo.CacheKey, data)
+
+	return data, nil
+}
+
+// merge combines lock files (indexed like m.repos, nil entries skipped) and records
+// which repository each artifact came from
+func (m *MultiRepository) merge(lockFiles []*lockfile.LockFile) (*lockfile.LockFile, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	merged := &lockfile.LockFile{}
+	origins := make(map[string]string)
+	var versions []string
+
+	for i, lf := range lockFiles {
+		if lf == nil {
+			continue
+		}
+		repo := m.repos[i]
+
+		if merged.LockVersion == "" {
+			merged.LockVersion = lf.LockVersion
+			merged.CreatedBy = lf.CreatedBy
+		}
+		versions = append(versions, repo.Name+"="+lf.Version)
+
+		for _, art := range lf.Artifacts {
+			if pinned, ok := m.pins[art.Name]; ok && pinned != repo.Name {
+				continue
+			}
+			if origin, ok := origins[art.Name]; ok && origin != repo.Name {
+				// Provided by a higher-priority repository
+				continue
+			}
+			origins[art.Name] = repo.Name
+			merged.Artifacts = append(merged.Artifacts, art)
+		}
+	}
+
+	for artifactName, repoName := range m.pins {
+		if _, ok := origins[artifactName]; !ok && m.providesAny(lockFiles, artifactName) {
+			return nil, fmt.Errorf("artifact %s is pinned to repository %s, which does not provide it", artifactName, repoName)
+		}
+	}
+
+	if err := checkMergedDependencies(merged, origins); err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 1 {
+		merged.Version = strings.SplitN(versions[0], "=", 2)[1]
+	} else {
+		merged.Version = utils.ComputeSHA256([]byte(strings.Join(versions, "\n")))[:12]
+	}
+
+	m.origins = origins
+	return merged, nil
+}
+
+// providesAny checks if any of the lock files contains the artifact
+func (m *MultiRepository) providesAny(lockFiles []*lockfile.LockFile, artifactName string) bool {
+	for _, lf := range lockFiles {
+		if lf == nil {
+			continue
+		}
+		for _, art := range lf.Artifacts {
+			if art.Name == artifactName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkMergedDependencies reports dependencies that the merge resolved to another
+// repository's version of the artifact
+func checkMergedDependencies(merged *lockfile.LockFile, origins map[string]string) error {
+	versions := make(map[string]string)
+	for _, art := range merged.Artifacts {
+		versions[art.Name] = art.Version
+	}
+
+	for _, art := range merged.Artifacts {
+		for _, dep := range art.Dependencies {
+			selected, ok := versions[dep.Name]
+			if !ok || dep.Version == "" || dep.Version == selected {
+				continue
+			}
+			return fmt.Errorf("%s@%s from repository %s depends on %s@%s, but %s@%s was taken from repository %s (pin %s to resolve this)",
+				art.Name, art.Version, origins[art.Name], dep.Name, dep.Version, dep.Name, selected, origins[dep.Name], dep.Name)
+		}
+	}
+
+	return nil
+}
+
+// route returns the repository an artifact should be read from:
+// its pin, the repository that provided it, or the primary repository
+func (m *MultiRepository) route(artifactName string) NamedRepository {
+	m.mu.Lock()
+	name, ok := m.pins[artifactName]
+	if !ok {
+		name, ok = m.origins[artifactName]
+	}
+	m.mu.Unlock()
+
+	if ok {
+		for _, repo := range m.repos {
+			if repo.Name == name {
+				return repo
+			}
+		}
+	}
+	return m.repos[0]
+}
+
+// RepositoryName returns the name of the repository an artifact is read from
+func (m *MultiRepository) RepositoryName(artifactName string) string {
+	return m.route(artifactName).Name
+}
+
+// GetArtifact downloads an artifact from the repository that provided it
+func (m *MultiRepository) GetArtifact(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
+	return m.route(artifact.Name).GetArtifact(ctx, artifact)
+}
+
+// AddArtifact uploads an artifact to its pinned repository, or the primary repository
+func (m *MultiRepository) AddArtifact(ctx context.Context, artifact *lockfile.Artifact, zipData []byte) error {
+	m.mu.Lock()
+	repoName, pinned := m.pins[artifact.Name]
+	m.mu.Unlock()
+
+	if pinned {
+		return m.Repository(repoName).AddArtifact(ctx, artifact, zipData)
+	}
+	return m.repos[0].AddArtifact(ctx, artifact, zipData)
+}
+
+// GetVersionList returns the versions published by the artifact's pinned repository,
+// or else by the highest-priority repository that publishes any
+// Repositories that fail are skipped unless none of the others publish the artifact
+func (m *MultiRepository) GetVersionList(ctx context.Context, name string) ([]string, error) {
+	m.mu.Lock()
+	pinned, isPinned := m.pins[name]
+	m.mu.Unlock()
+
+	if isPinned {
+		return m.Repository(pinned).GetVersionList(ctx, name)
+	}
+
+	var errs []error
+	for _, repo := range m.repos {
+		versions, err := repo.GetVersionList(ctx, name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("repository %s: %w", repo.Name, err))
+			continue
+		}
+		if len(versions) > 0 {
+			m.mu.Lock()
+			m.origins[name] = repo.Name
+			m.mu.Unlock()
+			return versions, nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return []string{}, nil
+}
+
+// GetMetadata retrieves metadata from the repository that provides the artifact
+func (m *MultiRepository) GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error) {
+	return m.route(name).GetMetadata(ctx, name, version)
+}
+
+// ResolveSource sets the source using the repository that provides the artifact
+func (m *MultiRepository) ResolveSource(ctx context.Context, artifact *lockfile.Artifact) error {
+	return m.route(artifact.Name).ResolveSource(ctx, artifact)
+}
+
+// VerifyIntegrity checks hashes and sizes using the primary repository
+func (m *MultiRepository) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
+	return m.repos[0].VerifyIntegrity(data, hashes, size)
+}
+
+// PostUsageStats sends each repository the usage events of the artifacts it provides
+// Events for artifacts no repository is known to provide go to the primary repository
+func (m *MultiRepository) PostUsageStats(ctx context.Context, jsonlData string) error {
+	m.mu.Lock()
+	resolved := len(m.origins) > 0
+	m.mu.Unlock()
+	if !resolved {
+		// Usage is usually flushed before any lock file is fetched, so fall back to
+		// the cached ones to learn where artifacts came from
+		_, _ = m.MergeCachedLockFiles()
+	}
+
+	byRepo := make(map[string][]string)
+	for _, line := range strings.Split(jsonlData, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var event struct {
+			ArtifactName string `json:"artifact_name"`
+		}
+		// Events that can't be parsed are routed to the primary repository
+		_ = json.Unmarshal([]byte(line), &event)
+		repoName := m.route(event.ArtifactName).Name
+		byRepo[repoName] = append(byRepo[repoName], line)
+	}
+
+	var errs []error
+	for _, repo := range m.repos {
+		lines := byRepo[repo.Name]
+		if len(lines) == 0 {
+			continue
+		}
+		if err := repo.PostUsageStats(ctx, strings.Join(lines, "\n")); err != nil {
+			errs = append(errs, fmt.Errorf("repository %s: %w", repo.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/cache"
+	"github.com/sleuth-io/skills/internal/constants"
+	"github.com/sleuth-io/skills/internal/lockfile"
+)
+
+// newTestPathRepo creates a path repository publishing the given name@version artifacts
+func newTestPathRepo(t *testing.T, artifacts ...string) (string, *PathRepository) {
+	t.Helper()
+	repoDir := t.TempDir()
+
+	lf := &lockfile.LockFile{LockVersion: "1.0", Version: filepath.Base(repoDir), CreatedBy: "test"}
+	for _, key := range artifacts {
+		name, ver, _ := strings.Cut(key, "@")
+		writePathRepoArtifact(t, repoDir, name, ver)
+		listPath := filepath.Join(repoDir, "artifacts", name, "list.txt")
+		if err := os.WriteFile(listPath, []byte(ver+"\n"), 0644); err != nil {
+			t.Fatalf("Failed to write version list: %v", err)
+		}
+		lf.Artifacts = append(lf.Artifacts, lockfile.Artifact{
+			Name:       name,
+			Version:    ver,
+			Type:       artifact.TypeMCP,
+			SourcePath: &lockfile.SourcePath{Path: fmt.Sprintf("./artifacts/%s/%s", name, ver)},
+		})
+	}
+	if err := lockfile.Write(lf, filepath.Join(repoDir, constants.SkillLockFile)); err != nil {
+		t.Fatalf("Failed to write lock file: %v", err)
+	}
+
+	repo, err := NewPathRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewPathRepository failed: %v", err)
+	}
+	return repoDir, repo
+}
+
+// mergedVersions fetches the merged lock file and returns name -> version
+func mergedVersions(t *testing.T, m *MultiRepository) map[string]string {
+	t.Helper()
+	data, _, _, err := m.GetLockFile(context.Background(), "")
+	if err != nil {
+		t.Fatalf("GetLockFile failed: %v", err)
+	}
+	lf, err := lockfile.Parse(data)
+	if err != nil {
+		t.Fatalf("Failed to parse merged lock file: %v", err)
+	}
+	if err := lf.Validate(); err != nil {
+		t.Fatalf("Merged lock file is invalid: %v", err)
+	}
+
+	versions := make(map[string]string)
+	for _, art := range lf.Artifacts {
+		versions[art.Name] = art.Version
+	}
+	return versions
+}
+
+func TestMultiRepositoryMergeByPriority(t *testing.T) {
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	companyDir, company := newTestPathRepo(t, "code-review@2.0.0", "db-server@1.0.0")
+	personalDir, personal := newTestPathRepo(t, "code-review@1.0.0", "scratch@0.1.0")
+
+	m, err := NewMultiRepository([]NamedRepository{
+		{Name: "personal", Priority: 0, CacheKey: cache.RepositoryCacheKey("personal", personalDir), Repository: personal},
+		{Name: "company", Priority: 10, CacheKey: cache.RepositoryCacheKey("company", companyDir), Repository: company},
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewMultiRepository failed: %v", err)
+	}
+
+	versions := mergedVersions(t, m)
+	expected := map[string]string{"code-review": "2.0.0", "db-server": "1.0.0", "scratch": "0.1.0"}
+	for name, ver := range expected {
+		if versions[name] != ver {
+			t.Errorf("Expected %s@%s, got %q", name, ver, versions[name])
+		}
+	}
+
+	// Artifacts only published by the lower-priority repository are fetched from it
+	scratch := &lockfile.Artifact{Name: "scratch", Version: "0.1.0", SourcePath: &lockfile.SourcePath{Path: "./artifacts/scratch/0.1.0"}}
+	if _, err := m.GetArtifact(context.Background(), scratch); err != nil {
+		t.Errorf("GetArtifact for lower-priority artifact failed: %v", err)
+	}
+
+	// Each repository keeps its own cached lock file
+	for _, key := range []string{cache.RepositoryCacheKey("personal", personalDir), cache.RepositoryCacheKey("company", companyDir)} {
+		if _, err := cache.LoadLockFile(key); err != nil {
+			t.Errorf("Expected cached lock file for %s: %v", key, err)
+		}
+	}
+}
+
+func TestMultiRepositoryPinOverridesPriority(t *testing.T) {
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	_, company := newTestPathRepo(t, "code-review@2.0.0")
+	_, personal := newTestPathRepo(t, "code-review@1.0.0")
+
+	repos := []NamedRepository{
+		{Name: "company", Priority: 10, Repository: company},
+		{Name: "personal", Repository: personal},
+	}
+
+	m, err := NewMultiRepository(repos, map[string]string{"code-review": "personal"})
+	if err != nil {
+		t.Fatalf("NewMultiRepository failed: %v", err)
+	}
+
+	if versions := mergedVersions(t, m); versions["code-review"] != "1.0.0" {
+		t.Errorf("Expected pinned code-review@1.0.0, got %q", versions["code-review"])
+	}
+
+	versions, err := m.GetVersionList(context.Background(), "code-review")
+	if err != nil {
+		t.Fatalf("GetVersionList failed: %v", err)
+	}
+	if len(versions) != 1 || versions[0] != "1.0.0" {
+		t.Errorf("Expected versions from pinned repository, got %v", versions)
+	}
+
+	if err := m.Pin("code-review", "company"); err == nil {
+		t.Error("Expected error when pinning an artifact to a second repository")
+	}
+	if _, err := NewMultiRepository(repos, map[string]string{"code-review": "missing"}); err == nil {
+		t.Error("Expected error for pin to unknown repository")
+	}
+}
+
+func TestMultiRepositoryPinnedArtifactMissing(t *testing.T) {
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	_, company := newTestPathRepo(t, "code-review@2.0.0")
+	_, personal := newTestPathRepo(t, "scratch@0.1.0")
+
+	m, err := NewMultiRepository([]NamedRepository{
+		{Name: "company", Priority: 10, Repository: company},
+		{Name: "personal", Repository: personal},
+	}, map[string]string{"code-review": "personal"})
+	if err != nil {
+		t.Fatalf("NewMultiRepository failed: %v", err)
+	}
+
+	_, _, _, err = m.GetLockFile(context.Background(), "")
+	if err == nil || !strings.Contains(err.Error(), "pinned to repository personal") {
+		t.Errorf("Expected pinned artifact error, got %v", err)
+	}
+}
+
+// usageRecordingRepo is a path repository that records the usage posted to it
+type usageRecordingRepo struct {
+	*PathRepository
+	posted []string
+}
+
+func (r *usageRecordingRepo) PostUsageStats(ctx context.Context, jsonlData string) error {
+	r.posted = append(r.posted, strings.Split(jsonlData, "\n")...)
+	return nil
+}
+
+func TestMultiRepositoryPostUsageStatsByOrigin(t *testing.T) {
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	companyDir, companyPath := newTestPathRepo(t, "code-review@2.0.0")
+	personalDir, personalPath := newTestPathRepo(t, "code-review@1.0.0", "scratch@0.1.0")
+	company := &usageRecordingRepo{PathRepository: companyPath}
+	personal := &usageRecordingRepo{PathRepository: personalPath}
+
+	repos := []NamedRepository{
+		{Name: "company", Priority: 10, CacheKey: cache.RepositoryCacheKey("company", companyDir), Repository: company},
+		{Name: "personal", CacheKey: cache.RepositoryCacheKey("personal", personalDir), Repository: personal},
+	}
+	fetched, err := NewMultiRepository(repos, nil)
+	if err != nil {
+		t.Fatalf("NewMultiRepository failed: %v", err)
+	}
+	mergedVersions(t, fetched)
+
+	// Usage is posted by a fresh process that only has the cached lock files
+	m, err := NewMultiRepository(repos, nil)
+	if err != nil {
+		t.Fatalf("NewMultiRepository failed: %v", err)
+	}
+
+	review := `{"artifact_name":"code-review","artifact_version":"2.0.0"}`
+	scratch := `{"artifact_name":"scratch","artifact_version":"0.1.0"}`
+	unknown := `{"artifact_name":"removed","artifact_version":"1.0.0"}`
+	if err := m.PostUsageStats(context.Background(), strings.Join([]string{review, scratch, unknown}, "\n")); err != nil {
+		t.Fatalf("PostUsageStats failed: %v", err)
+	}
+
+	if got := strings.Join(company.posted, "\n"); got != review+"\n"+unknown {
+		t.Errorf("Company repository got usage:\n%s", got)
+	}
+	if got := strings.Join(personal.posted, "\n"); got != scratch {
+		t.Errorf("Personal repository got usage:\n%s", got)
+	}
+}
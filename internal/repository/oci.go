@@ -0,0 +1,631 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sleuth-io/skills/internal/buildinfo"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// Media types used for artifacts and lock files stored in OCI registries
+const (
+	ociManifestMediaType      = "application/vnd.oci.image.manifest.v1+json"
+	ociEmptyMediaType         = "application/vnd.oci.empty.v1+json"
+	ociArtifactType           = "application/vnd.sleuth.skills.artifact.v1"
+	ociArtifactLayerMediaType = "application/vnd.sleuth.skills.artifact.v1+zip"
+	ociMetadataMediaType      = "application/vnd.sleuth.skills.metadata.v1+toml"
+	ociLockFileArtifactType   = "application/vnd.sleuth.skills.lock.v1"
+	ociLockFileMediaType      = "application/vnd.sleuth.skills.lock.v1+toml"
+	ociPolicyMediaType        = "application/vnd.sleuth.skills.policy.v1+toml"
+
+	// ociAnnotationPrefix namespaces the signature annotations on artifact manifests
+	ociAnnotationPrefix = "io.sleuth.skills."
+)
+
+// errOCINotFound is returned when a manifest or blob doesn't exist in the registry
+var errOCINotFound = errors.New("not found in registry")
+
+// ociDescriptor describes a blob referenced from a manifest
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// ociManifest is an OCI image manifest
+type ociManifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        ociDescriptor     `json:"config"`
+	Layers        []ociDescriptor   `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// layer returns the first layer with the given media type
+func (m *ociManifest) layer(mediaType string) (*ociDescriptor, error) {
+	for i := range m.Layers {
+		if m.Layers[i].MediaType == mediaType {
+			return &m.Layers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("manifest has no %s layer", mediaType)
+}
+
+// ociReference is a parsed registry/repository[:tag][@digest] reference
+type ociReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// parseOCIReference parses an OCI reference, with or without an oci:// prefix
+// The first path component is always the registry host
+func parseOCIReference(ref string) (*ociReference, error) {
+	rest := strings.TrimSuffix(strings.TrimPrefix(ref, "oci://"), "/")
+	parsed := &ociReference{}
+
+	if before, digest, ok := strings.Cut(rest, "@"); ok {
+		parsed.Digest = digest
+		rest = before
+	}
+
+	registry, repository, ok := strings.Cut(rest, "/")
+	if !ok || registry == "" {
+		return nil, fmt.Errorf("invalid OCI reference %q: must be registry/repository", ref)
+	}
+	parsed.Registry = registry
+
+	if i := strings.LastIndex(repository, ":"); i >= 0 {
+		parsed.Tag = repository[i+1:]
+		repository = repository[:i]
+	}
+	if repository == "" {
+		return nil, fmt.Errorf("invalid OCI reference %q: missing repository", ref)
+	}
+	parsed.Repository = repository
+
+	return parsed, nil
+}
+
+// String formats the reference as registry/repository[:tag][@digest]
+func (r *ociReference) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// ociTag converts a version to a valid tag, since tags can't contain '+'
+func ociTag(version string) string {
+	return strings.ReplaceAll(version, "+", "_")
+}
+
+// ociTagVersion converts a tag written by ociTag back to a version
+func ociTagVersion(tag string) string {
+	return strings.ReplaceAll(tag, "_", "+")
+}
+
+// ociDigest computes the sha256 digest of data
+func ociDigest(data []byte) string {
+	return "sha256:" + utils.ComputeSHA256(data)
+}
+
+// verifyOCIDigest checks data against a sha256 or sha512 digest
+func verifyOCIDigest(data []byte, digest string) error {
+	algo, expected, _ := strings.Cut(digest, ":")
+
+	var actual string
+	switch algo {
+	case "sha256":
+		sum := sha256.Sum256(data)
+		actual = hex.EncodeToString(sum[:])
+	case "sha512":
+		sum := sha512.Sum512(data)
+		actual = hex.EncodeToString(sum[:])
+	default:
+		return fmt.Errorf("unsupported digest algorithm: %s", algo)
+	}
+
+	if actual != expected {
+		return fmt.Errorf("digest mismatch: expected %s, got %s:%s", digest, algo, actual)
+	}
+	return nil
+}
+
+// ociClient talks to registries using the OCI distribution API
+// Credentials are only ever sent to the registry they were configured for
+type ociClient struct {
+	httpClient  *http.Client
+	registry    string
+	credentials string // "username:password", or a bearer token
+
+	mu     sync.Mutex
+	tokens map[string]string // registry/repository -> Authorization header
+}
+
+// newOCIClient creates a client that authenticates to registry with credentials
+func newOCIClient(registry, credentials string) *ociClient {
+	return &ociClient{
+		httpClient: &http.Client{
+			Timeout: 5 * time.Minute,
+		},
+		registry:    registry,
+		credentials: credentials,
+		tokens:      make(map[string]string),
+	}
+}
+
+// ociScheme returns the URL scheme for a registry
+// Like docker, registries on loopback addresses are reached over plain HTTP
+func ociScheme(registry string) string {
+	host := registry
+	if h, _, err := net.SplitHostPort(registry); err == nil {
+		host = h
+	}
+	if host == "localhost" {
+		return "http"
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return "http"
+	}
+	return "https"
+}
+
+// do sends a request to the registry, authenticating and retrying once if challenged
+// target is either an absolute URL or a path starting with /v2/
+func (c *ociClient) do(ctx context.Context, method, registry, repository, target string, body []byte, header http.Header) (*http.Response, error) {
+	if strings.HasPrefix(target, "/") {
+		target = ociScheme(registry) + "://" + registry + target
+	}
+	tokenKey := registry + "/" + repository
+
+	send := func() (*http.Response, error) {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, target, reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
+		req.Header.Set("User-Agent", buildinfo.GetUserAgent())
+
+		c.mu.Lock()
+		auth := c.tokens[tokenKey]
+		c.mu.Unlock()
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to contact registry %s: %w", registry, err)
+		}
+		return resp, nil
+	}
+
+	resp, err := send()
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	challenge := resp.Header.Get("WWW-Authenticate")
+	_ = resp.Body.Close()
+
+	auth, err := c.authorize(ctx, registry, repository, challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.tokens[tokenKey] = auth
+	c.mu.Unlock()
+
+	return send()
+}
+
+// authorize answers a WWW-Authenticate challenge with an Authorization header value
+func (c *ociClient) authorize(ctx context.Context, registry, repository, challenge string) (string, error) {
+	var username, password, token string
+	if registry == c.registry && c.credentials != "" {
+		if user, pass, ok := strings.Cut(c.credentials, ":"); ok {
+			username, password = user, pass
+		} else {
+			token = c.credentials
+		}
+	}
+
+	scheme, params := parseAuthChallenge(challenge)
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if username == "" {
+			return "", fmt.Errorf("registry %s requires a username and password", registry)
+		}
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password)), nil
+
+	case "bearer":
+		// A configured token is used as-is rather than exchanged
+		if token != "" {
+			return "Bearer " + token, nil
+		}
+
+		realm := params["realm"]
+		if realm == "" {
+			return "", fmt.Errorf("registry %s sent a bearer challenge without a realm", registry)
+		}
+		scope := params["scope"]
+		if scope == "" {
+			scope = fmt.Sprintf("repository:%s:pull,push", repository)
+		}
+
+		issued, err := c.fetchToken(ctx, realm, params["service"], scope, username, password)
+		if err != nil {
+			return "", fmt.Errorf("failed to authenticate with registry %s: %w", registry, err)
+		}
+		return "Bearer " + issued, nil
+
+	default:
+		return "", fmt.Errorf("registry %s requires unsupported authentication: %q", registry, challenge)
+	}
+}
+
+// fetchToken requests a bearer token from a registry's token service
+func (c *ociClient) fetchToken(ctx context.Context, realm, service, scope, username, password string) (string, error) {
+	tokenURL, err := url.Parse(realm)
+	if err != nil {
+		return "", fmt.Errorf("invalid token realm: %w", err)
+	}
+	query := tokenURL.Query()
+	if service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", scope)
+	tokenURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", tokenURL.String(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", buildinfo.GetUserAgent())
+	if username != "" {
+		req.SetBasicAuth(username, password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to request token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token service returned HTTP %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if result.Token != "" {
+		return result.Token, nil
+	}
+	if result.AccessToken != "" {
+		return result.AccessToken, nil
+	}
+	return "", fmt.Errorf("token service returned no token")
+}
+
+// parseAuthChallenge splits a WWW-Authenticate header into its scheme and parameters
+// Quoted values may contain commas, e.g. scope="repository:foo:pull,push"
+func parseAuthChallenge(header string) (string, map[string]string) {
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	params := make(map[string]string)
+
+	for rest != "" {
+		rest = strings.TrimLeft(rest, " ,")
+		key, value, ok := strings.Cut(rest, "=")
+		if !ok {
+			break
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+
+		if strings.HasPrefix(value, `"`) {
+			end := strings.Index(value[1:], `"`)
+			if end < 0 {
+				params[key] = value[1:]
+				break
+			}
+			params[key] = value[1 : end+1]
+			rest = value[end+2:]
+		} else {
+			params[key], rest, _ = strings.Cut(value, ",")
+		}
+	}
+
+	return scheme, params
+}
+
+// ociResponseError builds an error from a failed registry response
+func ociResponseError(resp *http.Response) error {
+	var body struct {
+		Errors []struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if json.Unmarshal(data, &body) == nil && len(body.Errors) > 0 {
+		return fmt.Errorf("registry returned HTTP %d: %s: %s", resp.StatusCode, body.Errors[0].Code, body.Errors[0].Message)
+	}
+	return fmt.Errorf("registry returned HTTP %d", resp.StatusCode)
+}
+
+// getManifest fetches a manifest by tag or digest and returns it with its digest
+func (c *ociClient) getManifest(ctx context.Context, registry, repository, reference string) ([]byte, string, error) {
+	header := http.Header{"Accept": {ociManifestMediaType}}
+	resp, err := c.do(ctx, "GET", registry, repository, fmt.Sprintf("/v2/%s/manifests/%s", repository, reference), nil, header)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", fmt.Errorf("manifest %s/%s:%s %w", registry, repository, reference, errOCINotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", ociResponseError(resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	digest := ociDigest(data)
+	if strings.Contains(reference, ":") {
+		if err := verifyOCIDigest(data, reference); err != nil {
+			return nil, "", fmt.Errorf("manifest verification failed: %w", err)
+		}
+		digest = reference
+	}
+
+	return data, digest, nil
+}
+
+// getImageManifest fetches and decodes a manifest
+func (c *ociClient) getImageManifest(ctx context.Context, registry, repository, reference string) (*ociManifest, string, error) {
+	data, digest, err := c.getManifest(ctx, registry, repository, reference)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	return &manifest, digest, nil
+}
+
+// putManifest uploads a manifest under a tag and returns its digest
+func (c *ociClient) putManifest(ctx context.Context, registry, repository, tag string, manifest *ociManifest) (string, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	header := http.Header{"Content-Type": {ociManifestMediaType}}
+	resp, err := c.do(ctx, "PUT", registry, repository, fmt.Sprintf("/v2/%s/manifests/%s", repository, tag), data, header)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		return "", ociResponseError(resp)
+	}
+
+	return ociDigest(data), nil
+}
+
+// getBlob downloads a blob and verifies it against its digest
+func (c *ociClient) getBlob(ctx context.Context, registry, repository, digest string) ([]byte, error) {
+	resp, err := c.do(ctx, "GET", registry, repository, fmt.Sprintf("/v2/%s/blobs/%s", repository, digest), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("blob %s %w", digest, errOCINotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, ociResponseError(resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob: %w", err)
+	}
+
+	if err := verifyOCIDigest(data, digest); err != nil {
+		return nil, fmt.Errorf("blob verification failed: %w", err)
+	}
+
+	return data, nil
+}
+
+// pushBlob uploads a blob unless the registry already has it
+func (c *ociClient) pushBlob(ctx context.Context, registry, repository, mediaType string, data []byte) (ociDescriptor, error) {
+	desc := ociDescriptor{
+		MediaType: mediaType,
+		Digest:    ociDigest(data),
+		Size:      int64(len(data)),
+	}
+
+	resp, err := c.do(ctx, "HEAD", registry, repository, fmt.Sprintf("/v2/%s/blobs/%s", repository, desc.Digest), nil, nil)
+	if err != nil {
+		return desc, err
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return desc, nil
+	}
+
+	// Start an upload session, then finish it with a single monolithic PUT
+	resp, err = c.do(ctx, "POST", registry, repository, fmt.Sprintf("/v2/%s/blobs/uploads/", repository), nil, nil)
+	if err != nil {
+		return desc, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		return desc, ociResponseError(resp)
+	}
+
+	location, err := resp.Location()
+	if err != nil {
+		return desc, fmt.Errorf("registry did not return an upload location: %w", err)
+	}
+	query := location.Query()
+	query.Set("digest", desc.Digest)
+	location.RawQuery = query.Encode()
+
+	header := http.Header{"Content-Type": {"application/octet-stream"}}
+	putResp, err := c.do(ctx, "PUT", registry, repository, location.String(), data, header)
+	if err != nil {
+		return desc, err
+	}
+	defer putResp.Body.Close()
+	if putResp.StatusCode != http.StatusCreated {
+		return desc, ociResponseError(putResp)
+	}
+
+	return desc, nil
+}
+
+// listTags lists every tag in a repository, following pagination links
+// A repository that doesn't exist yet has no tags
+func (c *ociClient) listTags(ctx context.Context, registry, repository string) ([]string, error) {
+	var tags []string
+	target := fmt.Sprintf("/v2/%s/tags/list", repository)
+
+	for target != "" {
+		resp, err := c.do(ctx, "GET", registry, repository, target, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusNotFound {
+			_ = resp.Body.Close()
+			return []string{}, nil
+		}
+		if resp.StatusCode != http.StatusOK {
+			err := ociResponseError(resp)
+			_ = resp.Body.Close()
+			return nil, err
+		}
+
+		var page struct {
+			Tags []string `json:"tags"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode tag list: %w", err)
+		}
+		tags = append(tags, page.Tags...)
+
+		target = nextLink(resp.Header.Get("Link"))
+	}
+
+	return tags, nil
+}
+
+// nextLink extracts the target of a rel="next" Link header
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(link, ";")
+		if !ok || !strings.Contains(params, `rel="next"`) {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(target), "<>")
+	}
+	return ""
+}
+
+// OCISourceHandler handles artifacts with source-oci
+type OCISourceHandler struct {
+	client *ociClient
+}
+
+// NewOCISourceHandler creates a new OCI source handler
+// Credentials are only sent to the given registry; others are accessed anonymously
+func NewOCISourceHandler(registry, credentials string) *OCISourceHandler {
+	return &OCISourceHandler{
+		client: newOCIClient(registry, credentials),
+	}
+}
+
+// Fetch downloads an artifact from an OCI registry
+// The manifest is fetched by digest, so a moved tag can't change what gets installed
+func (h *OCISourceHandler) Fetch(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
+	if artifact.SourceOCI == nil {
+		return nil, fmt.Errorf("artifact does not have source-oci")
+	}
+
+	source := artifact.SourceOCI
+	ref, err := parseOCIReference(source.Reference)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, _, err := h.client.getImageManifest(ctx, ref.Registry, ref.Repository, source.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest: %w", err)
+	}
+
+	layer, err := manifest.layer(ociArtifactLayerMediaType)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := h.client.getBlob(ctx, ref.Registry, ref.Repository, layer.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+
+	// Verify it's a valid zip file
+	if !utils.IsZipFile(data) {
+		return nil, fmt.Errorf("downloaded file is not a valid zip archive")
+	}
+
+	return data, nil
+}
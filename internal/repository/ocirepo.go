@@ -0,0 +1,345 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/sleuth-io/skills/internal/constants"
+	"github.com/sleuth-io/skills/internal/git"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// ociEmptyConfig is the OCI empty JSON descriptor content
+var ociEmptyConfig = []byte("{}")
+
+// OCIRepository implements Repository for OCI registries (ghcr.io, ECR, Harbor, ...)
+//
+// Layout under the configured repository, e.g. ghcr.io/acme/skills:
+//   - ghcr.io/acme/skills:skill.lock holds the lock file
+//   - ghcr.io/acme/skills:skill-policy.toml holds the policy, if there is one
+//   - ghcr.io/acme/skills/{name}:{version} holds each artifact version,
+//     with metadata.toml as the manifest config and the zip as its only layer
+type OCIRepository struct {
+	repoURL     string
+	registry    string
+	repository  string
+	client      *ociClient
+	httpHandler *HTTPSourceHandler
+	pathHandler *PathSourceHandler
+	gitHandler  *GitSourceHandler
+	ociHandler  *OCISourceHandler
+}
+
+// NewOCIRepository creates a new OCI repository from an oci://registry/repository URL
+// credentials are either "username:password" or a bearer token, and may be empty
+func NewOCIRepository(repoURL, credentials string) (*OCIRepository, error) {
+	ref, err := parseOCIReference(repoURL)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Tag != "" || ref.Digest != "" {
+		return nil, fmt.Errorf("OCI repository URL must not include a tag or digest: %s", repoURL)
+	}
+
+	client := newOCIClient(ref.Registry, credentials)
+	return &OCIRepository{
+		repoURL:     repoURL,
+		registry:    ref.Registry,
+		repository:  ref.Repository,
+		client:      client,
+		httpHandler: NewHTTPSourceHandler(""),
+		pathHandler: NewPathSourceHandler(""), // Lock file dir not applicable for OCI
+		gitHandler:  NewGitSourceHandler(git.NewClient()),
+		ociHandler:  &OCISourceHandler{client: client},
+	}, nil
+}
+
+// artifactRepository returns the registry repository holding an artifact's versions
+// Repository names must be lowercase, so artifact names are lowercased
+func (o *OCIRepository) artifactRepository(name string) string {
+	return o.repository + "/" + strings.ToLower(name)
+}
+
+// Authenticate performs authentication - no-op for OCI repositories
+// Credentials are exchanged for registry tokens when a request is challenged
+func (o *OCIRepository) Authenticate(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// GetLockFile retrieves the lock file from the skill.lock tag
+// The manifest digest is used as the ETag
+func (o *OCIRepository) GetLockFile(ctx context.Context, cachedETag string) (content []byte, etag string, notModified bool, err error) {
+	content, etag, notModified, err = o.fetchLockFile(ctx, cachedETag)
+	if errors.Is(err, errOCINotFound) {
+		return nil, "", false, fmt.Errorf("%s not found in registry: %s", constants.SkillLockFile, o.repoURL)
+	}
+	return content, etag, notModified, err
+}
+
+// fetchLockFile is GetLockFile, returning errOCINotFound if the tag doesn't exist
+func (o *OCIRepository) fetchLockFile(ctx context.Context, cachedETag string) ([]byte, string, bool, error) {
+	manifest, digest, err := o.client.getImageManifest(ctx, o.registry, o.repository, constants.SkillLockFile)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to fetch lock file manifest: %w", err)
+	}
+
+	if cachedETag != "" && digest == cachedETag {
+		return nil, digest, true, nil
+	}
+
+	layer, err := manifest.layer(ociLockFileMediaType)
+	if err != nil {
+		return nil, "", false, err
+	}
+
+	data, err := o.client.getBlob(ctx, o.registry, o.repository, layer.Digest)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to download lock file: %w", err)
+	}
+
+	return data, digest, false, nil
+}
+
+// GetPolicy retrieves the policy from the skill-policy.toml tag
+// The layer with the policy media type is used, or the only layer of manifests
+// pushed by other tools (e.g. oras push)
+func (o *OCIRepository) GetPolicy(ctx context.Context) ([]byte, error) {
+	manifest, _, err := o.client.getImageManifest(ctx, o.registry, o.repository, constants.SkillPolicyFile)
+	if errors.Is(err, errOCINotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy manifest: %w", err)
+	}
+
+	layer, err := manifest.layer(ociPolicyMediaType)
+	if err != nil {
+		if len(manifest.Layers) != 1 {
+			return nil, err
+		}
+		layer = &manifest.Layers[0]
+	}
+
+	data, err := o.client.getBlob(ctx, o.registry, o.repository, layer.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download policy: %w", err)
+	}
+	return data, nil
+}
+
+// UpdateLockFile applies update to the lock file and pushes it back to the skill.lock tag
+// A missing lock file starts out empty
+func (o *OCIRepository) UpdateLockFile(ctx context.Context, update func(*lockfile.LockFile) error) error {
+	var lockFile *lockfile.LockFile
+	data, _, _, err := o.fetchLockFile(ctx, "")
+	switch {
+	case errors.Is(err, errOCINotFound):
+		lockFile = lockfile.New()
+	case err != nil:
+		return err
+	default:
+		lockFile, err = lockfile.Parse(data)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := update(lockFile); err != nil {
+		return err
+	}
+
+	data, err = lockfile.Marshal(lockFile)
+	if err != nil {
+		return err
+	}
+
+	config, err := o.client.pushBlob(ctx, o.registry, o.repository, ociEmptyMediaType, ociEmptyConfig)
+	if err != nil {
+		return fmt.Errorf("failed to upload lock file config: %w", err)
+	}
+	layer, err := o.client.pushBlob(ctx, o.registry, o.repository, ociLockFileMediaType, data)
+	if err != nil {
+		return fmt.Errorf("failed to upload lock file: %w", err)
+	}
+	layer.Annotations = map[string]string{"org.opencontainers.image.title": constants.SkillLockFile}
+
+	manifest := &ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  ociLockFileArtifactType,
+		Config:        config,
+		Layers:        []ociDescriptor{layer},
+	}
+	if _, err := o.client.putManifest(ctx, o.registry, o.repository, constants.SkillLockFile, manifest); err != nil {
+		return fmt.Errorf("failed to push lock file: %w", err)
+	}
+
+	return nil
+}
+
+// GetArtifact downloads an artifact using its source configuration
+// Reuses the same dispatch pattern as the other repositories
+func (o *OCIRepository) GetArtifact(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
+	switch artifact.GetSourceType() {
+	case "http":
+		return o.httpHandler.Fetch(ctx, artifact)
+	case "path":
+		return o.pathHandler.Fetch(ctx, artifact)
+	case "git":
+		return o.gitHandler.Fetch(ctx, artifact)
+	case "oci":
+		return o.ociHandler.Fetch(ctx, artifact)
+	default:
+		return nil, fmt.Errorf("unsupported source type: %s", artifact.GetSourceType())
+	}
+}
+
+// AddArtifact pushes an artifact version as {repository}/{name}:{version}
+// The artifact's source-oci is set to the pushed manifest
+// Note: the lock file is NOT updated here, callers use UpdateLockFile
+func (o *OCIRepository) AddArtifact(ctx context.Context, artifact *lockfile.Artifact, zipData []byte) error {
+	repository := o.artifactRepository(artifact.Name)
+	tag := ociTag(artifact.Version)
+
+	// metadata.toml doubles as the manifest config so GetMetadata doesn't need the zip
+	configMediaType := ociMetadataMediaType
+	configData, err := utils.ReadZipFile(zipData, "metadata.toml")
+	if err != nil {
+		configMediaType, configData = ociEmptyMediaType, ociEmptyConfig
+	}
+
+	config, err := o.client.pushBlob(ctx, o.registry, repository, configMediaType, configData)
+	if err != nil {
+		return fmt.Errorf("failed to upload artifact metadata: %w", err)
+	}
+	layer, err := o.client.pushBlob(ctx, o.registry, repository, ociArtifactLayerMediaType, zipData)
+	if err != nil {
+		return fmt.Errorf("failed to upload artifact: %w", err)
+	}
+	layer.Annotations = map[string]string{
+		"org.opencontainers.image.title": fmt.Sprintf("%s-%s.zip", artifact.Name, artifact.Version),
+	}
+
+	manifest := &ociManifest{
+		SchemaVersion: 2,
+		MediaType:     ociManifestMediaType,
+		ArtifactType:  ociArtifactType,
+		Config:        config,
+		Layers:        []ociDescriptor{layer},
+		Annotations: map[string]string{
+			"org.opencontainers.image.title":   artifact.Name,
+			"org.opencontainers.image.version": artifact.Version,
+		},
+	}
+	for k, v := range signatureFields(ociAnnotationPrefix, artifact.Signature) {
+		manifest.Annotations[k] = v
+	}
+	digest, err := o.client.putManifest(ctx, o.registry, repository, tag, manifest)
+	if err != nil {
+		return fmt.Errorf("failed to push artifact manifest: %w", err)
+	}
+
+	artifact.SourceOCI = &lockfile.SourceOCI{
+		Reference: fmt.Sprintf("%s/%s:%s", o.registry, repository, tag),
+		Digest:    digest,
+	}
+	return nil
+}
+
+// GetVersionList retrieves available versions for an artifact from the tags API
+// Tags that aren't semantic versions (e.g. "latest") are ignored
+func (o *OCIRepository) GetVersionList(ctx context.Context, name string) ([]string, error) {
+	tags, err := o.client.listTags(ctx, o.registry, o.artifactRepository(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	versions := []string{}
+	for _, tag := range tags {
+		version := ociTagVersion(tag)
+		if _, err := semver.StrictNewVersion(version); err == nil {
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+// GetMetadata retrieves metadata for a specific artifact version from its manifest config
+func (o *OCIRepository) GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error) {
+	repository := o.artifactRepository(name)
+	manifest, _, err := o.client.getImageManifest(ctx, o.registry, repository, ociTag(version))
+	if errors.Is(err, errOCINotFound) {
+		return nil, fmt.Errorf("artifact %s@%s not found", name, version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if manifest.Config.MediaType == ociMetadataMediaType {
+		data, err = o.client.getBlob(ctx, o.registry, repository, manifest.Config.Digest)
+	} else {
+		// Pushed without metadata.toml as config, read it from the zip instead
+		var layer *ociDescriptor
+		if layer, err = manifest.layer(ociArtifactLayerMediaType); err == nil {
+			var zipData []byte
+			if zipData, err = o.client.getBlob(ctx, o.registry, repository, layer.Digest); err == nil {
+				data, err = utils.ReadZipFile(zipData, "metadata.toml")
+			}
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata for %s@%s: %w", name, version, err)
+	}
+
+	return metadata.Parse(data)
+}
+
+// ResolveSource sets source-oci for a published artifact version
+// The digest pins the manifest the version's tag currently points at. The signature comes
+// from the manifest annotations, or the repository's lock file for older pushes
+func (o *OCIRepository) ResolveSource(ctx context.Context, artifact *lockfile.Artifact) error {
+	repository := o.artifactRepository(artifact.Name)
+	tag := ociTag(artifact.Version)
+
+	manifest, digest, err := o.client.getImageManifest(ctx, o.registry, repository, tag)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s@%s: %w", artifact.Name, artifact.Version, err)
+	}
+
+	artifact.SourceOCI = &lockfile.SourceOCI{
+		Reference: fmt.Sprintf("%s/%s:%s", o.registry, repository, tag),
+		Digest:    digest,
+	}
+
+	artifact.Signature = signatureFromFields(ociAnnotationPrefix, manifest.Annotations)
+	if artifact.Signature == nil {
+		if data, _, _, err := o.GetLockFile(ctx, ""); err == nil {
+			artifact.Signature = signatureFromLockFile(data, artifact.Name, artifact.Version)
+		}
+	}
+	return nil
+}
+
+// VerifyIntegrity checks hashes and sizes for downloaded artifacts
+// Registry content is addressed by digest, so only explicit hashes are checked
+func (o *OCIRepository) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
+	if size > 0 && int64(len(data)) != size {
+		return fmt.Errorf("size mismatch: expected %d bytes, got %d bytes", size, len(data))
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+	return o.httpHandler.verifyHashes(data, hashes)
+}
+
+// PostUsageStats is a no-op for OCI repositories
+func (o *OCIRepository) PostUsageStats(ctx context.Context, jsonlData string) error {
+	return nil
+}
@@ -0,0 +1,362 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/constants"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// testRegistry is a minimal in-memory OCI distribution server
+// If username is set, requests need a bearer token issued by /token for those credentials
+type testRegistry struct {
+	username, password string
+	pageSize           int
+
+	mu        sync.Mutex
+	blobs     map[string][]byte            // digest -> content
+	manifests map[string][]byte            // digest -> content
+	tags      map[string]map[string]string // repository -> tag -> digest
+	uploads   int
+}
+
+func newTestRegistry(t *testing.T) (*testRegistry, *httptest.Server) {
+	t.Helper()
+	reg := &testRegistry{
+		blobs:     make(map[string][]byte),
+		manifests: make(map[string][]byte),
+		tags:      make(map[string]map[string]string),
+	}
+	server := httptest.NewServer(reg)
+	t.Cleanup(server.Close)
+	return reg, server
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		user, pass, ok := req.BasicAuth()
+		if !ok || user != r.username || pass != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "issued-token"})
+		return
+	}
+
+	if r.username != "" && req.Header.Get("Authorization") != "Bearer issued-token" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="http://%s/token",service="test",scope="repository:x:pull,push"`, req.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.HasSuffix(path, "/tags/list"):
+		r.serveTags(w, req, strings.TrimSuffix(path, "/tags/list"))
+
+	case strings.Contains(path, "/manifests/"):
+		i := strings.LastIndex(path, "/manifests/")
+		r.serveManifest(w, req, path[:i], path[i+len("/manifests/"):])
+
+	case strings.Contains(path, "/blobs/uploads/"):
+		i := strings.LastIndex(path, "/blobs/uploads/")
+		if req.Method == "POST" {
+			r.uploads++
+			w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%d?state=x", path[:i], r.uploads))
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		data, _ := io.ReadAll(req.Body)
+		digest := req.URL.Query().Get("digest")
+		if req.URL.Query().Get("state") != "x" || ociDigest(data) != digest {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[digest] = data
+		w.WriteHeader(http.StatusCreated)
+
+	case strings.Contains(path, "/blobs/"):
+		data, ok := r.blobs[path[strings.LastIndex(path, "/")+1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if req.Method == "GET" {
+			_, _ = w.Write(data)
+		}
+
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *testRegistry) serveManifest(w http.ResponseWriter, req *http.Request, repository, reference string) {
+	if req.Method == "PUT" {
+		data, _ := io.ReadAll(req.Body)
+		digest := ociDigest(data)
+		r.manifests[digest] = data
+		if r.tags[repository] == nil {
+			r.tags[repository] = make(map[string]string)
+		}
+		r.tags[repository][reference] = digest
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	digest := reference
+	if !strings.Contains(reference, ":") {
+		digest = r.tags[repository][reference]
+	}
+	data, ok := r.manifests[digest]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", ociManifestMediaType)
+	_, _ = w.Write(data)
+}
+
+func (r *testRegistry) serveTags(w http.ResponseWriter, req *http.Request, repository string) {
+	tagMap, ok := r.tags[repository]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var tags []string
+	last := req.URL.Query().Get("last")
+	for tag := range tagMap {
+		if tag > last {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+
+	if r.pageSize > 0 && len(tags) > r.pageSize {
+		tags = tags[:r.pageSize]
+		w.Header().Set("Link", fmt.Sprintf(`</v2/%s/tags/list?n=%d&last=%s>; rel="next"`, repository, r.pageSize, tags[len(tags)-1]))
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"name": repository, "tags": tags})
+}
+
+// testArtifactZip builds an mcp artifact zip for name@version
+func testArtifactZip(t *testing.T, name, version string) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	writePathRepoArtifact(t, dir, name, version)
+	data, err := utils.CreateZip(filepath.Join(dir, "artifacts", name, version))
+	if err != nil {
+		t.Fatalf("Failed to create zip: %v", err)
+	}
+	return data
+}
+
+func TestOCIRepositoryRoundTrip(t *testing.T) {
+	reg, server := newTestRegistry(t)
+	reg.username, reg.password = "ci", "secret"
+	reg.pageSize = 1
+
+	repoURL := "oci://" + strings.TrimPrefix(server.URL, "http://") + "/acme/skills"
+	repo, err := NewOCIRepository(repoURL, "ci:secret")
+	if err != nil {
+		t.Fatalf("NewOCIRepository failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, _, _, err := repo.GetLockFile(ctx, ""); err == nil {
+		t.Error("Expected error for missing lock file")
+	}
+
+	zips := map[string][]byte{}
+	for _, version := range []string{"1.0.0", "1.1.0+build.5"} {
+		zips[version] = testArtifactZip(t, "db-server", version)
+		art := &lockfile.Artifact{Name: "db-server", Version: version, Type: artifact.TypeMCP}
+		art.Signature = &lockfile.Signature{Algorithm: "ed25519", KeyID: "key1", Value: "c2ln-" + version}
+		if err := repo.AddArtifact(ctx, art, zips[version]); err != nil {
+			t.Fatalf("AddArtifact failed: %v", err)
+		}
+		if err := art.Validate(); err != nil {
+			t.Fatalf("Pushed artifact entry is invalid: %v", err)
+		}
+		err := repo.UpdateLockFile(ctx, func(lf *lockfile.LockFile) error {
+			lf.AddOrUpdate(art)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("UpdateLockFile failed: %v", err)
+		}
+	}
+
+	// The lock file round-trips and its manifest digest works as an ETag
+	data, etag, notModified, err := repo.GetLockFile(ctx, "")
+	if err != nil {
+		t.Fatalf("GetLockFile failed: %v", err)
+	}
+	if notModified || etag == "" {
+		t.Errorf("Expected fresh lock file with an ETag, got notModified=%v etag=%q", notModified, etag)
+	}
+	lf, err := lockfile.Parse(data)
+	if err != nil {
+		t.Fatalf("Failed to parse lock file: %v", err)
+	}
+	if err := lf.Validate(); err != nil {
+		t.Fatalf("Lock file is invalid: %v", err)
+	}
+	if len(lf.Artifacts) != 2 {
+		t.Fatalf("Expected 2 artifacts in lock file, got %d", len(lf.Artifacts))
+	}
+	if _, _, notModified, _ := repo.GetLockFile(ctx, etag); !notModified {
+		t.Error("Expected notModified for matching ETag")
+	}
+
+	// Versions come from the tags API, across pages
+	versions, err := repo.GetVersionList(ctx, "db-server")
+	if err != nil {
+		t.Fatalf("GetVersionList failed: %v", err)
+	}
+	if strings.Join(versions, ",") != "1.0.0,1.1.0+build.5" {
+		t.Errorf("Expected versions 1.0.0,1.1.0+build.5, got %v", versions)
+	}
+	if versions, _ := repo.GetVersionList(ctx, "missing"); len(versions) != 0 {
+		t.Errorf("Expected no versions for unknown artifact, got %v", versions)
+	}
+
+	meta, err := repo.GetMetadata(ctx, "db-server", "1.1.0+build.5")
+	if err != nil {
+		t.Fatalf("GetMetadata failed: %v", err)
+	}
+	if meta.Artifact.Version != "1.1.0+build.5" {
+		t.Errorf("Expected metadata version 1.1.0+build.5, got %s", meta.Artifact.Version)
+	}
+
+	// Resolved sources match what was pushed and fetch the original zip
+	resolved := &lockfile.Artifact{Name: "db-server", Version: "1.0.0", Type: artifact.TypeMCP}
+	if err := repo.ResolveSource(ctx, resolved); err != nil {
+		t.Fatalf("ResolveSource failed: %v", err)
+	}
+	if *resolved.SourceOCI != *lf.Artifacts[0].SourceOCI {
+		t.Errorf("Expected resolved source %+v, got %+v", lf.Artifacts[0].SourceOCI, resolved.SourceOCI)
+	}
+	if resolved.Signature == nil || *resolved.Signature != *lf.Artifacts[0].Signature {
+		t.Errorf("Expected resolved signature %+v, got %+v", lf.Artifacts[0].Signature, resolved.Signature)
+	}
+	fetched, err := repo.GetArtifact(ctx, resolved)
+	if err != nil {
+		t.Fatalf("GetArtifact failed: %v", err)
+	}
+	if string(fetched) != string(zips["1.0.0"]) {
+		t.Error("Fetched artifact doesn't match pushed zip")
+	}
+
+	// Moving the tag doesn't change what a locked digest installs
+	if err := repo.AddArtifact(ctx, &lockfile.Artifact{Name: "db-server", Version: "1.0.0"}, zips["1.1.0+build.5"]); err != nil {
+		t.Fatalf("AddArtifact failed: %v", err)
+	}
+	fetched, err = repo.GetArtifact(ctx, &lf.Artifacts[0])
+	if err != nil {
+		t.Fatalf("GetArtifact failed: %v", err)
+	}
+	if string(fetched) != string(zips["1.0.0"]) {
+		t.Error("Expected locked digest to fetch the original zip")
+	}
+}
+
+func TestOCIRepositoryGetPolicy(t *testing.T) {
+	_, server := newTestRegistry(t)
+	repo, err := NewOCIRepository("oci://"+strings.TrimPrefix(server.URL, "http://")+"/acme/skills", "")
+	if err != nil {
+		t.Fatalf("NewOCIRepository failed: %v", err)
+	}
+	ctx := context.Background()
+
+	if data, err := repo.GetPolicy(ctx); err != nil || data != nil {
+		t.Fatalf("GetPolicy without a policy = %q, %v; want nil, nil", data, err)
+	}
+
+	// Pushed the way oras push does, with a generic layer media type
+	policy := []byte("[sources]\ndeny = [\"path\"]\n")
+	config, err := repo.client.pushBlob(ctx, repo.registry, repo.repository, ociEmptyMediaType, ociEmptyConfig)
+	if err != nil {
+		t.Fatalf("Failed to push config: %v", err)
+	}
+	layer, err := repo.client.pushBlob(ctx, repo.registry, repo.repository, "application/vnd.oci.image.layer.v1.tar", policy)
+	if err != nil {
+		t.Fatalf("Failed to push policy: %v", err)
+	}
+	manifest := &ociManifest{SchemaVersion: 2, MediaType: ociManifestMediaType, Config: config, Layers: []ociDescriptor{layer}}
+	if _, err := repo.client.putManifest(ctx, repo.registry, repo.repository, constants.SkillPolicyFile, manifest); err != nil {
+		t.Fatalf("Failed to push policy manifest: %v", err)
+	}
+
+	data, err := repo.GetPolicy(ctx)
+	if err != nil {
+		t.Fatalf("GetPolicy failed: %v", err)
+	}
+	if string(data) != string(policy) {
+		t.Errorf("GetPolicy = %q, want %q", data, policy)
+	}
+}
+
+func TestOCIRepositoryRequiresCredentials(t *testing.T) {
+	reg, server := newTestRegistry(t)
+	reg.username, reg.password = "ci", "secret"
+
+	repo, err := NewOCIRepository(strings.TrimPrefix(server.URL, "http://")+"/acme/skills", "ci:wrong")
+	if err != nil {
+		t.Fatalf("NewOCIRepository failed: %v", err)
+	}
+	if _, err := repo.GetVersionList(context.Background(), "db-server"); err == nil {
+		t.Error("Expected authentication error")
+	}
+}
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		ref     string
+		want    ociReference
+		wantErr bool
+	}{
+		{ref: "oci://ghcr.io/acme/skills", want: ociReference{Registry: "ghcr.io", Repository: "acme/skills"}},
+		{ref: "localhost:5000/skills/db:1.0.0", want: ociReference{Registry: "localhost:5000", Repository: "skills/db", Tag: "1.0.0"}},
+		{ref: "ghcr.io/acme/db@sha256:abc", want: ociReference{Registry: "ghcr.io", Repository: "acme/db", Digest: "sha256:abc"}},
+		{ref: "ghcr.io", wantErr: true},
+		{ref: "ghcr.io/:1.0.0", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseOCIReference(tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseOCIReference() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("parseOCIReference() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAuthChallenge(t *testing.T) {
+	scheme, params := parseAuthChallenge(`Bearer realm="https://ghcr.io/token",service="ghcr.io",scope="repository:acme/skills:pull,push"`)
+	if scheme != "Bearer" {
+		t.Errorf("Expected Bearer scheme, got %q", scheme)
+	}
+	if params["realm"] != "https://ghcr.io/token" || params["service"] != "ghcr.io" || params["scope"] != "repository:acme/skills:pull,push" {
+		t.Errorf("Unexpected challenge params: %v", params)
+	}
+}
@@ -22,15 +22,25 @@ func NewPathSourceHandler(lockFileDir string) *PathSourceHandler {
 	}
 }
 
-// Fetch reads an artifact from a local file path
+// Fetch reads an artifact from a local file path and checks it against the
+// content hashes it was locked with
 func (p *PathSourceHandler) Fetch(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
 	if artifact.SourcePath == nil {
 		return nil, fmt.Errorf("artifact does not have source-path")
 	}
 
-	source := artifact.SourcePath
-	path := source.Path
+	data, err := p.read(artifact.SourcePath.Path)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyContentHashes(data, artifact.SourcePath.Hashes); err != nil {
+		return nil, fmt.Errorf("%s: %w", artifact.SourcePath.Path, err)
+	}
+	return data, nil
+}
 
+// read reads a zip file, or zips a directory
+func (p *PathSourceHandler) read(path string) ([]byte, error) {
 	// Resolve path based on type
 	var resolvedPath string
 	var err error
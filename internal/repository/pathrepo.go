@@ -21,6 +21,7 @@ type PathRepository struct {
 	httpHandler *HTTPSourceHandler
 	pathHandler *PathSourceHandler
 	gitHandler  *GitSourceHandler
+	ociHandler  *OCISourceHandler
 }
 
 // NewPathRepository creates a new path repository from a file:// URL
@@ -42,6 +43,7 @@ func NewPathRepository(repoURL string) (*PathRepository, error) {
 		httpHandler: NewHTTPSourceHandler(""),   // No auth token for path repos
 		pathHandler: NewPathSourceHandler(path), // Use repo path for relative paths
 		gitHandler:  NewGitSourceHandler(gitClient),
+		ociHandler:  NewOCISourceHandler("", ""), // Registries are accessed anonymously
 	}, nil
 }
 
@@ -90,6 +92,11 @@ func (p *PathRepository) GetLockFile(ctx context.Context, cachedETag string) (co
 	return data, "", false, nil
 }
 
+// GetPolicy reads skill-policy.toml from the repository directory
+func (p *PathRepository) GetPolicy(ctx context.Context) ([]byte, error) {
+	return readPolicyFile(filepath.Join(p.repoPath, constants.SkillPolicyFile))
+}
+
 // GetArtifact downloads an artifact using its source configuration
 // Reuses the same dispatch pattern as GitRepository and SleuthRepository
 func (p *PathRepository) GetArtifact(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
@@ -101,6 +108,8 @@ func (p *PathRepository) GetArtifact(ctx context.Context, artifact *lockfile.Art
 		return p.pathHandler.Fetch(ctx, artifact)
 	case "git":
 		return p.gitHandler.Fetch(ctx, artifact)
+	case "oci":
+		return p.ociHandler.Fetch(ctx, artifact)
 	default:
 		return nil, fmt.Errorf("unsupported source type: %s", artifact.GetSourceType())
 	}
@@ -116,8 +125,7 @@ func (p *PathRepository) AddArtifact(ctx context.Context, artifact *lockfile.Art
 	}
 
 	// Store artifacts exploded (not as zip) for easier browsing
-	// Reuse extractZipToDir from GitRepository
-	if err := extractZipToDir(zipData, artifactDir); err != nil {
+	if err := metadata.ExtractZip(zipData, artifactDir); err != nil {
 		return fmt.Errorf("failed to extract zip to directory: %w", err)
 	}
 
@@ -155,19 +163,44 @@ func (p *PathRepository) GetVersionList(ctx context.Context, name string) ([]str
 }
 
 // GetMetadata retrieves metadata for a specific artifact version
-// Not applicable for path repositories (metadata is inside the artifact)
+// Reads metadata.toml from the exploded artifact directory
 func (p *PathRepository) GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error) {
-	return nil, fmt.Errorf("GetMetadata not supported for path repositories")
+	artifactDir := filepath.Join(p.repoPath, "artifacts", name, version)
+	if _, err := os.Stat(artifactDir); os.IsNotExist(err) {
+		return nil, fmt.Errorf("artifact %s@%s not found", name, version)
+	}
+
+	return metadata.ParseFile(filepath.Join(artifactDir, "metadata.toml"))
 }
 
-// VerifyIntegrity checks hashes and sizes for downloaded artifacts
-// Same as GitRepository: no verification needed for local files
-func (p *PathRepository) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
-	// For path repos, integrity is assumed since files are local
-	// No additional verification needed
+// ResolveSource sets source-path for a published artifact version
+// Like AddArtifact, the path is relative to the repository root, which is what it's
+// fetched against wherever the lock file is written (e.g. skills lock -o), so lock
+// files stay portable between machines. The signature is copied from the repository's lock file
+func (p *PathRepository) ResolveSource(ctx context.Context, artifact *lockfile.Artifact) error {
+	relPath := filepath.Join("artifacts", artifact.Name, artifact.Version)
+	contentHash, err := hashArtifactDir(filepath.Join(p.repoPath, relPath))
+	if err != nil {
+		return fmt.Errorf("failed to hash %s@%s: %w", artifact.Name, artifact.Version, err)
+	}
+
+	artifact.SourcePath = &lockfile.SourcePath{
+		Path:   relPath,
+		Hashes: map[string]string{"sha256": contentHash},
+	}
+
+	if data, err := os.ReadFile(p.GetLockFilePath()); err == nil {
+		artifact.Signature = signatureFromLockFile(data, artifact.Name, artifact.Version)
+	}
 	return nil
 }
 
+// VerifyIntegrity checks an artifact against the content hashes of its source-path
+// Same as GitRepository
+func (p *PathRepository) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
+	return verifyContentHashes(data, hashes)
+}
+
 // PostUsageStats is a no-op for path repositories
 // Same as GitRepository
 func (p *PathRepository) PostUsageStats(ctx context.Context, jsonlData string) error {
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/lockfile"
+)
+
+func writePathRepoArtifact(t *testing.T, repoDir, name, version string) {
+	t.Helper()
+	artifactDir := filepath.Join(repoDir, "artifacts", name, version)
+	if err := os.MkdirAll(artifactDir, 0755); err != nil {
+		t.Fatalf("Failed to create artifact dir: %v", err)
+	}
+
+	meta := `[artifact]
+name = "` + name + `"
+version = "` + version + `"
+type = "mcp"
+
+[mcp]
+command = "node"
+args = ["server.js"]
+`
+	if err := os.WriteFile(filepath.Join(artifactDir, "metadata.toml"), []byte(meta), 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(artifactDir, "server.js"), []byte("// server"), 0644); err != nil {
+		t.Fatalf("Failed to write server.js: %v", err)
+	}
+}
+
+func TestPathRepositoryResolveSource(t *testing.T) {
+	repoDir := t.TempDir()
+	writePathRepoArtifact(t, repoDir, "db-server", "1.0.0")
+
+	repo, err := NewPathRepository("file://" + repoDir)
+	if err != nil {
+		t.Fatalf("NewPathRepository failed: %v", err)
+	}
+
+	artifact := &lockfile.Artifact{Name: "db-server", Version: "1.0.0"}
+	if err := repo.ResolveSource(context.Background(), artifact); err != nil {
+		t.Fatalf("ResolveSource failed: %v", err)
+	}
+
+	if artifact.SourcePath == nil {
+		t.Fatal("Expected source-path to be set")
+	}
+	if want := filepath.Join("artifacts", "db-server", "1.0.0"); artifact.SourcePath.Path != want {
+		t.Errorf("Unexpected path: %s", artifact.SourcePath.Path)
+	}
+	if len(artifact.SourcePath.Hashes["sha256"]) != 64 {
+		t.Errorf("Expected sha256 hash, got %v", artifact.SourcePath.Hashes)
+	}
+
+	// The resolved source must be fetchable through the repository, resolved against
+	// its root rather than where the lock file or the current directory is
+	t.Chdir(t.TempDir())
+	data, err := repo.GetArtifact(context.Background(), artifact)
+	if err != nil {
+		t.Fatalf("GetArtifact failed: %v", err)
+	}
+	if len(data) == 0 {
+		t.Error("Expected artifact data")
+	}
+
+	// A directory changed since it was locked isn't installed
+	if err := os.WriteFile(filepath.Join(repoDir, "artifacts", "db-server", "1.0.0", "server.js"), []byte("// tampered"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.GetArtifact(context.Background(), artifact); err == nil || !strings.Contains(err.Error(), "content hash mismatch") {
+		t.Errorf("Expected a content hash mismatch, got %v", err)
+	}
+
+	missing := &lockfile.Artifact{Name: "db-server", Version: "2.0.0"}
+	if err := repo.ResolveSource(context.Background(), missing); err == nil {
+		t.Error("Expected error for missing version")
+	}
+}
+
+func TestPathRepositoryGetMetadata(t *testing.T) {
+	repoDir := t.TempDir()
+	writePathRepoArtifact(t, repoDir, "db-server", "1.0.0")
+
+	repo, err := NewPathRepository(repoDir)
+	if err != nil {
+		t.Fatalf("NewPathRepository failed: %v", err)
+	}
+
+	meta, err := repo.GetMetadata(context.Background(), "db-server", "1.0.0")
+	if err != nil {
+		t.Fatalf("GetMetadata failed: %v", err)
+	}
+	if meta.Artifact.Type.Key != "mcp" {
+		t.Errorf("Expected type mcp, got %s", meta.Artifact.Type)
+	}
+
+	if _, err := repo.GetMetadata(context.Background(), "db-server", "9.9.9"); err == nil {
+		t.Error("Expected error for missing version")
+	}
+}
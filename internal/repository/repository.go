@@ -35,6 +35,11 @@ type Repository interface {
 	// Only applicable to repositories with version management (Sleuth, not Git)
 	GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error)
 
+	// ResolveSource sets the canonical source (source-http, source-git or source-path) for a
+	// published artifact version, including hashes and size where the backend can compute them
+	// The artifact parameter must have Name and Version set
+	ResolveSource(ctx context.Context, artifact *lockfile.Artifact) error
+
 	// VerifyIntegrity checks hashes and sizes for downloaded artifacts
 	VerifyIntegrity(data []byte, hashes map[string]string, size int64) error
 
@@ -43,6 +48,27 @@ type Repository interface {
 	PostUsageStats(ctx context.Context, jsonlData string) error
 }
 
+// LockFileUpdater is implemented by repositories whose lock file is rewritten remotely
+// rather than through a local checkout (GitRepository and PathRepository expose GetLockFilePath instead)
+type LockFileUpdater interface {
+	// UpdateLockFile loads the lock file, applies update and stores the result
+	UpdateLockFile(ctx context.Context, update func(*lockfile.LockFile) error) error
+}
+
+// PolicyProvider is implemented by repositories that can ship an organization policy
+// (skill-policy.toml) alongside their lock file
+type PolicyProvider interface {
+	// GetPolicy returns the repository's policy file, or nil if it doesn't have one
+	GetPolicy(ctx context.Context) ([]byte, error)
+}
+
+// UsageStatsProvider is implemented by repositories that store the usage stats
+// posted to them where every contributor can read them back
+type UsageStatsProvider interface {
+	// GetUsageStats returns every usage event stored in the repository as JSONL
+	GetUsageStats(ctx context.Context) ([]byte, error)
+}
+
 // SourceHandler handles fetching artifacts from specific source types
 // This is used internally by Repository implementations to handle different source types
 type SourceHandler interface {
@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sleuth-io/skills/internal/buildinfo"
+)
+
+// errS3NotFound is returned when an object doesn't exist in the bucket
+var errS3NotFound = errors.New("not found in bucket")
+
+// errS3PreconditionFailed is returned when a conditional put loses a race
+var errS3PreconditionFailed = errors.New("object was modified concurrently")
+
+// s3EmptyPayloadHash is the SHA-256 of an empty request body
+const s3EmptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// s3Credentials are AWS-style access keys
+type s3Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+}
+
+// s3CredentialsFrom parses "ACCESS_KEY_ID:SECRET_ACCESS_KEY" credentials,
+// falling back to the standard AWS environment variables
+// Returns nil for anonymous access
+func s3CredentialsFrom(authToken string) *s3Credentials {
+	if id, secret, ok := strings.Cut(authToken, ":"); ok {
+		return &s3Credentials{AccessKeyID: id, SecretAccessKey: secret}
+	}
+	if id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); id != "" && secret != "" {
+		return &s3Credentials{AccessKeyID: id, SecretAccessKey: secret, SessionToken: os.Getenv("AWS_SESSION_TOKEN")}
+	}
+	return nil
+}
+
+// s3Object is an object read from the bucket
+type s3Object struct {
+	Data     []byte
+	ETag     string
+	Metadata map[string]string // x-amz-meta-* headers, keys lowercased without the prefix
+}
+
+// s3Client talks to S3-compatible object storage using signature version 4
+type s3Client struct {
+	httpClient  *http.Client
+	endpoint    *url.URL // Custom endpoint (MinIO, R2, ...) using path-style URLs, or nil for AWS
+	bucket      string
+	region      string
+	credentials *s3Credentials
+	now         func() time.Time
+}
+
+// objectURL returns the URL of an object key
+func (c *s3Client) objectURL(key string) string {
+	if c.endpoint != nil {
+		return strings.TrimSuffix(c.endpoint.String(), "/") + "/" + c.bucket + "/" + s3EscapePath(key)
+	}
+	// Dotted bucket names don't match the wildcard certificate, so they use path-style URLs
+	if strings.Contains(c.bucket, ".") {
+		return fmt.Sprintf("https://s3.%s.amazonaws.com/%s/%s", c.region, c.bucket, s3EscapePath(key))
+	}
+	return fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", c.bucket, c.region, s3EscapePath(key))
+}
+
+// do sends a signed request for an object
+func (c *s3Client) do(ctx context.Context, method, key string, body []byte, header http.Header) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.objectURL(key), reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("User-Agent", buildinfo.GetUserAgent())
+
+	if c.credentials != nil {
+		signS3Request(req, body, c.credentials, c.region, c.now())
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to contact bucket %s: %w", c.bucket, err)
+	}
+	return resp, nil
+}
+
+// getObject downloads an object
+// If ifNoneMatch matches the object's ETag, returns nil with notModified=true
+func (c *s3Client) getObject(ctx context.Context, key, ifNoneMatch string) (obj *s3Object, notModified bool, err error) {
+	header := http.Header{}
+	if ifNoneMatch != "" {
+		header.Set("If-None-Match", ifNoneMatch)
+	}
+
+	resp, err := c.do(ctx, "GET", key, nil, header)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil, true, nil
+	case http.StatusNotFound:
+		return nil, false, fmt.Errorf("%s %w", key, errS3NotFound)
+	default:
+		return nil, false, s3ResponseError(resp)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	return &s3Object{Data: data, ETag: resp.Header.Get("ETag"), Metadata: s3UserMetadata(resp.Header)}, false, nil
+}
+
+// headObject reads an object's metadata without downloading it
+func (c *s3Client) headObject(ctx context.Context, key string) (*s3Object, int64, error) {
+	resp, err := c.do(ctx, "HEAD", key, nil, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+	_ = resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &s3Object{ETag: resp.Header.Get("ETag"), Metadata: s3UserMetadata(resp.Header)}, resp.ContentLength, nil
+	case http.StatusNotFound:
+		return nil, 0, fmt.Errorf("%s %w", key, errS3NotFound)
+	default:
+		return nil, 0, fmt.Errorf("bucket returned HTTP %d for %s", resp.StatusCode, key)
+	}
+}
+
+// putObject uploads an object
+// ifMatch makes the write conditional: an ETag to replace, "*" to require that the
+// object doesn't exist yet, or "" for an unconditional write
+// Returns errS3PreconditionFailed if the condition doesn't hold
+func (c *s3Client) putObject(ctx context.Context, key string, data []byte, contentType, ifMatch string, metadata map[string]string) (string, error) {
+	header := http.Header{"Content-Type": {contentType}}
+	switch ifMatch {
+	case "":
+	case "*":
+		header.Set("If-None-Match", "*")
+	default:
+		header.Set("If-Match", ifMatch)
+	}
+	for name, value := range metadata {
+		header.Set("X-Amz-Meta-"+name, value)
+	}
+
+	resp, err := c.do(ctx, "PUT", key, data, header)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Header.Get("ETag"), nil
+	case http.StatusPreconditionFailed, http.StatusConflict:
+		// 409 ConditionalRequestConflict is returned when a competing conditional write is in flight
+		return "", fmt.Errorf("%s: %w", key, errS3PreconditionFailed)
+	default:
+		return "", s3ResponseError(resp)
+	}
+}
+
+// updateObject rewrites an object with a conditional put, retrying if another writer got there first
+// update receives the current contents, or nil if the object doesn't exist
+func (c *s3Client) updateObject(ctx context.Context, key, contentType string, update func(current []byte) ([]byte, error)) error {
+	const maxAttempts = 10
+
+	for attempt := 1; ; attempt++ {
+		var current []byte
+		ifMatch := "*"
+
+		obj, _, err := c.getObject(ctx, key, "")
+		switch {
+		case errors.Is(err, errS3NotFound):
+		case err != nil:
+			return err
+		default:
+			current, ifMatch = obj.Data, obj.ETag
+		}
+
+		data, err := update(current)
+		if err != nil {
+			return err
+		}
+
+		_, err = c.putObject(ctx, key, data, contentType, ifMatch, nil)
+		if !errors.Is(err, errS3PreconditionFailed) || attempt == maxAttempts {
+			return err
+		}
+
+		// Back off briefly with jitter before re-reading, so competing writers spread out
+		backoff := time.Duration(attempt*50+rand.IntN(50)) * time.Millisecond
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
+
+// s3UserMetadata extracts x-amz-meta-* headers
+func s3UserMetadata(header http.Header) map[string]string {
+	metadata := make(map[string]string)
+	for name := range header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-meta-") {
+			metadata[strings.TrimPrefix(lower, "x-amz-meta-")] = header.Get(name)
+		}
+	}
+	return metadata
+}
+
+// s3ResponseError builds an error from a failed S3 response
+func s3ResponseError(resp *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+
+	// Errors are XML, e.g. <Error><Code>AccessDenied</Code><Message>...</Message></Error>
+	code := s3XMLElement(string(data), "Code")
+	message := s3XMLElement(string(data), "Message")
+	if code != "" {
+		return fmt.Errorf("bucket returned HTTP %d: %s: %s", resp.StatusCode, code, message)
+	}
+	return fmt.Errorf("bucket returned HTTP %d", resp.StatusCode)
+}
+
+// s3XMLElement returns the text of the first <name> element in an XML document
+func s3XMLElement(doc, name string) string {
+	_, rest, ok := strings.Cut(doc, "<"+name+">")
+	if !ok {
+		return ""
+	}
+	text, _, _ := strings.Cut(rest, "</"+name+">")
+	return text
+}
+
+// signS3Request adds AWS signature version 4 headers to a request
+// Host, Content-Type, Range, conditional and x-amz-* headers are signed
+func signS3Request(req *http.Request, body []byte, creds *s3Credentials, region string, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	payloadHash := s3EmptyPayloadHash
+	if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	// Canonical headers, sorted by lowercase name
+	signed := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		switch {
+		case strings.HasPrefix(lower, "x-amz-"),
+			lower == "content-type", lower == "range", lower == "if-match", lower == "if-none-match":
+			signed[lower] = strings.Join(req.Header.Values(name), ",")
+		}
+	}
+	names := make([]string, 0, len(signed))
+	for name := range signed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(signed[name]) + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		s3EscapePath(req.URL.Path),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := s3HMAC([]byte("AWS4"+creds.SecretAccessKey), date)
+	key = s3HMAC(key, region)
+	key = s3HMAC(key, "s3")
+	key = s3HMAC(key, "aws4_request")
+	signature := hex.EncodeToString(s3HMAC(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		creds.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// s3HMAC computes HMAC-SHA256
+func s3HMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// s3EscapePath URI-encodes a path, leaving slashes intact
+func s3EscapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = s3Escape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// s3CanonicalQuery encodes query parameters sorted by name
+func s3CanonicalQuery(query url.Values) string {
+	var pairs []string
+	for name, values := range query {
+		for _, value := range values {
+			pairs = append(pairs, s3Escape(name)+"="+s3Escape(value))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// s3Escape percent-encodes everything except RFC 3986 unreserved characters
+func s3Escape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
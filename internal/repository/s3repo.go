@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sleuth-io/skills/internal/constants"
+	"github.com/sleuth-io/skills/internal/git"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// S3Repository implements Repository for S3-compatible object storage (AWS S3, MinIO, R2, ...)
+//
+// Objects use the same layout as path and git repositories, under an optional prefix:
+//   - {prefix}/skill.lock
+//   - {prefix}/artifacts/{name}/list.txt
+//   - {prefix}/artifacts/{name}/{version}/metadata.toml
+//   - {prefix}/artifacts/{name}/{version}/{name}-{version}.zip
+type S3Repository struct {
+	repoURL     string
+	prefix      string
+	client      *s3Client
+	httpHandler *HTTPSourceHandler
+	pathHandler *PathSourceHandler
+	gitHandler  *GitSourceHandler
+	ociHandler  *OCISourceHandler
+}
+
+// NewS3Repository creates a new S3 repository from an s3://bucket/prefix URL
+//
+// Optional query parameters:
+//   - region: bucket region (default: AWS_REGION, AWS_DEFAULT_REGION, then us-east-1)
+//   - endpoint: S3-compatible endpoint such as http://localhost:9000, addressed path-style
+//
+// authToken is "ACCESS_KEY_ID:SECRET_ACCESS_KEY"; if empty, the AWS_* environment
+// variables are used, and without those the bucket is accessed anonymously
+func NewS3Repository(repoURL, authToken string) (*S3Repository, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 URL: %w", err)
+	}
+	if u.Scheme != "s3" || u.Host == "" {
+		return nil, fmt.Errorf("invalid S3 URL %q: must be s3://bucket/prefix", repoURL)
+	}
+
+	query := u.Query()
+	region := query.Get("region")
+	if region == "" {
+		region = os.Getenv("AWS_REGION")
+	}
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	var endpoint *url.URL
+	if raw := query.Get("endpoint"); raw != "" {
+		endpoint, err = url.Parse(raw)
+		if err != nil || endpoint.Host == "" {
+			return nil, fmt.Errorf("invalid S3 endpoint: %s", raw)
+		}
+	}
+
+	return &S3Repository{
+		repoURL: repoURL,
+		prefix:  strings.Trim(u.Path, "/"),
+		client: &s3Client{
+			httpClient:  &http.Client{Timeout: 5 * time.Minute},
+			endpoint:    endpoint,
+			bucket:      u.Host,
+			region:      region,
+			credentials: s3CredentialsFrom(authToken),
+			now:         time.Now,
+		},
+		httpHandler: NewHTTPSourceHandler(""),
+		pathHandler: NewPathSourceHandler(""), // Lock file dir not applicable for S3
+		gitHandler:  NewGitSourceHandler(git.NewClient()),
+		ociHandler:  NewOCISourceHandler("", ""), // Registries are accessed anonymously
+	}, nil
+}
+
+// key returns the object key for a path relative to the repository prefix
+func (s *S3Repository) key(parts ...string) string {
+	if s.prefix != "" {
+		parts = append([]string{s.prefix}, parts...)
+	}
+	return strings.Join(parts, "/")
+}
+
+// artifactKey returns the object key of an artifact version's zip
+func (s *S3Repository) artifactKey(name, version string) string {
+	return s.key("artifacts", name, version, fmt.Sprintf("%s-%s.zip", name, version))
+}
+
+// Authenticate performs authentication - no-op for S3 repositories
+// Requests are signed with the configured access keys
+func (s *S3Repository) Authenticate(ctx context.Context) (string, error) {
+	return "", nil
+}
+
+// GetLockFile retrieves the lock file from the bucket
+// The object's ETag is passed as If-None-Match to skip unchanged downloads
+func (s *S3Repository) GetLockFile(ctx context.Context, cachedETag string) (content []byte, etag string, notModified bool, err error) {
+	obj, notModified, err := s.client.getObject(ctx, s.key(constants.SkillLockFile), cachedETag)
+	if errors.Is(err, errS3NotFound) {
+		return nil, "", false, fmt.Errorf("%s not found in bucket: %s", constants.SkillLockFile, s.repoURL)
+	}
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to fetch lock file: %w", err)
+	}
+	if notModified {
+		return nil, cachedETag, true, nil
+	}
+
+	return obj.Data, obj.ETag, false, nil
+}
+
+// GetPolicy retrieves skill-policy.toml from the bucket
+func (s *S3Repository) GetPolicy(ctx context.Context) ([]byte, error) {
+	obj, _, err := s.client.getObject(ctx, s.key(constants.SkillPolicyFile), "")
+	if errors.Is(err, errS3NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy: %w", err)
+	}
+	return obj.Data, nil
+}
+
+// UpdateLockFile applies update to the lock file and writes it back with a conditional put
+// If another publisher changes the lock file in between, the update is re-applied to their version
+func (s *S3Repository) UpdateLockFile(ctx context.Context, update func(*lockfile.LockFile) error) error {
+	return s.client.updateObject(ctx, s.key(constants.SkillLockFile), "application/toml", func(current []byte) ([]byte, error) {
+		lockFile := lockfile.New()
+		if current != nil {
+			var err error
+			if lockFile, err = lockfile.Parse(current); err != nil {
+				return nil, err
+			}
+		}
+
+		if err := update(lockFile); err != nil {
+			return nil, err
+		}
+		return lockfile.Marshal(lockFile)
+	})
+}
+
+// GetArtifact downloads an artifact using its source configuration
+// source-http URLs inside this bucket are fetched with signed requests, so private buckets work
+func (s *S3Repository) GetArtifact(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
+	switch artifact.GetSourceType() {
+	case "http":
+		if key, ok := s.keyForURL(artifact.SourceHTTP.URL); ok {
+			return s.fetchObject(ctx, key, artifact.SourceHTTP)
+		}
+		return s.httpHandler.Fetch(ctx, artifact)
+	case "path":
+		return s.pathHandler.Fetch(ctx, artifact)
+	case "git":
+		return s.gitHandler.Fetch(ctx, artifact)
+	case "oci":
+		return s.ociHandler.Fetch(ctx, artifact)
+	default:
+		return nil, fmt.Errorf("unsupported source type: %s", artifact.GetSourceType())
+	}
+}
+
+// keyForURL returns the object key for a URL pointing into this bucket
+func (s *S3Repository) keyForURL(rawURL string) (string, bool) {
+	base := s.client.objectURL("")
+	if !strings.HasPrefix(rawURL, base) {
+		return "", false
+	}
+	key, err := url.PathUnescape(strings.TrimPrefix(rawURL, base))
+	if err != nil {
+		return "", false
+	}
+	return key, true
+}
+
+// fetchObject downloads an artifact zip and verifies it like HTTPSourceHandler does
+func (s *S3Repository) fetchObject(ctx context.Context, key string, source *lockfile.SourceHTTP) ([]byte, error) {
+	obj, _, err := s.client.getObject(ctx, key, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+
+	if err := s.VerifyIntegrity(obj.Data, source.Hashes, source.Size); err != nil {
+		return nil, fmt.Errorf("hash verification failed: %w", err)
+	}
+
+	if !utils.IsZipFile(obj.Data) {
+		return nil, fmt.Errorf("downloaded file is not a valid zip archive")
+	}
+
+	return obj.Data, nil
+}
+
+// AddArtifact uploads an artifact zip and its metadata and adds the version to list.txt
+// list.txt is rewritten with a conditional put, so concurrent publishers don't
+// overwrite each other's changes
+// Note: the lock file is NOT updated here, callers use UpdateLockFile
+func (s *S3Repository) AddArtifact(ctx context.Context, artifact *lockfile.Artifact, zipData []byte) error {
+	sha256Hash := utils.ComputeSHA256(zipData)
+
+	zipKey := s.artifactKey(artifact.Name, artifact.Version)
+	objectMetadata := map[string]string{"sha256": sha256Hash}
+	for k, v := range signatureFields("", artifact.Signature) {
+		objectMetadata[k] = v
+	}
+	if _, err := s.client.putObject(ctx, zipKey, zipData, "application/zip", "", objectMetadata); err != nil {
+		return fmt.Errorf("failed to upload artifact: %w", err)
+	}
+
+	if metadataData, err := utils.ReadZipFile(zipData, "metadata.toml"); err == nil {
+		metadataKey := s.key("artifacts", artifact.Name, artifact.Version, "metadata.toml")
+		if _, err := s.client.putObject(ctx, metadataKey, metadataData, "application/toml", "", nil); err != nil {
+			return fmt.Errorf("failed to upload metadata: %w", err)
+		}
+	}
+
+	listKey := s.key("artifacts", artifact.Name, "list.txt")
+	err := s.client.updateObject(ctx, listKey, "text/plain", func(current []byte) ([]byte, error) {
+		versions := parseVersionList(current)
+		for _, v := range versions {
+			if v == artifact.Version {
+				return current, nil
+			}
+		}
+		versions = append(versions, artifact.Version)
+		return []byte(strings.Join(versions, "\n") + "\n"), nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update version list: %w", err)
+	}
+
+	artifact.SourceHTTP = &lockfile.SourceHTTP{
+		URL:    s.client.objectURL(zipKey),
+		Hashes: map[string]string{"sha256": sha256Hash},
+		Size:   int64(len(zipData)),
+	}
+
+	return nil
+}
+
+// GetVersionList retrieves available versions for an artifact from list.txt
+func (s *S3Repository) GetVersionList(ctx context.Context, name string) ([]string, error) {
+	obj, _, err := s.client.getObject(ctx, s.key("artifacts", name, "list.txt"), "")
+	if errors.Is(err, errS3NotFound) {
+		// No versions exist for this artifact
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read version list: %w", err)
+	}
+
+	// Parse versions from file using common parser (shared with GitRepository and PathRepository)
+	return parseVersionList(obj.Data), nil
+}
+
+// GetMetadata retrieves metadata for a specific artifact version
+// Reads metadata.toml stored alongside the zip
+func (s *S3Repository) GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error) {
+	obj, _, err := s.client.getObject(ctx, s.key("artifacts", name, version, "metadata.toml"), "")
+	if errors.Is(err, errS3NotFound) {
+		return nil, fmt.Errorf("artifact %s@%s not found", name, version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read metadata: %w", err)
+	}
+
+	return metadata.Parse(obj.Data)
+}
+
+// ResolveSource sets source-http for a published artifact version
+// The hash recorded at upload is used when present, otherwise the zip is downloaded and hashed
+// The signature comes from the object metadata, or the repository's lock file for older uploads
+func (s *S3Repository) ResolveSource(ctx context.Context, artifact *lockfile.Artifact) error {
+	zipKey := s.artifactKey(artifact.Name, artifact.Version)
+
+	obj, size, err := s.client.headObject(ctx, zipKey)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s@%s: %w", artifact.Name, artifact.Version, err)
+	}
+
+	sha256Hash := obj.Metadata["sha256"]
+	if sha256Hash == "" {
+		full, _, err := s.client.getObject(ctx, zipKey, "")
+		if err != nil {
+			return fmt.Errorf("failed to download %s@%s: %w", artifact.Name, artifact.Version, err)
+		}
+		sha256Hash = utils.ComputeSHA256(full.Data)
+		size = int64(len(full.Data))
+	}
+
+	artifact.SourceHTTP = &lockfile.SourceHTTP{
+		URL:    s.client.objectURL(zipKey),
+		Hashes: map[string]string{"sha256": sha256Hash},
+		Size:   size,
+	}
+
+	artifact.Signature = signatureFromFields("", obj.Metadata)
+	if artifact.Signature == nil {
+		if data, _, _, err := s.GetLockFile(ctx, ""); err == nil {
+			artifact.Signature = signatureFromLockFile(data, artifact.Name, artifact.Version)
+		}
+	}
+	return nil
+}
+
+// VerifyIntegrity checks hashes and sizes for downloaded artifacts
+func (s *S3Repository) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
+	// Verify size if provided
+	if size > 0 {
+		if int64(len(data)) != size {
+			return fmt.Errorf("size mismatch: expected %d bytes, got %d bytes", size, len(data))
+		}
+	}
+
+	return s.httpHandler.verifyHashes(data, hashes)
+}
+
+// PostUsageStats is a no-op for S3 repositories
+func (s *S3Repository) PostUsageStats(ctx context.Context, jsonlData string) error {
+	return nil
+}
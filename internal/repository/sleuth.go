@@ -15,6 +15,7 @@ import (
 	"github.com/sleuth-io/skills/internal/git"
 	"github.com/sleuth-io/skills/internal/lockfile"
 	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
 )
 
 // SleuthRepository implements Repository for Sleuth HTTP servers
@@ -25,6 +26,7 @@ type SleuthRepository struct {
 	httpHandler *HTTPSourceHandler
 	pathHandler *PathSourceHandler
 	gitHandler  *GitSourceHandler
+	ociHandler  *OCISourceHandler
 }
 
 // NewSleuthRepository creates a new Sleuth repository
@@ -37,6 +39,7 @@ func NewSleuthRepository(serverURL, authToken string) *SleuthRepository {
 		httpHandler: NewHTTPSourceHandler(authToken),
 		pathHandler: NewPathSourceHandler(""), // Lock file dir not applicable for Sleuth
 		gitHandler:  NewGitSourceHandler(gitClient),
+		ociHandler:  NewOCISourceHandler("", ""), // Registries are accessed anonymously
 	}
 }
 
@@ -103,6 +106,42 @@ func (s *SleuthRepository) GetLockFile(ctx context.Context, cachedETag string) (
 	return data, newETag, false, nil
 }
 
+// GetPolicy retrieves the organization's policy from the Sleuth server
+// Servers without a policy respond with 404
+func (s *SleuthRepository) GetPolicy(ctx context.Context) ([]byte, error) {
+	endpoint := s.serverURL + "/api/skills/skill-policy.toml"
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("User-Agent", buildinfo.GetUserAgent())
+	if s.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.authToken)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(body))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return data, nil
+}
+
 // GetArtifact downloads an artifact using its source configuration
 func (s *SleuthRepository) GetArtifact(ctx context.Context, artifact *lockfile.Artifact) ([]byte, error) {
 	// Dispatch to appropriate source handler based on artifact source type
@@ -113,6 +152,8 @@ func (s *SleuthRepository) GetArtifact(ctx context.Context, artifact *lockfile.A
 		return s.pathHandler.Fetch(ctx, artifact)
 	case "git":
 		return s.gitHandler.Fetch(ctx, artifact)
+	case "oci":
+		return s.ociHandler.Fetch(ctx, artifact)
 	default:
 		return nil, fmt.Errorf("unsupported source type: %s", artifact.GetSourceType())
 	}
@@ -139,6 +180,11 @@ func (s *SleuthRepository) AddArtifact(ctx context.Context, artifact *lockfile.A
 	_ = writer.WriteField("name", artifact.Name)
 	_ = writer.WriteField("version", artifact.Version)
 	_ = writer.WriteField("type", artifact.Type.Key)
+	if artifact.Signature != nil {
+		_ = writer.WriteField("signature-algorithm", artifact.Signature.Algorithm)
+		_ = writer.WriteField("signature-key-id", artifact.Signature.KeyID)
+		_ = writer.WriteField("signature", artifact.Signature.Value)
+	}
 
 	// Close writer
 	if err := writer.Close(); err != nil {
@@ -266,6 +312,36 @@ func (s *SleuthRepository) GetMetadata(ctx context.Context, name, version string
 	return metadata.Parse(data)
 }
 
+// ResolveSource sets source-http for a published artifact version
+// The artifact is downloaded once so the lock file records its real hash and size
+// The signature is copied from the server's lock file
+func (s *SleuthRepository) ResolveSource(ctx context.Context, artifact *lockfile.Artifact) error {
+	artifactURL := fmt.Sprintf("%s/api/skills/artifacts/%s/%s/%s-%s.zip",
+		s.serverURL, artifact.Name, artifact.Version, artifact.Name, artifact.Version)
+
+	data, err := s.httpHandler.DownloadWithProgress(ctx, artifactURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to download %s@%s: %w", artifact.Name, artifact.Version, err)
+	}
+
+	if !utils.IsZipFile(data) {
+		return fmt.Errorf("downloaded file is not a valid zip archive")
+	}
+
+	artifact.SourceHTTP = &lockfile.SourceHTTP{
+		URL: artifactURL,
+		Hashes: map[string]string{
+			"sha256": utils.ComputeSHA256(data),
+		},
+		Size: int64(len(data)),
+	}
+
+	if lockData, _, _, err := s.GetLockFile(ctx, ""); err == nil {
+		artifact.Signature = signatureFromLockFile(lockData, artifact.Name, artifact.Version)
+	}
+	return nil
+}
+
 // VerifyIntegrity checks hashes and sizes for downloaded artifacts
 func (s *SleuthRepository) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
 	// Verify size if provided
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+func TestSleuthRepositoryResolveSource(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "metadata.toml"), []byte("[artifact]\n"), 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+	zipData, err := utils.CreateZip(dir)
+	if err != nil {
+		t.Fatalf("CreateZip failed: %v", err)
+	}
+
+	lockData := []byte(`lock-version = "1.0"
+version = "1"
+created-by = "test"
+
+[[artifacts]]
+name = "code-review"
+version = "1.2.0"
+type = "skill"
+
+[artifacts.source-http]
+url = "https://example.com/code-review-1.2.0.zip"
+hashes = { sha256 = "abc" }
+
+[artifacts.signature]
+algorithm = "ed25519"
+key-id = "key1"
+value = "c2ln"
+`)
+
+	var requestedPath, authHeader string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/skills/skill.lock" {
+			w.Write(lockData)
+			return
+		}
+		requestedPath = r.URL.Path
+		authHeader = r.Header.Get("Authorization")
+		w.Write(zipData)
+	}))
+	defer server.Close()
+
+	repo := NewSleuthRepository(server.URL, "token")
+	artifact := &lockfile.Artifact{Name: "code-review", Version: "1.2.0"}
+	if err := repo.ResolveSource(context.Background(), artifact); err != nil {
+		t.Fatalf("ResolveSource failed: %v", err)
+	}
+
+	expectedPath := "/api/skills/artifacts/code-review/1.2.0/code-review-1.2.0.zip"
+	if requestedPath != expectedPath {
+		t.Errorf("Requested %s, want %s", requestedPath, expectedPath)
+	}
+	if authHeader != "Bearer token" {
+		t.Errorf("Expected bearer auth, got %q", authHeader)
+	}
+
+	source := artifact.SourceHTTP
+	if source == nil {
+		t.Fatal("Expected source-http to be set")
+	}
+	if source.URL != server.URL+expectedPath {
+		t.Errorf("Unexpected URL: %s", source.URL)
+	}
+	if source.Hashes["sha256"] != utils.ComputeSHA256(zipData) {
+		t.Errorf("Unexpected hash: %v", source.Hashes)
+	}
+	if source.Size != int64(len(zipData)) {
+		t.Errorf("Expected size %d, got %d", len(zipData), source.Size)
+	}
+	if artifact.Signature == nil || artifact.Signature.KeyID != "key1" || artifact.Signature.Value != "c2ln" {
+		t.Errorf("Expected the signature from the server lock file, got %+v", artifact.Signature)
+	}
+}
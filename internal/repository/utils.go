@@ -1,6 +1,15 @@
 package repository
 
-import "bytes"
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/utils"
+)
 
 // parseVersionList parses a newline-separated list of versions from bytes
 // This is the standard format for list.txt files across all repository types
@@ -14,3 +23,88 @@ func parseVersionList(data []byte) []string {
 	}
 	return versions
 }
+
+// hashArtifactDir computes the content hash of an exploded artifact directory
+// The hash covers file names and contents only, so it is stable across checkouts
+func hashArtifactDir(artifactDir string) (string, error) {
+	if !utils.IsDirectory(artifactDir) {
+		return "", fmt.Errorf("artifact directory not found: %s", artifactDir)
+	}
+
+	zipData, err := utils.CreateZip(artifactDir)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.ComputeZipContentSHA256(zipData)
+}
+
+// verifyContentHashes checks an artifact zip against the content hashes its path or
+// git source was locked with, so a directory changed since then isn't installed
+// Sources locked without hashes aren't checked
+func verifyContentHashes(data []byte, hashes map[string]string) error {
+	for algo, expected := range hashes {
+		if err := utils.VerifyZipContentHash(data, algo, expected); err != nil {
+			return fmt.Errorf("%w (the source changed since it was locked)", err)
+		}
+	}
+	return nil
+}
+
+// signatureFromLockFile returns the signature the repository's own lock file records
+// for name@version, so re-resolved lock entries keep the signature given at publish
+// A missing or unreadable lock file leaves the artifact unsigned
+func signatureFromLockFile(data []byte, name, version string) *lockfile.Signature {
+	lockFile, err := lockfile.Parse(data)
+	if err != nil {
+		return nil
+	}
+	for _, artifact := range lockFile.Artifacts {
+		if artifact.Name == name && artifact.Version == version {
+			return artifact.Signature
+		}
+	}
+	return nil
+}
+
+// signatureFields flattens a signature into metadata fields (S3 object metadata, OCI
+// annotations), with the value base64 encoded since minisign signatures span lines
+func signatureFields(prefix string, sig *lockfile.Signature) map[string]string {
+	if sig == nil {
+		return nil
+	}
+	return map[string]string{
+		prefix + "signature-algorithm": sig.Algorithm,
+		prefix + "signature-key-id":    sig.KeyID,
+		prefix + "signature":           base64.StdEncoding.EncodeToString([]byte(sig.Value)),
+	}
+}
+
+// signatureFromFields reads a signature written by signatureFields, or nil if there isn't one
+func signatureFromFields(prefix string, fields map[string]string) *lockfile.Signature {
+	algorithm, encoded := fields[prefix+"signature-algorithm"], fields[prefix+"signature"]
+	if algorithm == "" || encoded == "" {
+		return nil
+	}
+	value, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil
+	}
+	return &lockfile.Signature{
+		Algorithm: algorithm,
+		KeyID:     fields[prefix+"signature-key-id"],
+		Value:     string(value),
+	}
+}
+
+// readPolicyFile reads a policy file, returning nil if it doesn't exist
+func readPolicyFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy: %w", err)
+	}
+	return data, nil
+}
@@ -12,10 +12,14 @@ type Requirement struct {
 	// Original line from the file
 	Raw string
 
+	// Line number in the requirements file (0 if not read from a file)
+	Line int
+
 	// Type of requirement
 	Type RequirementType
 
 	// For registry artifacts
+	Repository      string // Optional repository pin, from "repo::name"
 	Name            string
 	VersionSpec     string
 	VersionOperator string // ==, >=, >, <=, <, ~=
@@ -71,6 +75,7 @@ func Parse(filePath string) ([]Requirement, error) {
 		}
 
 		req.Raw = line
+		req.Line = lineNum
 		requirements = append(requirements, req)
 	}
 
@@ -107,6 +112,20 @@ func ParseLine(line string) (Requirement, error) {
 		}, nil
 	}
 
+	// Registry artifact pinned to a repository: repo::name[version-spec]
+	if repoName, rest, ok := strings.Cut(line, "::"); ok {
+		repoName = strings.TrimSpace(repoName)
+		if repoName == "" {
+			return Requirement{}, fmt.Errorf("missing repository name before '::': %s", line)
+		}
+		req, err := parseRegistryRequirement(strings.TrimSpace(rest))
+		if err != nil {
+			return Requirement{}, err
+		}
+		req.Repository = repoName
+		return req, nil
+	}
+
 	// Registry artifact: name[version-spec]
 	return parseRegistryRequirement(line)
 }
@@ -171,22 +190,27 @@ func parseGitRequirement(line string) (Requirement, error) {
 // parseRegistryRequirement parses name[version-spec]
 func parseRegistryRequirement(line string) (Requirement, error) {
 	// Check for version operators: ==, >=, >, <=, <, ~=
+	// The name ends at the first operator, so "name<2.0,>=1.0" keeps every specifier
 	operators := []string{"~=", "==", ">=", "<=", "!=", ">", "<"}
 
+	opIndex := -1
+	var operator string
 	for _, op := range operators {
-		if idx := strings.Index(line, op); idx != -1 {
-			name := strings.TrimSpace(line[:idx])
-			versionSpec := strings.TrimSpace(line[idx+len(op):])
-
-			return Requirement{
-				Type:            RequirementTypeRegistry,
-				Name:            name,
-				VersionOperator: op,
-				VersionSpec:     versionSpec,
-			}, nil
+		if idx := strings.Index(line, op); idx != -1 && (opIndex == -1 || idx < opIndex) {
+			opIndex = idx
+			operator = op
 		}
 	}
 
+	if opIndex != -1 {
+		return Requirement{
+			Type:            RequirementTypeRegistry,
+			Name:            strings.TrimSpace(line[:opIndex]),
+			VersionOperator: operator,
+			VersionSpec:     strings.TrimSpace(line[opIndex+len(operator):]),
+		}, nil
+	}
+
 	// No operator, just a name (latest version)
 	return Requirement{
 		Type: RequirementTypeRegistry,
@@ -198,10 +222,14 @@ func parseRegistryRequirement(line string) (Requirement, error) {
 func (r Requirement) String() string {
 	switch r.Type {
 	case RequirementTypeRegistry:
+		name := r.Name
+		if r.Repository != "" {
+			name = r.Repository + "::" + r.Name
+		}
 		if r.VersionOperator != "" {
-			return fmt.Sprintf("%s%s%s", r.Name, r.VersionOperator, r.VersionSpec)
+			return fmt.Sprintf("%s%s%s", name, r.VersionOperator, r.VersionSpec)
 		}
-		return r.Name
+		return name
 	case RequirementTypeGit:
 		result := fmt.Sprintf("git+%s@%s#name=%s", r.GitURL, r.GitRef, r.GitName)
 		if r.GitSubdirectory != "" {
@@ -0,0 +1,146 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sleuth-io/skills/internal/version"
+)
+
+const (
+	// maxShownAttempts limits how many failed candidate versions are rendered per artifact
+	maxShownAttempts = 5
+
+	// maxShownVersions limits how many available versions are listed per artifact
+	maxShownVersions = 10
+)
+
+// Conflict explains why no version of an artifact could be selected
+type Conflict struct {
+	// Name of the artifact that could not be resolved
+	Name string
+
+	// Terms describes every constraint on the artifact and where it came from
+	Terms []string
+
+	// Available lists the versions that were considered
+	Available []string
+
+	// Attempts records why each matching candidate version was rejected, highest first
+	Attempts []Attempt
+}
+
+// Attempt records a rejected candidate version
+type Attempt struct {
+	Version string
+
+	// Reason is set when the candidate's own dependencies clashed with a selected version
+	Reason string
+
+	// Cause is set when selecting the candidate led to a conflict further down the graph
+	Cause *Conflict
+}
+
+// ResolutionError is returned when requirements cannot be satisfied
+type ResolutionError struct {
+	Conflict *Conflict
+}
+
+// Error renders the conflict as a derivation tree
+func (e *ResolutionError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.Conflict.Summary())
+	e.Conflict.render(&sb, "")
+	return sb.String()
+}
+
+// Summary returns a one-line description of the conflict
+func (c *Conflict) Summary() string {
+	switch {
+	case len(c.Available) == 0:
+		return fmt.Sprintf("no versions of %s are available", c.Name)
+	case len(c.Attempts) == 0:
+		return fmt.Sprintf("no version of %s satisfies all constraints", c.Name)
+	default:
+		return fmt.Sprintf("no version of %s works with the rest of the requirements", c.Name)
+	}
+}
+
+// render writes the children of a conflict as tree branches
+func (c *Conflict) render(sb *strings.Builder, prefix string) {
+	type node struct {
+		label string
+		cause *Conflict
+	}
+
+	var nodes []node
+	for _, t := range c.Terms {
+		nodes = append(nodes, node{label: "required by " + t})
+	}
+
+	if len(c.Available) > 0 {
+		nodes = append(nodes, node{label: "available: " + formatVersions(c.Available)})
+	}
+
+	for i, attempt := range c.Attempts {
+		if i == maxShownAttempts {
+			nodes = append(nodes, node{label: fmt.Sprintf("... %d more version(s) rejected", len(c.Attempts)-i)})
+			break
+		}
+		if attempt.Cause != nil {
+			nodes = append(nodes, node{
+				label: fmt.Sprintf("%s@%s: %s", c.Name, attempt.Version, attempt.Cause.Summary()),
+				cause: attempt.Cause,
+			})
+		} else {
+			nodes = append(nodes, node{label: fmt.Sprintf("%s@%s: %s", c.Name, attempt.Version, attempt.Reason)})
+		}
+	}
+
+	for i, n := range nodes {
+		branch, indent := "├─ ", "│  "
+		if i == len(nodes)-1 {
+			branch, indent = "└─ ", "   "
+		}
+
+		sb.WriteString("\n" + prefix + branch + n.label)
+		if n.cause != nil {
+			n.cause.render(sb, prefix+indent)
+		}
+	}
+}
+
+// formatVersions lists versions highest first, truncating long lists
+func formatVersions(versions []string) string {
+	sorted := version.SortDescending(versions)
+	if len(sorted) == 0 {
+		// None of the versions are valid semver, show them as reported
+		sorted = versions
+	}
+
+	if len(sorted) > maxShownVersions {
+		return fmt.Sprintf("%s, ... (%d more)", strings.Join(sorted[:maxShownVersions], ", "), len(sorted)-maxShownVersions)
+	}
+	return strings.Join(sorted, ", ")
+}
+
+// describeTerms explains each term with the chain of requirements that introduced it
+func describeTerms(terms []*term) []string {
+	descriptions := make([]string, 0, len(terms))
+	for _, t := range terms {
+		descriptions = append(descriptions, t.describe())
+	}
+	return descriptions
+}
+
+// describe explains a term, e.g. "common-utils<2.0 ← code-review@1.4.0 ← code-review>=1.0 (line 1)"
+func (t *term) describe() string {
+	if t.parent == nil {
+		if t.req.Line > 0 {
+			return fmt.Sprintf("%s (line %d)", t.req.String(), t.req.Line)
+		}
+		return t.req.String()
+	}
+
+	return fmt.Sprintf("%s ← %s@%s ← %s", t.req.String(), t.parent.name, t.parent.version, t.parent.cause.describe())
+}
@@ -5,19 +5,15 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
-	"io"
-	"net/http"
-	"os"
-	"path/filepath"
-	"strings"
+	"sort"
 
-	"github.com/sleuth-io/skills/internal/artifact"
 	"github.com/sleuth-io/skills/internal/buildinfo"
 	"github.com/sleuth-io/skills/internal/git"
 	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
 	"github.com/sleuth-io/skills/internal/repository"
 	"github.com/sleuth-io/skills/internal/requirements"
-	"github.com/sleuth-io/skills/internal/version"
+	"github.com/sleuth-io/skills/internal/utils"
 )
 
 // Resolver resolves requirements to lock file artifacts
@@ -35,57 +31,88 @@ func New(ctx context.Context, repo repository.Repository) *Resolver {
 }
 
 // Resolve resolves a list of requirements to lock file artifacts
+// Every constraint on an artifact, from the requirements file or from a dependency, must be
+// satisfied by the selected version. Candidates are tried highest first and the resolver
+// backtracks when a choice leads to a conflict. If no consistent set of versions exists,
+// a *ResolutionError explains why.
 func (r *Resolver) Resolve(reqs []requirements.Requirement) (*lockfile.LockFile, error) {
-	// Map to track resolved artifacts by name
-	resolved := make(map[string]*lockfile.Artifact)
-	// Queue of artifacts to process (for dependency resolution)
-	queue := make([]requirements.Requirement, len(reqs))
-	copy(queue, reqs)
-
-	// Track what we're processing to detect circular dependencies
-	processing := make(map[string]bool)
-
-	for len(queue) > 0 {
-		req := queue[0]
-		queue = queue[1:]
-
-		// Determine the name for this requirement
-		var name string
-		switch req.Type {
-		case requirements.RequirementTypeRegistry:
-			name = req.Name
-		case requirements.RequirementTypeGit:
-			name = req.GitName
-		case requirements.RequirementTypePath, requirements.RequirementTypeHTTP:
-			// For path/HTTP, we need to download and extract to get the name
-			// We'll handle this specially
-			name = ""
-		}
-
-		// Skip if already resolved
-		if name != "" && resolved[name] != nil {
-			continue
-		}
+	return r.resolve(reqs, nil, nil)
+}
 
-		// Check for circular dependencies
-		if processing[name] {
-			return nil, fmt.Errorf("circular dependency detected: %s", name)
+// resolve runs the solver and builds the lock file
+// If current is set, entries for unchanged name@version pairs are carried over as-is unless
+// listed in refresh, and new entries keep their installation settings, so the lock file
+// changes as little as possible
+func (r *Resolver) resolve(reqs []requirements.Requirement, current *lockfile.LockFile, refresh map[string]bool) (*lockfile.LockFile, error) {
+	s := newSolver(r)
+
+	// Prefer the locked versions of everything that isn't being refreshed
+	if current != nil {
+		for _, art := range current.Artifacts {
+			if !refresh[art.Name] {
+				s.preferred[art.Name] = art.Version
+			}
 		}
-		processing[name] = true
+	}
 
-		// Resolve this requirement
-		artifact, deps, err := r.resolveRequirement(req)
+	for _, req := range reqs {
+		t, err := s.newTerm(req, nil)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve %s: %w", req.String(), err)
 		}
+		s.addTerm(t)
+	}
 
-		// Add to resolved map
-		resolved[artifact.Name] = artifact
+	conflict, err := s.solve()
+	if err != nil {
+		return nil, err
+	}
+	if conflict != nil {
+		return nil, &ResolutionError{Conflict: conflict}
+	}
+
+	currentByName := make(map[string]*lockfile.Artifact)
+	if current != nil {
+		for i := range current.Artifacts {
+			currentByName[current.Artifacts[i].Name] = &current.Artifacts[i]
+		}
+	}
 
-		// Add dependencies to queue
-		queue = append(queue, deps...)
+	// Collect selected artifacts with their dependencies pinned to the chosen versions
+	resolved := make(map[string]*lockfile.Artifact)
+	for name, d := range s.decisions {
+		var art lockfile.Artifact
+
+		existing := currentByName[name]
+		if existing != nil && existing.Version == d.version && (d.registry || !refresh[name]) {
+			// Unchanged, keep the existing entry including its source
+			art = *existing
+		} else {
+			art = *d.artifact
+
+			// Registry sources are only resolved for the selected versions, since it may
+			// require downloading the artifact to compute its hashes
+			if d.registry {
+				if err := r.resolveSource(&art); err != nil {
+					return nil, err
+				}
+			}
+
+			// Installation settings are chosen by the user, not by the version
+			if existing != nil {
+				art.Clients = existing.Clients
+				art.Repositories = existing.Repositories
+			}
+		}
 
-		delete(processing, name)
+		art.Dependencies = nil
+		for _, dep := range d.deps {
+			art.Dependencies = append(art.Dependencies, lockfile.Dependency{
+				Name:    dep.name,
+				Version: s.decisions[dep.name].version,
+			})
+		}
+		resolved[name] = &art
 	}
 
 	// Build lock file
@@ -96,18 +123,44 @@ func (r *Resolver) Resolve(reqs []requirements.Requirement) (*lockfile.LockFile,
 		Artifacts:   make([]lockfile.Artifact, 0, len(resolved)),
 	}
 
-	for _, artifact := range resolved {
-		lockFile.Artifacts = append(lockFile.Artifacts, *artifact)
+	// Keep the existing order, then add new artifacts sorted by name
+	if current != nil {
+		for _, art := range current.Artifacts {
+			if a, ok := resolved[art.Name]; ok {
+				lockFile.Artifacts = append(lockFile.Artifacts, *a)
+				delete(resolved, art.Name)
+			}
+		}
+	}
+	for _, name := range sortedNames(resolved) {
+		lockFile.Artifacts = append(lockFile.Artifacts, *resolved[name])
+	}
+
+	if err := lockFile.ValidateDependencies(); err != nil {
+		return nil, err
 	}
 
 	return lockFile, nil
 }
 
-// resolveRequirement resolves a single requirement
+// pinner is implemented by repositories that combine several named repositories
+type pinner interface {
+	Pin(artifactName, repoName string) error
+}
+
+// pin restricts an artifact to the repository named by a "repo::name" requirement
+func (r *Resolver) pin(req requirements.Requirement) error {
+	p, ok := r.repo.(pinner)
+	if !ok {
+		return fmt.Errorf("%s is pinned to repository %s, but only one repository is configured", req.Name, req.Repository)
+	}
+	return p.Pin(req.Name, req.Repository)
+}
+
+// resolveRequirement resolves a single git, path or HTTP requirement
+// Registry requirements are selected by the solver instead
 func (r *Resolver) resolveRequirement(req requirements.Requirement) (*lockfile.Artifact, []requirements.Requirement, error) {
 	switch req.Type {
-	case requirements.RequirementTypeRegistry:
-		return r.resolveRegistry(req)
 	case requirements.RequirementTypeGit:
 		return r.resolveGit(req)
 	case requirements.RequirementTypePath:
@@ -119,185 +172,159 @@ func (r *Resolver) resolveRequirement(req requirements.Requirement) (*lockfile.A
 	}
 }
 
-// resolveRegistry resolves a registry artifact
-func (r *Resolver) resolveRegistry(req requirements.Requirement) (*lockfile.Artifact, []requirements.Requirement, error) {
-	// Get available versions
-	versions, err := r.repo.GetVersionList(r.ctx, req.Name)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get version list: %w", err)
-	}
-
-	// Filter by version specifier
-	var matchedVersions []string
-	if req.VersionSpec != "" {
-		// Parse specifiers
-		specs, err := version.ParseMultipleSpecifiers(req.VersionOperator + req.VersionSpec)
-		if err != nil {
-			return nil, nil, fmt.Errorf("invalid version specifier: %w", err)
-		}
-
-		matchedVersions, err = version.FilterByMultiple(versions, specs)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to filter versions: %w", err)
-		}
-	} else {
-		matchedVersions = versions
+// buildRegistryArtifact builds the lock file entry for a registry artifact version
+// The source is left empty until the version is selected, see resolveSource
+func buildRegistryArtifact(name, ver string, meta *metadata.Metadata) *lockfile.Artifact {
+	return &lockfile.Artifact{
+		Name:    name,
+		Version: ver,
+		Type:    meta.Artifact.Type,
 	}
+}
 
-	if len(matchedVersions) == 0 {
-		return nil, nil, fmt.Errorf("no matching versions found for %s%s%s", req.Name, req.VersionOperator, req.VersionSpec)
+// resolveSource asks the repository for the canonical source of a registry artifact
+func (r *Resolver) resolveSource(art *lockfile.Artifact) error {
+	if err := r.repo.ResolveSource(r.ctx, art); err != nil {
+		return fmt.Errorf("failed to resolve source for %s@%s: %w", art.Name, art.Version, err)
 	}
+	return nil
+}
 
-	// Select best version
-	selectedVersion, err := version.SelectBest(matchedVersions)
+// resolveGit resolves a git source artifact
+func (r *Resolver) resolveGit(req requirements.Requirement) (*lockfile.Artifact, []requirements.Requirement, error) {
+	// Resolve ref to commit SHA
+	commitSHA, err := r.resolveGitRef(req.GitURL, req.GitRef)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to select best version: %w", err)
+		return nil, nil, fmt.Errorf("failed to resolve git ref: %w", err)
 	}
 
-	// Get metadata for selected version
-	meta, err := r.repo.GetMetadata(r.ctx, req.Name, selectedVersion)
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get metadata for %s@%s: %w", req.Name, selectedVersion, err)
+	source := &lockfile.SourceGit{
+		URL:          req.GitURL,
+		Ref:          commitSHA,
+		Subdirectory: req.GitSubdirectory,
 	}
 
-	// Build artifact
-	artifact := &lockfile.Artifact{
-		Name:    req.Name,
-		Version: selectedVersion,
-		Type:    meta.Artifact.Type,
-		SourceHTTP: &lockfile.SourceHTTP{
-			URL: r.buildArtifactURL(req.Name, selectedVersion),
-			// Hashes will be computed during download in actual implementation
-			// For now, we'll leave them empty and compute on demand
-			Hashes: make(map[string]string),
-		},
+	// Clone the repository at the resolved commit and package the artifact
+	handler := repository.NewGitSourceHandler(git.NewClient())
+	data, err := handler.Fetch(r.ctx, &lockfile.Artifact{Name: req.GitName, SourceGit: source})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch artifact: %w", err)
 	}
 
-	// Parse dependencies
-	var deps []requirements.Requirement
-	for _, depStr := range meta.Artifact.Dependencies {
-		depReq, err := requirements.ParseLine(depStr)
-		if err != nil {
-			return nil, nil, fmt.Errorf("invalid dependency %s: %w", depStr, err)
-		}
-		deps = append(deps, depReq)
+	contentHash, err := utils.ComputeZipContentSHA256(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to hash artifact: %w", err)
 	}
+	source.Hashes = map[string]string{"sha256": contentHash}
 
-	return artifact, deps, nil
-}
-
-// resolveGit resolves a git source artifact
-func (r *Resolver) resolveGit(req requirements.Requirement) (*lockfile.Artifact, []requirements.Requirement, error) {
-	// Resolve ref to commit SHA
-	commitSHA, err := r.resolveGitRef(req.GitURL, req.GitRef)
+	art, deps, err := artifactFromZip(data)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to resolve git ref: %w", err)
+		return nil, nil, err
 	}
 
-	// For now, we'll create a minimal artifact
-	// In a full implementation, we'd clone the repo and extract metadata
-	artifact := &lockfile.Artifact{
-		Name:    req.GitName,
-		Version: "0.0.0+git" + commitSHA[:7],
-		Type:    artifact.TypeSkill, // Default, should be read from metadata
-		SourceGit: &lockfile.SourceGit{
-			URL:          req.GitURL,
-			Ref:          commitSHA,
-			Subdirectory: req.GitSubdirectory,
-		},
+	if art.Name != req.GitName {
+		return nil, nil, fmt.Errorf("requirement names %q but metadata.toml declares %q", req.GitName, art.Name)
 	}
 
-	// TODO: Clone repo, extract metadata, parse dependencies
-	return artifact, nil, nil
+	art.SourceGit = source
+	return art, deps, nil
 }
 
-// resolvePath resolves a local path artifact
+// resolvePath resolves a local path artifact (zip file or directory)
 func (r *Resolver) resolvePath(req requirements.Requirement) (*lockfile.Artifact, []requirements.Requirement, error) {
-	// Expand path
-	path := req.Path
-	if strings.HasPrefix(path, "~/") {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get home directory: %w", err)
-		}
-		path = filepath.Join(home, path[2:])
+	// Relative paths are resolved from the current directory
+	handler := repository.NewPathSourceHandler(".")
+	data, err := handler.Fetch(r.ctx, &lockfile.Artifact{SourcePath: &lockfile.SourcePath{Path: req.Path}})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(path); err != nil {
-		return nil, nil, fmt.Errorf("path not found: %w", err)
+	contentHash, err := utils.ComputeZipContentSHA256(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to hash artifact: %w", err)
 	}
 
-	// Read metadata from zip
-	// For now, create a minimal artifact
-	artifact := &lockfile.Artifact{
-		Name:    filepath.Base(path),
-		Version: "0.0.0+local",
-		Type:    artifact.TypeSkill,
-		SourcePath: &lockfile.SourcePath{
-			Path: req.Path, // Use original path, not expanded
-		},
+	art, deps, err := artifactFromZip(data)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// TODO: Extract metadata from zip, parse dependencies
-	return artifact, nil, nil
+	art.SourcePath = &lockfile.SourcePath{
+		Path:   req.Path, // Use original path, not expanded
+		Hashes: map[string]string{"sha256": contentHash},
+	}
+	return art, deps, nil
 }
 
 // resolveHTTP resolves an HTTP source artifact
 func (r *Resolver) resolveHTTP(req requirements.Requirement) (*lockfile.Artifact, []requirements.Requirement, error) {
 	// Download artifact
-	resp, err := http.Get(req.URL)
+	handler := repository.NewHTTPSourceHandler("")
+	data, err := handler.DownloadWithProgress(r.ctx, req.URL, nil)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to download artifact: %w", err)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, nil, fmt.Errorf("HTTP %d: failed to download artifact", resp.StatusCode)
+	if !utils.IsZipFile(data) {
+		return nil, nil, fmt.Errorf("downloaded file is not a valid zip archive")
 	}
 
-	// Read data and compute hash
-	data, err := io.ReadAll(resp.Body)
+	art, deps, err := artifactFromZip(data)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read artifact data: %w", err)
+		return nil, nil, err
 	}
 
-	hash := sha256.Sum256(data)
-	hashStr := hex.EncodeToString(hash[:])
+	// HTTP sources are verified against the downloaded bytes, so hash the archive itself
+	art.SourceHTTP = &lockfile.SourceHTTP{
+		URL: req.URL,
+		Hashes: map[string]string{
+			"sha256": utils.ComputeSHA256(data),
+		},
+		Size: int64(len(data)),
+	}
+	return art, deps, nil
+}
 
-	// Extract name from URL
-	name := filepath.Base(req.URL)
-	name = strings.TrimSuffix(name, ".zip")
+// artifactFromZip builds a lock file artifact (without source) from the metadata.toml in an
+// artifact zip, along with the requirements for its declared dependencies
+func artifactFromZip(data []byte) (*lockfile.Artifact, []requirements.Requirement, error) {
+	metaData, err := utils.ReadZipFile(data, "metadata.toml")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read metadata.toml: %w", err)
+	}
 
-	artifact := &lockfile.Artifact{
-		Name:    name,
-		Version: "0.0.0+http",
-		Type:    artifact.TypeSkill,
-		SourceHTTP: &lockfile.SourceHTTP{
-			URL: req.URL,
-			Hashes: map[string]string{
-				"sha256": hashStr,
-			},
-			Size: int64(len(data)),
-		},
+	meta, err := metadata.Parse(metaData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := meta.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("invalid metadata.toml: %w", err)
 	}
 
-	// TODO: Extract metadata from zip, parse dependencies
-	return artifact, nil, nil
+	var deps []requirements.Requirement
+	for _, depStr := range meta.Artifact.Dependencies {
+		depReq, err := requirements.ParseLine(depStr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid dependency %s: %w", depStr, err)
+		}
+		depReq.Raw = depStr
+		deps = append(deps, depReq)
+	}
+
+	art := &lockfile.Artifact{
+		Name:    meta.Artifact.Name,
+		Version: meta.Artifact.Version,
+		Type:    meta.Artifact.Type,
+	}
+	return art, deps, nil
 }
 
 // resolveGitRef resolves a git ref (branch, tag, or commit) to a commit SHA
 func (r *Resolver) resolveGitRef(url, ref string) (string, error) {
 	// Use git client to resolve the ref
 	gitClient := git.NewClient()
-	return gitClient.LsRemote(context.Background(), url, ref)
-}
-
-// buildArtifactURL builds the URL for an artifact based on repository conventions
-func (r *Resolver) buildArtifactURL(name, version string) string {
-	// This should use the repository's base URL
-	// For now, return a placeholder that follows the spec
-	return fmt.Sprintf("https://app.sleuth.io/api/skills/artifacts/%s/%s/%s-%s.zip", name, version, name, version)
+	return gitClient.LsRemote(r.ctx, url, ref)
 }
 
 // generateLockFileVersion generates a version/hash for the lock file
@@ -305,14 +332,8 @@ func generateLockFileVersion(artifacts map[string]*lockfile.Artifact) string {
 	// Create a deterministic hash of all artifacts
 	h := sha256.New()
 
-	// Sort artifact names for deterministic output
-	var names []string
-	for name := range artifacts {
-		names = append(names, name)
-	}
-
-	// Simple hash of artifact keys
-	for _, name := range names {
+	// Simple hash of artifact keys, sorted for deterministic output
+	for _, name := range sortedNames(artifacts) {
 		artifact := artifacts[name]
 		fmt.Fprintf(h, "%s@%s\n", artifact.Name, artifact.Version)
 	}
@@ -320,3 +341,13 @@ func generateLockFileVersion(artifacts map[string]*lockfile.Artifact) string {
 	hash := h.Sum(nil)
 	return hex.EncodeToString(hash[:16]) // Use first 16 bytes
 }
+
+// sortedNames returns the artifact names in sorted order
+func sortedNames(artifacts map[string]*lockfile.Artifact) []string {
+	names := make([]string, 0, len(artifacts))
+	for name := range artifacts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
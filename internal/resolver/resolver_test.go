@@ -0,0 +1,467 @@
+package resolver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/repository"
+	"github.com/sleuth-io/skills/internal/requirements"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// fakeRepository serves version lists and metadata from memory
+type fakeRepository struct {
+	// deps maps name -> version -> dependency strings
+	deps map[string]map[string][]string
+}
+
+func (f *fakeRepository) Authenticate(ctx context.Context) (string, error) { return "", nil }
+
+func (f *fakeRepository) GetLockFile(ctx context.Context, cachedETag string) ([]byte, string, bool, error) {
+	return nil, "", false, nil
+}
+
+func (f *fakeRepository) GetArtifact(ctx context.Context, a *lockfile.Artifact) ([]byte, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+func (f *fakeRepository) AddArtifact(ctx context.Context, a *lockfile.Artifact, zipData []byte) error {
+	return fmt.Errorf("not implemented")
+}
+
+func (f *fakeRepository) GetVersionList(ctx context.Context, name string) ([]string, error) {
+	var versions []string
+	for v := range f.deps[name] {
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
+
+func (f *fakeRepository) GetMetadata(ctx context.Context, name, version string) (*metadata.Metadata, error) {
+	deps, ok := f.deps[name][version]
+	if !ok {
+		return nil, fmt.Errorf("%s@%s not found", name, version)
+	}
+	return &metadata.Metadata{
+		Artifact: metadata.Artifact{
+			Name:         name,
+			Version:      version,
+			Type:         artifact.TypeSkill,
+			Dependencies: deps,
+		},
+	}, nil
+}
+
+func (f *fakeRepository) ResolveSource(ctx context.Context, a *lockfile.Artifact) error {
+	a.SourcePath = &lockfile.SourcePath{Path: fmt.Sprintf("./artifacts/%s/%s", a.Name, a.Version)}
+	return nil
+}
+
+func (f *fakeRepository) VerifyIntegrity(data []byte, hashes map[string]string, size int64) error {
+	return nil
+}
+
+func (f *fakeRepository) PostUsageStats(ctx context.Context, jsonlData string) error { return nil }
+
+func parseRequirements(t *testing.T, lines ...string) []requirements.Requirement {
+	t.Helper()
+	var reqs []requirements.Requirement
+	for i, line := range lines {
+		req, err := requirements.ParseLine(line)
+		if err != nil {
+			t.Fatalf("ParseLine(%q) failed: %v", line, err)
+		}
+		req.Raw = line
+		req.Line = i + 1
+		reqs = append(reqs, req)
+	}
+	return reqs
+}
+
+func selectedVersions(lockFile *lockfile.LockFile) map[string]string {
+	selected := make(map[string]string)
+	for _, a := range lockFile.Artifacts {
+		selected[a.Name] = a.Version
+	}
+	return selected
+}
+
+func TestResolveBacktracks(t *testing.T) {
+	repo := &fakeRepository{deps: map[string]map[string][]string{
+		"code-review": {
+			"1.4.0": {"common-utils<2.0"},
+			"1.3.0": {"common-utils>=1.0"},
+		},
+		"linter": {
+			"2.0.0": {"common-utils>=2.0"},
+		},
+		"common-utils": {
+			"1.5.0": nil,
+			"2.1.0": nil,
+		},
+	}}
+
+	reqs := parseRequirements(t, "code-review>=1.0", "linter")
+	lockFile, err := New(context.Background(), repo).Resolve(reqs)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	expected := map[string]string{
+		"code-review":  "1.3.0",
+		"linter":       "2.0.0",
+		"common-utils": "2.1.0",
+	}
+	selected := selectedVersions(lockFile)
+	for name, want := range expected {
+		if selected[name] != want {
+			t.Errorf("Expected %s@%s, got %q", name, want, selected[name])
+		}
+	}
+
+	// Artifacts are sorted by name with dependencies pinned to the selected versions
+	if lockFile.Artifacts[0].Name != "code-review" {
+		t.Errorf("Expected artifacts sorted by name, got %s first", lockFile.Artifacts[0].Name)
+	}
+	deps := lockFile.Artifacts[0].Dependencies
+	if len(deps) != 1 || deps[0].Name != "common-utils" || deps[0].Version != "2.1.0" {
+		t.Errorf("Expected code-review to depend on common-utils@2.1.0, got %+v", deps)
+	}
+
+	// Sources come from the repository for the selected version only
+	source := lockFile.Artifacts[0].SourcePath
+	if source == nil || source.Path != "./artifacts/code-review/1.3.0" {
+		t.Errorf("Expected repository source for code-review@1.3.0, got %+v", source)
+	}
+}
+
+func TestResolveCombinesSpecifiers(t *testing.T) {
+	repo := &fakeRepository{deps: map[string]map[string][]string{
+		"app": {
+			"1.0.0": {"lib>=1.2"},
+		},
+		"lib": {
+			"1.0.0": nil,
+			"1.2.0": nil,
+			"1.9.0": nil,
+			"2.0.0": nil,
+		},
+	}}
+
+	reqs := parseRequirements(t, "app", "lib<2.0,>=1.0")
+	lockFile, err := New(context.Background(), repo).Resolve(reqs)
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if got := selectedVersions(lockFile)["lib"]; got != "1.9.0" {
+		t.Errorf("Expected lib@1.9.0, got %q", got)
+	}
+}
+
+func TestResolveConflictExplanation(t *testing.T) {
+	repo := &fakeRepository{deps: map[string]map[string][]string{
+		"code-review": {
+			"1.4.0": {"common-utils<2.0"},
+		},
+		"linter": {
+			"2.0.0": {"common-utils>=2.0"},
+		},
+		"common-utils": {
+			"1.5.0": nil,
+			"2.1.0": nil,
+		},
+	}}
+
+	reqs := parseRequirements(t, "code-review>=1.0", "linter")
+	_, err := New(context.Background(), repo).Resolve(reqs)
+	if err == nil {
+		t.Fatal("Expected resolution to fail")
+	}
+
+	var resErr *ResolutionError
+	if !errors.As(err, &resErr) {
+		t.Fatalf("Expected *ResolutionError, got %T: %v", err, err)
+	}
+
+	msg := err.Error()
+	for _, want := range []string{
+		"common-utils<2.0 ← code-review@1.4.0 ← code-review>=1.0 (line 1)",
+		"common-utils>=2.0 ← linter@2.0.0 ← linter (line 2)",
+		"available: 2.1.0, 1.5.0",
+	} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Expected error to contain %q, got:\n%s", want, msg)
+		}
+	}
+}
+
+func TestResolveMissingArtifact(t *testing.T) {
+	repo := &fakeRepository{deps: map[string]map[string][]string{}}
+
+	reqs := parseRequirements(t, "missing")
+	_, err := New(context.Background(), repo).Resolve(reqs)
+	if err == nil {
+		t.Fatal("Expected resolution to fail")
+	}
+	if !strings.Contains(err.Error(), "no versions of missing are available") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestResolveCircularDependency(t *testing.T) {
+	repo := &fakeRepository{deps: map[string]map[string][]string{
+		"a": {"1.0.0": {"b"}},
+		"b": {"1.0.0": {"a"}},
+	}}
+
+	reqs := parseRequirements(t, "a")
+	_, err := New(context.Background(), repo).Resolve(reqs)
+	if err == nil || !strings.Contains(err.Error(), "circular dependency") {
+		t.Errorf("Expected circular dependency error, got %v", err)
+	}
+}
+
+const mcpMetadata = `[artifact]
+name = "db-server"
+version = "1.2.0"
+type = "mcp"
+dependencies = ["common-utils>=1.0"]
+
+[mcp]
+command = "node"
+args = ["server.js"]
+`
+
+func writeArtifactDir(t *testing.T, metadataTOML string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "metadata.toml"), []byte(metadataTOML), 0644); err != nil {
+		t.Fatalf("Failed to write metadata: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "server.js"), []byte("console.log('hi')"), 0644); err != nil {
+		t.Fatalf("Failed to write server.js: %v", err)
+	}
+	return dir
+}
+
+func TestResolvePathReadsMetadata(t *testing.T) {
+	dir := writeArtifactDir(t, mcpMetadata)
+	repo := &fakeRepository{deps: map[string]map[string][]string{
+		"common-utils": {"1.1.0": nil},
+	}}
+
+	lockFile, err := New(context.Background(), repo).Resolve(parseRequirements(t, dir))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if len(lockFile.Artifacts) != 2 {
+		t.Fatalf("Expected artifact and its dependency, got %d artifacts", len(lockFile.Artifacts))
+	}
+
+	server := lockFile.Artifacts[1]
+	if server.Name != "db-server" || server.Version != "1.2.0" || server.Type != artifact.TypeMCP {
+		t.Errorf("Expected db-server@1.2.0 (mcp), got %s@%s (%s)", server.Name, server.Version, server.Type)
+	}
+	if server.SourcePath == nil || server.SourcePath.Path != dir {
+		t.Fatalf("Expected source-path %s, got %+v", dir, server.SourcePath)
+	}
+	if len(server.SourcePath.Hashes["sha256"]) != 64 {
+		t.Errorf("Expected sha256 content hash, got %v", server.SourcePath.Hashes)
+	}
+	if err := server.Validate(); err != nil {
+		t.Errorf("Resolved artifact is invalid: %v", err)
+	}
+
+	// Hash is stable across repeated resolution of the same directory
+	again, err := New(context.Background(), repo).Resolve(parseRequirements(t, dir))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if again.Artifacts[1].SourcePath.Hashes["sha256"] != server.SourcePath.Hashes["sha256"] {
+		t.Error("Expected content hash to be stable")
+	}
+}
+
+func TestResolveHTTPReadsMetadata(t *testing.T) {
+	zipData, err := utils.CreateZip(writeArtifactDir(t, mcpMetadata))
+	if err != nil {
+		t.Fatalf("CreateZip failed: %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(zipData)
+	}))
+	defer server.Close()
+
+	repo := &fakeRepository{deps: map[string]map[string][]string{
+		"common-utils": {"1.1.0": nil},
+	}}
+
+	lockFile, err := New(context.Background(), repo).Resolve(parseRequirements(t, server.URL+"/db-server.zip"))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	selected := selectedVersions(lockFile)
+	if selected["db-server"] != "1.2.0" || selected["common-utils"] != "1.1.0" {
+		t.Errorf("Unexpected selection: %v", selected)
+	}
+
+	for _, a := range lockFile.Artifacts {
+		if a.Name != "db-server" {
+			continue
+		}
+		if a.Type != artifact.TypeMCP {
+			t.Errorf("Expected type mcp, got %s", a.Type)
+		}
+		if a.SourceHTTP.Hashes["sha256"] != utils.ComputeSHA256(zipData) || a.SourceHTTP.Size != int64(len(zipData)) {
+			t.Errorf("Unexpected source-http: %+v", a.SourceHTTP)
+		}
+	}
+}
+
+func TestUpgradeKeepsOtherArtifactsLocked(t *testing.T) {
+	repo := &fakeRepository{deps: map[string]map[string][]string{
+		"code-review": {"1.0.0": nil, "1.1.0": nil, "2.0.0": nil},
+		"linter":      {"1.0.0": nil, "1.5.0": nil},
+	}}
+	reqs := parseRequirements(t, "code-review<2.0", "linter")
+
+	current := &lockfile.LockFile{
+		LockVersion: "1.0",
+		Artifacts: []lockfile.Artifact{
+			{
+				Name:         "linter",
+				Version:      "1.0.0",
+				Type:         artifact.TypeSkill,
+				SourcePath:   &lockfile.SourcePath{Path: "./artifacts/linter/1.0.0"},
+				Repositories: []lockfile.Repository{{Repo: "github.com/acme/app"}},
+			},
+			{
+				Name:         "code-review",
+				Version:      "1.0.0",
+				Type:         artifact.TypeSkill,
+				SourcePath:   &lockfile.SourcePath{Path: "./artifacts/code-review/1.0.0"},
+				Repositories: []lockfile.Repository{{Repo: "github.com/acme/app"}},
+			},
+		},
+	}
+
+	upgraded, err := New(context.Background(), repo).Upgrade(reqs, current, []string{"code-review"})
+	if err != nil {
+		t.Fatalf("Upgrade failed: %v", err)
+	}
+
+	// Existing order is kept
+	if upgraded.Artifacts[0].Name != "linter" || upgraded.Artifacts[1].Name != "code-review" {
+		t.Fatalf("Expected lock order to be preserved, got %s, %s", upgraded.Artifacts[0].Name, upgraded.Artifacts[1].Name)
+	}
+
+	if got := upgraded.Artifacts[0].Version; got != "1.0.0" {
+		t.Errorf("Expected linter to stay at 1.0.0, got %s", got)
+	}
+
+	reviewed := upgraded.Artifacts[1]
+	if reviewed.Version != "1.1.0" {
+		t.Errorf("Expected code-review@1.1.0, got %s", reviewed.Version)
+	}
+	if len(reviewed.Repositories) != 1 || reviewed.Repositories[0].Repo != "github.com/acme/app" {
+		t.Errorf("Expected installation settings to be kept, got %+v", reviewed.Repositories)
+	}
+	if reviewed.SourcePath.Path != "./artifacts/code-review/1.1.0" {
+		t.Errorf("Expected new source, got %s", reviewed.SourcePath.Path)
+	}
+
+	if _, err := New(context.Background(), repo).Upgrade(reqs, current, []string{"unknown"}); err == nil {
+		t.Error("Expected error for artifact not in lock file")
+	}
+}
+
+func TestOutdated(t *testing.T) {
+	repo := &fakeRepository{deps: map[string]map[string][]string{
+		"code-review":  {"1.0.0": {"common-utils<2.0"}, "1.1.0": nil, "2.0.0": nil},
+		"common-utils": {"1.0.0": nil, "1.4.0": nil, "2.0.0": nil},
+		"linter":       {"1.0.0": nil},
+	}}
+	reqs := parseRequirements(t, "code-review<2.0", "linter")
+
+	lockFile := &lockfile.LockFile{
+		Artifacts: []lockfile.Artifact{
+			{Name: "code-review", Version: "1.0.0", Dependencies: []lockfile.Dependency{{Name: "common-utils", Version: "1.0.0"}}},
+			{Name: "common-utils", Version: "1.0.0"},
+			{Name: "linter", Version: "1.0.0"},
+			{Name: "local-skill", Version: "0.1.0"},
+		},
+	}
+
+	outdated, err := New(context.Background(), repo).Outdated(reqs, lockFile)
+	if err != nil {
+		t.Fatalf("Outdated failed: %v", err)
+	}
+
+	expected := []OutdatedArtifact{
+		{Name: "code-review", Current: "1.0.0", Wanted: "1.1.0", Latest: "2.0.0"},
+		{Name: "common-utils", Current: "1.0.0", Wanted: "1.4.0", Latest: "2.0.0"},
+	}
+	if len(outdated) != len(expected) {
+		t.Fatalf("Expected %d outdated artifacts, got %+v", len(expected), outdated)
+	}
+	for i, want := range expected {
+		if outdated[i] != want {
+			t.Errorf("outdated[%d] = %+v, want %+v", i, outdated[i], want)
+		}
+	}
+}
+
+func TestResolveRepositoryPin(t *testing.T) {
+	company := &fakeRepository{deps: map[string]map[string][]string{
+		"lib": {"2.0.0": nil},
+	}}
+	personal := &fakeRepository{deps: map[string]map[string][]string{
+		"lib": {"1.0.0": nil},
+	}}
+
+	newMulti := func() *repository.MultiRepository {
+		m, err := repository.NewMultiRepository([]repository.NamedRepository{
+			{Name: "company", Priority: 10, Repository: company},
+			{Name: "personal", Repository: personal},
+		}, nil)
+		if err != nil {
+			t.Fatalf("NewMultiRepository failed: %v", err)
+		}
+		return m
+	}
+
+	lockFile, err := New(context.Background(), newMulti()).Resolve(parseRequirements(t, "lib"))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if got := selectedVersions(lockFile)["lib"]; got != "2.0.0" {
+		t.Errorf("Expected lib@2.0.0 from the higher-priority repository, got %q", got)
+	}
+
+	lockFile, err = New(context.Background(), newMulti()).Resolve(parseRequirements(t, "personal::lib"))
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if got := selectedVersions(lockFile)["lib"]; got != "1.0.0" {
+		t.Errorf("Expected pinned lib@1.0.0, got %q", got)
+	}
+
+	_, err = New(context.Background(), company).Resolve(parseRequirements(t, "personal::lib"))
+	if err == nil || !strings.Contains(err.Error(), "only one repository is configured") {
+		t.Errorf("Expected pin error for single repository, got %v", err)
+	}
+}
@@ -0,0 +1,389 @@
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/requirements"
+	"github.com/sleuth-io/skills/internal/version"
+)
+
+// term is a constraint placed on an artifact name by a requirement line or a dependency
+type term struct {
+	name  string
+	specs []*version.Specifier
+	req   requirements.Requirement
+
+	// parent is the decision whose dependencies introduced this term (nil for requirements file lines)
+	parent *decision
+
+	// direct is set for git, path and HTTP requirements, which resolve to exactly one artifact
+	direct *directSource
+}
+
+// decision records the version selected for an artifact name
+type decision struct {
+	name     string
+	version  string
+	artifact *lockfile.Artifact
+
+	// cause is the first term that placed this name in the graph, used to explain conflicts
+	cause *term
+
+	// deps are the terms added by this artifact's dependencies
+	deps []*term
+
+	// registry is set when the version was selected from the repository rather than a direct source
+	registry bool
+}
+
+// directSource is a git, path or HTTP requirement that has already been fetched
+type directSource struct {
+	artifact *lockfile.Artifact
+	deps     []requirements.Requirement
+}
+
+// maxResolutionSteps bounds the number of candidate versions tried before giving up
+const maxResolutionSteps = 10000
+
+// solver performs a backtracking search over candidate versions
+type solver struct {
+	r     *Resolver
+	steps int
+
+	terms     map[string][]*term
+	order     []string
+	decisions map[string]*decision
+
+	// preferred versions are tried before any other candidate (used to keep locked versions)
+	preferred map[string]string
+
+	// Caches so that backtracking doesn't repeat network requests
+	versionLists map[string][]string
+	metadata     map[string]*metadata.Metadata
+	direct       map[string]*directSource
+}
+
+// newSolver creates a solver backed by the resolver's repository
+func newSolver(r *Resolver) *solver {
+	return &solver{
+		r:            r,
+		terms:        make(map[string][]*term),
+		decisions:    make(map[string]*decision),
+		preferred:    make(map[string]string),
+		versionLists: make(map[string][]string),
+		metadata:     make(map[string]*metadata.Metadata),
+		direct:       make(map[string]*directSource),
+	}
+}
+
+// newTerm converts a requirement into a term, fetching direct sources to learn their name
+func (s *solver) newTerm(req requirements.Requirement, parent *decision) (*term, error) {
+	if req.Type == requirements.RequirementTypeRegistry {
+		var specs []*version.Specifier
+		if req.VersionSpec != "" {
+			var err error
+			specs, err = version.ParseMultipleSpecifiers(req.VersionOperator + req.VersionSpec)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version specifier: %w", err)
+			}
+		}
+		if req.Repository != "" {
+			if err := s.r.pin(req); err != nil {
+				return nil, err
+			}
+		}
+		return &term{name: req.Name, specs: specs, req: req, parent: parent}, nil
+	}
+
+	src, err := s.resolveDirect(req)
+	if err != nil {
+		return nil, err
+	}
+	return &term{name: src.artifact.Name, req: req, parent: parent, direct: src}, nil
+}
+
+// resolveDirect resolves a git, path or HTTP requirement once and caches the result
+func (s *solver) resolveDirect(req requirements.Requirement) (*directSource, error) {
+	key := req.String()
+	if src, ok := s.direct[key]; ok {
+		return src, nil
+	}
+
+	art, deps, err := s.r.resolveRequirement(req)
+	if err != nil {
+		return nil, err
+	}
+
+	src := &directSource{artifact: art, deps: deps}
+	s.direct[key] = src
+	return src, nil
+}
+
+// addTerm registers a term, tracking the order in which names first appear
+func (s *solver) addTerm(t *term) {
+	if len(s.terms[t.name]) == 0 {
+		s.order = append(s.order, t.name)
+	}
+	s.terms[t.name] = append(s.terms[t.name], t)
+}
+
+// removeTerm unregisters a term added by addTerm
+func (s *solver) removeTerm(t *term) {
+	terms := s.terms[t.name]
+	for i := len(terms) - 1; i >= 0; i-- {
+		if terms[i] == t {
+			terms = append(terms[:i], terms[i+1:]...)
+			break
+		}
+	}
+
+	if len(terms) > 0 {
+		s.terms[t.name] = terms
+		return
+	}
+
+	delete(s.terms, t.name)
+	for i := len(s.order) - 1; i >= 0; i-- {
+		if s.order[i] == t.name {
+			s.order = append(s.order[:i], s.order[i+1:]...)
+			break
+		}
+	}
+}
+
+// nextUndecided returns the first constrained name without a selected version
+func (s *solver) nextUndecided() string {
+	for _, name := range s.order {
+		if s.decisions[name] == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// solve selects a version for every constrained name
+// Returns a conflict explaining the failure if no consistent selection exists
+func (s *solver) solve() (*Conflict, error) {
+	name := s.nextUndecided()
+	if name == "" {
+		return nil, nil
+	}
+
+	terms := append([]*term(nil), s.terms[name]...)
+	candidates, available, err := s.candidates(name, terms)
+	if err != nil {
+		return nil, err
+	}
+
+	conflict := &Conflict{
+		Name:      name,
+		Terms:     describeTerms(terms),
+		Available: available,
+	}
+
+	for _, candidate := range candidates {
+		s.steps++
+		if s.steps > maxResolutionSteps {
+			return nil, fmt.Errorf("gave up after trying %d candidate versions, try narrowing the version specifiers", maxResolutionSteps)
+		}
+
+		d, err := s.newDecision(name, candidate, terms)
+		if err != nil {
+			return nil, err
+		}
+
+		// A dependency may clash with a version that has already been selected
+		if reason := s.clash(d); reason != "" {
+			conflict.Attempts = append(conflict.Attempts, Attempt{Version: candidate, Reason: reason})
+			continue
+		}
+
+		s.push(d)
+		cause, err := s.solve()
+		if err != nil {
+			return nil, err
+		}
+		if cause == nil {
+			return nil, nil
+		}
+		s.pop(d)
+
+		conflict.Attempts = append(conflict.Attempts, Attempt{Version: candidate, Cause: cause})
+	}
+
+	return conflict, nil
+}
+
+// candidates returns the versions of a name satisfying every term, highest first,
+// along with all versions that were considered
+func (s *solver) candidates(name string, terms []*term) (candidates, available []string, err error) {
+	// Direct sources pin the artifact to the single version they resolved to
+	var direct *directSource
+	for _, t := range terms {
+		if t.direct == nil {
+			continue
+		}
+		if direct != nil && direct.artifact.Version != t.direct.artifact.Version {
+			// Two direct sources disagree, so nothing can satisfy both
+			return nil, []string{direct.artifact.Version, t.direct.artifact.Version}, nil
+		}
+		direct = t.direct
+	}
+
+	if direct != nil {
+		available = []string{direct.artifact.Version}
+	} else {
+		available, err = s.versionList(name)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	preferred := s.preferred[name]
+	for _, v := range version.SortDescending(available) {
+		if !satisfiesAll(v, terms) {
+			continue
+		}
+		if v == preferred {
+			candidates = append([]string{v}, candidates...)
+		} else {
+			candidates = append(candidates, v)
+		}
+	}
+
+	return candidates, available, nil
+}
+
+// versionList fetches and caches the available versions for a registry artifact
+func (s *solver) versionList(name string) ([]string, error) {
+	if versions, ok := s.versionLists[name]; ok {
+		return versions, nil
+	}
+
+	versions, err := s.r.repo.GetVersionList(s.r.ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get version list for %s: %w", name, err)
+	}
+
+	s.versionLists[name] = versions
+	return versions, nil
+}
+
+// newDecision builds a decision for a candidate, including the terms for its dependencies
+func (s *solver) newDecision(name, candidate string, terms []*term) (*decision, error) {
+	d := &decision{
+		name:    name,
+		version: candidate,
+		cause:   terms[0],
+	}
+
+	var depReqs []requirements.Requirement
+	var direct *directSource
+	for _, t := range terms {
+		if t.direct != nil {
+			direct = t.direct
+			break
+		}
+	}
+
+	if direct != nil {
+		d.artifact = direct.artifact
+		depReqs = direct.deps
+	} else {
+		meta, err := s.registryMetadata(name, candidate)
+		if err != nil {
+			return nil, err
+		}
+
+		d.artifact = buildRegistryArtifact(name, candidate, meta)
+		d.registry = true
+
+		for _, depStr := range meta.Artifact.Dependencies {
+			depReq, err := requirements.ParseLine(depStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid dependency %s of %s@%s: %w", depStr, name, candidate, err)
+			}
+			depReq.Raw = depStr
+			depReqs = append(depReqs, depReq)
+		}
+	}
+
+	for _, depReq := range depReqs {
+		t, err := s.newTerm(depReq, d)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve %s (dependency of %s@%s): %w", depReq.String(), name, candidate, err)
+		}
+		d.deps = append(d.deps, t)
+	}
+
+	return d, nil
+}
+
+// registryMetadata fetches and caches metadata for a registry artifact version
+func (s *solver) registryMetadata(name, ver string) (*metadata.Metadata, error) {
+	key := name + "@" + ver
+	if meta, ok := s.metadata[key]; ok {
+		return meta, nil
+	}
+
+	meta, err := s.r.repo.GetMetadata(s.r.ctx, name, ver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata for %s: %w", key, err)
+	}
+
+	s.metadata[key] = meta
+	return meta, nil
+}
+
+// clash checks a decision's dependencies against versions that are already selected
+// Returns a description of the first clash, or empty string if there is none
+func (s *solver) clash(d *decision) string {
+	for _, dep := range d.deps {
+		if dep.name == d.name {
+			return fmt.Sprintf("depends on itself via %s", dep.req.String())
+		}
+
+		existing := s.decisions[dep.name]
+		if existing == nil || satisfiesAll(existing.version, []*term{dep}) {
+			continue
+		}
+
+		return fmt.Sprintf("requires %s, but %s@%s was already selected for %s",
+			dep.req.String(), existing.name, existing.version, existing.cause.describe())
+	}
+	return ""
+}
+
+// push applies a decision and its dependency terms
+func (s *solver) push(d *decision) {
+	s.decisions[d.name] = d
+	for _, dep := range d.deps {
+		s.addTerm(dep)
+	}
+}
+
+// pop reverts a decision applied by push
+func (s *solver) pop(d *decision) {
+	for i := len(d.deps) - 1; i >= 0; i-- {
+		s.removeTerm(d.deps[i])
+	}
+	delete(s.decisions, d.name)
+}
+
+// satisfiesAll checks if a version satisfies every term
+func satisfiesAll(v string, terms []*term) bool {
+	for _, t := range terms {
+		if t.direct != nil {
+			if t.direct.artifact.Version != v {
+				return false
+			}
+			continue
+		}
+		if !version.MatchesAll(v, t.specs) {
+			return false
+		}
+	}
+	return true
+}
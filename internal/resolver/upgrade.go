@@ -0,0 +1,150 @@
+package resolver
+
+import (
+	"fmt"
+
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/requirements"
+	"github.com/sleuth-io/skills/internal/version"
+)
+
+// OutdatedArtifact describes a locked artifact that has newer versions available
+type OutdatedArtifact struct {
+	Name    string
+	Current string
+
+	// Wanted is the highest version allowed by the requirements file and locked dependents
+	// Empty if no available version satisfies the constraints
+	Wanted string
+
+	// Latest is the highest version available in the repository
+	Latest string
+}
+
+// Upgrade re-resolves the named artifacts to the highest versions their constraints allow,
+// keeping every other artifact at its locked version where possible
+// If names is empty, every artifact is upgraded
+func (r *Resolver) Upgrade(reqs []requirements.Requirement, current *lockfile.LockFile, names []string) (*lockfile.LockFile, error) {
+	locked := make(map[string]bool)
+	for _, art := range current.Artifacts {
+		locked[art.Name] = true
+	}
+
+	refresh := make(map[string]bool)
+	if len(names) == 0 {
+		refresh = locked
+	}
+	for _, name := range names {
+		if !locked[name] {
+			return nil, fmt.Errorf("%s is not in the lock file", name)
+		}
+		refresh[name] = true
+	}
+
+	return r.resolve(reqs, current, refresh)
+}
+
+// Outdated compares locked artifacts against the repository's version lists
+// Artifacts whose locked version isn't published in the repository (git, path and HTTP
+// requirements) are skipped, as are artifacts already at the latest version
+func (r *Resolver) Outdated(reqs []requirements.Requirement, lockFile *lockfile.LockFile) ([]OutdatedArtifact, error) {
+	// Fetch version lists and work out which locked artifacts come from the repository
+	versionLists := make(map[string][]string)
+	for _, art := range lockFile.Artifacts {
+		versions, err := r.repo.GetVersionList(r.ctx, art.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get version list for %s: %w", art.Name, err)
+		}
+		if containsVersion(versions, art.Version) {
+			versionLists[art.Name] = versions
+		}
+	}
+
+	// Gather specifiers from the requirements file
+	constraints := make(map[string][]*version.Specifier)
+	for _, req := range reqs {
+		if req.Type != requirements.RequirementTypeRegistry || req.VersionSpec == "" {
+			continue
+		}
+		specs, err := version.ParseMultipleSpecifiers(req.VersionOperator + req.VersionSpec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version specifier for %s: %w", req.Name, err)
+		}
+		constraints[req.Name] = append(constraints[req.Name], specs...)
+	}
+
+	// Gather specifiers from the metadata of locked dependents
+	for _, art := range lockFile.Artifacts {
+		if len(art.Dependencies) == 0 || versionLists[art.Name] == nil {
+			continue
+		}
+
+		meta, err := r.repo.GetMetadata(r.ctx, art.Name, art.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get metadata for %s@%s: %w", art.Name, art.Version, err)
+		}
+
+		for _, depStr := range meta.Artifact.Dependencies {
+			depReq, err := requirements.ParseLine(depStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid dependency %s of %s@%s: %w", depStr, art.Name, art.Version, err)
+			}
+			if depReq.Type != requirements.RequirementTypeRegistry || depReq.VersionSpec == "" {
+				continue
+			}
+			specs, err := version.ParseMultipleSpecifiers(depReq.VersionOperator + depReq.VersionSpec)
+			if err != nil {
+				return nil, fmt.Errorf("invalid dependency %s of %s@%s: %w", depStr, art.Name, art.Version, err)
+			}
+			constraints[depReq.Name] = append(constraints[depReq.Name], specs...)
+		}
+	}
+
+	var outdated []OutdatedArtifact
+	for _, art := range lockFile.Artifacts {
+		versions := versionLists[art.Name]
+		if versions == nil {
+			continue
+		}
+
+		latest, err := version.SelectBest(versions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select latest version of %s: %w", art.Name, err)
+		}
+		if latest == art.Version {
+			continue
+		}
+
+		allowed, err := version.FilterByMultiple(versions, constraints[art.Name])
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter versions of %s: %w", art.Name, err)
+		}
+
+		var wanted string
+		if len(allowed) > 0 {
+			wanted, err = version.SelectBest(allowed)
+			if err != nil {
+				return nil, fmt.Errorf("failed to select allowed version of %s: %w", art.Name, err)
+			}
+		}
+
+		outdated = append(outdated, OutdatedArtifact{
+			Name:    art.Name,
+			Current: art.Version,
+			Wanted:  wanted,
+			Latest:  latest,
+		})
+	}
+
+	return outdated, nil
+}
+
+// containsVersion checks if a version string appears in a list
+func containsVersion(versions []string, v string) bool {
+	for _, candidate := range versions {
+		if candidate == v {
+			return true
+		}
+	}
+	return false
+}
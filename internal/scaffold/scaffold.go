@@ -0,0 +1,177 @@
+package scaffold
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+// templates holds the files each artifact type starts with, under templates/<type>
+//
+//go:embed templates
+var templates embed.FS
+
+// InitialVersion is the version new artifacts start at
+const InitialVersion = "0.1.0"
+
+// templateData is what the templates are rendered with
+type templateData struct {
+	Name  string // Artifact name, e.g. "code-review"
+	Title string // Name as a title, e.g. "Code Review"
+}
+
+// NewMetadata returns the metadata.toml a new artifact of a type starts with
+func NewMetadata(artifactType artifact.Type, name string) (*metadata.Metadata, error) {
+	meta := &metadata.Metadata{
+		MetadataVersion: "1.0",
+		Artifact: metadata.Artifact{
+			Name:    name,
+			Version: InitialVersion,
+			Type:    artifactType,
+		},
+	}
+
+	switch artifactType {
+	case artifact.TypeSkill:
+		meta.Artifact.Description = "Describe what this skill does"
+		meta.Skill = &metadata.SkillConfig{PromptFile: "SKILL.md"}
+	case artifact.TypeCommand:
+		meta.Artifact.Description = "Describe what this command does"
+		meta.Command = &metadata.CommandConfig{PromptFile: "COMMAND.md"}
+	case artifact.TypeAgent:
+		meta.Artifact.Description = "Describe what this agent does"
+		meta.Agent = &metadata.AgentConfig{PromptFile: "AGENT.md"}
+	case artifact.TypeHook:
+		meta.Artifact.Description = "Describe what this hook checks"
+		meta.Hook = &metadata.HookConfig{
+			Event:      "PostToolUse",
+			Matcher:    "Edit|Write",
+			ScriptFile: "hook.sh",
+		}
+	case artifact.TypeMCP:
+		meta.Artifact.Description = "Describe what this MCP server provides"
+		meta.MCP = &metadata.MCPConfig{
+			Command: "node",
+			Args:    []string{"src/index.js"},
+		}
+	case artifact.TypeMCPRemote:
+		meta.Artifact.Description = "Describe the remote MCP server"
+		meta.MCP = &metadata.MCPConfig{
+			Command: "npx",
+			Args:    []string{"-y", "mcp-remote", "https://example.com/mcp"},
+		}
+	default:
+		return nil, fmt.Errorf("unknown artifact type: %s (must be one of: skill, command, agent, hook, mcp, mcp-remote)", artifactType)
+	}
+
+	if err := meta.Validate(); err != nil {
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Create scaffolds a new artifact of a type in dir, which must not exist or be empty
+// Returns the files written, relative to dir
+func Create(dir string, artifactType artifact.Type, name string) ([]string, error) {
+	meta, err := NewMetadata(artifactType, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("directory %s already exists and isn't empty", dir)
+	} else if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read directory: %w", err)
+	}
+
+	files, err := renderTemplates(artifactType, templateData{Name: name, Title: title(name)})
+	if err != nil {
+		return nil, err
+	}
+	metadataData, err := metadata.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	files["metadata.toml"] = metadataData
+
+	executables := meta.ExecutableFiles()
+	written := make([]string, 0, len(files))
+	for name := range files {
+		written = append(written, name)
+	}
+	slices.Sort(written)
+
+	for _, name := range written {
+		mode := os.FileMode(0644)
+		if slices.Contains(executables, name) {
+			mode = 0755
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(target, files[name], mode); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+
+	return written, nil
+}
+
+// renderTemplates renders the templates of an artifact type, keyed by the path of
+// the file they produce
+func renderTemplates(artifactType artifact.Type, data templateData) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+
+	root := path.Join("templates", artifactType.Key)
+	if _, err := fs.Stat(templates, root); errors.Is(err, fs.ErrNotExist) {
+		// Some types, like mcp-remote, are just metadata
+		return files, nil
+	}
+
+	err := fs.WalkDir(templates, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		content, err := templates.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		tmpl, err := template.New(path.Base(name)).Parse(string(content))
+		if err != nil {
+			return err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return err
+		}
+
+		files[strings.TrimSuffix(strings.TrimPrefix(name, root+"/"), ".tmpl")] = buf.Bytes()
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s templates: %w", artifactType.Key, err)
+	}
+	return files, nil
+}
+
+// title turns an artifact name like "code-review" into "Code Review"
+func title(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,91 @@
+package scaffold
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/artifact"
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		artifactType artifact.Type
+		wantFiles    []string
+	}{
+		{artifact.TypeSkill, []string{"SKILL.md", "metadata.toml"}},
+		{artifact.TypeCommand, []string{"COMMAND.md", "metadata.toml"}},
+		{artifact.TypeAgent, []string{"AGENT.md", "metadata.toml"}},
+		{artifact.TypeHook, []string{"hook.sh", "metadata.toml"}},
+		{artifact.TypeMCP, []string{"metadata.toml", "package.json", "src/index.js"}},
+		{artifact.TypeMCPRemote, []string{"metadata.toml"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.artifactType.Key, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "code-review")
+			files, err := Create(dir, tt.artifactType, "code-review")
+			if err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+			if strings.Join(files, ",") != strings.Join(tt.wantFiles, ",") {
+				t.Errorf("Create() files = %v, want %v", files, tt.wantFiles)
+			}
+
+			meta, err := metadata.ParseFile(filepath.Join(dir, "metadata.toml"))
+			if err != nil {
+				t.Fatalf("Failed to parse metadata.toml: %v", err)
+			}
+			if err := meta.Validate(); err != nil {
+				t.Errorf("Scaffolded metadata is invalid: %v", err)
+			}
+			if meta.Artifact.Name != "code-review" || meta.Artifact.Type != tt.artifactType || meta.Artifact.Version != InitialVersion {
+				t.Errorf("Unexpected artifact section: %+v", meta.Artifact)
+			}
+
+			for _, file := range meta.ExecutableFiles() {
+				info, err := os.Stat(filepath.Join(dir, file))
+				if errors.Is(err, os.ErrNotExist) {
+					continue // a command on the PATH, like node
+				}
+				if err != nil || info.Mode().Perm()&0111 == 0 {
+					t.Errorf("Expected %s to be executable", file)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateRendersTemplates(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := Create(dir, artifact.TypeSkill, "code-review"); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "name: code-review") || !strings.Contains(string(data), "# Code Review") {
+		t.Errorf("Expected the name in the SKILL.md frontmatter and title, got:\n%s", data)
+	}
+}
+
+func TestCreateErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "existing.txt"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Create(dir, artifact.TypeSkill, "code-review"); err == nil {
+		t.Error("Expected an error for a non-empty directory")
+	}
+
+	if _, err := Create(filepath.Join(t.TempDir(), "bad"), artifact.TypeSkill, "bad name"); err == nil {
+		t.Error("Expected an error for an invalid name")
+	}
+	if _, err := Create(filepath.Join(t.TempDir(), "x"), artifact.FromString("plugin"), "x"); err == nil {
+		t.Error("Expected an error for an unknown type")
+	}
+}
@@ -0,0 +1,172 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+)
+
+// FileStore keeps secrets in an AES-256-GCM encrypted file in the config directory
+// The key is stored next to it, readable only by the owner, so the file is protected
+// from casual reads and from being copied elsewhere on its own
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore creates a file store in dir
+func NewFileStore(dir string) *FileStore {
+	return &FileStore{dir: dir}
+}
+
+func (f *FileStore) keyPath() string  { return filepath.Join(f.dir, "secrets.key") }
+func (f *FileStore) dataPath() string { return filepath.Join(f.dir, "secrets.enc") }
+func (f *FileStore) lockPath() string { return filepath.Join(f.dir, "secrets.lock") }
+
+// Get returns the value for key
+func (f *FileStore) Get(key string) (string, bool, error) {
+	values, err := f.load()
+	if err != nil {
+		return "", false, err
+	}
+	value, ok := values[key]
+	return value, ok, nil
+}
+
+// Set stores value for key
+func (f *FileStore) Set(key, value string) error {
+	return f.update(func(values map[string]string) {
+		values[key] = value
+	})
+}
+
+// Delete removes the value for key
+func (f *FileStore) Delete(key string) error {
+	return f.update(func(values map[string]string) {
+		delete(values, key)
+	})
+}
+
+// Description names the encrypted file
+func (f *FileStore) Description() string {
+	return "encrypted file " + f.dataPath()
+}
+
+// update applies change to the stored values while holding the store's lock
+func (f *FileStore) update(change func(map[string]string)) error {
+	if err := os.MkdirAll(f.dir, 0700); err != nil {
+		return fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+
+	lock := flock.New(f.lockPath())
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("failed to lock secrets file: %w", err)
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	values, err := f.load()
+	if err != nil {
+		return err
+	}
+	change(values)
+	return f.save(values)
+}
+
+// load decrypts the stored values, returning an empty map if there are none
+func (f *FileStore) load() (map[string]string, error) {
+	data, err := os.ReadFile(f.dataPath())
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]string), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets file: %w", err)
+	}
+
+	gcm, err := f.cipher(false)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < gcm.NonceSize() {
+		return nil, fmt.Errorf("secrets file %s is corrupt", f.dataPath())
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secrets file %s: %w", f.dataPath(), err)
+	}
+
+	values := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file: %w", err)
+	}
+	return values, nil
+}
+
+// save encrypts values and atomically replaces the secrets file
+func (f *FileStore) save(values map[string]string) error {
+	plaintext, err := json.Marshal(values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal secrets: %w", err)
+	}
+
+	gcm, err := f.cipher(true)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	data := gcm.Seal(nonce, nonce, plaintext, nil)
+
+	tmp, err := os.CreateTemp(f.dir, "secrets-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create secrets file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write secrets file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.dataPath()); err != nil {
+		return fmt.Errorf("failed to replace secrets file: %w", err)
+	}
+	return nil
+}
+
+// cipher returns the AEAD for the store's key, generating the key if create is set
+func (f *FileStore) cipher(create bool) (cipher.AEAD, error) {
+	key, err := os.ReadFile(f.keyPath())
+	if errors.Is(err, os.ErrNotExist) && create {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("failed to generate secrets key: %w", err)
+		}
+		if err := os.WriteFile(f.keyPath(), key, 0600); err != nil {
+			return nil, fmt.Errorf("failed to write secrets key: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read secrets key: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("secrets key %s is corrupt", f.keyPath())
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return gcm, nil
+}
@@ -0,0 +1,167 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sleuth-io/skills/internal/metadata"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// inputsFile holds the values of non-secret inputs, by artifact then input name
+const inputsFile = "inputs.json"
+
+// LoadValues returns the stored non-secret input values for an artifact
+func LoadValues(artifactName string) (map[string]string, error) {
+	all, err := loadAllValues()
+	if err != nil {
+		return nil, err
+	}
+	values := all[artifactName]
+	if values == nil {
+		values = make(map[string]string)
+	}
+	return values, nil
+}
+
+// saveValue stores a non-secret input value for an artifact
+func saveValue(artifactName, inputName, value string) error {
+	all, err := loadAllValues()
+	if err != nil {
+		return err
+	}
+	if all[artifactName] == nil {
+		all[artifactName] = make(map[string]string)
+	}
+	all[artifactName][inputName] = value
+
+	path, err := inputsPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal input values: %w", err)
+	}
+	if err := utils.EnsureDir(filepath.Dir(path)); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return fmt.Errorf("failed to write input values: %w", err)
+	}
+	return nil
+}
+
+func loadAllValues() (map[string]map[string]string, error) {
+	path, err := inputsPath()
+	if err != nil {
+		return nil, err
+	}
+	all := make(map[string]map[string]string)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input values: %w", err)
+	}
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return all, nil
+}
+
+func inputsPath() (string, error) {
+	configDir, err := utils.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, inputsFile), nil
+}
+
+// PromptFunc asks the developer for an input's value
+// An empty answer falls back to the input's default
+type PromptFunc func(artifactName string, input metadata.MCPInput) (string, error)
+
+// Resolver makes sure every declared input has a stored value
+type Resolver struct {
+	store  Store
+	prompt PromptFunc
+}
+
+// NewResolver creates a resolver that keeps secrets in store
+// A nil prompt means non-interactive: values come only from the environment and defaults
+func NewResolver(store Store, prompt PromptFunc) *Resolver {
+	return &Resolver{store: store, prompt: prompt}
+}
+
+// Resolve stores a value for each input that doesn't have one yet, taken from the
+// environment variable of the same name, then the prompt, then the input's default
+// Inputs already provided are never asked for again
+func (r *Resolver) Resolve(artifactName string, inputs []metadata.MCPInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	values, err := LoadValues(artifactName)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, input := range inputs {
+		if input.Secret {
+			if _, ok, err := r.store.Get(Key(artifactName, input.Name)); err != nil {
+				return err
+			} else if ok {
+				continue
+			}
+		} else if _, ok := values[input.Name]; ok {
+			continue
+		}
+
+		value, err := r.lookup(artifactName, input)
+		if err != nil {
+			return err
+		}
+		if value == "" {
+			missing = append(missing, input.Name)
+			continue
+		}
+
+		if input.Secret {
+			err = r.store.Set(Key(artifactName, input.Name), value)
+		} else {
+			err = saveValue(artifactName, input.Name, value)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to store input %s: %w", input.Name, err)
+		}
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required inputs: %s (set them as environment variables or run 'skills install' interactively)", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// lookup finds a new value for an input, returning empty if there is none
+func (r *Resolver) lookup(artifactName string, input metadata.MCPInput) (string, error) {
+	if value := os.Getenv(input.Name); value != "" {
+		return value, nil
+	}
+	if r.prompt != nil {
+		value, err := r.prompt(artifactName, input)
+		if err != nil {
+			return "", fmt.Errorf("failed to read input %s: %w", input.Name, err)
+		}
+		if value = strings.TrimSpace(value); value != "" {
+			return value, nil
+		}
+	}
+	return input.Default, nil
+}
@@ -0,0 +1,121 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// keyringService is the service name secrets are filed under in the OS keyring
+const keyringService = "skills"
+
+// runFunc runs a keyring tool with stdin and returns its trimmed stdout
+type runFunc func(stdin string, name string, args ...string) (string, error)
+
+// keyringStore keeps secrets in the OS keyring through its command line tool:
+// security on macOS, and secret-tool (libsecret) on Linux
+type keyringStore struct {
+	goos string
+	run  runFunc
+}
+
+// newSystemKeyring returns the keyring store for this OS, or nil if there is none
+func newSystemKeyring() *keyringStore {
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("security"); err != nil {
+			return nil
+		}
+	case "linux":
+		// secret-tool needs a session bus to reach the keyring daemon
+		if _, err := exec.LookPath("secret-tool"); err != nil || os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return &keyringStore{goos: runtime.GOOS, run: runKeyringTool}
+}
+
+// Get returns the value for key from the keyring
+func (k *keyringStore) Get(key string) (string, bool, error) {
+	var out string
+	var err error
+	if k.goos == "darwin" {
+		out, err = k.run("", "security", "find-generic-password", "-s", keyringService, "-a", key, "-w")
+	} else {
+		out, err = k.run("", "secret-tool", "lookup", "service", keyringService, "account", key)
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return "", false, nil // Both tools exit non-zero when nothing is stored
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read from keyring: %w", err)
+	}
+	return out, true, nil
+}
+
+// Set stores value for key in the keyring
+func (k *keyringStore) Set(key, value string) error {
+	var err error
+	if k.goos == "darwin" {
+		// Arguments are visible to other processes, so the command is read from stdin
+		// by security's interactive mode, with the value hex-encoded to avoid quoting it
+		command := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", quoteSecurityArg(keyringService), quoteSecurityArg(key), hex.EncodeToString([]byte(value)))
+		_, err = k.run(command, "security", "-i")
+	} else {
+		_, err = k.run(value, "secret-tool", "store", "--label", "skills: "+key, "service", keyringService, "account", key)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write to keyring: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the value for key from the keyring
+func (k *keyringStore) Delete(key string) error {
+	var err error
+	if k.goos == "darwin" {
+		_, err = k.run("", "security", "delete-generic-password", "-s", keyringService, "-a", key)
+	} else {
+		_, err = k.run("", "secret-tool", "clear", "service", keyringService, "account", key)
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return fmt.Errorf("failed to delete from keyring: %w", err)
+	}
+	return nil
+}
+
+// Description names the keyring
+func (k *keyringStore) Description() string {
+	if k.goos == "darwin" {
+		return "macOS keychain"
+	}
+	return "system keyring"
+}
+
+// quoteSecurityArg quotes an argument for a command read by security -i
+func quoteSecurityArg(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+// runKeyringTool runs a command, feeding it stdin and returning its trimmed stdout
+func runKeyringTool(stdin string, name string, args ...string) (string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+	return strings.TrimRight(stdout.String(), "\n"), nil
+}
@@ -0,0 +1,136 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+// referenceRegex matches ${NAME} references in env values and args
+var referenceRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ExecCommand is the hidden command that starts MCP servers with their secrets
+const ExecCommand = "mcp-exec"
+
+// Launch is how a client starts an MCP server
+type Launch struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+}
+
+// ApplyInputs rewrites a server launch so the client config carries input references
+// rather than values: non-secret inputs are filled in from the stored values, and if any
+// input is secret the server is started through 'skills mcp-exec', which reads secrets
+// from the store when the server starts
+func ApplyInputs(artifactName string, launch Launch, inputs []metadata.MCPInput) (Launch, error) {
+	if len(inputs) == 0 {
+		return launch, nil
+	}
+
+	values, err := LoadValues(artifactName)
+	if err != nil {
+		return Launch{}, err
+	}
+
+	secret := make(map[string]bool)
+	env := make(map[string]string, len(launch.Env)+len(inputs))
+	for k, v := range launch.Env {
+		env[k] = v
+	}
+	for _, input := range inputs {
+		if input.Secret {
+			secret[input.Name] = true
+		}
+		// Every input reaches the server as an environment variable of the same name
+		if _, ok := env[input.Name]; !ok {
+			env[input.Name] = "${" + input.Name + "}"
+		}
+	}
+
+	nonSecret := func(name string) (string, bool) {
+		if secret[name] {
+			return "", false
+		}
+		value, ok := values[name]
+		return value, ok
+	}
+
+	result := Launch{Command: launch.Command, Env: make(map[string]string)}
+	for _, arg := range launch.Args {
+		result.Args = append(result.Args, Expand(arg, nonSecret))
+	}
+
+	// Env entries that still reference secrets are expanded by mcp-exec instead
+	var deferred []string
+	for _, k := range sortedKeys(env) {
+		v := Expand(env[k], nonSecret)
+		if secret[k] && v == "${"+k+"}" {
+			continue // mcp-exec sets secret inputs directly
+		}
+		if len(References(v)) > 0 {
+			deferred = append(deferred, k+"="+v)
+			continue
+		}
+		result.Env[k] = v
+	}
+
+	var secretNames []string
+	for _, input := range inputs {
+		if input.Secret {
+			secretNames = append(secretNames, input.Name)
+		}
+	}
+	if len(secretNames) == 0 && len(deferred) == 0 {
+		return result, nil
+	}
+
+	executable, err := os.Executable()
+	if err != nil {
+		return Launch{}, fmt.Errorf("failed to locate skills executable: %w", err)
+	}
+	wrapped := []string{ExecCommand, "--artifact", artifactName}
+	for _, name := range secretNames {
+		wrapped = append(wrapped, "--secret", name)
+	}
+	for _, entry := range deferred {
+		wrapped = append(wrapped, "--env", entry)
+	}
+	wrapped = append(wrapped, "--", result.Command)
+	result.Args = append(wrapped, result.Args...)
+	result.Command = executable
+
+	return result, nil
+}
+
+// Expand replaces ${NAME} references that lookup knows, leaving the rest in place
+func Expand(template string, lookup func(name string) (string, bool)) string {
+	return referenceRegex.ReplaceAllStringFunc(template, func(ref string) string {
+		name := referenceRegex.FindStringSubmatch(ref)[1]
+		if value, ok := lookup(name); ok {
+			return value
+		}
+		return ref
+	})
+}
+
+// References returns the names referenced as ${NAME} in s
+func References(s string) []string {
+	var names []string
+	for _, match := range referenceRegex.FindAllStringSubmatch(s, -1) {
+		names = append(names, match[1])
+	}
+	return names
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
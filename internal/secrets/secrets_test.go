@@ -0,0 +1,196 @@
+package secrets
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/metadata"
+)
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStore(dir)
+
+	if _, ok, err := store.Get("a/TOKEN"); err != nil || ok {
+		t.Fatalf("Get() on empty store = %v, %v; want not found", ok, err)
+	}
+
+	if err := store.Set("a/TOKEN", "s3cret"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := store.Set("b/TOKEN", "other"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+
+	// A new store over the same directory reads the same values
+	value, ok, err := NewFileStore(dir).Get("a/TOKEN")
+	if err != nil || !ok || value != "s3cret" {
+		t.Errorf("Get() = %q, %v, %v; want s3cret", value, ok, err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "secrets.enc"))
+	if err != nil {
+		t.Fatalf("Failed to read secrets file: %v", err)
+	}
+	if bytes.Contains(data, []byte("s3cret")) {
+		t.Error("Secrets file contains the plaintext value")
+	}
+	info, err := os.Stat(filepath.Join(dir, "secrets.key"))
+	if err != nil {
+		t.Fatalf("Failed to stat secrets key: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("Secrets key permissions = %o, want 600", perm)
+	}
+
+	if err := store.Delete("a/TOKEN"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, ok, _ := store.Get("a/TOKEN"); ok {
+		t.Error("Expected deleted value to be gone")
+	}
+	if value, _, _ := store.Get("b/TOKEN"); value != "other" {
+		t.Errorf("Expected other values to be kept, got %q", value)
+	}
+}
+
+func TestKeyringStoreSetKeepsValueOffCommandLine(t *testing.T) {
+	for _, goos := range []string{"darwin", "linux"} {
+		t.Run(goos, func(t *testing.T) {
+			var stdin string
+			var args []string
+			store := &keyringStore{goos: goos, run: func(in string, name string, a ...string) (string, error) {
+				stdin, args = in, append([]string{name}, a...)
+				return "", nil
+			}}
+
+			if err := store.Set(`a/"TOKEN"`, "s3cret"); err != nil {
+				t.Fatalf("Set failed: %v", err)
+			}
+			if strings.Contains(strings.Join(args, " "), "s3cret") {
+				t.Errorf("Secret passed as an argument: %v", args)
+			}
+			if goos == "darwin" {
+				want := `add-generic-password -U -s "skills" -a "a/\"TOKEN\"" -X 733363726574` + "\n"
+				if stdin != want {
+					t.Errorf("stdin = %q, want %q", stdin, want)
+				}
+			} else if stdin != "s3cret" {
+				t.Errorf("stdin = %q, want the secret", stdin)
+			}
+		})
+	}
+}
+
+func TestResolver(t *testing.T) {
+	t.Setenv("SKILLS_CONFIG_DIR", t.TempDir())
+	t.Setenv("TEST_MCP_TOKEN", "from-env")
+	store := NewFileStore(t.TempDir())
+
+	inputs := []metadata.MCPInput{
+		{Name: "TEST_MCP_TOKEN", Secret: true},
+		{Name: "TEST_MCP_HOST", Default: "example.com"},
+	}
+
+	// Non-interactive: secrets from the environment, others from their default
+	if err := NewResolver(store, nil).Resolve("github", inputs); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if value, _, _ := store.Get(Key("github", "TEST_MCP_TOKEN")); value != "from-env" {
+		t.Errorf("Expected secret from environment, got %q", value)
+	}
+	values, err := LoadValues("github")
+	if err != nil {
+		t.Fatalf("LoadValues failed: %v", err)
+	}
+	if values["TEST_MCP_HOST"] != "example.com" {
+		t.Errorf("Expected default for non-secret input, got %q", values["TEST_MCP_HOST"])
+	}
+	if _, ok := values["TEST_MCP_TOKEN"]; ok {
+		t.Error("Secret input was stored with the plain values")
+	}
+
+	// Missing inputs are reported together
+	err = NewResolver(store, nil).Resolve("other", []metadata.MCPInput{{Name: "TEST_MCP_A", Secret: true}, {Name: "TEST_MCP_B"}})
+	if err == nil || !strings.Contains(err.Error(), "TEST_MCP_A, TEST_MCP_B") {
+		t.Errorf("Expected error naming both missing inputs, got %v", err)
+	}
+
+	// Inputs are only asked for once
+	prompts := 0
+	prompt := func(artifactName string, input metadata.MCPInput) (string, error) {
+		prompts++
+		return "typed", nil
+	}
+	promptInputs := []metadata.MCPInput{{Name: "TEST_MCP_KEY", Secret: true}}
+	for i := 0; i < 2; i++ {
+		if err := NewResolver(store, prompt).Resolve("linear", promptInputs); err != nil {
+			t.Fatalf("Resolve failed: %v", err)
+		}
+	}
+	if prompts != 1 {
+		t.Errorf("Expected 1 prompt, got %d", prompts)
+	}
+}
+
+func TestApplyInputs(t *testing.T) {
+	t.Setenv("SKILLS_CONFIG_DIR", t.TempDir())
+	if err := saveValue("github", "GITHUB_HOST", "ghe.example.com"); err != nil {
+		t.Fatalf("saveValue failed: %v", err)
+	}
+
+	launch := Launch{
+		Command: "npx",
+		Args:    []string{"server", "--host", "${GITHUB_HOST}"},
+		Env:     map[string]string{"GITHUB_PERSONAL_ACCESS_TOKEN": "Bearer ${GITHUB_TOKEN}"},
+	}
+
+	t.Run("no inputs", func(t *testing.T) {
+		got, err := ApplyInputs("github", launch, nil)
+		if err != nil {
+			t.Fatalf("ApplyInputs failed: %v", err)
+		}
+		if got.Command != "npx" || got.Args[2] != "${GITHUB_HOST}" {
+			t.Errorf("Expected launch to be unchanged, got %+v", got)
+		}
+	})
+
+	t.Run("non-secret inputs are filled in", func(t *testing.T) {
+		got, err := ApplyInputs("github", Launch{Command: "npx", Args: launch.Args}, []metadata.MCPInput{{Name: "GITHUB_HOST"}})
+		if err != nil {
+			t.Fatalf("ApplyInputs failed: %v", err)
+		}
+		if got.Command != "npx" || got.Args[2] != "ghe.example.com" || got.Env["GITHUB_HOST"] != "ghe.example.com" {
+			t.Errorf("Unexpected launch: %+v", got)
+		}
+	})
+
+	t.Run("secret inputs go through mcp-exec", func(t *testing.T) {
+		inputs := []metadata.MCPInput{{Name: "GITHUB_TOKEN", Secret: true}, {Name: "GITHUB_HOST"}}
+		got, err := ApplyInputs("github", launch, inputs)
+		if err != nil {
+			t.Fatalf("ApplyInputs failed: %v", err)
+		}
+
+		want := []string{
+			ExecCommand, "--artifact", "github", "--secret", "GITHUB_TOKEN",
+			"--env", "GITHUB_PERSONAL_ACCESS_TOKEN=Bearer ${GITHUB_TOKEN}",
+			"--", "npx", "server", "--host", "ghe.example.com",
+		}
+		if strings.Join(got.Args, " ") != strings.Join(want, " ") {
+			t.Errorf("Args = %q, want %q", got.Args, want)
+		}
+		if _, ok := got.Env["GITHUB_PERSONAL_ACCESS_TOKEN"]; ok {
+			t.Error("Env entry referencing a secret was written to the client config")
+		}
+		if _, ok := got.Env["GITHUB_TOKEN"]; ok {
+			t.Error("Secret input was written to the client config")
+		}
+		if got.Env["GITHUB_HOST"] != "ghe.example.com" {
+			t.Errorf("Expected non-secret input in env, got %v", got.Env)
+		}
+	})
+}
@@ -0,0 +1,62 @@
+// Package secrets stores the values developers provide for MCP server inputs.
+//
+// Secret values go in the OS keyring where one is available, and otherwise in an
+// encrypted file in the config directory. They are never written to client configs:
+// servers that need them are started through 'skills mcp-exec', which reads them back.
+package secrets
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// Backends that SKILLS_SECRETS_BACKEND can force
+const (
+	BackendKeyring = "keyring"
+	BackendFile    = "file"
+)
+
+// Store keeps secret values by key
+type Store interface {
+	// Get returns the value for key, and false if none is stored
+	Get(key string) (string, bool, error)
+	// Set stores value for key, replacing any previous value
+	Set(key, value string) error
+	// Delete removes the value for key, if any
+	Delete(key string) error
+	// Description names where values are kept, for messages
+	Description() string
+}
+
+// Key returns the store key for an artifact's input
+func Key(artifactName, inputName string) string {
+	return artifactName + "/" + inputName
+}
+
+// Open returns the OS keyring store if one is available, otherwise the encrypted file store
+// SKILLS_SECRETS_BACKEND=keyring or file forces a backend
+func Open() (Store, error) {
+	backend := os.Getenv("SKILLS_SECRETS_BACKEND")
+	switch backend {
+	case "", BackendKeyring, BackendFile:
+	default:
+		return nil, fmt.Errorf("invalid SKILLS_SECRETS_BACKEND %q (must be %s or %s)", backend, BackendKeyring, BackendFile)
+	}
+
+	if backend != BackendFile {
+		if keyring := newSystemKeyring(); keyring != nil {
+			return keyring, nil
+		}
+		if backend == BackendKeyring {
+			return nil, fmt.Errorf("no OS keyring is available")
+		}
+	}
+
+	configDir, err := utils.GetConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return NewFileStore(configDir), nil
+}
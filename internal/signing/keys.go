@@ -0,0 +1,142 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Supported signature algorithms
+const (
+	AlgorithmEd25519  = "ed25519"
+	AlgorithmMinisign = "minisign"
+)
+
+// ed25519KeyPrefix marks an inline raw ed25519 public key, e.g. "ed25519:<base64>"
+const ed25519KeyPrefix = "ed25519:"
+
+// PrivateKey is a key used to sign artifacts
+type PrivateKey struct {
+	Algorithm string
+	KeyID     string
+
+	key ed25519.PrivateKey
+
+	// minisignID is the raw 8-byte key ID embedded in minisign signatures
+	minisignID []byte
+}
+
+// PublicKey is a key used to verify artifact signatures
+type PublicKey struct {
+	Algorithm string
+	KeyID     string
+
+	key        ed25519.PublicKey
+	minisignID []byte
+}
+
+// PasswordFunc returns the password protecting an encrypted private key
+type PasswordFunc func() ([]byte, error)
+
+// LoadPrivateKey reads a signing key from a file
+// Supports PKCS#8 PEM ed25519 keys and minisign secret keys
+// password is only called for encrypted minisign keys and may be nil otherwise
+func LoadPrivateKey(path string, password PasswordFunc) (*PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	return ParsePrivateKey(data, password)
+}
+
+// ParsePrivateKey parses a PKCS#8 PEM ed25519 key or a minisign secret key
+func ParsePrivateKey(data []byte, password PasswordFunc) (*PrivateKey, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PEM private key: %w", err)
+		}
+		key, ok := parsed.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T (must be ed25519)", parsed)
+		}
+		return &PrivateKey{
+			Algorithm: AlgorithmEd25519,
+			KeyID:     ed25519KeyID(key.Public().(ed25519.PublicKey)),
+			key:       key,
+		}, nil
+	}
+
+	return parseMinisignSecretKey(data, password)
+}
+
+// Public returns the public half of the key
+func (k *PrivateKey) Public() *PublicKey {
+	return &PublicKey{
+		Algorithm:  k.Algorithm,
+		KeyID:      k.KeyID,
+		key:        k.key.Public().(ed25519.PublicKey),
+		minisignID: k.minisignID,
+	}
+}
+
+// ParsePublicKey parses a public key as written in the trust policy:
+// a minisign public key (with or without its comment line), "ed25519:<base64>",
+// or a PKIX PEM ed25519 public key
+func ParsePublicKey(s string) (*PublicKey, error) {
+	s = strings.TrimSpace(s)
+
+	if block, _ := pem.Decode([]byte(s)); block != nil {
+		parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse PEM public key: %w", err)
+		}
+		key, ok := parsed.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("unsupported public key type %T (must be ed25519)", parsed)
+		}
+		return newEd25519PublicKey(key), nil
+	}
+
+	if encoded, ok := strings.CutPrefix(s, ed25519KeyPrefix); ok {
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ed25519 public key encoding: %w", err)
+		}
+		if len(raw) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid ed25519 public key length: %d", len(raw))
+		}
+		return newEd25519PublicKey(ed25519.PublicKey(raw)), nil
+	}
+
+	return parseMinisignPublicKey(s)
+}
+
+// String returns the key in the form accepted by ParsePublicKey
+func (k *PublicKey) String() string {
+	if k.Algorithm == AlgorithmMinisign {
+		return encodeMinisignPublicKey(k)
+	}
+	return ed25519KeyPrefix + base64.StdEncoding.EncodeToString(k.key)
+}
+
+// newEd25519PublicKey wraps a raw ed25519 public key
+func newEd25519PublicKey(key ed25519.PublicKey) *PublicKey {
+	return &PublicKey{
+		Algorithm: AlgorithmEd25519,
+		KeyID:     ed25519KeyID(key),
+		key:       key,
+	}
+}
+
+// ed25519KeyID derives a short identifier from a public key
+func ed25519KeyID(key ed25519.PublicKey) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
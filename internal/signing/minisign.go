@@ -0,0 +1,248 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Minisign format constants (see https://jedisct1.github.io/minisign/)
+const (
+	minisignAlgLegacy    = "Ed" // Signature over the message itself
+	minisignAlgPrehashed = "ED" // Signature over the BLAKE2b-512 hash of the message
+	minisignKDFScrypt    = "Sc"
+	minisignChecksumAlg  = "B2"
+
+	minisignKeyIDSize     = 8
+	minisignSecretKeySize = 2 + 2 + 2 + 32 + 8 + 8 + minisignKeyIDSize + ed25519.PrivateKeySize + 32
+	minisignPublicKeySize = 2 + minisignKeyIDSize + ed25519.PublicKeySize
+	minisignSignatureSize = 2 + minisignKeyIDSize + ed25519.SignatureSize
+
+	untrustedCommentPrefix = "untrusted comment: "
+	trustedCommentPrefix   = "trusted comment: "
+)
+
+// parseMinisignSecretKey decodes a minisign secret key file, decrypting it if needed
+func parseMinisignSecretKey(data []byte, password PasswordFunc) (*PrivateKey, error) {
+	raw, err := decodeMinisignBlob(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("unrecognized signing key format: %w", err)
+	}
+	if len(raw) != minisignSecretKeySize {
+		return nil, fmt.Errorf("invalid minisign secret key length: %d", len(raw))
+	}
+
+	sigAlg := raw[0:2]
+	kdfAlg := raw[2:4]
+	chkAlg := raw[4:6]
+	salt := raw[6:38]
+	opsLimit := binary.LittleEndian.Uint64(raw[38:46])
+	memLimit := binary.LittleEndian.Uint64(raw[46:54])
+	keynum := bytes.Clone(raw[54:])
+
+	if string(sigAlg) != minisignAlgLegacy || string(chkAlg) != minisignChecksumAlg {
+		return nil, fmt.Errorf("unsupported minisign key algorithm")
+	}
+
+	switch {
+	case string(kdfAlg) == minisignKDFScrypt:
+		if password == nil {
+			return nil, fmt.Errorf("minisign key is encrypted but no password was provided")
+		}
+		pw, err := password()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key password: %w", err)
+		}
+		n, r, p := minisignScryptParams(opsLimit, memLimit)
+		stream, err := scrypt.Key(pw, salt, n, r, p, len(keynum))
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive key: %w", err)
+		}
+		for i := range keynum {
+			keynum[i] ^= stream[i]
+		}
+	case kdfAlg[0] == 0 && kdfAlg[1] == 0:
+		// Unencrypted key
+	default:
+		return nil, fmt.Errorf("unsupported minisign key derivation: %q", kdfAlg)
+	}
+
+	keyID := keynum[:minisignKeyIDSize]
+	secret := keynum[minisignKeyIDSize : minisignKeyIDSize+ed25519.PrivateKeySize]
+	checksum := keynum[minisignKeyIDSize+ed25519.PrivateKeySize:]
+
+	expected := blake2b.Sum256(append(append(bytes.Clone(sigAlg), keyID...), secret...))
+	if subtle.ConstantTimeCompare(expected[:], checksum) != 1 {
+		return nil, fmt.Errorf("failed to decrypt minisign key: wrong password or corrupted key")
+	}
+
+	return &PrivateKey{
+		Algorithm:  AlgorithmMinisign,
+		KeyID:      minisignKeyIDString(keyID),
+		key:        ed25519.PrivateKey(secret),
+		minisignID: keyID,
+	}, nil
+}
+
+// minisignScryptParams converts libsodium's opslimit/memlimit into scrypt N, r and p
+// This mirrors libsodium's pickparams so keys created by minisign decrypt correctly
+func minisignScryptParams(opsLimit, memLimit uint64) (n, r, p int) {
+	if opsLimit < 32768 {
+		opsLimit = 32768
+	}
+	r = 8
+
+	var nLog2 uint
+	if opsLimit < memLimit/32 {
+		p = 1
+		maxN := opsLimit / uint64(r*4)
+		for nLog2 = 1; nLog2 < 63; nLog2++ {
+			if uint64(1)<<nLog2 > maxN/2 {
+				break
+			}
+		}
+	} else {
+		maxN := memLimit / uint64(r*128)
+		for nLog2 = 1; nLog2 < 63; nLog2++ {
+			if uint64(1)<<nLog2 > maxN/2 {
+				break
+			}
+		}
+		maxRP := (opsLimit / 4) / (uint64(1) << nLog2)
+		if maxRP > 0x3fffffff {
+			maxRP = 0x3fffffff
+		}
+		p = int(maxRP) / r
+	}
+
+	return 1 << nLog2, r, p
+}
+
+// parseMinisignPublicKey decodes a minisign public key
+func parseMinisignPublicKey(s string) (*PublicKey, error) {
+	raw, err := decodeMinisignBlob(s)
+	if err != nil {
+		return nil, fmt.Errorf("unrecognized public key format: %w", err)
+	}
+	if len(raw) != minisignPublicKeySize || string(raw[:2]) != minisignAlgLegacy {
+		return nil, fmt.Errorf("invalid minisign public key")
+	}
+
+	keyID := raw[2 : 2+minisignKeyIDSize]
+	return &PublicKey{
+		Algorithm:  AlgorithmMinisign,
+		KeyID:      minisignKeyIDString(keyID),
+		key:        ed25519.PublicKey(raw[2+minisignKeyIDSize:]),
+		minisignID: keyID,
+	}, nil
+}
+
+// encodeMinisignPublicKey encodes a public key in minisign's base64 form
+func encodeMinisignPublicKey(k *PublicKey) string {
+	raw := append([]byte(minisignAlgLegacy), k.minisignID...)
+	raw = append(raw, k.key...)
+	return base64.StdEncoding.EncodeToString(raw)
+}
+
+// signMinisign produces a prehashed minisign signature file for a message
+func signMinisign(k *PrivateKey, message []byte, trustedComment string) string {
+	hash := blake2b.Sum512(message)
+	sig := ed25519.Sign(k.key, hash[:])
+
+	blob := append([]byte(minisignAlgPrehashed), k.minisignID...)
+	blob = append(blob, sig...)
+
+	globalSig := ed25519.Sign(k.key, append(bytes.Clone(sig), trustedComment...))
+
+	var sb strings.Builder
+	sb.WriteString(untrustedCommentPrefix + "signature from skills secret key\n")
+	sb.WriteString(base64.StdEncoding.EncodeToString(blob) + "\n")
+	sb.WriteString(trustedCommentPrefix + trustedComment + "\n")
+	sb.WriteString(base64.StdEncoding.EncodeToString(globalSig) + "\n")
+	return sb.String()
+}
+
+// verifyMinisign checks a minisign signature file, including its trusted comment
+func verifyMinisign(k *PublicKey, message []byte, signature string) error {
+	lines := strings.Split(strings.TrimSpace(signature), "\n")
+	if len(lines) != 4 {
+		return fmt.Errorf("invalid minisign signature: expected 4 lines, got %d", len(lines))
+	}
+
+	blob, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[1]))
+	if err != nil || len(blob) != minisignSignatureSize {
+		return fmt.Errorf("invalid minisign signature encoding")
+	}
+
+	trustedComment, ok := strings.CutPrefix(strings.TrimRight(lines[2], "\r"), trustedCommentPrefix)
+	if !ok {
+		return fmt.Errorf("invalid minisign signature: missing trusted comment")
+	}
+
+	globalSig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(lines[3]))
+	if err != nil || len(globalSig) != ed25519.SignatureSize {
+		return fmt.Errorf("invalid minisign global signature encoding")
+	}
+
+	alg := string(blob[:2])
+	keyID := blob[2 : 2+minisignKeyIDSize]
+	sig := blob[2+minisignKeyIDSize:]
+
+	if !bytes.Equal(keyID, k.minisignID) {
+		return fmt.Errorf("signature was made with key %s, not %s", minisignKeyIDString(keyID), k.KeyID)
+	}
+
+	signed := message
+	switch alg {
+	case minisignAlgPrehashed:
+		hash := blake2b.Sum512(message)
+		signed = hash[:]
+	case minisignAlgLegacy:
+	default:
+		return fmt.Errorf("unsupported minisign signature algorithm: %q", alg)
+	}
+
+	if !ed25519.Verify(k.key, signed, sig) {
+		return fmt.Errorf("signature verification failed")
+	}
+	if !ed25519.Verify(k.key, append(bytes.Clone(sig), trustedComment...), globalSig) {
+		return fmt.Errorf("trusted comment verification failed")
+	}
+
+	return nil
+}
+
+// minisignTrustedComment returns the trusted comment recorded in new signatures
+func minisignTrustedComment(name, version string) string {
+	return fmt.Sprintf("timestamp:%d\tartifact:%s@%s", time.Now().Unix(), name, version)
+}
+
+// decodeMinisignBlob decodes a base64 minisign key, skipping an optional comment line
+func decodeMinisignBlob(s string) ([]byte, error) {
+	var encoded string
+	for _, line := range strings.Split(strings.TrimSpace(s), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, untrustedCommentPrefix) {
+			continue
+		}
+		encoded = line
+		break
+	}
+	if encoded == "" {
+		return nil, fmt.Errorf("no key data found")
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// minisignKeyIDString formats a key ID the way minisign displays it
+func minisignKeyIDString(keyID []byte) string {
+	return fmt.Sprintf("%016X", binary.LittleEndian.Uint64(keyID))
+}
@@ -0,0 +1,139 @@
+package signing
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// TrustPolicyFile is the name of the trust policy file in the config directory
+const TrustPolicyFile = "trust.toml"
+
+// TrustPolicy lists the signer keys accepted for each configured repository
+//
+// Example trust.toml:
+//
+//	# Applies to repositories without their own section
+//	require-signatures = false
+//
+//	[repositories.default]
+//	require-signatures = true
+//	keys = ["RWQf6LRCGA9i53mlYecO4IzT51TGPpvWucNSCh1CBM0QTaLn73Y7GFO3"]
+type TrustPolicy struct {
+	RepositoryPolicy
+
+	// Repositories overrides the top-level policy for named repositories
+	Repositories map[string]RepositoryPolicy `toml:"repositories"`
+}
+
+// RepositoryPolicy is the trust policy for one repository
+type RepositoryPolicy struct {
+	// RequireSignatures refuses artifacts that aren't signed by one of Keys
+	RequireSignatures bool `toml:"require-signatures"`
+
+	// Keys are trusted public keys (minisign, "ed25519:<base64>" or PEM)
+	Keys []string `toml:"keys"`
+}
+
+// GetTrustPolicyPath returns the path of the trust policy file
+func GetTrustPolicyPath() (string, error) {
+	configDir, err := utils.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, TrustPolicyFile), nil
+}
+
+// LoadTrustPolicy loads the trust policy from the config directory
+// A missing file yields an empty policy that accepts unsigned artifacts
+func LoadTrustPolicy() (*TrustPolicy, error) {
+	path, err := GetTrustPolicyPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trust policy path: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &TrustPolicy{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read trust policy: %w", err)
+	}
+
+	return ParseTrustPolicy(data)
+}
+
+// ParseTrustPolicy parses and validates a trust policy
+func ParseTrustPolicy(data []byte) (*TrustPolicy, error) {
+	var policy TrustPolicy
+	if err := toml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse trust policy: %w", err)
+	}
+
+	if _, err := policy.RepositoryPolicy.publicKeys(); err != nil {
+		return nil, err
+	}
+	for name, repoPolicy := range policy.Repositories {
+		if _, err := repoPolicy.publicKeys(); err != nil {
+			return nil, fmt.Errorf("repositories.%s: %w", name, err)
+		}
+	}
+
+	return &policy, nil
+}
+
+// ForRepository returns the policy that applies to the named repository
+func (p *TrustPolicy) ForRepository(name string) RepositoryPolicy {
+	if repoPolicy, ok := p.Repositories[name]; ok {
+		return repoPolicy
+	}
+	return p.RepositoryPolicy
+}
+
+// Check verifies an artifact from the named repository against the policy
+//
+// Signatures made by trusted keys are always verified. Unsigned artifacts and
+// artifacts signed by unknown keys are only refused if the repository requires signatures.
+func (p *TrustPolicy) Check(repoName string, artifact *lockfile.Artifact, zipData []byte) error {
+	repoPolicy := p.ForRepository(repoName)
+
+	if artifact.Signature == nil {
+		if repoPolicy.RequireSignatures {
+			return fmt.Errorf("%s is not signed, but repository %s requires signed artifacts", artifact.Key(), repoName)
+		}
+		return nil
+	}
+
+	keys, err := repoPolicy.publicKeys()
+	if err != nil {
+		return err
+	}
+
+	if _, err := VerifyArtifact(artifact.Signature, keys, artifact.Name, artifact.Version, zipData); err != nil {
+		if errors.Is(err, ErrUntrustedKey) && !repoPolicy.RequireSignatures {
+			return nil
+		}
+		return fmt.Errorf("%s: %w", artifact.Key(), err)
+	}
+
+	return nil
+}
+
+// publicKeys parses the policy's trusted keys
+func (r RepositoryPolicy) publicKeys() ([]*PublicKey, error) {
+	keys := make([]*PublicKey, 0, len(r.Keys))
+	for i, s := range r.Keys {
+		key, err := ParsePublicKey(s)
+		if err != nil {
+			return nil, fmt.Errorf("keys[%d]: %w", i, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
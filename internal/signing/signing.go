@@ -0,0 +1,88 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// ErrUntrustedKey is returned when an artifact is signed by a key that isn't trusted
+var ErrUntrustedKey = errors.New("artifact is signed by an untrusted key")
+
+// Message returns the statement that is signed for an artifact
+// The content hash is signed rather than the zip bytes, so signatures stay valid
+// when path and git repositories re-zip exploded artifacts
+func Message(name, version string, zipData []byte) ([]byte, error) {
+	contentHash, err := utils.ComputeZipContentSHA256(zipData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash artifact contents: %w", err)
+	}
+	return []byte(fmt.Sprintf("skills-artifact-v1\nname=%s\nversion=%s\nsha256=%s\n", name, version, contentHash)), nil
+}
+
+// SignArtifact signs an artifact's name, version and contents
+func SignArtifact(key *PrivateKey, name, version string, zipData []byte) (*lockfile.Signature, error) {
+	message, err := Message(name, version, zipData)
+	if err != nil {
+		return nil, err
+	}
+
+	var value string
+	switch key.Algorithm {
+	case AlgorithmEd25519:
+		value = base64.StdEncoding.EncodeToString(ed25519.Sign(key.key, message))
+	case AlgorithmMinisign:
+		value = signMinisign(key, message, minisignTrustedComment(name, version))
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", key.Algorithm)
+	}
+
+	return &lockfile.Signature{
+		Algorithm: key.Algorithm,
+		KeyID:     key.KeyID,
+		Value:     value,
+	}, nil
+}
+
+// VerifyArtifact checks an artifact's signature against a set of trusted keys
+// Returns the key that made the signature, or ErrUntrustedKey if none of the keys match
+func VerifyArtifact(sig *lockfile.Signature, keys []*PublicKey, name, version string, zipData []byte) (*PublicKey, error) {
+	var signer *PublicKey
+	for _, key := range keys {
+		if key.Algorithm == sig.Algorithm && key.KeyID == sig.KeyID {
+			signer = key
+			break
+		}
+	}
+	if signer == nil {
+		return nil, fmt.Errorf("%w: %s key %s", ErrUntrustedKey, sig.Algorithm, sig.KeyID)
+	}
+
+	message, err := Message(name, version, zipData)
+	if err != nil {
+		return nil, err
+	}
+
+	switch sig.Algorithm {
+	case AlgorithmEd25519:
+		raw, err := base64.StdEncoding.DecodeString(sig.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signature encoding: %w", err)
+		}
+		if !ed25519.Verify(signer.key, message, raw) {
+			return nil, fmt.Errorf("signature verification failed")
+		}
+	case AlgorithmMinisign:
+		if err := verifyMinisign(signer, message, sig.Value); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported signature algorithm: %s", sig.Algorithm)
+	}
+
+	return signer, nil
+}
@@ -0,0 +1,232 @@
+package signing
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/sleuth-io/skills/internal/lockfile"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// testZip builds an artifact zip with the given metadata.toml contents
+func testZip(t *testing.T, content string) []byte {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "metadata.toml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	data, err := utils.CreateZip(dir)
+	if err != nil {
+		t.Fatalf("Failed to create zip: %v", err)
+	}
+	return data
+}
+
+// ed25519PEMKey generates a PKCS#8 PEM ed25519 private key
+func ed25519PEMKey(t *testing.T) []byte {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+// minisignSecretKey generates a minisign secret key file
+// If password is set the key is encrypted with small scrypt limits to keep tests fast
+func minisignSecretKey(t *testing.T, password string) []byte {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	keyID := make([]byte, minisignKeyIDSize)
+	_, _ = rand.Read(keyID)
+	checksum := blake2b.Sum256(append(append([]byte(minisignAlgLegacy), keyID...), priv...))
+
+	keynum := append(append(bytes.Clone(keyID), priv...), checksum[:]...)
+	salt := make([]byte, 32)
+	_, _ = rand.Read(salt)
+
+	kdf := []byte{0, 0}
+	var opsLimit, memLimit uint64 = 65536, 16777216
+	if password != "" {
+		kdf = []byte(minisignKDFScrypt)
+		n, r, p := minisignScryptParams(opsLimit, memLimit)
+		stream, err := scrypt.Key([]byte(password), salt, n, r, p, len(keynum))
+		if err != nil {
+			t.Fatalf("Failed to derive key: %v", err)
+		}
+		for i := range keynum {
+			keynum[i] ^= stream[i]
+		}
+	}
+
+	raw := append([]byte(minisignAlgLegacy), kdf...)
+	raw = append(raw, minisignChecksumAlg...)
+	raw = append(raw, salt...)
+	raw = binary.LittleEndian.AppendUint64(raw, opsLimit)
+	raw = binary.LittleEndian.AppendUint64(raw, memLimit)
+	raw = append(raw, keynum...)
+
+	return []byte("untrusted comment: minisign encrypted secret key\n" + base64.StdEncoding.EncodeToString(raw) + "\n")
+}
+
+func TestSignAndVerify(t *testing.T) {
+	zipData := testZip(t, "original")
+
+	tests := []struct {
+		name     string
+		keyData  []byte
+		password string
+		algo     string
+	}{
+		{name: "ed25519", keyData: ed25519PEMKey(t), algo: AlgorithmEd25519},
+		{name: "minisign", keyData: minisignSecretKey(t, ""), algo: AlgorithmMinisign},
+		{name: "encrypted minisign", keyData: minisignSecretKey(t, "hunter2"), password: "hunter2", algo: AlgorithmMinisign},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParsePrivateKey(tt.keyData, func() ([]byte, error) { return []byte(tt.password), nil })
+			if err != nil {
+				t.Fatalf("ParsePrivateKey failed: %v", err)
+			}
+			if key.Algorithm != tt.algo {
+				t.Errorf("Expected algorithm %s, got %s", tt.algo, key.Algorithm)
+			}
+
+			sig, err := SignArtifact(key, "code-review", "1.0.0", zipData)
+			if err != nil {
+				t.Fatalf("SignArtifact failed: %v", err)
+			}
+			if err := sig.Validate(); err != nil {
+				t.Errorf("Signature entry is invalid: %v", err)
+			}
+
+			// Trusted keys round-trip through their policy representation
+			pub, err := ParsePublicKey(key.Public().String())
+			if err != nil {
+				t.Fatalf("ParsePublicKey failed: %v", err)
+			}
+
+			if _, err := VerifyArtifact(sig, []*PublicKey{pub}, "code-review", "1.0.0", zipData); err != nil {
+				t.Errorf("VerifyArtifact failed: %v", err)
+			}
+
+			// Different contents or a different name must not verify
+			if _, err := VerifyArtifact(sig, []*PublicKey{pub}, "code-review", "1.0.0", testZip(t, "tampered")); err == nil {
+				t.Error("Expected verification failure for tampered contents")
+			}
+			if _, err := VerifyArtifact(sig, []*PublicKey{pub}, "other", "1.0.0", zipData); err == nil {
+				t.Error("Expected verification failure for a different artifact name")
+			}
+
+			// Unknown keys are reported as untrusted
+			other, _ := ParsePrivateKey(ed25519PEMKey(t), nil)
+			if _, err := VerifyArtifact(sig, []*PublicKey{other.Public()}, "code-review", "1.0.0", zipData); !errors.Is(err, ErrUntrustedKey) {
+				t.Errorf("Expected ErrUntrustedKey, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParsePrivateKeyWrongPassword(t *testing.T) {
+	keyData := minisignSecretKey(t, "correct")
+
+	_, err := ParsePrivateKey(keyData, func() ([]byte, error) { return []byte("wrong"), nil })
+	if err == nil || !strings.Contains(err.Error(), "wrong password") {
+		t.Errorf("Expected wrong password error, got %v", err)
+	}
+}
+
+func TestMinisignScryptParams(t *testing.T) {
+	// minisign's default limits map to N=2^20, r=8, p=1
+	n, r, p := minisignScryptParams(33554432, 1073741824)
+	if n != 1<<20 || r != 8 || p != 1 {
+		t.Errorf("Expected N=2^20 r=8 p=1, got N=%d r=%d p=%d", n, r, p)
+	}
+}
+
+func TestTrustPolicyCheck(t *testing.T) {
+	zipData := testZip(t, "contents")
+
+	trusted, _ := ParsePrivateKey(ed25519PEMKey(t), nil)
+	untrusted, _ := ParsePrivateKey(ed25519PEMKey(t), nil)
+
+	policy, err := ParseTrustPolicy([]byte(`
+[repositories.company]
+require-signatures = true
+keys = ["` + trusted.Public().String() + `"]
+
+[repositories.personal]
+keys = ["` + trusted.Public().String() + `"]
+`))
+	if err != nil {
+		t.Fatalf("ParseTrustPolicy failed: %v", err)
+	}
+
+	newArtifact := func(signer *PrivateKey) *lockfile.Artifact {
+		art := &lockfile.Artifact{Name: "hook", Version: "1.0.0"}
+		if signer != nil {
+			art.Signature, err = SignArtifact(signer, art.Name, art.Version, zipData)
+			if err != nil {
+				t.Fatalf("SignArtifact failed: %v", err)
+			}
+		}
+		return art
+	}
+
+	tests := []struct {
+		name    string
+		repo    string
+		art     *lockfile.Artifact
+		wantErr bool
+	}{
+		{name: "required and trusted", repo: "company", art: newArtifact(trusted)},
+		{name: "required but unsigned", repo: "company", art: newArtifact(nil), wantErr: true},
+		{name: "required but untrusted", repo: "company", art: newArtifact(untrusted), wantErr: true},
+		{name: "optional and unsigned", repo: "personal", art: newArtifact(nil)},
+		{name: "optional and untrusted", repo: "personal", art: newArtifact(untrusted)},
+		{name: "unlisted repository", repo: "other", art: newArtifact(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.repo, tt.art, zipData)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// A trusted key with a bad signature is refused even when signatures are optional
+	forged := newArtifact(trusted)
+	if err := policy.Check("personal", forged, testZip(t, "tampered")); err == nil {
+		t.Error("Expected tampered artifact to be refused")
+	}
+}
+
+func TestParseTrustPolicyInvalidKey(t *testing.T) {
+	if _, err := ParseTrustPolicy([]byte(`keys = ["not-a-key"]`)); err == nil {
+		t.Error("Expected error for invalid key")
+	}
+}
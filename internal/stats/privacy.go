@@ -0,0 +1,59 @@
+package stats
+
+import (
+	"github.com/sleuth-io/skills/internal/config"
+	"github.com/sleuth-io/skills/internal/scope"
+	"github.com/sleuth-io/skills/internal/utils"
+)
+
+// hashValue returns a short, stable HMAC of a value keyed by the usage hash key
+// Guessed values can only be matched against it by someone holding the key, and the
+// value is dropped if there's no key
+func hashValue(settings *config.UsageConfig, value string) string {
+	if value == "" {
+		return ""
+	}
+	key, err := settings.GetHashKey()
+	if err != nil {
+		return ""
+	}
+	return utils.ComputeHMACSHA256(key, []byte(value))[:16]
+}
+
+// HashSessionID hashes a client's session ID so events from one session can be
+// grouped without recording the ID itself
+func HashSessionID(sessionID string, settings *config.UsageConfig) string {
+	return hashValue(settings, sessionID)
+}
+
+// Anonymize applies the usage settings to an event
+// Returns false if usage shouldn't be recorded at all
+func Anonymize(event UsageEvent, settings *config.UsageConfig) (UsageEvent, bool) {
+	if settings == nil {
+		return event, true
+	}
+	if settings.Disabled {
+		return event, false
+	}
+
+	switch settings.Repository {
+	case config.UsageRepositoryHashed:
+		// Normalized so every clone URL of a repository hashes the same
+		event.RepositoryURL = hashValue(settings, scope.NormalizeRepoURL(event.RepositoryURL))
+		event.Path = hashValue(settings, event.Path)
+	case config.UsageRepositoryNone:
+		event.RepositoryURL = ""
+		event.Path = ""
+	}
+	if settings.OmitSession {
+		event.SessionID = ""
+	}
+	if settings.OmitClient {
+		event.ClientID = ""
+	}
+	if settings.OmitOutcome {
+		event.Success = nil
+		event.DurationMs = 0
+	}
+	return event, true
+}
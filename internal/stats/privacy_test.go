@@ -0,0 +1,110 @@
+package stats
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sleuth-io/skills/internal/config"
+)
+
+func TestAnonymize(t *testing.T) {
+	t.Setenv("SKILLS_CONFIG_DIR", t.TempDir())
+	success := true
+	event := UsageEvent{
+		SchemaVersion: EventSchemaVersion,
+		ArtifactName:  "review",
+		ClientID:      "claude-code",
+		SessionID:     HashSessionID("session-1", &config.UsageConfig{}),
+		ScopeType:     ScopeTypePath,
+		RepositoryURL: "https://github.com/acme/app.git",
+		Path:          "services/api",
+		Success:       &success,
+		DurationMs:    120,
+	}
+
+	tests := []struct {
+		name       string
+		settings   *config.UsageConfig
+		wantRecord bool
+		check      func(t *testing.T, got UsageEvent)
+	}{
+		{
+			name:       "defaults keep everything",
+			settings:   &config.UsageConfig{},
+			wantRecord: true,
+			check: func(t *testing.T, got UsageEvent) {
+				if got.RepositoryURL != event.RepositoryURL || got.Path != event.Path || got.SessionID == "" || got.Success == nil {
+					t.Errorf("Expected the event unchanged, got %+v", got)
+				}
+			},
+		},
+		{
+			name:     "disabled",
+			settings: &config.UsageConfig{Disabled: true},
+		},
+		{
+			name:       "hashed repository",
+			settings:   &config.UsageConfig{Repository: config.UsageRepositoryHashed},
+			wantRecord: true,
+			check: func(t *testing.T, got UsageEvent) {
+				other, _ := Anonymize(UsageEvent{RepositoryURL: "git@github.com:acme/app.git"}, &config.UsageConfig{Repository: config.UsageRepositoryHashed})
+				if got.RepositoryURL == event.RepositoryURL || got.RepositoryURL != other.RepositoryURL {
+					t.Errorf("Expected clone URLs of one repository to hash the same, got %q and %q", got.RepositoryURL, other.RepositoryURL)
+				}
+				if got.Path == event.Path || got.Path == "" {
+					t.Errorf("Expected the path to be hashed, got %q", got.Path)
+				}
+				keyed, _ := Anonymize(event, &config.UsageConfig{Repository: config.UsageRepositoryHashed, HashKey: "acme"})
+				if keyed.RepositoryURL == got.RepositoryURL || keyed.Path == got.Path {
+					t.Errorf("Expected hashes keyed by the hash key, got %q with both keys", keyed.RepositoryURL)
+				}
+			},
+		},
+		{
+			name:       "everything omitted",
+			settings:   &config.UsageConfig{Repository: config.UsageRepositoryNone, OmitSession: true, OmitClient: true, OmitOutcome: true},
+			wantRecord: true,
+			check: func(t *testing.T, got UsageEvent) {
+				if got.RepositoryURL != "" || got.Path != "" || got.SessionID != "" || got.ClientID != "" || got.Success != nil || got.DurationMs != 0 {
+					t.Errorf("Expected only the artifact and scope type, got %+v", got)
+				}
+				if got.Scope() != ScopeTypePath {
+					t.Errorf("Scope() = %q, want %q", got.Scope(), ScopeTypePath)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, record := Anonymize(event, tt.settings)
+			if record != tt.wantRecord {
+				t.Fatalf("Anonymize() record = %v, want %v", record, tt.wantRecord)
+			}
+			if tt.check != nil {
+				tt.check(t, got)
+			}
+		})
+	}
+}
+
+func TestUsageEventSchemaCompatibility(t *testing.T) {
+	// Version 1 events, such as those still queued from older releases, parse unchanged
+	var v1 UsageEvent
+	if err := json.Unmarshal([]byte(`{"artifact_name":"review","artifact_version":"1.0.0","artifact_type":"skill","timestamp":"2026-03-01T09:00:00Z"}`), &v1); err != nil {
+		t.Fatalf("Failed to parse version 1 event: %v", err)
+	}
+	if v1.SchemaVersion != 0 || v1.ArtifactName != "review" || v1.Scope() != "" {
+		t.Errorf("Unexpected version 1 event: %+v", v1)
+	}
+
+	// Version 2 events without context keep the version 1 fields and add nothing else
+	data, err := json.Marshal(UsageEvent{SchemaVersion: EventSchemaVersion, ArtifactName: "review", ArtifactVersion: "1.0.0", ArtifactType: "skill", Timestamp: "2026-03-01T09:00:00Z"})
+	if err != nil {
+		t.Fatalf("Failed to marshal event: %v", err)
+	}
+	want := `{"schema_version":2,"artifact_name":"review","artifact_version":"1.0.0","artifact_type":"skill","timestamp":"2026-03-01T09:00:00Z"}`
+	if string(data) != want {
+		t.Errorf("Marshal() = %s, want %s", data, want)
+	}
+}
@@ -15,12 +15,61 @@ import (
 	"github.com/sleuth-io/skills/internal/repository"
 )
 
+// EventSchemaVersion is the version of the usage event schema
+// Version 1 events have only the artifact and timestamp, and no schema_version field.
+// Version 2 adds the optional context fields; servers that only know version 1 ignore them.
+const EventSchemaVersion = 2
+
+// Install scope types recorded in usage events
+const (
+	ScopeTypeGlobal     = "global"
+	ScopeTypeRepository = "repository"
+	ScopeTypePath       = "path"
+)
+
 // UsageEvent represents a single artifact usage event
 type UsageEvent struct {
+	SchemaVersion   int    `json:"schema_version,omitempty"`
 	ArtifactName    string `json:"artifact_name"`
 	ArtifactVersion string `json:"artifact_version"`
 	ArtifactType    string `json:"artifact_type"`
 	Timestamp       string `json:"timestamp"`
+
+	// ClientID is the client the artifact was used in
+	ClientID string `json:"client_id,omitempty"`
+
+	// SessionID is a hash of the client's session ID (see HashSessionID)
+	SessionID string `json:"session_id,omitempty"`
+
+	// ScopeType is where the used artifact is installed: global, repository or path
+	ScopeType string `json:"scope_type,omitempty"`
+
+	// RepositoryURL and Path are the repository and path the artifact is installed for,
+	// possibly hashed (see Anonymize)
+	RepositoryURL string `json:"repository_url,omitempty"`
+	Path          string `json:"path,omitempty"`
+
+	// Success is whether the tool call succeeded, nil if the hook didn't say
+	Success *bool `json:"success,omitempty"`
+
+	// DurationMs is how long the tool call took, 0 if the hook didn't say
+	DurationMs int64 `json:"duration_ms,omitempty"`
+}
+
+// Scope describes where the used artifact is installed, like InstalledArtifact.ScopeDescription,
+// falling back to the scope type when the repository isn't recorded
+func (e *UsageEvent) Scope() string {
+	switch {
+	case e.ScopeType == "" && e.RepositoryURL == "":
+		return ""
+	case e.ScopeType == ScopeTypeGlobal:
+		return "Global"
+	case e.RepositoryURL == "":
+		return e.ScopeType
+	case e.Path != "":
+		return e.RepositoryURL + ":" + e.Path
+	}
+	return e.RepositoryURL
 }
 
 // GetQueuePath returns the path to the usage queue directory
@@ -29,7 +78,8 @@ func GetQueuePath() string {
 	return filepath.Join(cacheDir, "usage-queue")
 }
 
-// EnqueueEvent writes a usage event to the queue directory
+// EnqueueEvent writes a usage event to the queue directory and records it in the
+// local usage store
 func EnqueueEvent(event UsageEvent) error {
 	queueDir := GetQueuePath()
 
@@ -54,6 +104,11 @@ func EnqueueEvent(event UsageEvent) error {
 		return fmt.Errorf("failed to write queue file: %w", err)
 	}
 
+	// Repositories other than Sleuth discard posted events, so keep a local copy
+	if err := RecordEvent(event); err != nil {
+		return fmt.Errorf("failed to record usage event: %w", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,90 @@
+package stats
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Grouping is what usage totals are broken down by
+type Grouping string
+
+const (
+	GroupByArtifact Grouping = "artifact"
+	GroupByVersion  Grouping = "version"
+	GroupByScope    Grouping = "scope"
+	GroupByDay      Grouping = "day"
+)
+
+// ParseGrouping parses a grouping name
+func ParseGrouping(s string) (Grouping, error) {
+	switch g := Grouping(s); g {
+	case GroupByArtifact, GroupByVersion, GroupByScope, GroupByDay:
+		return g, nil
+	}
+	return "", fmt.Errorf("unknown grouping %q (expected artifact, version, scope or day)", s)
+}
+
+// Total is the number of uses of an artifact, broken down by a grouping
+// Only the fields of the grouping are set
+type Total struct {
+	Day      string `json:"day,omitempty"`
+	Name     string `json:"artifact_name"`
+	Type     string `json:"artifact_type"`
+	Version  string `json:"artifact_version,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+	Uses     int    `json:"uses"`
+	LastUsed string `json:"last_used"`
+}
+
+// Totals adds up the uses in records on or after the since day (YYYY-MM-DD, empty for all),
+// ordered by day for GroupByDay and by most used otherwise
+func Totals(records []Record, by Grouping, since string) []Total {
+	totals := make(map[Total]*Total)
+	for _, r := range records {
+		if since != "" && r.Day < since {
+			continue
+		}
+
+		key := Total{Name: r.ArtifactName, Type: r.ArtifactType}
+		switch by {
+		case GroupByVersion:
+			key.Version = r.ArtifactVersion
+		case GroupByScope:
+			key.Scope = r.Scope
+		case GroupByDay:
+			key.Day = r.Day
+		}
+
+		total, ok := totals[key]
+		if !ok {
+			total = &Total{Day: key.Day, Name: key.Name, Type: key.Type, Version: key.Version, Scope: key.Scope}
+			totals[key] = total
+		}
+		total.Uses += r.Count
+		if r.Day > total.LastUsed {
+			total.LastUsed = r.Day
+		}
+	}
+
+	result := make([]Total, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.Uses != b.Uses {
+			return a.Uses > b.Uses
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Version != b.Version {
+			return a.Version < b.Version
+		}
+		return a.Scope < b.Scope
+	})
+	return result
+}
@@ -0,0 +1,268 @@
+package stats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+
+	"github.com/sleuth-io/skills/internal/cache"
+)
+
+// compactThreshold is the store size above which recording an event compacts the store
+const compactThreshold = 1 << 20
+
+// compactGrowth is how many times its size after the last compaction the store has to
+// grow to before it's compacted again, so a store that stays large once compacted
+// isn't rewritten on every event
+const compactGrowth = 2
+
+// Record is a line in the local usage store: a single use of an artifact, or after
+// compaction, the number of uses of an artifact version in a scope on a day
+type Record struct {
+	ArtifactName    string `json:"artifact_name"`
+	ArtifactVersion string `json:"artifact_version"`
+	ArtifactType    string `json:"artifact_type"`
+	Scope           string `json:"scope,omitempty"`
+	Day             string `json:"day"`                 // UTC, YYYY-MM-DD
+	Timestamp       string `json:"timestamp,omitempty"` // only set before compaction
+	Count           int    `json:"count"`
+}
+
+// key identifies the records compaction merges
+func (r *Record) key() Record {
+	return Record{ArtifactName: r.ArtifactName, ArtifactVersion: r.ArtifactVersion, ArtifactType: r.ArtifactType, Scope: r.Scope, Day: r.Day}
+}
+
+// GetStorePath returns the path to the local usage store, kept next to the queue
+func GetStorePath() string {
+	cacheDir, _ := cache.GetCacheDir()
+	return filepath.Join(cacheDir, "usage-store.jsonl")
+}
+
+// compactedSizePath returns the file recording the store's size after its last compaction
+func compactedSizePath() string {
+	return GetStorePath() + ".compacted"
+}
+
+// compactedSize returns the store's size after its last compaction, 0 if unknown
+func compactedSize() int64 {
+	data, err := os.ReadFile(compactedSizePath())
+	if err != nil {
+		return 0
+	}
+	size, _ := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	return size
+}
+
+// shouldCompact reports whether a store of size bytes, which was compacted bytes
+// after its last compaction, is due for compaction
+func shouldCompact(size, compacted int64) bool {
+	return size > compactThreshold && size > compactGrowth*compacted
+}
+
+// lockStore locks the usage store against other skills processes
+func lockStore() (*flock.Flock, error) {
+	path := GetStorePath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	lock := flock.New(path + ".lock")
+	if err := lock.Lock(); err != nil {
+		return nil, fmt.Errorf("failed to lock usage store: %w", err)
+	}
+	return lock, nil
+}
+
+// RecordEvent appends a usage event to the local usage store, compacting the store
+// once it grows past compactThreshold and has doubled since it was last compacted
+func RecordEvent(event UsageEvent) error {
+	data, err := json.Marshal(RecordFromEvent(event))
+	if err != nil {
+		return fmt.Errorf("failed to marshal usage record: %w", err)
+	}
+
+	lock, err := lockStore()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	f, err := os.OpenFile(GetStorePath(), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open usage store: %w", err)
+	}
+	_, err = f.Write(append(data, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write usage store: %w", err)
+	}
+
+	if info, err := os.Stat(GetStorePath()); err == nil && shouldCompact(info.Size(), compactedSize()) {
+		return compactStore()
+	}
+	return nil
+}
+
+// RecordFromEvent returns the store record of a single usage event
+func RecordFromEvent(event UsageEvent) Record {
+	day := ""
+	if t, err := time.Parse(time.RFC3339, event.Timestamp); err == nil {
+		day = t.UTC().Format(time.DateOnly)
+	}
+	return Record{
+		ArtifactName:    event.ArtifactName,
+		ArtifactVersion: event.ArtifactVersion,
+		ArtifactType:    event.ArtifactType,
+		Scope:           event.Scope(),
+		Day:             day,
+		Timestamp:       event.Timestamp,
+		Count:           1,
+	}
+}
+
+// RecordsFromJSONL converts JSONL usage events, as posted to repositories, into
+// store records, skipping lines that don't parse
+func RecordsFromJSONL(data []byte) []Record {
+	var records []Record
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var event UsageEvent
+		if err := json.Unmarshal(line, &event); err != nil || event.ArtifactName == "" {
+			continue
+		}
+		records = append(records, RecordFromEvent(event))
+	}
+	return records
+}
+
+// LoadRecords reads every record in the local usage store
+func LoadRecords() ([]Record, error) {
+	lock, err := lockStore()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	return loadRecords()
+}
+
+// loadRecords reads the store, skipping lines that don't parse (such as a line cut
+// short by a crash)
+func loadRecords() ([]Record, error) {
+	data, err := os.ReadFile(GetStorePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read usage store: %w", err)
+	}
+
+	var records []Record
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.ArtifactName == "" {
+			continue
+		}
+		if record.Count == 0 {
+			record.Count = 1
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage store: %w", err)
+	}
+	return records, nil
+}
+
+// CompactStore merges the records of each artifact version, scope and day into one
+func CompactStore() error {
+	lock, err := lockStore()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Unlock() }()
+
+	return compactStore()
+}
+
+// compactStore compacts the store while the caller holds its lock
+func compactStore() error {
+	records, err := loadRecords()
+	if err != nil {
+		return err
+	}
+
+	merged := compactRecords(records)
+
+	var buf bytes.Buffer
+	for _, record := range merged {
+		data, err := json.Marshal(record)
+		if err != nil {
+			return fmt.Errorf("failed to marshal usage record: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+
+	path := GetStorePath()
+	tmp, err := os.CreateTemp(filepath.Dir(path), "usage-store-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create usage store: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write usage store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write usage store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace usage store: %w", err)
+	}
+
+	// Only costs an early compaction if it's lost
+	_ = os.WriteFile(compactedSizePath(), []byte(strconv.Itoa(buf.Len())), 0644)
+	return nil
+}
+
+// compactRecords merges records with the same key, ordered by day then artifact
+func compactRecords(records []Record) []Record {
+	counts := make(map[Record]int)
+	for i := range records {
+		counts[records[i].key()] += records[i].Count
+	}
+
+	merged := make([]Record, 0, len(counts))
+	for key, count := range counts {
+		key.Count = count
+		merged = append(merged, key)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.Day != b.Day {
+			return a.Day < b.Day
+		}
+		if a.ArtifactName != b.ArtifactName {
+			return a.ArtifactName < b.ArtifactName
+		}
+		if a.ArtifactVersion != b.ArtifactVersion {
+			return a.ArtifactVersion < b.ArtifactVersion
+		}
+		return a.Scope < b.Scope
+	})
+	return merged
+}
@@ -0,0 +1,156 @@
+package stats
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestRecordAndCompact(t *testing.T) {
+	t.Setenv("SKILLS_CACHE_DIR", t.TempDir())
+
+	events := []UsageEvent{
+		{ArtifactName: "review", ArtifactVersion: "1.0.0", ArtifactType: "skill", Timestamp: "2026-03-01T09:00:00Z", ScopeType: ScopeTypeGlobal},
+		{ArtifactName: "review", ArtifactVersion: "1.0.0", ArtifactType: "skill", Timestamp: "2026-03-01T17:30:00Z", ScopeType: ScopeTypeGlobal},
+		{ArtifactName: "review", ArtifactVersion: "1.1.0", ArtifactType: "skill", Timestamp: "2026-03-02T10:00:00Z", ScopeType: ScopeTypeRepository, RepositoryURL: "https://github.com/acme/app"},
+		{ArtifactName: "lint", ArtifactVersion: "2.0.0", ArtifactType: "hook", Timestamp: "2026-03-02T11:00:00Z", ScopeType: ScopeTypeGlobal},
+	}
+	for _, event := range events {
+		if err := RecordEvent(event); err != nil {
+			t.Fatalf("RecordEvent failed: %v", err)
+		}
+	}
+
+	// A line cut short by a crash is skipped
+	f, err := os.OpenFile(GetStorePath(), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	_, _ = f.WriteString(`{"artifact_name":"trunc`)
+	_ = f.Close()
+
+	before, err := LoadRecords()
+	if err != nil {
+		t.Fatalf("LoadRecords failed: %v", err)
+	}
+	if len(before) != 4 {
+		t.Fatalf("Expected 4 records, got %d", len(before))
+	}
+
+	if err := CompactStore(); err != nil {
+		t.Fatalf("CompactStore failed: %v", err)
+	}
+	after, err := LoadRecords()
+	if err != nil {
+		t.Fatalf("LoadRecords failed: %v", err)
+	}
+	if len(after) != 3 || after[0].Count != 2 || after[0].Timestamp != "" {
+		t.Errorf("Expected the two uses on the same day to be merged, got %+v", after)
+	}
+
+	if compactedSize() == 0 {
+		t.Error("Expected the compacted size to be recorded")
+	}
+
+	// Compaction doesn't change the totals
+	for _, by := range []Grouping{GroupByArtifact, GroupByVersion, GroupByScope, GroupByDay} {
+		if got, want := Totals(after, by, ""), Totals(before, by, ""); !reflect.DeepEqual(got, want) {
+			t.Errorf("Totals by %s changed after compaction: got %+v, want %+v", by, got, want)
+		}
+	}
+}
+
+func TestShouldCompact(t *testing.T) {
+	tests := []struct {
+		name      string
+		size      int64
+		compacted int64
+		want      bool
+	}{
+		{"small store", compactThreshold / 2, 0, false},
+		{"never compacted", compactThreshold + 1, 0, true},
+		{"large after compaction", compactThreshold + 1, compactThreshold, false},
+		{"doubled since compaction", 2*compactThreshold + 1, compactThreshold, true},
+		{"small compaction", compactThreshold + 1, 100, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldCompact(tt.size, tt.compacted); got != tt.want {
+				t.Errorf("shouldCompact(%d, %d) = %v, want %v", tt.size, tt.compacted, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTotals(t *testing.T) {
+	records := []Record{
+		{ArtifactName: "review", ArtifactVersion: "1.0.0", ArtifactType: "skill", Scope: "Global", Day: "2026-03-01", Count: 2},
+		{ArtifactName: "review", ArtifactVersion: "1.1.0", ArtifactType: "skill", Scope: "https://github.com/acme/app", Day: "2026-03-02", Count: 1},
+		{ArtifactName: "lint", ArtifactVersion: "2.0.0", ArtifactType: "hook", Scope: "Global", Day: "2026-03-02", Count: 1},
+	}
+
+	tests := []struct {
+		name  string
+		by    Grouping
+		since string
+		want  []Total
+	}{
+		{
+			name: "by artifact",
+			by:   GroupByArtifact,
+			want: []Total{
+				{Name: "review", Type: "skill", Uses: 3, LastUsed: "2026-03-02"},
+				{Name: "lint", Type: "hook", Uses: 1, LastUsed: "2026-03-02"},
+			},
+		},
+		{
+			name: "by version",
+			by:   GroupByVersion,
+			want: []Total{
+				{Name: "review", Type: "skill", Version: "1.0.0", Uses: 2, LastUsed: "2026-03-01"},
+				{Name: "lint", Type: "hook", Version: "2.0.0", Uses: 1, LastUsed: "2026-03-02"},
+				{Name: "review", Type: "skill", Version: "1.1.0", Uses: 1, LastUsed: "2026-03-02"},
+			},
+		},
+		{
+			name: "by day",
+			by:   GroupByDay,
+			want: []Total{
+				{Day: "2026-03-01", Name: "review", Type: "skill", Uses: 2, LastUsed: "2026-03-01"},
+				{Day: "2026-03-02", Name: "lint", Type: "hook", Uses: 1, LastUsed: "2026-03-02"},
+				{Day: "2026-03-02", Name: "review", Type: "skill", Uses: 1, LastUsed: "2026-03-02"},
+			},
+		},
+		{
+			name:  "since",
+			by:    GroupByScope,
+			since: "2026-03-02",
+			want: []Total{
+				{Name: "lint", Type: "hook", Scope: "Global", Uses: 1, LastUsed: "2026-03-02"},
+				{Name: "review", Type: "skill", Scope: "https://github.com/acme/app", Uses: 1, LastUsed: "2026-03-02"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Totals(records, tt.by, tt.since); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Totals() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordsFromJSONL(t *testing.T) {
+	data := []byte(`{"artifact_name":"review","artifact_version":"1.0.0","artifact_type":"skill","timestamp":"2026-03-01T09:00:00Z"}
+not json
+{"schema_version":2,"artifact_name":"lint","artifact_version":"2.0.0","artifact_type":"hook","timestamp":"2026-03-02T11:00:00Z","scope_type":"repository","repository_url":"https://github.com/acme/app"}
+`)
+	want := []Record{
+		{ArtifactName: "review", ArtifactVersion: "1.0.0", ArtifactType: "skill", Day: "2026-03-01", Timestamp: "2026-03-01T09:00:00Z", Count: 1},
+		{ArtifactName: "lint", ArtifactVersion: "2.0.0", ArtifactType: "hook", Scope: "https://github.com/acme/app", Day: "2026-03-02", Timestamp: "2026-03-02T11:00:00Z", Count: 1},
+	}
+	if got := RecordsFromJSONL(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("RecordsFromJSONL() = %+v, want %+v", got, want)
+	}
+}
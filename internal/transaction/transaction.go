@@ -0,0 +1,527 @@
+// Package transaction makes multi-file installs all-or-nothing.
+//
+// Before a path is first changed, its current state is copied into the
+// transaction's directory and recorded in a journal. New content is staged next
+// to the target and moved into place with a rename, so Claude Code and Cursor
+// never read a half-written file. Commit discards the journal; Rollback, or
+// Recover after a crash, puts every journaled path back the way it was.
+package transaction
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofrs/flock"
+)
+
+const (
+	journalFile = "journal.json"
+	backupDir   = "backup"
+)
+
+// Transaction journals the paths it changes so they can be restored together
+type Transaction struct {
+	id   string
+	dir  string
+	lock *flock.Flock
+
+	mu      sync.Mutex
+	journal journal
+	tracked map[string]bool
+	done    bool
+}
+
+// journal is the on-disk record of the paths a transaction has touched
+type journal struct {
+	ID      string  `json:"id"`
+	Entries []entry `json:"entries"`
+}
+
+// entry records one path and whether it existed before the transaction.
+// Its backup, if any, is stored as backup/<index> in the transaction directory.
+type entry struct {
+	Path    string `json:"path"`
+	Existed bool   `json:"existed"`
+}
+
+type contextKey struct{}
+
+// NewContext returns a context whose file operations are journaled by tx
+func NewContext(ctx context.Context, tx *Transaction) context.Context {
+	return context.WithValue(ctx, contextKey{}, tx)
+}
+
+// FromContext returns the transaction carried by ctx, or nil
+func FromContext(ctx context.Context) *Transaction {
+	tx, _ := ctx.Value(contextKey{}).(*Transaction)
+	return tx
+}
+
+// Begin starts a transaction whose journal and backups live under root
+func Begin(root string) (*Transaction, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create transaction directory: %w", err)
+	}
+
+	id := fmt.Sprintf("%d-%d", time.Now().UnixNano(), os.Getpid())
+
+	// The lock is taken before the directory exists, so Recover never sees a live transaction unlocked
+	lock := flock.New(filepath.Join(root, id+".lock"))
+	locked, err := lock.TryLock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock transaction: %w", err)
+	}
+	if !locked {
+		return nil, fmt.Errorf("transaction %s is already locked", id)
+	}
+
+	tx := &Transaction{
+		id:      id,
+		dir:     filepath.Join(root, id),
+		lock:    lock,
+		journal: journal{ID: id},
+		tracked: make(map[string]bool),
+	}
+
+	if err := os.MkdirAll(filepath.Join(tx.dir, backupDir), 0755); err != nil {
+		tx.release()
+		return nil, fmt.Errorf("failed to create transaction directory: %w", err)
+	}
+	if err := tx.saveJournal(); err != nil {
+		tx.release()
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// ID returns the transaction's identifier
+func (t *Transaction) ID() string {
+	return t.id
+}
+
+// Track records the current state of path so Rollback can restore it.
+// Call it before changing a path by means other than this package's helpers.
+func (t *Transaction) Track(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("failed to resolve path: %w", err)
+	}
+	if err := t.trackMissingParents(path); err != nil {
+		return err
+	}
+	return t.track(path)
+}
+
+// trackMissingParents records the topmost missing ancestor of path, so directories
+// created for it are removed again on rollback
+func (t *Transaction) trackMissingParents(path string) error {
+	missing := ""
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if _, err := os.Lstat(dir); err == nil {
+			break
+		}
+		missing = dir
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	if missing == "" {
+		return nil
+	}
+	return t.track(missing)
+}
+
+// track journals path and backs it up before its first change
+func (t *Transaction) track(path string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return fmt.Errorf("transaction %s has already finished", t.id)
+	}
+	if t.tracked[path] {
+		return nil
+	}
+
+	_, err := os.Lstat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	existed := err == nil
+
+	// Journal first: after a crash, an entry without a backup means the path was never changed
+	index := len(t.journal.Entries)
+	t.journal.Entries = append(t.journal.Entries, entry{Path: path, Existed: existed})
+	if err := t.saveJournal(); err != nil {
+		t.journal.Entries = t.journal.Entries[:index]
+		return err
+	}
+
+	if existed {
+		backup := backupFile(t.dir, index)
+		if err := copyPath(path, backup+".partial"); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+		if err := os.Rename(backup+".partial", backup); err != nil {
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+
+	t.tracked[path] = true
+	return nil
+}
+
+// Commit keeps every change made in the transaction
+func (t *Transaction) Commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return fmt.Errorf("transaction %s has already finished", t.id)
+	}
+	t.done = true
+
+	// Removing the journal is the commit point; a leftover directory without one is just cleaned up
+	if err := os.Remove(filepath.Join(t.dir, journalFile)); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	_ = os.RemoveAll(t.dir)
+	t.release()
+	return nil
+}
+
+// Rollback restores every path touched by the transaction to its previous state.
+// It is a no-op after Commit, so it can be deferred.
+func (t *Transaction) Rollback() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.done {
+		return nil
+	}
+	t.done = true
+
+	if err := t.journal.rollback(t.dir); err != nil {
+		// Keep the journal so Recover can finish the job
+		t.release()
+		return err
+	}
+
+	_ = os.RemoveAll(t.dir)
+	t.release()
+	return nil
+}
+
+// release unlocks the transaction and removes its lock file
+func (t *Transaction) release() {
+	_ = t.lock.Unlock()
+	_ = os.Remove(t.lock.Path())
+}
+
+// saveJournal atomically rewrites the journal file
+func (t *Transaction) saveJournal() error {
+	data, err := json.MarshalIndent(t.journal, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal journal: %w", err)
+	}
+	if err := writeFileSynced(filepath.Join(t.dir, journalFile), data, 0644, t.id); err != nil {
+		return fmt.Errorf("failed to write journal: %w", err)
+	}
+	return nil
+}
+
+// backupFile returns where the journal entry at index is backed up
+func backupFile(dir string, index int) string {
+	return filepath.Join(dir, backupDir, strconv.Itoa(index))
+}
+
+// rollback restores entries in reverse order. Backups are copied rather than moved,
+// so a rollback interrupted by a crash can simply be run again.
+func (j *journal) rollback(dir string) error {
+	var errs []error
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		if err := j.restore(dir, i); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// restore puts a single journaled path back
+func (j *journal) restore(dir string, index int) error {
+	e := j.Entries[index]
+	staged := tempPath(e.Path, "staged", j.ID)
+	old := tempPath(e.Path, "old", j.ID)
+	_ = os.RemoveAll(staged)
+	_ = os.RemoveAll(old)
+
+	if !e.Existed {
+		if err := os.RemoveAll(e.Path); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", e.Path, err)
+		}
+		return nil
+	}
+
+	backup := backupFile(dir, index)
+	if _, err := os.Lstat(backup); os.IsNotExist(err) {
+		// Interrupted before the backup was taken, so the path was never changed
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(e.Path), 0755); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", e.Path, err)
+	}
+	if err := copyPath(backup, staged); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", e.Path, err)
+	}
+	if err := swapInto(staged, e.Path, old); err != nil {
+		return fmt.Errorf("failed to restore %s: %w", e.Path, err)
+	}
+	return nil
+}
+
+// Recover rolls back transactions under root left unfinished by a crashed process.
+// Transactions still held by a running process are left alone.
+func Recover(root string) (int, error) {
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read transaction directory: %w", err)
+	}
+
+	recovered := 0
+	var errs []error
+	for _, dirEntry := range entries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+
+		id := dirEntry.Name()
+		lock := flock.New(filepath.Join(root, id+".lock"))
+		locked, err := lock.TryLock()
+		if err != nil || !locked {
+			continue
+		}
+
+		dir := filepath.Join(root, id)
+		rolledBack, err := recoverDir(dir)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("transaction %s: %w", id, err))
+		} else {
+			_ = os.RemoveAll(dir)
+			if rolledBack {
+				recovered++
+			}
+		}
+
+		_ = lock.Unlock()
+		_ = os.Remove(lock.Path())
+	}
+
+	return recovered, errors.Join(errs...)
+}
+
+// recoverDir rolls back the journal in dir, reporting whether there was one
+func recoverDir(dir string) (bool, error) {
+	data, err := os.ReadFile(filepath.Join(dir, journalFile))
+	if os.IsNotExist(err) {
+		// Committed, or never got as far as writing a journal
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read journal: %w", err)
+	}
+
+	var j journal
+	if err := json.Unmarshal(data, &j); err != nil {
+		return false, fmt.Errorf("failed to parse journal: %w", err)
+	}
+	if j.ID == "" {
+		j.ID = filepath.Base(dir)
+	}
+
+	return true, j.rollback(dir)
+}
+
+// WriteFile atomically replaces the file at path with data.
+// If ctx carries a transaction, the previous content is journaled first.
+func WriteFile(ctx context.Context, path string, data []byte, perm os.FileMode) error {
+	tx := FromContext(ctx)
+	if tx != nil {
+		if err := tx.Track(path); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return writeFileSynced(path, data, perm, txID(tx))
+}
+
+// ReplaceDir atomically replaces the directory at path with one filled in by populate.
+// populate writes into a staging directory on the same filesystem; if it fails,
+// path is left untouched. If ctx carries a transaction, the previous directory is journaled first.
+func ReplaceDir(ctx context.Context, path string, populate func(dir string) error) error {
+	tx := FromContext(ctx)
+	if tx != nil {
+		if err := tx.Track(path); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	id := txID(tx)
+	staged := tempPath(path, "staged", id)
+	if err := os.RemoveAll(staged); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(staged, 0755); err != nil {
+		return err
+	}
+	if err := populate(staged); err != nil {
+		_ = os.RemoveAll(staged)
+		return err
+	}
+
+	return swapInto(staged, path, tempPath(path, "old", id))
+}
+
+// RemoveAll removes path and anything it contains.
+// If ctx carries a transaction, the path is journaled first so it can be restored.
+func RemoveAll(ctx context.Context, path string) error {
+	if _, err := os.Lstat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	tx := FromContext(ctx)
+	if tx != nil {
+		if err := tx.Track(path); err != nil {
+			return err
+		}
+	}
+
+	// Move the path aside first so it disappears in one step
+	old := tempPath(path, "old", txID(tx))
+	_ = os.RemoveAll(old)
+	if err := os.Rename(path, old); err != nil {
+		return err
+	}
+	return os.RemoveAll(old)
+}
+
+// swapInto moves staged to path, moving any existing path aside to old first
+func swapInto(staged, path, old string) error {
+	_ = os.RemoveAll(old)
+	if err := os.Rename(path, old); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.Rename(staged, path); err != nil {
+		// Put the previous content back rather than leaving nothing at path
+		_ = os.Rename(old, path)
+		return err
+	}
+	return os.RemoveAll(old)
+}
+
+// writeFileSynced writes data to a temporary sibling, syncs it and renames it over path
+// An existing file keeps its permissions
+func writeFileSynced(path string, data []byte, perm os.FileMode, id string) error {
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	tmp := tempPath(path, "staged", id)
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// tempPath returns a hidden sibling of path used while staging or replacing it
+func tempPath(path, kind, id string) string {
+	return filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.%s-%s", filepath.Base(path), kind, id))
+}
+
+// txID returns the id used to name temporary files for tx
+func txID(tx *Transaction) string {
+	if tx == nil {
+		return "pid" + strconv.Itoa(os.Getpid())
+	}
+	return tx.id
+}
+
+// copyPath copies a file, symlink or directory tree from src to dst
+func copyPath(src, dst string) error {
+	info, err := os.Lstat(src)
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(dst); err != nil {
+		return err
+	}
+
+	switch {
+	case info.Mode()&os.ModeSymlink != 0:
+		target, err := os.Readlink(src)
+		if err != nil {
+			return err
+		}
+		return os.Symlink(target, dst)
+
+	case info.IsDir():
+		if err := os.MkdirAll(dst, info.Mode().Perm()|0700); err != nil {
+			return err
+		}
+		entries, err := os.ReadDir(src)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err := copyPath(filepath.Join(src, e.Name()), filepath.Join(dst, e.Name())); err != nil {
+				return err
+			}
+		}
+		return os.Chmod(dst, info.Mode().Perm())
+
+	default:
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(dst, data, info.Mode().Perm()); err != nil {
+			return err
+		}
+		return os.Chmod(dst, info.Mode().Perm())
+	}
+}
@@ -0,0 +1,215 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setupTree creates a small install target: a config file and an installed artifact directory
+func setupTree(t *testing.T) string {
+	t.Helper()
+	base := t.TempDir()
+	writeTestFile(t, filepath.Join(base, ".mcp.json"), `{"mcpServers":{}}`)
+	writeTestFile(t, filepath.Join(base, "skills", "old", "SKILL.md"), "old skill")
+	return base
+}
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+}
+
+func readTestFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func assertMissing(t *testing.T, path string) {
+	t.Helper()
+	if _, err := os.Lstat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected %s to not exist, got err=%v", path, err)
+	}
+}
+
+// assertOriginalTree checks base is exactly as setupTree left it, with no staging leftovers
+func assertOriginalTree(t *testing.T, base string) {
+	t.Helper()
+	if got := readTestFile(t, filepath.Join(base, ".mcp.json")); got != `{"mcpServers":{}}` {
+		t.Errorf(".mcp.json = %q, want original content", got)
+	}
+	if got := readTestFile(t, filepath.Join(base, "skills", "old", "SKILL.md")); got != "old skill" {
+		t.Errorf("SKILL.md = %q, want original content", got)
+	}
+	assertMissing(t, filepath.Join(base, "commands"))
+	assertMissing(t, filepath.Join(base, "skills", "new"))
+
+	for _, dir := range []string{base, filepath.Join(base, "skills")} {
+		entries, _ := os.ReadDir(dir)
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		if dir == base && len(names) != 2 || dir != base && len(names) != 1 {
+			t.Errorf("Unexpected entries in %s: %v", dir, names)
+		}
+	}
+}
+
+// makeChanges performs one of each kind of change an install makes
+func makeChanges(t *testing.T, ctx context.Context, base string) {
+	t.Helper()
+	if err := WriteFile(ctx, filepath.Join(base, ".mcp.json"), []byte(`{"mcpServers":{"db":{}}}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if err := WriteFile(ctx, filepath.Join(base, "commands", "deploy.md"), []byte("deploy"), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	err := ReplaceDir(ctx, filepath.Join(base, "skills", "new"), func(dir string) error {
+		return os.WriteFile(filepath.Join(dir, "SKILL.md"), []byte("new skill"), 0644)
+	})
+	if err != nil {
+		t.Fatalf("ReplaceDir failed: %v", err)
+	}
+	if err := RemoveAll(ctx, filepath.Join(base, "skills", "old")); err != nil {
+		t.Fatalf("RemoveAll failed: %v", err)
+	}
+}
+
+func TestRollbackRestoresEveryPath(t *testing.T) {
+	base := setupTree(t)
+	root := t.TempDir()
+
+	tx, err := Begin(root)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	ctx := NewContext(context.Background(), tx)
+	makeChanges(t, ctx, base)
+
+	// Writing the same file again keeps the original backup
+	if err := WriteFile(ctx, filepath.Join(base, ".mcp.json"), []byte(`{}`), 0644); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback failed: %v", err)
+	}
+	assertOriginalTree(t, base)
+
+	if entries, _ := os.ReadDir(root); len(entries) != 0 {
+		t.Errorf("Expected transaction directory to be cleaned up, found %d entries", len(entries))
+	}
+	if err := WriteFile(ctx, filepath.Join(base, ".mcp.json"), nil, 0644); err == nil {
+		t.Error("Expected error writing through a finished transaction")
+	}
+}
+
+func TestCommitKeepsChanges(t *testing.T) {
+	base := setupTree(t)
+	root := t.TempDir()
+
+	tx, err := Begin(root)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	makeChanges(t, NewContext(context.Background(), tx), base)
+
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Rollback after commit should be a no-op, got %v", err)
+	}
+
+	if got := readTestFile(t, filepath.Join(base, ".mcp.json")); got != `{"mcpServers":{"db":{}}}` {
+		t.Errorf(".mcp.json = %q, want updated content", got)
+	}
+	if got := readTestFile(t, filepath.Join(base, "skills", "new", "SKILL.md")); got != "new skill" {
+		t.Errorf("new SKILL.md = %q", got)
+	}
+	assertMissing(t, filepath.Join(base, "skills", "old"))
+
+	if entries, _ := os.ReadDir(root); len(entries) != 0 {
+		t.Errorf("Expected transaction directory to be cleaned up, found %d entries", len(entries))
+	}
+}
+
+func TestRecoverRollsBackCrashedTransaction(t *testing.T) {
+	base := setupTree(t)
+	root := t.TempDir()
+
+	tx, err := Begin(root)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	makeChanges(t, NewContext(context.Background(), tx), base)
+
+	// A staged file left behind mid-write is cleaned up too
+	writeTestFile(t, tempPath(filepath.Join(base, ".mcp.json"), "staged", tx.ID()), "{partial")
+
+	// While the process holding the transaction is alive, it is left alone
+	if n, err := Recover(root); err != nil || n != 0 {
+		t.Fatalf("Recover of live transaction = %d, %v; want 0, nil", n, err)
+	}
+
+	// Simulate the process dying: its lock is released but nothing is rolled back
+	_ = tx.lock.Unlock()
+
+	n, err := Recover(root)
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("Recover = %d, want 1", n)
+	}
+	assertOriginalTree(t, base)
+
+	if entries, _ := os.ReadDir(root); len(entries) != 0 {
+		t.Errorf("Expected transaction directory to be cleaned up, found %d entries", len(entries))
+	}
+}
+
+func TestReplaceDirFailureLeavesExistingDir(t *testing.T) {
+	base := setupTree(t)
+	target := filepath.Join(base, "skills", "old")
+
+	err := ReplaceDir(context.Background(), target, func(dir string) error {
+		writeTestFile(t, filepath.Join(dir, "SKILL.md"), "half extracted")
+		return errors.New("corrupt zip")
+	})
+	if err == nil {
+		t.Fatal("Expected ReplaceDir to fail")
+	}
+	assertOriginalTree(t, base)
+}
+
+func TestWriteFileWithoutTransaction(t *testing.T) {
+	base := setupTree(t)
+	path := filepath.Join(base, "settings.json")
+
+	if err := WriteFile(context.Background(), path, []byte(`{"hooks":{}}`), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if got := readTestFile(t, path); got != `{"hooks":{}}` {
+		t.Errorf("settings.json = %q", got)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Mode = %v, want 0600", info.Mode().Perm())
+	}
+}
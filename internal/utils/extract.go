@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// DefaultMaxExtractSize is the default limit on the uncompressed size of an extracted zip
+	DefaultMaxExtractSize = 256 << 20
+
+	// DefaultMaxExtractEntries is the default limit on the number of entries in an extracted zip
+	DefaultMaxExtractEntries = 10000
+)
+
+// ExtractOptions limits what extracting a zip may write
+type ExtractOptions struct {
+	// MaxTotalSize is the largest total uncompressed size (0 for DefaultMaxExtractSize)
+	MaxTotalSize uint64
+
+	// MaxEntries is the largest number of entries (0 for DefaultMaxExtractEntries)
+	MaxEntries int
+
+	// Executables are the paths written 0755; every other file is written 0644
+	Executables []string
+}
+
+// UnsafeZipEntryError is returned when extraction refuses an entry
+type UnsafeZipEntryError struct {
+	Entry  string
+	Reason string
+}
+
+// Error implements the error interface
+func (e *UnsafeZipEntryError) Error() string {
+	return fmt.Sprintf("unsafe zip entry %q: %s", e.Entry, e.Reason)
+}
+
+// CheckZipEntryName returns an error if a zip entry name would be written outside
+// the extraction directory: absolute paths, drive letters, backslashes and ".." components
+func CheckZipEntryName(name string) error {
+	switch {
+	case name == "":
+		return &UnsafeZipEntryError{Entry: name, Reason: "empty name"}
+	case strings.HasPrefix(name, "/"):
+		return &UnsafeZipEntryError{Entry: name, Reason: "absolute path"}
+	case len(name) >= 2 && name[1] == ':':
+		return &UnsafeZipEntryError{Entry: name, Reason: "drive letter"}
+	case strings.Contains(name, "\\"):
+		return &UnsafeZipEntryError{Entry: name, Reason: "backslash in path"}
+	case strings.ContainsRune(name, 0):
+		return &UnsafeZipEntryError{Entry: name, Reason: "NUL in path"}
+	}
+	for _, part := range strings.Split(name, "/") {
+		if part == ".." {
+			return &UnsafeZipEntryError{Entry: name, Reason: "path escapes the target directory"}
+		}
+	}
+	return nil
+}
+
+// ExtractZipWithOptions extracts a zip file to a target directory, refusing entries
+// that escape it, symlinks and other special files, and archives over the size or
+// entry limits. The target directory may be left partially written on error.
+func ExtractZipWithOptions(zipData []byte, targetDir string, opts ExtractOptions) error {
+	if !IsZipFile(zipData) {
+		return fmt.Errorf("invalid zip file: missing magic bytes")
+	}
+	if opts.MaxTotalSize == 0 {
+		opts.MaxTotalSize = DefaultMaxExtractSize
+	}
+	if opts.MaxEntries == 0 {
+		opts.MaxEntries = DefaultMaxExtractEntries
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		return fmt.Errorf("failed to read zip: %w", err)
+	}
+
+	if len(reader.File) > opts.MaxEntries {
+		return fmt.Errorf("zip has %d entries, more than the limit of %d", len(reader.File), opts.MaxEntries)
+	}
+
+	executables := make(map[string]bool, len(opts.Executables))
+	for _, name := range opts.Executables {
+		executables[path.Clean(name)] = true
+	}
+
+	// Check every entry before writing anything
+	var declared uint64
+	for _, file := range reader.File {
+		if err := CheckZipEntryName(file.Name); err != nil {
+			return err
+		}
+		mode := file.Mode()
+		if mode&os.ModeSymlink != 0 {
+			return &UnsafeZipEntryError{Entry: file.Name, Reason: "symbolic link"}
+		}
+		if !mode.IsDir() && !mode.IsRegular() {
+			return &UnsafeZipEntryError{Entry: file.Name, Reason: "not a regular file or directory"}
+		}
+		declared += file.UncompressedSize64
+		if declared > opts.MaxTotalSize {
+			return &UnsafeZipEntryError{Entry: file.Name, Reason: fmt.Sprintf("archive exceeds the %d byte size limit", opts.MaxTotalSize)}
+		}
+	}
+
+	cleanDir := filepath.Clean(targetDir)
+	// The declared sizes can lie, so the written bytes are limited too
+	remaining := int64(opts.MaxTotalSize)
+	for _, file := range reader.File {
+		name := path.Clean(file.Name)
+		if name == "." {
+			continue
+		}
+		targetPath := filepath.Join(cleanDir, filepath.FromSlash(name))
+		if rel, err := filepath.Rel(cleanDir, targetPath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return &UnsafeZipEntryError{Entry: file.Name, Reason: "path escapes the target directory"}
+		}
+
+		if file.Mode().IsDir() {
+			if err := os.MkdirAll(targetPath, 0755); err != nil {
+				return fmt.Errorf("failed to create directory %s: %w", file.Name, err)
+			}
+			continue
+		}
+
+		mode := os.FileMode(0644)
+		if executables[name] {
+			mode = 0755
+		}
+		written, err := extractZipEntry(file, targetPath, mode, remaining)
+		if err != nil {
+			return err
+		}
+		remaining -= written
+	}
+
+	return nil
+}
+
+// extractZipEntry writes a single file from a zip archive, writing at most limit bytes
+func extractZipEntry(file *zip.File, targetPath string, mode os.FileMode, limit int64) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create parent directory for %s: %w", file.Name, err)
+	}
+
+	// Refuse to write through a symlink already in the target directory
+	if info, err := os.Lstat(targetPath); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		return 0, &UnsafeZipEntryError{Entry: file.Name, Reason: "target is a symbolic link"}
+	}
+
+	rc, err := file.Open()
+	if err != nil {
+		return 0, fmt.Errorf("failed to open file %s in zip: %w", file.Name, err)
+	}
+	defer rc.Close()
+
+	outFile, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create file %s: %w", file.Name, err)
+	}
+	defer outFile.Close()
+
+	written, err := io.Copy(outFile, io.LimitReader(rc, limit+1))
+	if err != nil {
+		return written, fmt.Errorf("failed to write file %s: %w", file.Name, err)
+	}
+	if written > limit {
+		return written, &UnsafeZipEntryError{Entry: file.Name, Reason: "archive exceeds the size limit when decompressed"}
+	}
+
+	// The mode passed to OpenFile only applies to new files
+	if err := outFile.Chmod(mode); err != nil {
+		return written, fmt.Errorf("failed to set permissions on %s: %w", file.Name, err)
+	}
+
+	return written, nil
+}
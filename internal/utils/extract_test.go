@@ -0,0 +1,220 @@
+package utils
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// zipEntry is a file to put in a test zip
+type zipEntry struct {
+	name    string
+	content string
+	mode    os.FileMode
+}
+
+func buildTestZip(t testing.TB, entries ...zipEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, e := range entries {
+		header := &zip.FileHeader{Name: e.name, Method: zip.Deflate}
+		mode := e.mode
+		if mode == 0 {
+			mode = 0644
+		}
+		header.SetMode(mode)
+		fw, err := w.CreateHeader(header)
+		if err != nil {
+			t.Fatalf("Failed to create %s: %v", e.name, err)
+		}
+		if _, err := fw.Write([]byte(e.content)); err != nil {
+			t.Fatalf("Failed to write %s: %v", e.name, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Failed to close zip: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestExtractZipWithOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		entries   []zipEntry
+		opts      ExtractOptions
+		wantEntry string // entry named in the error, empty for success
+	}{
+		{
+			name:    "regular files and directories",
+			entries: []zipEntry{{name: "docs/", mode: fs.ModeDir | 0755}, {name: "docs/README.md", content: "hi"}, {name: "SKILL.md", content: "x"}},
+		},
+		{
+			name:      "parent directory",
+			entries:   []zipEntry{{name: "ok.txt"}, {name: "a/../../evil.txt", content: "x"}},
+			wantEntry: "a/../../evil.txt",
+		},
+		{
+			name:      "absolute path",
+			entries:   []zipEntry{{name: "/etc/evil", content: "x"}},
+			wantEntry: "/etc/evil",
+		},
+		{
+			name:      "windows path",
+			entries:   []zipEntry{{name: "..\\evil.txt", content: "x"}},
+			wantEntry: "..\\evil.txt",
+		},
+		{
+			name:      "symlink",
+			entries:   []zipEntry{{name: "link", content: "/etc/passwd", mode: fs.ModeSymlink | 0777}},
+			wantEntry: "link",
+		},
+		{
+			name:      "too large",
+			entries:   []zipEntry{{name: "small.txt", content: "abc"}, {name: "big.txt", content: strings.Repeat("a", 100)}},
+			opts:      ExtractOptions{MaxTotalSize: 50},
+			wantEntry: "big.txt",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := ExtractZipWithOptions(buildTestZip(t, tt.entries...), dir, tt.opts)
+
+			if tt.wantEntry == "" {
+				if err != nil {
+					t.Fatalf("ExtractZipWithOptions failed: %v", err)
+				}
+				return
+			}
+
+			var unsafeErr *UnsafeZipEntryError
+			if !errors.As(err, &unsafeErr) || unsafeErr.Entry != tt.wantEntry {
+				t.Fatalf("ExtractZipWithOptions() error = %v, want an error naming %q", err, tt.wantEntry)
+			}
+			// Unsafe entries are refused before anything is written
+			if files, _ := os.ReadDir(dir); len(files) != 0 {
+				t.Errorf("Expected nothing to be extracted, got %d files", len(files))
+			}
+		})
+	}
+}
+
+func TestExtractZipLimits(t *testing.T) {
+	entries := []zipEntry{{name: "a"}, {name: "b"}, {name: "c"}}
+	err := ExtractZipWithOptions(buildTestZip(t, entries...), t.TempDir(), ExtractOptions{MaxEntries: 2})
+	if err == nil || !strings.Contains(err.Error(), "3 entries") {
+		t.Errorf("Expected the entry limit to be enforced, got %v", err)
+	}
+
+	// The bytes actually written are limited, not just the sizes the headers declare
+	zipData := buildTestZip(t, zipEntry{name: "bomb.txt", content: strings.Repeat("a", 4096)})
+	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
+	if err != nil {
+		t.Fatalf("Failed to read zip: %v", err)
+	}
+	dir := t.TempDir()
+	_, err = extractZipEntry(reader.File[0], filepath.Join(dir, "bomb.txt"), 0644, 1024)
+	var unsafeErr *UnsafeZipEntryError
+	if !errors.As(err, &unsafeErr) || unsafeErr.Entry != "bomb.txt" {
+		t.Errorf("Expected the written size to be limited, got %v", err)
+	}
+}
+
+func TestExtractZipExecutables(t *testing.T) {
+	zipData := buildTestZip(t,
+		zipEntry{name: "hook.sh", content: "#!/bin/sh\n", mode: 0755},
+		zipEntry{name: "helper.sh", content: "#!/bin/sh\n", mode: 0755},
+		zipEntry{name: "bin/server", content: "binary", mode: 0644},
+	)
+
+	dir := t.TempDir()
+	if err := ExtractZipWithOptions(zipData, dir, ExtractOptions{Executables: []string{"hook.sh", "./bin/server"}}); err != nil {
+		t.Fatalf("ExtractZipWithOptions failed: %v", err)
+	}
+
+	for name, want := range map[string]os.FileMode{"hook.sh": 0755, "helper.sh": 0644, "bin/server": 0755} {
+		info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatalf("Failed to stat %s: %v", name, err)
+		}
+		if got := info.Mode().Perm(); got != want {
+			t.Errorf("%s mode = %v, want %v", name, got, want)
+		}
+	}
+}
+
+// checkExtracted fails the test if anything in dir is a symlink or special file
+func checkExtracted(t *testing.T, dir string) {
+	t.Helper()
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && !d.Type().IsRegular() {
+			t.Errorf("Extracted a non-regular file: %s", path)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to walk %s: %v", dir, err)
+	}
+}
+
+func FuzzExtractZip(f *testing.F) {
+	f.Add(buildTestZip(f, zipEntry{name: "SKILL.md", content: "hello"}))
+	f.Add(buildTestZip(f, zipEntry{name: "../evil", content: "x"}))
+	f.Add(buildTestZip(f, zipEntry{name: "link", content: "/", mode: fs.ModeSymlink | 0777}))
+	f.Add([]byte("PK\x03\x04 truncated"))
+
+	f.Fuzz(func(t *testing.T, zipData []byte) {
+		// Nest the target so an escape would land inside the test's temp dir
+		root := t.TempDir()
+		dir := filepath.Join(root, "a", "b")
+		_ = ExtractZipWithOptions(zipData, dir, ExtractOptions{MaxTotalSize: 1 << 20, MaxEntries: 100})
+
+		entries, err := os.ReadDir(root)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", root, err)
+		}
+		if len(entries) > 1 || (len(entries) == 1 && entries[0].Name() != "a") {
+			t.Fatalf("Extraction wrote outside the target directory")
+		}
+		checkExtracted(t, root)
+	})
+}
+
+func FuzzExtractZipEntryName(f *testing.F) {
+	for _, name := range []string{"SKILL.md", "a/b.txt", "../x", "/abs", "C:/x", "a/./../../x", "a\\..\\x", "./ok"} {
+		f.Add(name)
+	}
+
+	f.Fuzz(func(t *testing.T, name string) {
+		var buf bytes.Buffer
+		w := zip.NewWriter(&buf)
+		if _, err := w.Create(name); err != nil {
+			t.Skip()
+		}
+		if err := w.Close(); err != nil {
+			t.Skip()
+		}
+
+		root := t.TempDir()
+		dir := filepath.Join(root, "a", "b")
+		err := ExtractZipWithOptions(buf.Bytes(), dir, ExtractOptions{})
+
+		if CheckZipEntryName(name) != nil && err == nil {
+			t.Fatalf("Extracted unsafe entry %q", name)
+		}
+		entries, _ := os.ReadDir(root)
+		if len(entries) > 1 || (len(entries) == 1 && entries[0].Name() != "a") {
+			t.Fatalf("Entry %q was written outside the target directory", name)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"crypto/hmac"
 	"crypto/sha256"
 	"crypto/sha512"
 	"encoding/hex"
@@ -15,6 +16,13 @@ func ComputeSHA256(data []byte) string {
 	return hex.EncodeToString(hash[:])
 }
 
+// ComputeHMACSHA256 computes the HMAC-SHA256 of data with a key
+func ComputeHMACSHA256(key, data []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
 // ComputeSHA512 computes the SHA512 hash of data
 func ComputeSHA512(data []byte) string {
 	hash := sha512.Sum512(data)
@@ -4,17 +4,28 @@ import (
 	"archive/zip"
 	"bytes"
 	"crypto/md5"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 )
 
 // ZipMagicBytes are the first 4 bytes of a ZIP file
 var ZipMagicBytes = []byte{0x50, 0x4B, 0x03, 0x04}
 
+// reproducibleZipTime is the modification time of every entry in a reproducible zip,
+// the earliest time a zip entry can hold
+var reproducibleZipTime = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+
 // IsZipFile checks if data starts with ZIP magic bytes
 func IsZipFile(data []byte) bool {
 	if len(data) < 4 {
@@ -23,58 +34,10 @@ func IsZipFile(data []byte) bool {
 	return bytes.Equal(data[:4], ZipMagicBytes)
 }
 
-// ExtractZip extracts a zip file to a target directory
+// ExtractZip extracts a zip file to a target directory with the default limits
+// Executable bits are dropped; use ExtractZipWithOptions to keep them on expected files
 func ExtractZip(zipData []byte, targetDir string) error {
-	if !IsZipFile(zipData) {
-		return fmt.Errorf("invalid zip file: missing magic bytes")
-	}
-
-	reader, err := zip.NewReader(bytes.NewReader(zipData), int64(len(zipData)))
-	if err != nil {
-		return fmt.Errorf("failed to read zip: %w", err)
-	}
-
-	for _, file := range reader.File {
-		if err := extractZipFile(file, targetDir); err != nil {
-			return fmt.Errorf("failed to extract %s: %w", file.Name, err)
-		}
-	}
-
-	return nil
-}
-
-// extractZipFile extracts a single file from a zip archive
-func extractZipFile(file *zip.File, targetDir string) error {
-	// Prevent zip slip vulnerability
-	targetPath := filepath.Join(targetDir, file.Name)
-	if !strings.HasPrefix(targetPath, filepath.Clean(targetDir)+string(os.PathSeparator)) {
-		return fmt.Errorf("illegal file path: %s", file.Name)
-	}
-
-	if file.FileInfo().IsDir() {
-		return os.MkdirAll(targetPath, file.Mode())
-	}
-
-	// Ensure parent directory exists
-	if err := os.MkdirAll(filepath.Dir(targetPath), 0755); err != nil {
-		return err
-	}
-
-	// Extract file
-	outFile, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, file.Mode())
-	if err != nil {
-		return err
-	}
-	defer outFile.Close()
-
-	rc, err := file.Open()
-	if err != nil {
-		return err
-	}
-	defer rc.Close()
-
-	_, err = io.Copy(outFile, rc)
-	return err
+	return ExtractZipWithOptions(zipData, targetDir, ExtractOptions{})
 }
 
 // ReadZipFile reads a specific file from a zip archive without extracting
@@ -109,6 +72,28 @@ func ReadZipFile(zipData []byte, filename string) ([]byte, error) {
 
 // ListZipFiles returns a list of all files in a zip archive
 func ListZipFiles(zipData []byte) ([]string, error) {
+	entries, err := ListZipEntries(zipData)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, len(entries))
+	for i, entry := range entries {
+		files[i] = entry.Name
+	}
+
+	return files, nil
+}
+
+// ZipEntry describes a file in a zip archive
+type ZipEntry struct {
+	Name string
+	Size uint64 // Uncompressed size
+	Mode os.FileMode
+}
+
+// ListZipEntries returns the name, size and mode of every entry in a zip archive
+func ListZipEntries(zipData []byte) ([]ZipEntry, error) {
 	if !IsZipFile(zipData) {
 		return nil, fmt.Errorf("invalid zip file: missing magic bytes")
 	}
@@ -118,12 +103,16 @@ func ListZipFiles(zipData []byte) ([]string, error) {
 		return nil, fmt.Errorf("failed to read zip: %w", err)
 	}
 
-	var files []string
+	entries := make([]ZipEntry, 0, len(reader.File))
 	for _, file := range reader.File {
-		files = append(files, file.Name)
+		entries = append(entries, ZipEntry{
+			Name: file.Name,
+			Size: file.UncompressedSize64,
+			Mode: file.Mode(),
+		})
 	}
 
-	return files, nil
+	return entries, nil
 }
 
 // CreateZip creates a zip archive from a directory
@@ -194,6 +183,91 @@ func CreateZip(sourceDir string) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// ZipOptions controls CreateReproducibleZip
+type ZipOptions struct {
+	// Executables are the paths (relative to the directory, slash-separated) packed as
+	// 0755; everything else is 0644, whatever its mode on disk
+	Executables []string
+
+	// Exclude are paths (relative to the directory, slash-separated) left out of the zip
+	Exclude []string
+
+	// SkipDir reports, by name, subdirectories left out of the zip (optional)
+	SkipDir func(name string) bool
+}
+
+// CreateReproducibleZip creates a zip archive from a directory that is byte-for-byte
+// the same whenever the files are: entries are sorted, timestamps are fixed and modes
+// come from opts rather than the host, so checkouts without exec bits pack the same
+// Directory entries, symlinks, .git and the excluded paths and directories are left out
+func CreateReproducibleZip(sourceDir string, opts ZipOptions) ([]byte, error) {
+	var names []string
+	err := filepath.WalkDir(sourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" || (path != sourceDir && opts.SkipDir != nil && opts.SkipDir(d.Name())) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(sourceDir, path)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if slices.Contains(opts.Exclude, relPath) {
+			return nil
+		}
+		names = append(names, relPath)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zip: %w", err)
+	}
+	sort.Strings(names)
+
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(sourceDir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zip: %w", err)
+		}
+
+		header := &zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: reproducibleZipTime,
+		}
+		if slices.Contains(opts.Executables, name) {
+			header.SetMode(0755)
+		} else {
+			header.SetMode(0644)
+		}
+
+		w, err := writer.CreateHeader(header)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zip: %w", err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to create zip: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close zip writer: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
 // AddFileToZip adds or updates a file in a zip archive
 func AddFileToZip(zipData []byte, filename string, content []byte) ([]byte, error) {
 	if !IsZipFile(zipData) {
@@ -274,6 +348,45 @@ func copyZipFile(writer *zip.Writer, file *zip.File) error {
 // ComputeZipHash computes an MD5 hash of all files in a zip archive
 // Files are hashed individually, then combined in alphabetical order by filename
 func ComputeZipHash(zipData []byte) ([]byte, error) {
+	return computeZipContentHash(zipData, md5.New)
+}
+
+// ComputeZipContentSHA256 computes a hex SHA256 of all files in a zip archive
+// Unlike hashing the archive bytes, the result ignores timestamps and entry order,
+// so a zip built from a directory hashes the same every time
+func ComputeZipContentSHA256(zipData []byte) (string, error) {
+	sum, err := computeZipContentHash(zipData, sha256.New)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum), nil
+}
+
+// VerifyZipContentHash verifies the content hash of a zip archive (see
+// ComputeZipContentSHA256) with the given algorithm
+func VerifyZipContentHash(zipData []byte, algorithm, expected string) error {
+	var newHash func() hash.Hash
+	switch algorithm {
+	case "sha256":
+		newHash = sha256.New
+	case "sha512":
+		newHash = sha512.New
+	default:
+		return fmt.Errorf("unsupported hash algorithm: %s", algorithm)
+	}
+
+	sum, err := computeZipContentHash(zipData, newHash)
+	if err != nil {
+		return err
+	}
+	if actual := hex.EncodeToString(sum); actual != expected {
+		return fmt.Errorf("content hash mismatch: expected %s, got %s", expected, actual)
+	}
+	return nil
+}
+
+// computeZipContentHash hashes each file, then combines the hashes in alphabetical order by filename
+func computeZipContentHash(zipData []byte, newHash func() hash.Hash) ([]byte, error) {
 	if !IsZipFile(zipData) {
 		return nil, fmt.Errorf("invalid zip file: missing magic bytes")
 	}
@@ -297,7 +410,7 @@ func ComputeZipHash(zipData []byte) ([]byte, error) {
 			return nil, fmt.Errorf("failed to open file %s in zip: %w", file.Name, err)
 		}
 
-		h := md5.New()
+		h := newHash()
 		if _, err := io.Copy(h, rc); err != nil {
 			rc.Close()
 			return nil, fmt.Errorf("failed to hash file %s: %w", file.Name, err)
@@ -315,7 +428,7 @@ func ComputeZipHash(zipData []byte) ([]byte, error) {
 	sort.Strings(filenames)
 
 	// Combine all hashes in sorted order
-	combined := md5.New()
+	combined := newHash()
 	for _, name := range filenames {
 		// Include filename in hash to detect renames
 		combined.Write([]byte(name))
@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCreateReproducibleZip(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]os.FileMode{
+		"metadata.toml":    0644,
+		"hook.sh":          0644,
+		"lib/helpers.sh":   0755,
+		"lib/a/nested.txt": 0644,
+		".git/HEAD":        0644,
+		"dist/old.zip":     0644,
+		"node_modules/x":   0644,
+	}
+	for name, mode := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := ZipOptions{
+		Executables: []string{"hook.sh"},
+		Exclude:     []string{"dist/old.zip"},
+		SkipDir:     func(name string) bool { return name == "node_modules" },
+	}
+	first, err := CreateReproducibleZip(dir, opts)
+	if err != nil {
+		t.Fatalf("CreateReproducibleZip failed: %v", err)
+	}
+
+	// Touching files doesn't change the zip
+	later := time.Now().Add(time.Hour)
+	for name := range files {
+		_ = os.Chtimes(filepath.Join(dir, filepath.FromSlash(name)), later, later)
+	}
+	second, err := CreateReproducibleZip(dir, opts)
+	if err != nil {
+		t.Fatalf("CreateReproducibleZip failed: %v", err)
+	}
+	if !bytes.Equal(first, second) {
+		t.Error("Expected identical zips for identical files")
+	}
+
+	entries, err := ListZipEntries(first)
+	if err != nil {
+		t.Fatalf("ListZipEntries failed: %v", err)
+	}
+	got := make(map[string]os.FileMode)
+	var names []string
+	for _, entry := range entries {
+		got[entry.Name] = entry.Mode.Perm()
+		names = append(names, entry.Name)
+	}
+	wantNames := []string{"hook.sh", "lib/a/nested.txt", "lib/helpers.sh", "metadata.toml"}
+	if !reflect.DeepEqual(names, wantNames) {
+		t.Errorf("Entries = %v, want %v", names, wantNames)
+	}
+	if got["hook.sh"] != 0755 || got["lib/helpers.sh"] != 0644 {
+		t.Errorf("Expected modes from the executables, not the files, got %v", got)
+	}
+}
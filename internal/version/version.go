@@ -236,6 +236,50 @@ func SelectBest(versions []string) (string, error) {
 	return versionMap[best.String()], nil
 }
 
+// SortDescending returns the valid versions from a list, highest first
+// Invalid versions are skipped, mirroring SelectBest
+func SortDescending(versions []string) []string {
+	type parsedVersion struct {
+		raw     string
+		version *Version
+	}
+
+	parsed := make([]parsedVersion, 0, len(versions))
+	for _, vStr := range versions {
+		v, err := Parse(vStr)
+		if err != nil {
+			continue // Skip invalid versions
+		}
+		parsed = append(parsed, parsedVersion{raw: vStr, version: v})
+	}
+
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[i].version.Compare(parsed[j].version) > 0
+	})
+
+	sorted := make([]string, len(parsed))
+	for i, p := range parsed {
+		sorted[i] = p.raw
+	}
+	return sorted
+}
+
+// MatchesAll checks if a version string satisfies every specifier
+// Returns false if the version cannot be parsed
+func MatchesAll(vStr string, specifiers []*Specifier) bool {
+	v, err := Parse(vStr)
+	if err != nil {
+		return false
+	}
+
+	for _, spec := range specifiers {
+		if !spec.Matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
 // ParseMultipleSpecifiers parses comma-separated specifiers (e.g., ">=1.0,<2.0")
 func ParseMultipleSpecifiers(spec string) ([]*Specifier, error) {
 	if spec == "" {